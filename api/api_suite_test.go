@@ -22,8 +22,10 @@ import (
 	"github.com/concourse/atc/api/pipes/pipesfakes"
 	"github.com/concourse/atc/api/resourceserver/resourceserverfakes"
 	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/atc/db/dbfakes"
 	"github.com/concourse/atc/engine/enginefakes"
+	"github.com/concourse/atc/notifications/notificationsfakes"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/atc/wrappa"
 )
@@ -53,6 +55,12 @@ var (
 	teamDB                        *dbfakes.FakeTeamDB
 	build                         *dbngfakes.FakeBuild
 	dbBuildFactory                *dbngfakes.FakeBuildFactory
+	fakeStoredArtifactFactory     *dbngfakes.FakeStoredArtifactFactory
+	fakeArtifactStore             *blobstorefakes.FakeStore
+	fakeTestResultFactory         *dbngfakes.FakeTestResultFactory
+	fakeNotificationFactory       *dbngfakes.FakeNotificationFactory
+	fakeEndpointValidator         *notificationsfakes.FakeEndpointValidator
+	fakeAuditFactory              *dbngfakes.FakeAuditFactory
 	dbTeam                        *dbngfakes.FakeTeam
 	fakeSchedulerFactory          *jobserverfakes.FakeSchedulerFactory
 	fakeScannerFactory            *resourceserverfakes.FakeScannerFactory
@@ -95,6 +103,12 @@ var _ = BeforeEach(func() {
 	dbTeamFactory = new(dbngfakes.FakeTeamFactory)
 	dbPipelineFactory = new(dbngfakes.FakePipelineFactory)
 	dbBuildFactory = new(dbngfakes.FakeBuildFactory)
+	fakeStoredArtifactFactory = new(dbngfakes.FakeStoredArtifactFactory)
+	fakeArtifactStore = new(blobstorefakes.FakeStore)
+	fakeTestResultFactory = new(dbngfakes.FakeTestResultFactory)
+	fakeNotificationFactory = new(dbngfakes.FakeNotificationFactory)
+	fakeEndpointValidator = new(notificationsfakes.FakeEndpointValidator)
+	fakeAuditFactory = new(dbngfakes.FakeAuditFactory)
 
 	dbTeam = new(dbngfakes.FakeTeam)
 	dbTeam.IDReturns(734)
@@ -177,9 +191,16 @@ var _ = BeforeEach(func() {
 		dbTeamFactory,
 		dbPipelineFactory,
 		dbWorkerFactory,
+		dbWorkerLifecycle,
 		fakeVolumeFactory,
 		fakeContainerFactory,
 		dbBuildFactory,
+		fakeStoredArtifactFactory,
+		fakeArtifactStore,
+		fakeTestResultFactory,
+		fakeNotificationFactory,
+		fakeEndpointValidator,
+		fakeAuditFactory,
 
 		pipeDB,
 
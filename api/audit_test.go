@@ -0,0 +1,300 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit API", func() {
+	Describe("GET /api/v1/audit", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/audit" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated as an admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("main", true, true)
+
+				fakeAuditFactory.EventsReturns([]atc.AuditEvent{
+					{
+						ID:       2,
+						Time:     1234,
+						Route:    atc.SaveConfig,
+						Method:   "PUT",
+						Team:     "some-team",
+						Pipeline: "some-pipeline",
+						Actor:    "some-team",
+						Status:   http.StatusOK,
+					},
+					{
+						ID:     1,
+						Time:   1000,
+						Route:  atc.SetLogLevel,
+						Method: "PUT",
+						Actor:  atc.AuditActorSystem,
+						Status: http.StatusOK,
+					},
+				}, dbng.Pagination{}, nil)
+			})
+
+			It("returns the audit events", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 2,
+						"time": 1234,
+						"route": "SaveConfig",
+						"method": "PUT",
+						"team": "some-team",
+						"pipeline": "some-pipeline",
+						"actor": "some-team",
+						"status": 200
+					},
+					{
+						"id": 1,
+						"time": 1000,
+						"route": "SetLogLevel",
+						"method": "PUT",
+						"actor": "system",
+						"status": 200
+					}
+				]`))
+			})
+
+			It("lists events with the default page size", func() {
+				Expect(fakeAuditFactory.EventsCallCount()).To(Equal(1))
+				Expect(fakeAuditFactory.EventsArgsForCall(0)).To(Equal(dbng.AuditFilter{
+					Page: dbng.Page{Limit: atc.PaginationAPIDefaultLimit},
+				}))
+			})
+
+			Context("when filters are given", func() {
+				BeforeEach(func() {
+					query = "?team=some-team&pipeline=some-pipeline&actor=main&route=SaveConfig&since=10&limit=5"
+				})
+
+				It("passes them along", func() {
+					Expect(fakeAuditFactory.EventsArgsForCall(0)).To(Equal(dbng.AuditFilter{
+						Team:     "some-team",
+						Pipeline: "some-pipeline",
+						Actor:    "main",
+						Route:    "SaveConfig",
+						Page:     dbng.Page{Since: 10, Limit: 5},
+					}))
+				})
+			})
+
+			Context("when the limit is negative", func() {
+				BeforeEach(func() {
+					query = "?limit=-1&since=-5"
+				})
+
+				It("uses the default page", func() {
+					Expect(fakeAuditFactory.EventsArgsForCall(0)).To(Equal(dbng.AuditFilter{
+						Page: dbng.Page{Limit: atc.PaginationAPIDefaultLimit},
+					}))
+				})
+			})
+
+			Context("when the limit is too large", func() {
+				BeforeEach(func() {
+					query = "?limit=1000000"
+				})
+
+				It("clamps it", func() {
+					Expect(fakeAuditFactory.EventsArgsForCall(0)).To(Equal(dbng.AuditFilter{
+						Page: dbng.Page{Limit: 1000},
+					}))
+				})
+			})
+
+			Context("when a next page is available", func() {
+				BeforeEach(func() {
+					query = "?pipeline=some-pipeline&limit=2"
+
+					fakeAuditFactory.EventsReturns([]atc.AuditEvent{}, dbng.Pagination{
+						Next: &dbng.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns a Link header that keeps the filters", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/audit?limit=2&pipeline=some-pipeline&since=3>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					fakeAuditFactory.EventsReturns(nil, dbng.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(fakeAuditFactory.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/audit", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/audit" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				fakeAuditFactory.EventsReturns([]atc.AuditEvent{
+					{
+						ID:       1,
+						Time:     1000,
+						Route:    atc.PausePipeline,
+						Method:   "PUT",
+						Team:     "some-team",
+						Pipeline: "some-pipeline",
+						Actor:    "some-team",
+						Status:   http.StatusOK,
+					},
+				}, dbng.Pagination{}, nil)
+			})
+
+			It("returns the team's audit events", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 1,
+						"time": 1000,
+						"route": "PausePipeline",
+						"method": "PUT",
+						"team": "some-team",
+						"pipeline": "some-pipeline",
+						"actor": "some-team",
+						"status": 200
+					}
+				]`))
+			})
+
+			Context("when another team is given in the query", func() {
+				BeforeEach(func() {
+					query = "?team=other-team&actor=system"
+				})
+
+				It("only lists events for the team in the route", func() {
+					Expect(fakeAuditFactory.EventsArgsForCall(0)).To(Equal(dbng.AuditFilter{
+						Team:  "some-team",
+						Actor: "system",
+						Page:  dbng.Page{Limit: atc.PaginationAPIDefaultLimit},
+					}))
+				})
+			})
+
+			Context("when a next page is available", func() {
+				BeforeEach(func() {
+					fakeAuditFactory.EventsReturns([]atc.AuditEvent{}, dbng.Pagination{
+						Next: &dbng.Page{Since: 3, Limit: 2},
+					}, nil)
+				})
+
+				It("returns a Link header", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/teams/some-team/audit?limit=2&since=3>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					fakeAuditFactory.EventsReturns(nil, dbng.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when authorized for another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
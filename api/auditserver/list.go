@@ -0,0 +1,106 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	filter, query := parseFilter(r)
+
+	filter.Team = r.FormValue("team")
+	if filter.Team != "" {
+		query.Set("team", filter.Team)
+	}
+
+	s.listEvents(logger, w, "/api/v1/audit", filter, query)
+}
+
+func (s *Server) ListTeamAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-team-audit-events")
+
+	teamName := rata.Param(r, "team_name")
+
+	filter, query := parseFilter(r)
+	filter.Team = teamName
+
+	s.listEvents(logger, w, "/api/v1/teams/"+teamName+"/audit", filter, query)
+}
+
+// maxLimit is the most events returned on one page.
+const maxLimit = 1000
+
+// parseFilter returns the filter given by the request, along with the query
+// that carries it over to the next page.
+func parseFilter(r *http.Request) (dbng.AuditFilter, url.Values) {
+	since, _ := strconv.Atoi(r.FormValue(atc.PaginationQuerySince))
+	if since < 0 {
+		since = 0
+	}
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit <= 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	filter := dbng.AuditFilter{
+		Pipeline: r.FormValue("pipeline"),
+		Actor:    r.FormValue("actor"),
+		Route:    r.FormValue("route"),
+		Page:     dbng.Page{Since: since, Limit: limit},
+	}
+
+	query := url.Values{}
+	for name, value := range map[string]string{
+		"pipeline": filter.Pipeline,
+		"actor":    filter.Actor,
+		"route":    filter.Route,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+
+	return filter, query
+}
+
+func (s *Server) listEvents(logger lager.Logger, w http.ResponseWriter, path string, filter dbng.AuditFilter, query url.Values) {
+	events, pagination, err := s.auditFactory.Events(filter)
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Next != nil {
+		query.Set(atc.PaginationQuerySince, strconv.Itoa(pagination.Next.Since))
+		query.Set(atc.PaginationQueryLimit, strconv.Itoa(pagination.Next.Limit))
+
+		w.Header().Add("Link", fmt.Sprintf(
+			`<%s%s?%s>; rel="%s"`,
+			s.externalURL,
+			path,
+			query.Encode(),
+			atc.LinkRelNext,
+		))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(events)
+}
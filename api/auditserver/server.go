@@ -0,0 +1,24 @@
+package auditserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+type Server struct {
+	logger       lager.Logger
+	externalURL  string
+	auditFactory dbng.AuditFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	auditFactory dbng.AuditFactory,
+) *Server {
+	return &Server{
+		logger:       logger,
+		externalURL:  externalURL,
+		auditFactory: auditFactory,
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/onsi/gomega/ghttp"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	"github.com/concourse/atc/engine/enginefakes"
@@ -325,6 +326,22 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 					})
+
+					Context("when the build has metadata", func() {
+						BeforeEach(func() {
+							build.MetadataReturns(map[string]string{"version": "1.2.3"})
+						})
+
+						It("includes it in the build", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							var returned atc.Build
+							err = json.Unmarshal(body, &returned)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(returned.Metadata).To(Equal(map[string]string{"version": "1.2.3"}))
+						})
+					})
 				})
 			})
 		})
@@ -1174,6 +1191,7 @@ var _ = Describe("Builds API", func() {
 					},
 					InputsSatisfied:     dbng.BuildPreparationStatusBlocking,
 					MissingInputReasons: dbng.MissingInputReasons{"some-input": "some-reason"},
+					WorkerCapacity:      dbng.BuildPreparationStatusNotBlocking,
 				}
 				dbBuildFactory.BuildReturns(build, true, nil)
 				build.JobNameReturns("job1")
@@ -1285,7 +1303,8 @@ var _ = Describe("Builds API", func() {
 					"inputs_satisfied": "blocking",
 					"missing_input_reasons": {
 						"some-input": "some-reason"
-					}
+					},
+					"worker_capacity": "not_blocking"
 				}`))
 				})
 
@@ -1498,4 +1517,314 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					fakePipeline.PublicReturns(true)
+					fakePipeline.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, "", 0, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when the build stored artifacts", func() {
+					BeforeEach(func() {
+						fakeStoredArtifactFactory.FindStoredArtifactsReturns([]dbng.StoredArtifact{
+							{
+								ID:        1,
+								BuildID:   42,
+								Name:      "some-reports",
+								BlobKey:   "builds/42/some-reports.tgz",
+								Size:      1024,
+								CreatedAt: time.Unix(100, 0),
+							},
+						}, nil)
+					})
+
+					It("looks up the artifacts of the build", func() {
+						Expect(fakeStoredArtifactFactory.FindStoredArtifactsCallCount()).To(Equal(1))
+						Expect(fakeStoredArtifactFactory.FindStoredArtifactsArgsForCall(0)).To(Equal([]int{42}))
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns the artifacts", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"name": "some-reports",
+								"size": 1024,
+								"created_at": 100
+							}
+						]`))
+					})
+				})
+
+				Context("when looking up the artifacts fails", func() {
+					BeforeEach(func() {
+						fakeStoredArtifactFactory.FindStoredArtifactsReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/artifacts/:artifact_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/artifacts/some-reports")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when authenticated, but not authorized", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-other-team", false, true)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when the artifact is found", func() {
+					BeforeEach(func() {
+						fakeStoredArtifactFactory.FindStoredArtifactReturns(dbng.StoredArtifact{
+							ID:      1,
+							BuildID: 42,
+							Name:    "some-reports",
+							BlobKey: "builds/42/some-reports.tgz",
+							Size:    int64(len("some-compressed-tarball")),
+						}, true, nil)
+
+						fakeArtifactStore.GetReturns(ioutil.NopCloser(bytes.NewBufferString("some-compressed-tarball")), nil)
+					})
+
+					It("looks up the artifact by name", func() {
+						Expect(fakeStoredArtifactFactory.FindStoredArtifactCallCount()).To(Equal(1))
+						buildID, name := fakeStoredArtifactFactory.FindStoredArtifactArgsForCall(0)
+						Expect(buildID).To(Equal(42))
+						Expect(name).To(Equal("some-reports"))
+
+						Expect(fakeArtifactStore.GetCallCount()).To(Equal(1))
+						Expect(fakeArtifactStore.GetArgsForCall(0)).To(Equal("builds/42/some-reports.tgz"))
+					})
+
+					It("returns the tarball as an attachment", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/gzip"))
+						Expect(response.Header.Get("Content-Disposition")).To(Equal("attachment; filename=some-reports.tgz"))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-compressed-tarball"))
+					})
+
+					Context("when the artifact's name needs quoting", func() {
+						BeforeEach(func() {
+							fakeStoredArtifactFactory.FindStoredArtifactReturns(dbng.StoredArtifact{
+								ID:      1,
+								BuildID: 42,
+								Name:    "some reports; \"final\"",
+								BlobKey: "builds/42/some-reports.tgz",
+							}, true, nil)
+						})
+
+						It("quotes the filename", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(response.Header.Get("Content-Disposition")).To(Equal(`attachment; filename="some reports; \"final\".tgz"`))
+						})
+					})
+
+					Context("when the blob is gone", func() {
+						BeforeEach(func() {
+							fakeArtifactStore.GetReturns(nil, blobstore.ErrBlobNotFound)
+						})
+
+						It("returns 404 Not Found", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+						})
+					})
+				})
+
+				Context("when the artifact is not found", func() {
+					BeforeEach(func() {
+						fakeStoredArtifactFactory.FindStoredArtifactReturns(dbng.StoredArtifact{}, false, nil)
+					})
+
+					It("returns 404 Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when looking up the artifact fails", func() {
+					BeforeEach(func() {
+						fakeStoredArtifactFactory.FindStoredArtifactReturns(dbng.StoredArtifact{}, false, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/tests", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/tests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the build is found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.IDReturns(42)
+				build.JobNameReturns("job1")
+				build.TeamNameReturns("some-team")
+				build.PipelineReturns(fakePipeline, true, nil)
+			})
+
+			Context("when not authenticated and the job is private", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(false)
+					fakePipeline.PublicReturns(true)
+					fakePipeline.ConfigReturns(atc.Config{
+						Jobs: atc.JobConfigs{
+							{Name: "job1", Public: false},
+						},
+					}, "", 0, nil)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					authValidator.IsAuthenticatedReturns(true)
+					userContextReader.GetTeamReturns("some-team", false, true)
+				})
+
+				Context("when the build recorded test results", func() {
+					BeforeEach(func() {
+						fakeTestResultFactory.FindBuildTestSuitesReturns([]dbng.TestSuite{
+							{
+								Name:     "unit",
+								Duration: 1500 * time.Millisecond,
+								Cases: []dbng.TestCase{
+									{
+										ClassName: "some.Class",
+										Name:      "passes",
+										Status:    dbng.TestStatusPassed,
+										Duration:  500 * time.Millisecond,
+									},
+									{
+										ClassName: "some.Class",
+										Name:      "fails",
+										Status:    dbng.TestStatusFailed,
+										Duration:  time.Second,
+										Message:   "nope",
+									},
+								},
+							},
+						}, nil)
+					})
+
+					It("looks up the test results of the build", func() {
+						Expect(fakeTestResultFactory.FindBuildTestSuitesCallCount()).To(Equal(1))
+						Expect(fakeTestResultFactory.FindBuildTestSuitesArgsForCall(0)).To(Equal(42))
+					})
+
+					It("returns 200 OK with the suites", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`[
+							{
+								"name": "unit",
+								"duration": 1.5,
+								"cases": [
+									{"class_name": "some.Class", "name": "passes", "status": "passed", "duration": 0.5},
+									{"class_name": "some.Class", "name": "fails", "status": "failed", "duration": 1, "message": "nope"}
+								]
+							}
+						]`))
+					})
+				})
+
+				Context("when looking up the test results fails", func() {
+					BeforeEach(func() {
+						fakeTestResultFactory.FindBuildTestSuitesReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,86 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) ListBuildArtifacts(build dbng.Build) http.Handler {
+	hLog := s.logger.Session("list-build-artifacts", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artifacts, err := s.storedArtifactFactory.FindStoredArtifacts([]int{build.ID()})
+		if err != nil {
+			hLog.Error("failed-to-find-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.BuildArtifact{}
+		for _, artifact := range artifacts {
+			presented = append(presented, present.BuildArtifact(artifact))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
+
+func (s *Server) GetBuildArtifact(build dbng.Build) http.Handler {
+	hLog := s.logger.Session("get-build-artifact", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.FormValue(":artifact_name")
+
+		if s.artifactStore == nil {
+			http.Error(w, "artifact store not configured", http.StatusNotFound)
+			return
+		}
+
+		artifact, found, err := s.storedArtifactFactory.FindStoredArtifact(build.ID(), name)
+		if err != nil {
+			hLog.Error("failed-to-find-artifact", err, lager.Data{"artifact": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		blob, err := s.artifactStore.Get(artifact.BlobKey)
+		if err == blobstore.ErrBlobNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			hLog.Error("failed-to-get-artifact-blob", err, lager.Data{"artifact": name})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer blob.Close()
+
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Length", fmt.Sprintf("%d", artifact.Size))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": artifact.Name + ".tgz"}))
+		w.WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(w, blob)
+		if err != nil {
+			hLog.Info("failed-to-stream-artifact", lager.Data{"artifact": name, "error": err.Error()})
+		}
+	})
+}
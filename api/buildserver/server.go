@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/worker"
@@ -17,13 +18,16 @@ type Server struct {
 
 	externalURL string
 
-	engine              engine.Engine
-	workerClient        worker.Client
-	teamFactory         dbng.TeamFactory
-	buildFactory        dbng.BuildFactory
-	eventHandlerFactory EventHandlerFactory
-	drain               <-chan struct{}
-	rejector            auth.Rejector
+	engine                engine.Engine
+	workerClient          worker.Client
+	teamFactory           dbng.TeamFactory
+	buildFactory          dbng.BuildFactory
+	storedArtifactFactory dbng.StoredArtifactFactory
+	artifactStore         blobstore.Store
+	testResultFactory     dbng.TestResultFactory
+	eventHandlerFactory   EventHandlerFactory
+	drain                 <-chan struct{}
+	rejector              auth.Rejector
 }
 
 func NewServer(
@@ -33,6 +37,9 @@ func NewServer(
 	workerClient worker.Client,
 	teamFactory dbng.TeamFactory,
 	buildFactory dbng.BuildFactory,
+	storedArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	testResultFactory dbng.TestResultFactory,
 	eventHandlerFactory EventHandlerFactory,
 	drain <-chan struct{},
 ) *Server {
@@ -41,12 +48,15 @@ func NewServer(
 
 		externalURL: externalURL,
 
-		engine:              engine,
-		workerClient:        workerClient,
-		teamFactory:         teamFactory,
-		buildFactory:        buildFactory,
-		eventHandlerFactory: eventHandlerFactory,
-		drain:               drain,
+		engine:                engine,
+		workerClient:          workerClient,
+		teamFactory:           teamFactory,
+		buildFactory:          buildFactory,
+		storedArtifactFactory: storedArtifactFactory,
+		artifactStore:         artifactStore,
+		testResultFactory:     testResultFactory,
+		eventHandlerFactory:   eventHandlerFactory,
+		drain:                 drain,
 
 		rejector: auth.UnauthorizedRejector{},
 	}
@@ -0,0 +1,33 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/dbng"
+)
+
+func (s *Server) GetBuildTests(build dbng.Build) http.Handler {
+	hLog := s.logger.Session("get-build-tests", lager.Data{"build-id": build.ID()})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suites, err := s.testResultFactory.FindBuildTestSuites(build.ID())
+		if err != nil {
+			hLog.Error("failed-to-find-test-suites", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.TestSuite{}
+		for _, suite := range suites {
+			presented = append(presented, present.TestSuite(suite))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/auditserver"
 	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
@@ -17,6 +18,7 @@ import (
 	"github.com/concourse/atc/api/infoserver"
 	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/api/loglevelserver"
+	"github.com/concourse/atc/api/notificationserver"
 	"github.com/concourse/atc/api/pipelineserver"
 	"github.com/concourse/atc/api/pipes"
 	"github.com/concourse/atc/api/resourceserver"
@@ -25,10 +27,12 @@ import (
 	"github.com/concourse/atc/api/volumeserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/mainredirect"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/wrappa"
 )
@@ -49,9 +53,16 @@ func NewHandler(
 	dbTeamFactory dbng.TeamFactory,
 	dbPipelineFactory dbng.PipelineFactory,
 	dbWorkerFactory dbng.WorkerFactory,
+	dbWorkerLifecycle dbng.WorkerLifecycle,
 	volumeFactory dbng.VolumeFactory,
 	containerFactory dbng.ContainerFactory,
 	dbBuildFactory dbng.BuildFactory,
+	dbStoredArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	dbTestResultFactory dbng.TestResultFactory,
+	dbNotificationFactory dbng.NotificationFactory,
+	notificationEndpointValidator notifications.EndpointValidator,
+	dbAuditFactory dbng.AuditFactory,
 
 	pipeDB pipes.PipeDB,
 
@@ -103,11 +114,14 @@ func NewHandler(
 		workerClient,
 		dbTeamFactory,
 		dbBuildFactory,
+		dbStoredArtifactFactory,
+		artifactStore,
+		dbTestResultFactory,
 		eventHandlerFactory,
 		drain,
 	)
 
-	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL)
+	jobServer := jobserver.NewServer(logger, schedulerFactory, externalURL, dbTestResultFactory)
 	resourceServer := resourceserver.NewServer(logger, scannerFactory)
 	versionServer := versionserver.NewServer(logger, externalURL)
 	pipeServer := pipes.NewServer(logger, peerURL, externalURL, pipeDB)
@@ -116,7 +130,7 @@ func NewHandler(
 
 	configServer := configserver.NewServer(logger, dbTeamFactory)
 
-	workerServer := workerserver.NewServer(logger, teamDBFactory, dbTeamFactory, dbWorkerFactory)
+	workerServer := workerserver.NewServer(logger, teamDBFactory, dbTeamFactory, dbWorkerFactory, dbWorkerLifecycle)
 
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 
@@ -130,6 +144,10 @@ func NewHandler(
 
 	infoServer := infoserver.NewServer(logger, version, workerVersion)
 
+	notificationServer := notificationserver.NewServer(logger, dbTeamFactory, dbNotificationFactory, notificationEndpointValidator)
+
+	auditServer := auditserver.NewServer(logger, externalURL, dbAuditFactory)
+
 	handlers := map[string]http.Handler{
 		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
 		atc.GetAuthToken:    http.HandlerFunc(authServer.GetAuthToken),
@@ -145,17 +163,21 @@ func NewHandler(
 		atc.GetBuildPlan:        buildHandlerFactory.HandlerFor(buildServer.GetBuildPlan),
 		atc.GetBuildPreparation: buildHandlerFactory.HandlerFor(buildServer.GetBuildPreparation),
 		atc.BuildEvents:         buildHandlerFactory.HandlerFor(buildServer.BuildEvents),
-
-		atc.ListJobs:       pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
-		atc.GetJob:         pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
-		atc.ListJobBuilds:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
-		atc.ListJobInputs:  pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
-		atc.GetJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.CreateJobBuild: pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
-		atc.PauseJob:       pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
-		atc.UnpauseJob:     pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
-		atc.JobBadge:       pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
-		atc.MainJobBadge:   mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.ListBuildArtifacts:  buildHandlerFactory.HandlerFor(buildServer.ListBuildArtifacts),
+		atc.GetBuildArtifact:    buildHandlerFactory.HandlerFor(buildServer.GetBuildArtifact),
+		atc.GetBuildTests:       buildHandlerFactory.HandlerFor(buildServer.GetBuildTests),
+
+		atc.ListJobs:          pipelineHandlerFactory.HandlerFor(jobServer.ListJobs),
+		atc.GetJob:            pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
+		atc.ListJobBuilds:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
+		atc.ListJobInputs:     pipelineHandlerFactory.HandlerFor(jobServer.ListJobInputs),
+		atc.GetJobBuild:       pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
+		atc.CreateJobBuild:    pipelineHandlerFactory.HandlerFor(jobServer.CreateJobBuild),
+		atc.PauseJob:          pipelineHandlerFactory.HandlerFor(jobServer.PauseJob),
+		atc.UnpauseJob:        pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob),
+		atc.JobBadge:          pipelineHandlerFactory.HandlerFor(jobServer.JobBadge),
+		atc.MainJobBadge:      mainredirect.Handler{atc.Routes, atc.JobBadge},
+		atc.GetJobTestHistory: pipelineHandlerFactory.HandlerFor(jobServer.GetJobTestHistory),
 
 		atc.ListAllPipelines: http.HandlerFunc(pipelineServer.ListAllPipelines),
 		atc.ListPipelines:    http.HandlerFunc(pipelineServer.ListPipelines),
@@ -177,6 +199,7 @@ func NewHandler(
 		atc.CheckResourceWebHook: pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebHook),
 
 		atc.ListResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.ListResourceVersions),
+		atc.SaveResourceVersions:          pipelineHandlerFactory.HandlerFor(versionServer.SaveResourceVersions),
 		atc.EnableResourceVersion:         pipelineHandlerFactory.HandlerFor(versionServer.EnableResourceVersion),
 		atc.DisableResourceVersion:        pipelineHandlerFactory.HandlerFor(versionServer.DisableResourceVersion),
 		atc.ListBuildsWithVersionAsInput:  pipelineHandlerFactory.HandlerFor(versionServer.ListBuildsWithVersionAsInput),
@@ -193,6 +216,7 @@ func NewHandler(
 		atc.PruneWorker:     http.HandlerFunc(workerServer.PruneWorker),
 		atc.HeartbeatWorker: http.HandlerFunc(workerServer.HeartbeatWorker),
 		atc.DeleteWorker:    http.HandlerFunc(workerServer.DeleteWorker),
+		atc.GetWorkerHealth: http.HandlerFunc(workerServer.GetWorkerHealth),
 
 		atc.SetLogLevel: http.HandlerFunc(logLevelServer.SetMinLevel),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
@@ -210,6 +234,16 @@ func NewHandler(
 		atc.ListTeams:   http.HandlerFunc(teamServer.ListTeams),
 		atc.SetTeam:     http.HandlerFunc(teamServer.SetTeam),
 		atc.DestroyTeam: http.HandlerFunc(teamServer.DestroyTeam),
+
+		atc.GetTeamQuotas: http.HandlerFunc(teamServer.GetTeamQuotas),
+
+		atc.ListNotificationSubscriptions:  http.HandlerFunc(notificationServer.ListNotificationSubscriptions),
+		atc.CreateNotificationSubscription: http.HandlerFunc(notificationServer.CreateNotificationSubscription),
+		atc.DeleteNotificationSubscription: http.HandlerFunc(notificationServer.DeleteNotificationSubscription),
+		atc.ListNotificationDeliveries:     http.HandlerFunc(notificationServer.ListNotificationDeliveries),
+
+		atc.ListAuditEvents:     http.HandlerFunc(auditServer.ListAuditEvents),
+		atc.ListTeamAuditEvents: http.HandlerFunc(auditServer.ListTeamAuditEvents),
 	}
 
 	return rata.NewRouter(atc.Routes, wrapper.Wrap(handlers))
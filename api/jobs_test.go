@@ -1244,7 +1244,8 @@ var _ = Describe("Jobs API", func() {
 				Context("when getting the job config succeeds", func() {
 					BeforeEach(func() {
 						fakeJob.ConfigReturns(atc.JobConfig{
-							Name: "some-job",
+							Name:     "some-job",
+							Priority: 5,
 							Plan: atc.PlanSequence{
 								{
 									Get: "some-input",
@@ -1280,10 +1281,77 @@ var _ = Describe("Jobs API", func() {
 						It("triggers using the current config", func() {
 							Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
 
-							_, job, resources, resourceTypes := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+							_, job, resources, resourceTypes, priority := fakeScheduler.TriggerImmediatelyArgsForCall(0)
 							Expect(job).To(Equal(fakeJob))
 							Expect(resources).To(Equal(dbng.Resources{fakeResource, fakeResource2}))
 							Expect(resourceTypes).To(Equal(versionedResourceTypes))
+							Expect(priority).To(Equal(5))
+						})
+
+						Context("when a priority is given", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?priority=20", nil)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("triggers with the given priority", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+								_, _, _, _, priority := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+								Expect(priority).To(Equal(20))
+							})
+						})
+
+						Context("when a priority above the job's is given by a non-admin", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-team", false, true)
+
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?priority=20", nil)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 403", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+							})
+						})
+
+						Context("when a priority below the job's is given by a non-admin", func() {
+							BeforeEach(func() {
+								userContextReader.GetTeamReturns("some-team", false, true)
+
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?priority=-3", nil)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("triggers with the given priority", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(1))
+
+								_, _, _, _, priority := fakeScheduler.TriggerImmediatelyArgsForCall(0)
+								Expect(priority).To(Equal(-3))
+							})
+						})
+
+						Context("when the given priority is malformed", func() {
+							BeforeEach(func() {
+								var err error
+								request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds?priority=urgent", nil)
+								Expect(err).NotTo(HaveOccurred())
+							})
+
+							It("returns 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeScheduler.TriggerImmediatelyCallCount()).To(Equal(0))
+							})
 						})
 
 						It("returns 200 OK", func() {
@@ -1838,6 +1906,132 @@ var _ = Describe("Jobs API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tests", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/tests" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized and the pipeline is private", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				userContextReader.GetTeamReturns("", false, false)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", true, true)
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakeJob.IDReturns(7)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					fakeTestResultFactory.FindJobTestHistoryReturns([]dbng.TestHistory{
+						{
+							Suite:     "unit",
+							ClassName: "some.Class",
+							Name:      "flaky",
+							Flakiness: 1,
+							Runs: []dbng.TestCaseRun{
+								{BuildID: 12, BuildName: "2", Status: dbng.TestStatusFailed, Duration: 2 * time.Second},
+								{BuildID: 11, BuildName: "1", Status: dbng.TestStatusPassed, Duration: 500 * time.Millisecond},
+							},
+						},
+					}, nil)
+				})
+
+				It("looks up the history of the job's default number of builds", func() {
+					Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+
+					Expect(fakeTestResultFactory.FindJobTestHistoryCallCount()).To(Equal(1))
+					jobID, builds := fakeTestResultFactory.FindJobTestHistoryArgsForCall(0)
+					Expect(jobID).To(Equal(7))
+					Expect(builds).To(Equal(100))
+				})
+
+				Context("when a limit is given", func() {
+					BeforeEach(func() {
+						queryParams = "?limit=5"
+					})
+
+					It("looks up the history of that many builds", func() {
+						_, builds := fakeTestResultFactory.FindJobTestHistoryArgsForCall(0)
+						Expect(builds).To(Equal(5))
+					})
+				})
+
+				It("returns 200 OK with the history", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"suite": "unit",
+							"class_name": "some.Class",
+							"name": "flaky",
+							"flakiness": 1,
+							"runs": [
+								{"build_id": 12, "build_name": "2", "status": "failed", "duration": 2},
+								{"build_id": 11, "build_name": "1", "status": "passed", "duration": 0.5}
+							]
+						}
+					]`))
+				})
+
+				Context("when looking up the history fails", func() {
+					BeforeEach(func() {
+						fakeTestResultFactory.FindJobTestHistoryReturns(nil, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when finding the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
 
 func fakeDBNGResourceType(t atc.VersionedResourceType) *dbngfakes.FakeResourceType {
@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/dbng"
 )
 
@@ -32,6 +35,26 @@ func (s *Server) CreateJobBuild(pipeline dbng.Pipeline) http.Handler {
 			return
 		}
 
+		priority := job.Config().Priority
+		if priorityStr := r.URL.Query().Get("priority"); priorityStr != "" {
+			priority, err = strconv.Atoi(priorityStr)
+			if err != nil {
+				logger.Info("malformed-priority", lager.Data{"priority": priorityStr})
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "malformed priority: %s", priorityStr)
+				return
+			}
+
+			// only admins may jump the queue; anyone may let others ahead
+			authTeam, authTeamFound := auth.GetTeam(r)
+			if priority > job.Config().Priority && (!authTeamFound || !authTeam.IsAdmin()) {
+				logger.Info("priority-above-job-priority", lager.Data{"priority": priority})
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprintf(w, "only admins may trigger builds above the job's priority of %d", job.Config().Priority)
+				return
+			}
+		}
+
 		scheduler := s.schedulerFactory.BuildScheduler(pipeline, s.externalURL)
 
 		resourceTypes, err := pipeline.ResourceTypes()
@@ -48,7 +71,7 @@ func (s *Server) CreateJobBuild(pipeline dbng.Pipeline) http.Handler {
 			return
 		}
 
-		build, _, err := scheduler.TriggerImmediately(logger, job, resources, resourceTypes.Deserialize())
+		build, _, err := scheduler.TriggerImmediately(logger, job, resources, resourceTypes.Deserialize(), priority)
 		if err != nil {
 			logger.Error("failed-to-trigger", err)
 			w.WriteHeader(http.StatusInternalServerError)
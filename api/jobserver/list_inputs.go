@@ -56,7 +56,10 @@ func (s *Server) ListJobInputs(pipeline dbng.Pipeline) http.Handler {
 		jobInputs := job.Config().Inputs()
 		presentedBuildInputs := make([]atc.BuildInput, len(buildInputs))
 		for i, input := range buildInputs {
-			resource, _ := resources.Lookup(input.Resource)
+			var source atc.Source
+			if resource, found := resources.Lookup(input.Resource); found {
+				source = resource.Source()
+			}
 
 			var config atc.JobInput
 			for _, jobInput := range jobInputs {
@@ -66,7 +69,7 @@ func (s *Server) ListJobInputs(pipeline dbng.Pipeline) http.Handler {
 				}
 			}
 
-			presentedBuildInputs[i] = present.BuildInput(input, config, resource.Source())
+			presentedBuildInputs[i] = present.BuildInput(input, config, source)
 		}
 
 		json.NewEncoder(w).Encode(presentedBuildInputs)
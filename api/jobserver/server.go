@@ -16,20 +16,23 @@ type SchedulerFactory interface {
 type Server struct {
 	logger lager.Logger
 
-	schedulerFactory SchedulerFactory
-	externalURL      string
-	rejector         auth.Rejector
+	schedulerFactory  SchedulerFactory
+	externalURL       string
+	testResultFactory dbng.TestResultFactory
+	rejector          auth.Rejector
 }
 
 func NewServer(
 	logger lager.Logger,
 	schedulerFactory SchedulerFactory,
 	externalURL string,
+	testResultFactory dbng.TestResultFactory,
 ) *Server {
 	return &Server{
-		logger:           logger,
-		schedulerFactory: schedulerFactory,
-		externalURL:      externalURL,
-		rejector:         auth.UnauthorizedRejector{},
+		logger:            logger,
+		schedulerFactory:  schedulerFactory,
+		externalURL:       externalURL,
+		testResultFactory: testResultFactory,
+		rejector:          auth.UnauthorizedRejector{},
 	}
 }
@@ -0,0 +1,54 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/dbng"
+)
+
+// GetJobTestHistory returns the history of each test case across the job's
+// most recent builds which recorded test results, most flaky first.
+func (s *Server) GetJobTestHistory(pipeline dbng.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-job-test-history")
+
+		jobName := r.FormValue(":job_name")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		history, err := s.testResultFactory.FindJobTestHistory(job.ID(), limit)
+		if err != nil {
+			logger.Error("failed-to-find-test-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.TestHistory{}
+		for _, h := range history {
+			presented = append(presented, present.TestHistory(h))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(presented)
+	})
+}
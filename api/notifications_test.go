@@ -0,0 +1,386 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/notifications"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Notifications API", func() {
+	BeforeEach(func() {
+		dbTeam.IDReturns(734)
+		fakePipeline.IDReturns(42)
+	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				fakeNotificationFactory.SubscriptionsReturns([]atc.NotificationSubscription{
+					{ID: 1, URL: "https://example.com/team"},
+					{
+						ID:       2,
+						Pipeline: "some-pipeline",
+						URL:      "https://example.com/pipeline",
+						Jobs:     []string{"some-job"},
+						Statuses: []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+					},
+				}, nil)
+			})
+
+			It("returns the team's subscriptions", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"id": 1, "url": "https://example.com/team"},
+					{
+						"id": 2,
+						"pipeline": "some-pipeline",
+						"url": "https://example.com/pipeline",
+						"jobs": ["some-job"],
+						"statuses": ["failed", "errored"]
+					}
+				]`))
+
+				Expect(fakeNotificationFactory.SubscriptionsArgsForCall(0)).To(Equal(734))
+			})
+
+			Context("when getting the subscriptions fails", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.SubscriptionsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when authorized for another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("other-team", false, true)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/notifications", func() {
+		var (
+			subscription atc.NotificationSubscription
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			subscription = atc.NotificationSubscription{
+				Pipeline: "some-pipeline",
+				URL:      "https://example.com/hook",
+				Secret:   "some-secret",
+				Statuses: []atc.BuildStatus{atc.StatusFailed},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/some-team/notifications", "application/json", jsonEncode(subscription))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+
+				fakeNotificationFactory.CreateSubscriptionReturns(atc.NotificationSubscription{
+					ID:       3,
+					Pipeline: "some-pipeline",
+					URL:      "https://example.com/hook",
+					Statuses: []atc.BuildStatus{atc.StatusFailed},
+				}, nil)
+			})
+
+			It("creates the subscription for the pipeline", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+				Expect(dbTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+
+				Expect(fakeNotificationFactory.CreateSubscriptionCallCount()).To(Equal(1))
+				teamID, pipelineID, created := fakeNotificationFactory.CreateSubscriptionArgsForCall(0)
+				Expect(teamID).To(Equal(734))
+				Expect(pipelineID).To(Equal(42))
+				Expect(created).To(Equal(subscription))
+			})
+
+			It("returns the subscription without its secret", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`{
+					"id": 3,
+					"pipeline": "some-pipeline",
+					"url": "https://example.com/hook",
+					"statuses": ["failed"]
+				}`))
+			})
+
+			Context("when subscribing to the whole team", func() {
+				BeforeEach(func() {
+					subscription.Pipeline = ""
+				})
+
+				It("creates the subscription without a pipeline", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbTeam.PipelineCallCount()).To(BeZero())
+
+					_, pipelineID, _ := fakeNotificationFactory.CreateSubscriptionArgsForCall(0)
+					Expect(pipelineID).To(BeZero())
+				})
+			})
+
+			Context("when the pipeline is not found", func() {
+				BeforeEach(func() {
+					dbTeam.PipelineReturns(nil, false, nil)
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("invalid subscription: pipeline 'some-pipeline' not found"))
+				})
+			})
+
+			Context("when the subscription is invalid", func() {
+				BeforeEach(func() {
+					subscription.Statuses = []atc.BuildStatus{atc.StatusPending}
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("invalid subscription: unknown status 'pending'"))
+
+					Expect(fakeNotificationFactory.CreateSubscriptionCallCount()).To(BeZero())
+				})
+			})
+
+			It("validates the subscription's endpoint", func() {
+				Expect(fakeEndpointValidator.ValidateCallCount()).To(Equal(1))
+				Expect(fakeEndpointValidator.ValidateArgsForCall(0)).To(Equal("https://example.com/hook"))
+			})
+
+			Context("when the endpoint is not allowed", func() {
+				BeforeEach(func() {
+					fakeEndpointValidator.ValidateReturns(notifications.ErrForbiddenAddress)
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("invalid subscription: endpoint address is not allowed"))
+
+					Expect(fakeNotificationFactory.CreateSubscriptionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when creating the subscription fails", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.CreateSubscriptionReturns(atc.NotificationSubscription{}, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/notifications/:subscription_id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/notifications/3", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the subscription is deleted", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.DeleteSubscriptionReturns(true, nil)
+				})
+
+				It("returns 204 No Content", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					teamID, subscriptionID := fakeNotificationFactory.DeleteSubscriptionArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(subscriptionID).To(Equal(3))
+				})
+			})
+
+			Context("when the subscription is not found", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.DeleteSubscriptionReturns(false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/notifications/:subscription_id/deliveries", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/notifications/3/deliveries?limit=5")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the subscription is found", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.DeliveriesReturns([]atc.NotificationDelivery{
+						{
+							NotificationID: 10,
+							BuildID:        128,
+							Status:         atc.StatusFailed,
+							Attempt:        2,
+							StatusCode:     502,
+							Error:          "endpoint responded with 502 Bad Gateway",
+							Duration:       0.5,
+							AttemptedAt:    1234,
+						},
+					}, true, nil)
+				})
+
+				It("returns the subscription's recent deliveries", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"notification_id": 10,
+							"build_id": 128,
+							"status": "failed",
+							"attempt": 2,
+							"status_code": 502,
+							"error": "endpoint responded with 502 Bad Gateway",
+							"duration": 0.5,
+							"attempted_at": 1234
+						}
+					]`))
+
+					teamID, subscriptionID, limit := fakeNotificationFactory.DeliveriesArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(subscriptionID).To(Equal(3))
+					Expect(limit).To(Equal(5))
+				})
+			})
+
+			Context("when the subscription is not found", func() {
+				BeforeEach(func() {
+					fakeNotificationFactory.DeliveriesReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
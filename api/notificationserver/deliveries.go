@@ -0,0 +1,56 @@
+package notificationserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+// ListNotificationDeliveries returns the most recent attempts to deliver
+// notifications to a subscription, for debugging the receiving end.
+func (s *Server) ListNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("list-notification-deliveries", lager.Data{"team": teamName})
+
+	subscriptionID, err := strconv.Atoi(r.FormValue(":subscription_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if limit <= 0 {
+		limit = atc.PaginationAPIDefaultLimit
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	deliveries, found, err := s.notificationFactory.Deliveries(team.ID(), subscriptionID, limit)
+	if err != nil {
+		hLog.Error("failed-to-get-deliveries", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
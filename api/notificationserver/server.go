@@ -0,0 +1,28 @@
+package notificationserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/notifications"
+)
+
+type Server struct {
+	logger              lager.Logger
+	teamFactory         dbng.TeamFactory
+	notificationFactory dbng.NotificationFactory
+	endpointValidator   notifications.EndpointValidator
+}
+
+func NewServer(
+	logger lager.Logger,
+	teamFactory dbng.TeamFactory,
+	notificationFactory dbng.NotificationFactory,
+	endpointValidator notifications.EndpointValidator,
+) *Server {
+	return &Server{
+		logger:              logger,
+		teamFactory:         teamFactory,
+		notificationFactory: notificationFactory,
+		endpointValidator:   endpointValidator,
+	}
+}
@@ -0,0 +1,148 @@
+package notificationserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+func (s *Server) ListNotificationSubscriptions(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("list-notification-subscriptions", lager.Data{"team": teamName})
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	subscriptions, err := s.notificationFactory.Subscriptions(team.ID())
+	if err != nil {
+		hLog.Error("failed-to-get-subscriptions", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subscriptions)
+}
+
+func (s *Server) CreateNotificationSubscription(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("create-notification-subscription", lager.Data{"team": teamName})
+
+	var subscription atc.NotificationSubscription
+	err := json.NewDecoder(r.Body).Decode(&subscription)
+	if err != nil {
+		hLog.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = subscription.Validate()
+	if err != nil {
+		hLog.Info("invalid-subscription", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid subscription: %s", err)
+		return
+	}
+
+	err = s.endpointValidator.Validate(subscription.URL)
+	if err != nil {
+		hLog.Info("invalid-endpoint", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid subscription: %s", err)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var pipelineID int
+	if subscription.Pipeline != "" {
+		pipeline, found, err := team.Pipeline(subscription.Pipeline)
+		if err != nil {
+			hLog.Error("failed-to-get-pipeline", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "invalid subscription: pipeline '%s' not found", subscription.Pipeline)
+			return
+		}
+
+		pipelineID = pipeline.ID()
+	}
+
+	created, err := s.notificationFactory.CreateSubscription(team.ID(), pipelineID, subscription)
+	if err != nil {
+		hLog.Error("failed-to-create-subscription", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (s *Server) DeleteNotificationSubscription(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("delete-notification-subscription", lager.Data{"team": teamName})
+
+	subscriptionID, err := strconv.Atoi(r.FormValue(":subscription_id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	deleted, err := s.notificationFactory.DeleteSubscription(team.ID(), subscriptionID)
+	if err != nil {
+		hLog.Error("failed-to-delete-subscription", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
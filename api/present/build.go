@@ -52,6 +52,8 @@ func Build(build dbng.Build) atc.Build {
 		TeamName:     build.TeamName(),
 		URL:          reqURL,
 		APIURL:       apiURL,
+		Priority:     build.Priority(),
+		Metadata:     build.Metadata(),
 	}
 
 	if !build.StartTime().IsZero() {
@@ -0,0 +1,14 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+func BuildArtifact(artifact dbng.StoredArtifact) atc.BuildArtifact {
+	return atc.BuildArtifact{
+		Name:      artifact.Name,
+		Size:      artifact.Size,
+		CreatedAt: artifact.CreatedAt.Unix(),
+	}
+}
@@ -20,5 +20,7 @@ func BuildPreparation(preparation dbng.BuildPreparation) atc.BuildPreparation {
 		Inputs:              inputs,
 		InputsSatisfied:     atc.BuildPreparationStatus(preparation.InputsSatisfied),
 		MissingInputReasons: atc.MissingInputReasons(preparation.MissingInputReasons),
+		WorkerCapacity:      atc.BuildPreparationStatus(preparation.WorkerCapacity),
+		QueuePosition:       preparation.QueuePosition,
 	}
 }
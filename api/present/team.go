@@ -10,6 +10,8 @@ func Team(team dbng.Team) atc.Team {
 	return atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
+
+		ContainerPlacementStrategy: team.ContainerPlacementStrategy(),
 	}
 }
 func SavedTeam(team db.SavedTeam) atc.Team {
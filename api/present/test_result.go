@@ -0,0 +1,45 @@
+package present
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+func TestSuite(suite dbng.TestSuite) atc.TestSuite {
+	cases := []atc.TestCase{}
+	for _, c := range suite.Cases {
+		cases = append(cases, atc.TestCase{
+			ClassName: c.ClassName,
+			Name:      c.Name,
+			Status:    string(c.Status),
+			Duration:  c.Duration.Seconds(),
+			Message:   c.Message,
+		})
+	}
+
+	return atc.TestSuite{
+		Name:     suite.Name,
+		Duration: suite.Duration.Seconds(),
+		Cases:    cases,
+	}
+}
+
+func TestHistory(history dbng.TestHistory) atc.TestHistory {
+	runs := []atc.TestCaseRun{}
+	for _, run := range history.Runs {
+		runs = append(runs, atc.TestCaseRun{
+			BuildID:   run.BuildID,
+			BuildName: run.BuildName,
+			Status:    string(run.Status),
+			Duration:  run.Duration.Seconds(),
+		})
+	}
+
+	return atc.TestHistory{
+		Suite:     history.Suite,
+		ClassName: history.ClassName,
+		Name:      history.Name,
+		Flakiness: history.Flakiness,
+		Runs:      runs,
+	}
+}
@@ -19,19 +19,42 @@ func Worker(workerInfo dbng.Worker) atc.Worker {
 		version = *workerInfo.Version()
 	}
 
+	var allocated *atc.WorkerResources
+	if workerInfo.Capacity() != nil {
+		resources := workerInfo.Allocated()
+		allocated = &resources
+	}
+
 	return atc.Worker{
-		GardenAddr:       gardenAddr,
-		BaggageclaimURL:  baggageclaimURL,
-		HTTPProxyURL:     workerInfo.HTTPProxyURL(),
-		HTTPSProxyURL:    workerInfo.HTTPSProxyURL(),
-		NoProxy:          workerInfo.NoProxy(),
-		ActiveContainers: workerInfo.ActiveContainers(),
-		ResourceTypes:    workerInfo.ResourceTypes(),
-		Platform:         workerInfo.Platform(),
-		Tags:             workerInfo.Tags(),
-		Name:             workerInfo.Name(),
-		Team:             workerInfo.TeamName(),
-		State:            string(workerInfo.State()),
-		Version:          version,
+		GardenAddr:          gardenAddr,
+		BaggageclaimURL:     baggageclaimURL,
+		HTTPProxyURL:        workerInfo.HTTPProxyURL(),
+		HTTPSProxyURL:       workerInfo.HTTPSProxyURL(),
+		NoProxy:             workerInfo.NoProxy(),
+		ActiveContainers:    workerInfo.ActiveContainers(),
+		Capacity:            workerInfo.Capacity(),
+		Allocated:           allocated,
+		Disk:                workerInfo.Disk(),
+		HealthCheckFailures: workerInfo.HealthCheckFailures(),
+		ResourceTypes:       workerInfo.ResourceTypes(),
+		Platform:            workerInfo.Platform(),
+		Tags:                workerInfo.Tags(),
+		Name:                workerInfo.Name(),
+		Team:                workerInfo.TeamName(),
+		State:               string(workerInfo.State()),
+		Version:             version,
+	}
+}
+
+func WorkerDrain(workerInfo dbng.Worker, progress dbng.WorkerDrainProgress) *atc.WorkerDrain {
+	drain := &atc.WorkerDrain{
+		RemainingContainers: progress.Containers,
+		RemainingBuilds:     progress.Builds,
+	}
+
+	if !workerInfo.LandingStartedAt().IsZero() {
+		drain.LandingStartedAt = workerInfo.LandingStartedAt().Unix()
 	}
+
+	return drain
 }
@@ -2,9 +2,14 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io/ioutil"
 	"net/http"
 
@@ -692,4 +697,232 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var (
+			fakeScanner  *radarfakes.FakeScanner
+			fakeResource *dbngfakes.FakeResource
+
+			query   string
+			header  http.Header
+			payload []byte
+
+			response *http.Response
+		)
+
+		sign := func(hash func() hash.Hash, secret string) string {
+			mac := hmac.New(hash, []byte(secret))
+			mac.Write(payload)
+			return hex.EncodeToString(mac.Sum(nil))
+		}
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeScannerFactory.NewResourceScannerReturns(fakeScanner)
+
+			fakeResource = new(dbngfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+			query = ""
+			header = http.Header{}
+			payload = []byte(`{"ref":"refs/heads/master"}`)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook"+query, bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+			request.Header = header
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the resource is verified by token", func() {
+			BeforeEach(func() {
+				fakeResource.WebhookTokenReturns("some-token")
+			})
+
+			Context("when the token matches", func() {
+				BeforeEach(func() {
+					query = "?webhook_token=some-token"
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+					_, actualResourceName, actualFromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualResourceName).To(Equal("resource-name"))
+					Expect(actualFromVersion).To(BeNil())
+				})
+			})
+
+			Context("when the token does not match", func() {
+				BeforeEach(func() {
+					query = "?webhook_token=wrong-token"
+				})
+
+				It("returns 401 without checking", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the token is missing", func() {
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when the resource is verified by a github signature", func() {
+			BeforeEach(func() {
+				fakeResource.WebhookReturns(&atc.WebhookConfig{
+					Provider: atc.WebhookProviderGitHub,
+					Secret:   "some-secret",
+				})
+			})
+
+			Context("when the payload is signed with the secret", func() {
+				BeforeEach(func() {
+					header.Set("X-Hub-Signature-256", "sha256="+sign(sha256.New, "some-secret"))
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the payload is signed with sha1", func() {
+				BeforeEach(func() {
+					header.Set("X-Hub-Signature", "sha1="+sign(sha1.New, "some-secret"))
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the payload is signed with another secret", func() {
+				BeforeEach(func() {
+					header.Set("X-Hub-Signature-256", "sha256="+sign(sha256.New, "other-secret"))
+				})
+
+				It("returns 401 without checking", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when only the webhook token is given", func() {
+				BeforeEach(func() {
+					fakeResource.WebhookTokenReturns("some-token")
+					query = "?webhook_token=some-token"
+				})
+
+				It("returns 401 without checking", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the resource is verified by a gitlab token", func() {
+			BeforeEach(func() {
+				fakeResource.WebhookReturns(&atc.WebhookConfig{
+					Provider: atc.WebhookProviderGitLab,
+					Secret:   "some-secret",
+				})
+			})
+
+			Context("when the token matches", func() {
+				BeforeEach(func() {
+					header.Set("X-Gitlab-Token", "some-secret")
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the token is missing", func() {
+				It("returns 401 without checking", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the resource is verified by a bitbucket signature", func() {
+			BeforeEach(func() {
+				fakeResource.WebhookReturns(&atc.WebhookConfig{
+					Provider: atc.WebhookProviderBitbucket,
+					Secret:   "some-secret",
+					Refs:     []string{"release/*"},
+				})
+
+				payload = []byte(`{"push":{"changes":[{"new":{"type":"branch","name":"release/1.0"}}]}}`)
+			})
+
+			Context("when the push matches the refs", func() {
+				BeforeEach(func() {
+					header.Set("X-Hub-Signature", "sha256="+sign(sha256.New, "some-secret"))
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the push is to another branch", func() {
+				BeforeEach(func() {
+					payload = []byte(`{"push":{"changes":[{"new":{"type":"branch","name":"master"}}]}}`)
+					header.Set("X-Hub-Signature", "sha256="+sign(sha256.New, "some-secret"))
+				})
+
+				It("returns 200 without checking", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the payload does not name a ref", func() {
+				BeforeEach(func() {
+					payload = []byte(`{"test":true}`)
+					header.Set("X-Hub-Signature", "sha256="+sign(sha256.New, "some-secret"))
+				})
+
+				It("checks the resource", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the resource is not found", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourceReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		Context("when looking up the resource fails", func() {
+			BeforeEach(func() {
+				fakePipeline.ResourceReturns(nil, false, errors.New("disaster"))
+			})
+
+			It("returns 500", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
 })
@@ -2,6 +2,8 @@ package resourceserver
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -10,39 +12,70 @@ import (
 	"github.com/tedsuo/rata"
 )
 
-// CheckResourceWebHook defines a handler for process a check resource request via an access token.
+// MaxWebhookPayloadSize bounds how much of a webhook's payload is read to
+// verify its signature.
+const MaxWebhookPayloadSize = 25 * 1024 * 1024
+
+// CheckResourceWebHook defines a handler for process a check resource request
+// via an access token, or via a payload signed by the resource's webhook
+// provider.
 func (s *Server) CheckResourceWebHook(dbPipeline dbng.Pipeline) http.Handler {
 	logger := s.logger.Session("check-resource-webhook")
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		resourceName := rata.Param(r, "resource_name")
-		webhookToken := r.URL.Query().Get("webhook_token")
 
-		if webhookToken == "" {
-			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
-			w.WriteHeader(http.StatusBadRequest)
+		pipelineResource, found, err := dbPipeline.Resource(resourceName)
+		if err != nil {
+			logger.Info("database-error", lager.Data{"error": err})
+			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		pipelineResource, found, err := dbPipeline.Resource(resourceName)
 		if !found {
 			logger.Info("resource-not-found", lager.Data{"error": fmt.Sprintf("Resource not found %s", resourceName)})
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
-		if err != nil {
-			logger.Info("database-error", lager.Data{"error": err})
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+		if webhook := pipelineResource.Webhook(); webhook != nil {
+			payload, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxWebhookPayloadSize))
+			if err != nil {
+				logger.Info("failed-to-read-payload", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 
-		token := pipelineResource.WebhookToken()
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
-			w.WriteHeader(http.StatusUnauthorized)
-			return
+			if !verifyWebhook(*webhook, r.Header, payload) {
+				logger.Info("invalid-signature", lager.Data{"resource": resourceName, "provider": webhook.Provider})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if len(webhook.Refs) > 0 {
+				refs := payloadRefs(payload)
+				if len(refs) > 0 && !matchesRefs(webhook.Refs, refs) {
+					logger.Debug("ignoring-refs", lager.Data{"resource": resourceName, "refs": refs})
+					w.WriteHeader(http.StatusOK)
+					return
+				}
+			}
+		} else {
+			webhookToken := r.URL.Query().Get("webhook_token")
+			if webhookToken == "" {
+				logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			token := pipelineResource.WebhookToken()
+			if token != webhookToken {
+				logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
 		}
+
 		var fromVersion atc.Version
 		latestVersion, found, err := dbPipeline.GetLatestVersionedResource(resourceName)
 		if err != nil {
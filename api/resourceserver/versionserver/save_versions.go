@@ -0,0 +1,72 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) SaveResourceVersions(pipeline dbng.Pipeline) http.Handler {
+	logger := s.logger.Session("save-resource-versions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		var reqBody atc.SaveVersionsRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(reqBody.Versions) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "no versions given")
+			return
+		}
+
+		for _, version := range reqBody.Versions {
+			if len(version.Version) == 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "versions must not be empty")
+				return
+			}
+		}
+
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = pipeline.SaveResourceVersionsWithMetadata(logger, atc.ResourceConfig{
+			Name: resource.Name(),
+			Type: resource.Type(),
+		}, reqBody.Versions)
+		if err != nil {
+			logger.Error("failed-to-save-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("saved-versions", lager.Data{
+			"resource": resourceName,
+			"total":    len(reqBody.Versions),
+		})
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,131 @@
+package resourceserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/concourse/atc"
+)
+
+// verifyWebhook checks that the payload was sent by the provider configured
+// for the resource, which signs it with the shared secret.
+func verifyWebhook(webhook atc.WebhookConfig, header http.Header, payload []byte) bool {
+	switch webhook.Provider {
+	case atc.WebhookProviderGitHub:
+		if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+			return validSignature(sha256.New, "sha256=", webhook.Secret, signature, payload)
+		}
+
+		return validSignature(sha1.New, "sha1=", webhook.Secret, header.Get("X-Hub-Signature"), payload)
+
+	case atc.WebhookProviderBitbucket:
+		return validSignature(sha256.New, "sha256=", webhook.Secret, header.Get("X-Hub-Signature"), payload)
+
+	case atc.WebhookProviderGitLab:
+		token := header.Get("X-Gitlab-Token")
+		return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(webhook.Secret)) == 1
+	}
+
+	return false
+}
+
+func validSignature(hash func() hash.Hash, prefix string, secret string, signature string, payload []byte) bool {
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(hash, []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+type webhookPayload struct {
+	// GitHub and GitLab
+	Ref string `json:"ref"`
+
+	// Bitbucket Server
+	Changes []struct {
+		RefID string `json:"refId"`
+	} `json:"changes"`
+
+	// Bitbucket Cloud
+	Push struct {
+		Changes []struct {
+			New *struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+}
+
+// payloadRefs returns the refs pushed to according to the payload, if it
+// names any.
+func payloadRefs(payload []byte) []string {
+	var parsed webhookPayload
+	err := json.Unmarshal(payload, &parsed)
+	if err != nil {
+		return nil
+	}
+
+	refs := []string{}
+
+	if parsed.Ref != "" {
+		refs = append(refs, parsed.Ref)
+	}
+
+	for _, change := range parsed.Changes {
+		if change.RefID != "" {
+			refs = append(refs, change.RefID)
+		}
+	}
+
+	for _, change := range parsed.Push.Changes {
+		if change.New == nil {
+			continue
+		}
+
+		switch change.New.Type {
+		case "branch":
+			refs = append(refs, "refs/heads/"+change.New.Name)
+		case "tag":
+			refs = append(refs, "refs/tags/"+change.New.Name)
+		}
+	}
+
+	return refs
+}
+
+// matchesRefs returns whether any of the refs matches any of the patterns,
+// which may name the full ref or just the branch or tag.
+func matchesRefs(patterns []string, refs []string) bool {
+	for _, ref := range refs {
+		short := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+
+		for _, pattern := range patterns {
+			if matched, _ := path.Match(pattern, ref); matched {
+				return true
+			}
+
+			if matched, _ := path.Match(pattern, short); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -73,6 +73,7 @@ var _ = Describe("Teams API", func() {
 
 				fakeTeamOne.IDReturns(5)
 				fakeTeamOne.NameReturns("avengers")
+				fakeTeamOne.ContainerPlacementStrategyReturns("fewest-containers")
 
 				fakeTeamTwo.IDReturns(9)
 				fakeTeamTwo.NameReturns("aliens")
@@ -101,7 +102,8 @@ var _ = Describe("Teams API", func() {
 				Expect(body).To(MatchJSON(`[
 					{
 						"id": 5,
-						"name": "avengers"
+						"name": "avengers",
+						"container_placement_strategy": "fewest-containers"
 					},
 					{
 						"id": 9,
@@ -196,6 +198,12 @@ var _ = Describe("Teams API", func() {
 							Expect(updatedBasicAuth).To(Equal(atcTeam.BasicAuth))
 						})
 
+						It("leaves the settings which were not given alone", func() {
+							Expect(fakeTeam.UpdateContainerPlacementStrategyCallCount()).To(BeZero())
+							Expect(fakeTeam.UpdateQuotasCallCount()).To(BeZero())
+							Expect(fakeTeam.UpdateSerialGroupsCallCount()).To(BeZero())
+						})
+
 						Context("when updating basic auth fails", func() {
 							BeforeEach(func() {
 								fakeTeam.UpdateBasicAuthReturns(errors.New("stop trying to make fetch happen"))
@@ -209,6 +217,96 @@ var _ = Describe("Teams API", func() {
 				})
 			})
 
+			Context("when the team has a container placement strategy configured", func() {
+				Context("when the strategy is unknown", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							ContainerPlacementStrategy: "bogus",
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the strategy is known", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							ContainerPlacementStrategy: "fewest-containers",
+						}
+					})
+
+					Context("when the team is found", func() {
+						BeforeEach(func() {
+							dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("updates the container placement strategy", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateContainerPlacementStrategyCallCount()).To(Equal(1))
+							Expect(fakeTeam.UpdateContainerPlacementStrategyArgsForCall(0)).To(Equal("fewest-containers"))
+						})
+
+						Context("when updating the container placement strategy fails", func() {
+							BeforeEach(func() {
+								fakeTeam.UpdateContainerPlacementStrategyReturns(errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+				})
+			})
+
+			Context("when the team has serial groups configured", func() {
+				Context("when a serial group is invalid", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							SerialGroups: atc.SerialGroupConfigs{{Name: "staging", Size: 0}},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the serial groups are valid", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							SerialGroups: atc.SerialGroupConfigs{{Name: "staging", Size: 3}},
+						}
+					})
+
+					Context("when the team is found", func() {
+						BeforeEach(func() {
+							dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("updates the serial groups", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateSerialGroupsCallCount()).To(Equal(1))
+							Expect(fakeTeam.UpdateSerialGroupsArgsForCall(0)).To(Equal(atc.SerialGroupConfigs{
+								{Name: "staging", Size: 3},
+							}))
+						})
+
+						Context("when updating the serial groups fails", func() {
+							BeforeEach(func() {
+								fakeTeam.UpdateSerialGroupsReturns(errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+				})
+			})
+
 			Context("when the team has provider auth configured", func() {
 				var (
 					fakeProviderName    = "FakeProvider"
@@ -311,6 +409,93 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team has quotas configured", func() {
+				Context("when a quota is negative", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							Quotas: &atc.TeamQuotas{MaxRunningBuilds: -1},
+						}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the quotas are valid", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{
+							Quotas: &atc.TeamQuotas{
+								MaxRunningBuilds: 5,
+								MaxContainers:    100,
+								MaxVolumes:       200,
+							},
+						}
+					})
+
+					Context("when the team is found", func() {
+						BeforeEach(func() {
+							dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+						})
+
+						It("updates the quotas", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateQuotasCallCount()).To(Equal(1))
+							Expect(fakeTeam.UpdateQuotasArgsForCall(0)).To(Equal(atc.TeamQuotas{
+								MaxRunningBuilds: 5,
+								MaxContainers:    100,
+								MaxVolumes:       200,
+							}))
+						})
+
+						Context("when updating the quotas fails", func() {
+							BeforeEach(func() {
+								fakeTeam.UpdateQuotasReturns(errors.New("nope"))
+							})
+
+							It("returns 500 Internal Server error", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+					})
+				})
+			})
+
+			Context("when the team has an admission weight configured", func() {
+				Context("when the weight is negative", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{AdmissionWeight: -1}
+					})
+
+					It("returns a 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the team is found", func() {
+					BeforeEach(func() {
+						atcTeam = atc.Team{AdmissionWeight: 3}
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					It("updates the admission weight", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateAdmissionWeightCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateAdmissionWeightArgsForCall(0)).To(Equal(3))
+					})
+
+					Context("when updating the admission weight fails", func() {
+						BeforeEach(func() {
+							fakeTeam.UpdateAdmissionWeightReturns(errors.New("nope"))
+						})
+
+						It("returns 500 Internal Server error", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -347,6 +532,34 @@ var _ = Describe("Teams API", func() {
 
 			authorizedTeamTests()
 
+			Context("when the team has quotas configured", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{
+						Quotas: &atc.TeamQuotas{MaxRunningBuilds: 5},
+					}
+
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateQuotasCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the team has an admission weight configured", func() {
+				BeforeEach(func() {
+					atcTeam = atc.Team{AdmissionWeight: 3}
+
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				})
+
+				It("returns 403 Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					Expect(fakeTeam.UpdateAdmissionWeightCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when the team is not found", func() {
 				BeforeEach(func() {
 					dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -499,4 +712,101 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/quotas", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/quotas")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			Context("when the team is found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					fakeTeam.FindQuotasReturns(atc.TeamQuotas{
+						MaxRunningBuilds: 5,
+						MaxContainers:    100,
+					}, nil)
+					fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{
+						RunningBuilds: 2,
+						Containers:    42,
+						Volumes:       64,
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns application/json", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the team's quotas and usage", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"quotas": {
+							"max_running_builds": 5,
+							"max_containers": 100
+						},
+						"usage": {
+							"running_builds": 2,
+							"containers": 42,
+							"volumes": 64
+						}
+					}`))
+				})
+
+				Context("when finding the quotas fails", func() {
+					BeforeEach(func() {
+						fakeTeam.FindQuotasReturns(atc.TeamQuotas{}, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when finding the usage fails", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, errors.New("nope"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team is not found", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404 Not Found", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
 })
@@ -0,0 +1,47 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+func (s *Server) GetTeamQuotas(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("get-team-quotas", lager.Data{"team": teamName})
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		hLog.Error("failed-to-get-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	quotas, err := team.FindQuotas()
+	if err != nil {
+		hLog.Error("failed-to-find-quotas", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	usage, err := team.QuotaUsage()
+	if err != nil {
+		hLog.Error("failed-to-find-quota-usage", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(atc.TeamQuotaStatus{
+		Quotas: quotas,
+		Usage:  usage,
+	})
+}
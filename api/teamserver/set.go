@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker"
 )
 
 func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +42,18 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if atcTeam.Quotas != nil && !authTeam.IsAdmin() {
+		hLog.Info("only-admins-may-set-quotas")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if atcTeam.AdmissionWeight != 0 && !authTeam.IsAdmin() {
+		hLog.Info("only-admins-may-set-admission-weight")
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	hLog.Debug("configured-authentication", lager.Data{"BasicAuth": atcTeam.BasicAuth, "ProviderAuth": atcTeam.Auth})
 
 	if atcTeam.BasicAuth != nil {
@@ -51,6 +64,37 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if atcTeam.ContainerPlacementStrategy != "" {
+		err := worker.ValidateContainerPlacementStrategy(atcTeam.ContainerPlacementStrategy)
+		if err != nil {
+			hLog.Info("invalid-container-placement-strategy", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.Quotas != nil {
+		err := atcTeam.Quotas.Validate()
+		if err != nil {
+			hLog.Info("invalid-quotas", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if atcTeam.AdmissionWeight < 0 {
+		hLog.Info("invalid-admission-weight", lager.Data{"weight": atcTeam.AdmissionWeight})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = atcTeam.SerialGroups.Validate()
+	if err != nil {
+		hLog.Info("invalid-serial-groups", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	providers := provider.GetProviders()
 
 	for providerName, config := range atcTeam.Auth {
@@ -111,6 +155,8 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(present.Team(team))
 }
 
+// updateCredentials always replaces the team's auth; its other settings are
+// only changed when the request gives them.
 func (s *Server) updateCredentials(atcTeam atc.Team, team dbng.Team) error {
 	err := team.UpdateBasicAuth(atcTeam.BasicAuth)
 	if err != nil {
@@ -122,5 +168,33 @@ func (s *Server) updateCredentials(atcTeam atc.Team, team dbng.Team) error {
 		return err
 	}
 
+	if atcTeam.ContainerPlacementStrategy != "" {
+		err = team.UpdateContainerPlacementStrategy(atcTeam.ContainerPlacementStrategy)
+		if err != nil {
+			return err
+		}
+	}
+
+	if atcTeam.Quotas != nil {
+		err = team.UpdateQuotas(*atcTeam.Quotas)
+		if err != nil {
+			return err
+		}
+	}
+
+	if atcTeam.SerialGroups != nil {
+		err = team.UpdateSerialGroups(atcTeam.SerialGroups)
+		if err != nil {
+			return err
+		}
+	}
+
+	if atcTeam.AdmissionWeight != 0 {
+		err = team.UpdateAdmissionWeight(atcTeam.AdmissionWeight)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
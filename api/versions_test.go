@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 )
@@ -238,6 +239,120 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
+		var (
+			fakeResource *dbngfakes.FakeResource
+			reqBody      atc.SaveVersionsRequestBody
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(dbngfakes.FakeResource)
+			fakeResource.NameReturns("resource-name")
+			fakeResource.TypeReturns("some-type")
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+			reqBody = atc.SaveVersionsRequestBody{
+				Versions: []atc.VersionWithMetadata{
+					{
+						Version:  atc.Version{"ref": "v1"},
+						Metadata: []atc.MetadataField{{Name: "commit", Value: "some-commit"}},
+					},
+					{
+						Version: atc.Version{"ref": "v2"},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Post(server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions", "application/json", jsonEncode(reqBody))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				userContextReader.GetTeamReturns("a-team", true, true)
+			})
+
+			It("saves the versions for the resource", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+
+				Expect(fakePipeline.SaveResourceVersionsWithMetadataCallCount()).To(Equal(1))
+				_, config, versions := fakePipeline.SaveResourceVersionsWithMetadataArgsForCall(0)
+				Expect(config).To(Equal(atc.ResourceConfig{Name: "resource-name", Type: "some-type"}))
+				Expect(versions).To(Equal(reqBody.Versions))
+			})
+
+			Context("when no versions are given", func() {
+				BeforeEach(func() {
+					reqBody.Versions = nil
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("no versions given"))
+
+					Expect(fakePipeline.SaveResourceVersionsWithMetadataCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when a version is empty", func() {
+				BeforeEach(func() {
+					reqBody.Versions[1].Version = atc.Version{}
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("versions must not be empty"))
+
+					Expect(fakePipeline.SaveResourceVersionsWithMetadataCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the resource is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when saving the versions fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SaveResourceVersionsWithMetadataReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(fakePipeline.SaveResourceVersionsWithMetadataCallCount()).To(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", func() {
 		var response *http.Response
 
@@ -91,6 +91,45 @@ var _ = Describe("Workers API", func() {
 					}))
 
 				})
+
+				It("does not get the drain progress of running workers", func() {
+					Expect(teamWorker1.DrainProgressCallCount()).To(BeZero())
+					Expect(teamWorker2.DrainProgressCallCount()).To(BeZero())
+				})
+
+				Context("when a worker is landing", func() {
+					BeforeEach(func() {
+						teamWorker2.StateReturns(dbng.WorkerStateLanding)
+						teamWorker2.LandingStartedAtReturns(time.Unix(1234, 0))
+						teamWorker2.DrainProgressReturns(dbng.WorkerDrainProgress{
+							Containers: 3,
+							Builds:     1,
+						}, nil)
+					})
+
+					It("returns its drain progress", func() {
+						var returnedWorkers []atc.Worker
+						err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(returnedWorkers[0].Drain).To(BeNil())
+						Expect(returnedWorkers[1].Drain).To(Equal(&atc.WorkerDrain{
+							RemainingContainers: 3,
+							RemainingBuilds:     1,
+							LandingStartedAt:    1234,
+						}))
+					})
+
+					Context("when getting its drain progress fails", func() {
+						BeforeEach(func() {
+							teamWorker2.DrainProgressReturns(dbng.WorkerDrainProgress{}, errors.New("nope"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
 			})
 
 			Context("when getting the workers fails", func() {
@@ -393,7 +432,6 @@ var _ = Describe("Workers API", func() {
 			workerName = "some-worker"
 			fakeWorker.NameReturns(workerName)
 			fakeWorker.TeamNameReturns("some-team")
-			fakeWorker.LandReturns(nil)
 
 			authValidator.IsAuthenticatedReturns(true)
 			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
@@ -411,7 +449,8 @@ var _ = Describe("Workers API", func() {
 			It("sees if the worker exists and attempts to land it", func() {
 				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
 				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
-				Expect(fakeWorker.LandCallCount()).To(Equal(1))
+				Expect(dbWorkerLifecycle.LandWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerLifecycle.LandWorkerArgsForCall(0)).To(Equal(workerName))
 			})
 
 			Context("when landing the worker fails", func() {
@@ -419,7 +458,7 @@ var _ = Describe("Workers API", func() {
 
 				BeforeEach(func() {
 					returnedErr = errors.New("some-error")
-					fakeWorker.LandReturns(returnedErr)
+					dbWorkerLifecycle.LandWorkerReturns(returnedErr)
 				})
 
 				It("returns 500", func() {
@@ -473,6 +512,96 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/workers/:worker_name/health", func() {
+		var (
+			response   *http.Response
+			workerName string
+			fakeWorker *dbngfakes.FakeWorker
+		)
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/workers/"+workerName+"/health", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		BeforeEach(func() {
+			fakeWorker = new(dbngfakes.FakeWorker)
+			workerName = "some-worker"
+			fakeWorker.NameReturns(workerName)
+			fakeWorker.TeamNameReturns("some-team")
+			fakeWorker.HealthChecksReturns([]atc.WorkerHealthCheck{
+				{CheckedAt: 200, Error: "garden: connection refused"},
+				{CheckedAt: 100, GardenLatency: 12, BaggageclaimLatency: 34},
+			}, nil)
+
+			authValidator.IsAuthenticatedReturns(true)
+			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
+		})
+
+		Context("when the request is authenticated as the worker's owner", func() {
+			BeforeEach(func() {
+				userContextReader.GetTeamReturns("some-team", false, true)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns the health check history", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{"checked_at": 200, "garden_latency": 0, "baggageclaim_latency": 0, "error": "garden: connection refused"},
+					{"checked_at": 100, "garden_latency": 12, "baggageclaim_latency": 34}
+				]`))
+			})
+
+			Context("when getting the health checks fails", func() {
+				BeforeEach(func() {
+					fakeWorker.HealthChecksReturns(nil, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the worker does not exist", func() {
+				BeforeEach(func() {
+					dbWorkerFactory.GetWorkerReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+
+		Context("when the request is authenticated as the wrong team", func() {
+			BeforeEach(func() {
+				userContextReader.GetTeamReturns("some-other-team", false, true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/workers/:worker_name/retire", func() {
 		var (
 			response   *http.Response
@@ -497,7 +626,6 @@ var _ = Describe("Workers API", func() {
 			authValidator.IsAuthenticatedReturns(true)
 
 			dbWorkerFactory.GetWorkerReturns(fakeWorker, true, nil)
-			fakeWorker.RetireReturns(nil)
 		})
 
 		Context("when autheticated as system", func() {
@@ -513,7 +641,8 @@ var _ = Describe("Workers API", func() {
 				Expect(dbWorkerFactory.GetWorkerCallCount()).To(Equal(1))
 				Expect(dbWorkerFactory.GetWorkerArgsForCall(0)).To(Equal(workerName))
 
-				Expect(fakeWorker.RetireCallCount()).To(Equal(1))
+				Expect(dbWorkerLifecycle.RetireWorkerCallCount()).To(Equal(1))
+				Expect(dbWorkerLifecycle.RetireWorkerArgsForCall(0)).To(Equal(workerName))
 			})
 
 			Context("when retiring the worker fails", func() {
@@ -521,7 +650,7 @@ var _ = Describe("Workers API", func() {
 
 				BeforeEach(func() {
 					returnedErr = errors.New("some-error")
-					fakeWorker.RetireReturns(returnedErr)
+					dbWorkerLifecycle.RetireWorkerReturns(returnedErr)
 				})
 
 				It("returns 500", func() {
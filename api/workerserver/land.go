@@ -6,7 +6,7 @@ func (s *Server) LandWorker(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("landing-worker")
 	workerName := r.FormValue(":worker_name")
 
-	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	_, found, err := s.dbWorkerFactory.GetWorker(workerName)
 	if err != nil {
 		logger.Error("failed-finding-worker-to-land", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -19,7 +19,7 @@ func (s *Server) LandWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = worker.Land()
+	err = s.dbWorkerLifecycle.LandWorker(workerName)
 	if err != nil {
 		logger.Error("failed-to-land-worker", err)
 		w.WriteHeader(http.StatusInternalServerError)
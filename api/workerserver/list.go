@@ -33,6 +33,19 @@ func (s *Server) ListWorkers(_ db.TeamDB, team dbng.Team) http.Handler {
 		workers := make([]atc.Worker, len(savedWorkers))
 		for i, savedWorker := range savedWorkers {
 			workers[i] = present.Worker(savedWorker)
+
+			if savedWorker.State() != dbng.WorkerStateLanding && savedWorker.State() != dbng.WorkerStateRetiring {
+				continue
+			}
+
+			progress, err := savedWorker.DrainProgress()
+			if err != nil {
+				logger.Error("failed-to-get-worker-drain-progress", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			workers[i].Drain = present.WorkerDrain(savedWorker, progress)
 		}
 
 		json.NewEncoder(w).Encode(workers)
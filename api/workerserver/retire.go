@@ -6,7 +6,7 @@ func (s *Server) RetireWorker(w http.ResponseWriter, r *http.Request) {
 	logger := s.logger.Session("retiring-worker")
 	workerName := r.FormValue(":worker_name")
 
-	worker, found, err := s.dbWorkerFactory.GetWorker(workerName)
+	_, found, err := s.dbWorkerFactory.GetWorker(workerName)
 
 	if err != nil {
 		logger.Error("failed-finding-worker-to-retire", err)
@@ -20,7 +20,7 @@ func (s *Server) RetireWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = worker.Retire()
+	err = s.dbWorkerLifecycle.RetireWorker(workerName)
 
 	if err != nil {
 		logger.Error("failed-to-retire-worker", err)
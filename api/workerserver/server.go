@@ -9,9 +9,10 @@ import (
 type Server struct {
 	logger lager.Logger
 
-	teamDBFactory   db.TeamDBFactory
-	dbTeamFactory   dbng.TeamFactory
-	dbWorkerFactory dbng.WorkerFactory
+	teamDBFactory     db.TeamDBFactory
+	dbTeamFactory     dbng.TeamFactory
+	dbWorkerFactory   dbng.WorkerFactory
+	dbWorkerLifecycle dbng.WorkerLifecycle
 }
 
 func NewServer(
@@ -19,11 +20,13 @@ func NewServer(
 	teamDBFactory db.TeamDBFactory,
 	dbTeamFactory dbng.TeamFactory,
 	dbWorkerFactory dbng.WorkerFactory,
+	dbWorkerLifecycle dbng.WorkerLifecycle,
 ) *Server {
 	return &Server{
-		logger:          logger,
-		teamDBFactory:   teamDBFactory,
-		dbTeamFactory:   dbTeamFactory,
-		dbWorkerFactory: dbWorkerFactory,
+		logger:            logger,
+		teamDBFactory:     teamDBFactory,
+		dbTeamFactory:     dbTeamFactory,
+		dbWorkerFactory:   dbWorkerFactory,
+		dbWorkerLifecycle: dbWorkerLifecycle,
 	}
 }
@@ -21,26 +21,32 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/audit"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/engine"
+	"github.com/concourse/atc/errorreporting"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/lockrunner"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/notifications"
 	"github.com/concourse/atc/pipelines"
 	"github.com/concourse/atc/radar"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/web"
 	"github.com/concourse/atc/web/publichandler"
 	"github.com/concourse/atc/web/robotstxt"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/image"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/atc/wrappa"
 	"github.com/concourse/retryhttp"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -52,7 +58,6 @@ import (
 	"github.com/tedsuo/ifrit/grouper"
 	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/ifrit/sigmon"
-	"github.com/xoebus/zest"
 
 	"github.com/concourse/atc/auth/provider"
 	"github.com/concourse/atc/auth/routes"
@@ -104,6 +109,16 @@ type ATCCommand struct {
 
 	CLIArtifactsDir DirFlag `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ContainerPlacementStrategy string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"fewest-containers" choice:"random" description:"Method by which a worker is selected for a container, unless overridden by its team. Capacity reserved for containers with limits, and the priority of containers waiting for it, are tracked by each ATC separately, so with several ATCs a worker may be overcommitted until it next heartbeats."`
+
+	MaxConcurrentBuilds int `long:"max-concurrent-builds" default:"0" description:"Maximum number of builds to run at once across all teams. Pending builds beyond it are started in fair-share order across teams and pipelines. 0 means unlimited."`
+
+	ArtifactStore struct {
+		LocalDir DirFlag `long:"local-dir" description:"Directory in which to keep the outputs archived as artifacts of builds. If not specified, artifacts are not kept. When running several web nodes, this must be a directory shared by all of them (e.g. an NFS mount), as artifacts are served and collected by whichever node handles the request."`
+
+		MaxAge time.Duration `long:"max-age" default:"168h" description:"How long to keep the artifacts of one-off builds and of jobs which do not set build_logs_to_retain. 0 keeps them indefinitely."`
+	} `group:"Build Artifacts" namespace:"artifact-store"`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -120,10 +135,19 @@ type ATCCommand struct {
 		HostName   string            `long:"metrics-host-name"   description:"Host string to attach to emitted metrics."`
 		Attributes map[string]string `long:"metrics-attribute"   description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
 
-		YellerAPIKey      string `long:"yeller-api-key"     description:"Yeller API key. If specified, all errors logged will be emitted."`
-		YellerEnvironment string `long:"yeller-environment" description:"Environment to tag on all Yeller events emitted."`
+		SentryDSN            string        `long:"sentry-dsn"             description:"DSN of a Sentry-compatible endpoint. If specified, all errors logged will be reported."`
+		SentryEnvironment    string        `long:"sentry-environment"     description:"Environment to tag on all reported errors."`
+		SentryRateLimit      int           `long:"sentry-rate-limit"      default:"60" description:"Maximum number of errors to report per minute. 0 means unlimited."`
+		SentryDedupeInterval time.Duration `long:"sentry-dedupe-interval" default:"1m" description:"How long after an error is reported that the same error is not reported again."`
+
+		YellerAPIKey      string `long:"yeller-api-key"     hidden:"true" description:"Deprecated; Yeller is no longer supported. Use --sentry-dsn instead."`
+		YellerEnvironment string `long:"yeller-environment" hidden:"true" description:"Deprecated; Yeller is no longer supported. Use --sentry-environment instead."`
 	} `group:"Metrics & Diagnostics"`
 
+	Tracing tracing.Config `group:"Tracing"`
+
+	Audit audit.Config `group:"Audit Logging"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" description:"The value to set for X-Frame-Options. If omitted, the header is not set."`
 	} `group:"Web Server"`
@@ -132,7 +156,25 @@ type ATCCommand struct {
 
 	GCInterval time.Duration `long:"gc-interval" default:"30s" description:"Interval on which to perform garbage collection."`
 
+	VolumeEvictionThreshold int `long:"volume-eviction-threshold" default:"80" description:"Percentage of a worker's disk in use above which its least recently used cache volumes are evicted. 0 disables eviction."`
+	WorkerDiskUsageLimit    int `long:"worker-disk-usage-limit"   default:"95" description:"Percentage of a worker's disk in use above which no new containers are placed on it. 0 disables the limit."`
+
+	WorkerLandingDeadline          time.Duration `long:"worker-landing-deadline"            default:"0" description:"How long a landing worker waits for its builds before the rest are aborted so that it can land. 0 means wait indefinitely."`
+	RequeueBuildsOnLandingDeadline bool          `long:"requeue-builds-on-landing-deadline"             description:"Queue a new build of each job whose build is aborted at a worker's landing deadline, to run on another worker."`
+
+	WorkerHealthCheckInterval time.Duration `long:"worker-health-check-interval" default:"30s" description:"Interval on which to ping each worker's Garden and baggageclaim servers. 0 disables health checks."`
+	WorkerHealthCheckTimeout  time.Duration `long:"worker-health-check-timeout"  default:"5s"  description:"How long to wait for a worker to answer a health check."`
+	WorkerUnhealthyThreshold  int           `long:"worker-unhealthy-threshold"   default:"3"   description:"Number of consecutive failed health checks after which no new containers are placed on a worker. 0 disables the threshold."`
+
+	WorkerCircuitBreakerFailures int           `long:"worker-circuit-breaker-failures" default:"5"   description:"Number of consecutive failed requests to a worker after which requests to it fail fast until the cooldown passes. 0 disables the circuit breaker."`
+	WorkerCircuitBreakerCooldown time.Duration `long:"worker-circuit-breaker-cooldown" default:"30s" description:"How long a worker's circuit breaker stays open before a request is let through to try it again."`
+
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
+
+	NotificationDeliveryInterval     time.Duration `long:"notification-delivery-interval"      default:"10s" description:"Interval on which to deliver build status notifications to their subscriptions."`
+	NotificationTimeout              time.Duration `long:"notification-timeout"                default:"10s" description:"How long to wait for a notification subscription's endpoint to respond."`
+	NotificationMaxAttempts          int           `long:"notification-max-attempts"           default:"8"   description:"Number of attempts to deliver a notification before giving up on it."`
+	NotificationAllowPrivateNetworks bool          `long:"notification-allow-private-networks"               description:"Allow notifications to be delivered to loopback, private and link-local addresses."`
 }
 
 func (cmd *ATCCommand) WireDynamicFlags(commandFlags *flags.Command) {
@@ -202,11 +244,22 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		workerVersion = &version
 	}
 
-	logger, reconfigurableSink := cmd.constructLogger()
+	logger, reconfigurableSink, err := cmd.constructLogger()
+	if err != nil {
+		return nil, err
+	}
 
 	go metric.PeriodicallyEmit(logger.Session("periodic-metrics"), 10*time.Second)
 
-	cmd.configureMetrics(logger)
+	err = cmd.configureMetrics(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cmd.configureTracing(logger)
+	if err != nil {
+		return nil, err
+	}
 
 	connectionCountingDriverName := "connection-counting"
 	metric.SetupConnectionCountingDriver("postgres", cmd.Postgres.ConnectionString(), connectionCountingDriverName)
@@ -251,12 +304,19 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 	dbContainerFactory := dbng.NewContainerFactory(dbngConn)
 	dbPipelineFactory := dbng.NewPipelineFactory(dbngConn, lockFactory)
 	dbWorkerFactory := dbng.NewWorkerFactory(dbngConn)
-	dbWorkerLifecycle := dbng.NewWorkerLifecycle(dbngConn)
+	dbWorkerLifecycle := dbng.NewWorkerLifecycle(dbngConn, metric.NewWorkerStateObserver(logger.Session("worker-lifecycle")))
 	dbResourceCacheFactory := dbng.NewResourceCacheFactory(dbngConn, lockFactory)
+	dbTaskCacheFactory := dbng.NewTaskCacheFactory(dbngConn)
+	dbBuildArtifactFactory := dbng.NewBuildArtifactFactory(dbngConn)
+	dbStoredArtifactFactory := dbng.NewStoredArtifactFactory(dbngConn)
+	artifactStore := cmd.constructArtifactStore()
+	dbTestResultFactory := dbng.NewTestResultFactory(dbngConn)
+	dbNotificationFactory := dbng.NewNotificationFactory(dbngConn)
+	dbAuditFactory := dbng.NewAuditFactory(dbngConn)
 	dbResourceConfigFactory := dbng.NewResourceConfigFactory(dbngConn, lockFactory)
 	dbWorkerBaseResourceTypeFactory := dbng.NewWorkerBaseResourceTypeFactory(dbngConn)
 	resourceFetcherFactory := resource.NewFetcherFactory(sqlDB, clock.NewClock(), dbResourceCacheFactory)
-	workerClient := cmd.constructWorkerPool(
+	workerClient, err := cmd.constructWorkerPool(
 		logger,
 		sqlDB,
 		resourceFetcherFactory,
@@ -269,16 +329,21 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		dbTeamFactory,
 		workerVersion,
 	)
+	if err != nil {
+		return nil, err
+	}
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resourceFactoryFactory.FactoryFor(workerClient)
 	teamDBFactory := db.NewTeamDBFactory(dbConn, bus, lockFactory)
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, teamDBFactory)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbTaskCacheFactory, dbBuildArtifactFactory, dbStoredArtifactFactory, artifactStore, dbTestResultFactory, teamDBFactory)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		cmd.ResourceCheckingInterval,
 		engine,
+		dbTeamFactory,
+		dbng.NewAdmissionQueue(dbngConn, cmd.MaxConcurrentBuilds),
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
@@ -312,6 +377,13 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		return nil, err
 	}
 
+	auditSinks, err := cmd.Audit.Sinks()
+	if err != nil {
+		return nil, err
+	}
+
+	auditor := audit.NewAuditor(dbAuditFactory, auditSinks, audit.SinkBufferSize)
+
 	drain := make(chan struct{})
 
 	apiHandler, err := cmd.constructAPIHandler(
@@ -322,9 +394,16 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		dbTeamFactory,
 		dbPipelineFactory,
 		dbWorkerFactory,
+		dbWorkerLifecycle,
 		dbVolumeFactory,
 		dbContainerFactory,
 		dbBuildFactory,
+		dbStoredArtifactFactory,
+		artifactStore,
+		dbTestResultFactory,
+		dbNotificationFactory,
+		dbAuditFactory,
+		auditor,
 		providerFactory,
 		signingKey,
 		engine,
@@ -457,6 +536,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 				gc.NewWorkerCollector(
 					logger.Session("worker-collector"),
 					dbWorkerLifecycle,
+					cmd.WorkerLandingDeadline,
+					cmd.RequeueBuildsOnLandingDeadline,
 				),
 				gc.NewResourceCacheUseCollector(
 					logger.Session("resource-cache-use-collector"),
@@ -474,6 +555,28 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 					logger.Session("resource-cache-collector"),
 					dbResourceCacheFactory,
 				),
+				gc.NewTaskCacheCollector(
+					logger.Session("task-cache-collector"),
+					dbTaskCacheFactory,
+				),
+				gc.NewBuildArtifactCollector(
+					logger.Session("build-artifact-collector"),
+					dbPipelineFactory,
+					dbBuildArtifactFactory,
+				),
+				gc.NewStoredArtifactCollector(
+					logger.Session("stored-artifact-collector"),
+					dbPipelineFactory,
+					dbStoredArtifactFactory,
+					artifactStore,
+					cmd.ArtifactStore.MaxAge,
+				),
+				gc.NewVolumeEvictionCollector(
+					logger.Session("volume-eviction-collector"),
+					dbWorkerFactory,
+					dbVolumeFactory,
+					cmd.VolumeEvictionThreshold,
+				),
 				gc.NewVolumeCollector(
 					logger.Session("volume-collector"),
 					dbVolumeFactory,
@@ -497,6 +600,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			gc.NewBuildReaper(
 				logger.Session("build-reaper"),
 				dbPipelineFactory,
+				dbStoredArtifactFactory,
+				artifactStore,
 				500,
 			),
 			"build-reaper",
@@ -504,6 +609,51 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 			clock.NewClock(),
 			30*time.Second,
 		)},
+
+		{"team-quota-usage-emitter", lockrunner.NewRunner(
+			logger.Session("team-quota-usage-emitter-runner"),
+			metric.NewTeamQuotaUsageEmitter(
+				logger.Session("team-quota-usage-emitter"),
+				dbTeamFactory,
+			),
+			"team-quota-usage-emitter",
+			sqlDB,
+			clock.NewClock(),
+			30*time.Second,
+		)},
+
+		{"notification-deliverer", lockrunner.NewRunner(
+			logger.Session("notification-deliverer-runner"),
+			notifications.NewDeliverer(
+				logger.Session("notification-deliverer"),
+				dbNotificationFactory,
+				notifications.NewClient(cmd.NotificationTimeout, cmd.NotificationAllowPrivateNetworks),
+				clock.NewClock(),
+				cmd.ExternalURL.String(),
+				cmd.NotificationMaxAttempts,
+			),
+			"notification-deliverer",
+			sqlDB,
+			clock.NewClock(),
+			cmd.NotificationDeliveryInterval,
+		)},
+	}
+
+	if cmd.WorkerHealthCheckInterval != 0 {
+		members = append(members, grouper.Member{"worker-health-prober", lockrunner.NewRunner(
+			logger.Session("worker-health-prober-runner"),
+			transport.NewHealthProber(
+				logger.Session("worker-health-prober"),
+				dbWorkerFactory,
+				&http.Transport{DisableKeepAlives: true},
+				clock.NewClock(),
+				cmd.WorkerHealthCheckTimeout,
+			),
+			"worker-health-prober",
+			sqlDB,
+			clock.NewClock(),
+			cmd.WorkerHealthCheckInterval,
+		)})
 	}
 
 	if cmd.Worker.GardenURL.URL() != nil {
@@ -533,6 +683,8 @@ func (cmd *ATCCommand) Runner(args []string) (ifrit.Runner, error) {
 		httpHandler,
 	)})
 
+	members = append(members, metric.Runners()...)
+
 	return onReady(grouper.NewParallel(os.Interrupt, members), func() {
 		logData := lager.Data{
 			"http":  cmd.nonTLSBindAddr(),
@@ -568,6 +720,14 @@ func onReady(runner ifrit.Runner, cb func()) ifrit.Runner {
 	})
 }
 
+func (cmd *ATCCommand) constructArtifactStore() blobstore.Store {
+	if cmd.ArtifactStore.LocalDir == "" {
+		return nil
+	}
+
+	return blobstore.NewLocalStore(cmd.ArtifactStore.LocalDir.Path())
+}
+
 func (cmd *ATCCommand) oauthBaseURL() string {
 	baseURL := cmd.OAuthBaseURL.String()
 	if baseURL == "" {
@@ -659,24 +819,54 @@ func (cmd *ATCCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
-func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink) {
+func (cmd *ATCCommand) constructLogger() (lager.Logger, *lager.ReconfigurableSink, error) {
 	logger, reconfigurableSink := cmd.Logger.Logger("atc")
 
-	if cmd.Metrics.YellerAPIKey != "" {
-		yellerSink := zest.NewYellerSink(cmd.Metrics.YellerAPIKey, cmd.Metrics.YellerEnvironment)
-		logger.RegisterSink(yellerSink)
+	if cmd.Metrics.YellerAPIKey != "" || cmd.Metrics.YellerEnvironment != "" {
+		logger.Info("yeller-flags-deprecated", lager.Data{
+			"message": "Yeller is no longer supported and errors will not be reported to it; use --sentry-dsn and --sentry-environment instead",
+		})
+	}
+
+	if cmd.Metrics.SentryDSN != "" {
+		host := cmd.Metrics.HostName
+		if host == "" {
+			host, _ = os.Hostname()
+		}
+
+		sentrySink, err := errorreporting.NewSentrySink(errorreporting.SentryConfig{
+			DSN:            cmd.Metrics.SentryDSN,
+			Environment:    cmd.Metrics.SentryEnvironment,
+			ServerName:     host,
+			RateLimit:      cmd.Metrics.SentryRateLimit,
+			DedupeInterval: cmd.Metrics.SentryDedupeInterval,
+		}, clock.NewClock())
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid Sentry DSN: %s", err)
+		}
+
+		logger.RegisterSink(sentrySink)
 	}
 
-	return logger, reconfigurableSink
+	return logger, reconfigurableSink, nil
 }
 
-func (cmd *ATCCommand) configureMetrics(logger lager.Logger) {
+func (cmd *ATCCommand) configureMetrics(logger lager.Logger) error {
 	host := cmd.Metrics.HostName
 	if host == "" {
 		host, _ = os.Hostname()
 	}
 
-	metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
+	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes)
+}
+
+func (cmd *ATCCommand) configureTracing(logger lager.Logger) error {
+	host := cmd.Metrics.HostName
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+
+	return tracing.Initialize(logger.Session("tracing"), cmd.Tracing, host)
 }
 
 func (cmd *ATCCommand) constructDBConn(driverName string, logger lager.Logger, newKey *dbng.EncryptionKey, oldKey *dbng.EncryptionKey) (db.Conn, dbng.Conn, error) {
@@ -721,7 +911,14 @@ func (cmd *ATCCommand) constructWorkerPool(
 	dbWorkerFactory dbng.WorkerFactory,
 	dbTeamFactory dbng.TeamFactory,
 	workerVersion *version.Version,
-) worker.Client {
+) (worker.Client, error) {
+	randSource := worker.NewRandSource(time.Now().UnixNano())
+
+	placementStrategy, err := worker.NewContainerPlacementStrategy(cmd.ContainerPlacementStrategy, randSource)
+	if err != nil {
+		return nil, err
+	}
+
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
 		resourceFetcherFactory,
 		resourceFactoryFactory,
@@ -733,6 +930,12 @@ func (cmd *ATCCommand) constructWorkerPool(
 		worker.NewDBWorkerProvider(
 			sqlDB,
 			retryhttp.NewExponentialBackOffFactory(5*time.Minute),
+			transport.NewCircuitBreakers(
+				logger.Session("circuit-breakers"),
+				clock.NewClock(),
+				cmd.WorkerCircuitBreakerFailures,
+				cmd.WorkerCircuitBreakerCooldown,
+			),
 			image.NewImageFactory(imageResourceFetcherFactory),
 			dbResourceCacheFactory,
 			dbResourceConfigFactory,
@@ -742,7 +945,13 @@ func (cmd *ATCCommand) constructWorkerPool(
 			dbWorkerFactory,
 			workerVersion,
 		),
-	)
+		dbTeamFactory,
+		placementStrategy,
+		randSource,
+		clock.NewClock(),
+		cmd.WorkerDiskUsageLimit,
+		cmd.WorkerUnhealthyThreshold,
+	), nil
 }
 
 func (cmd *ATCCommand) loadOrGenerateSigningKey() (*rsa.PrivateKey, error) {
@@ -818,6 +1027,11 @@ func (cmd *ATCCommand) constructEngine(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTaskCacheFactory dbng.TaskCacheFactory,
+	dbBuildArtifactFactory dbng.BuildArtifactFactory,
+	dbStoredArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	dbTestResultFactory dbng.TestResultFactory,
 	teamDBFactory db.TeamDBFactory,
 ) engine.Engine {
 	gardenFactory := exec.NewGardenFactory(
@@ -825,6 +1039,11 @@ func (cmd *ATCCommand) constructEngine(
 		resourceFetcher,
 		resourceFactory,
 		dbResourceCacheFactory,
+		dbTaskCacheFactory,
+		dbBuildArtifactFactory,
+		dbStoredArtifactFactory,
+		artifactStore,
+		dbTestResultFactory,
 	)
 
 	execV2Engine := engine.NewExecEngine(
@@ -878,9 +1097,16 @@ func (cmd *ATCCommand) constructAPIHandler(
 	dbTeamFactory dbng.TeamFactory,
 	dbPipelineFactory dbng.PipelineFactory,
 	dbWorkerFactory dbng.WorkerFactory,
+	dbWorkerLifecycle dbng.WorkerLifecycle,
 	dbVolumeFactory dbng.VolumeFactory,
 	dbContainerFactory dbng.ContainerFactory,
 	dbBuildFactory dbng.BuildFactory,
+	dbStoredArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	dbTestResultFactory dbng.TestResultFactory,
+	dbNotificationFactory dbng.NotificationFactory,
+	dbAuditFactory dbng.AuditFactory,
+	auditor audit.Auditor,
 	providerFactory auth.OAuthFactory,
 	signingKey *rsa.PrivateKey,
 	engine engine.Engine,
@@ -907,6 +1133,7 @@ func (cmd *ATCCommand) constructAPIHandler(
 
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
+		wrappa.NewAPITracingWrappa(),
 		wrappa.NewAPIAuthWrappa(
 			authValidator,
 			getTokenValidator,
@@ -916,6 +1143,11 @@ func (cmd *ATCCommand) constructAPIHandler(
 			checkBuildWriteAccessHandlerFactory,
 			checkWorkerTeamAccessHandlerFactory,
 		),
+		wrappa.NewAPIAuditWrappa(
+			logger.Session("audit"),
+			auditor,
+			auth.JWTReader{PublicKey: &signingKey.PublicKey},
+		),
 		wrappa.NewConcourseVersionWrappa(Version),
 	}
 
@@ -933,9 +1165,16 @@ func (cmd *ATCCommand) constructAPIHandler(
 		dbTeamFactory,
 		dbPipelineFactory,
 		dbWorkerFactory,
+		dbWorkerLifecycle,
 		dbVolumeFactory,
 		dbContainerFactory,
 		dbBuildFactory,
+		dbStoredArtifactFactory,
+		artifactStore,
+		dbTestResultFactory,
+		dbNotificationFactory,
+		notifications.NewEndpointValidator(cmd.NotificationAllowPrivateNetworks),
+		dbAuditFactory,
 
 		sqlDB, // pipes.PipeDB
 
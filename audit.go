@@ -0,0 +1,24 @@
+package atc
+
+// AuditEvent records a call to a mutating API route.
+type AuditEvent struct {
+	ID     int    `json:"id"`
+	Time   int64  `json:"time"`
+	Route  string `json:"route"`
+	Method string `json:"method"`
+
+	Team     string `json:"team,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+
+	// Actor is the team the caller's token was issued for, "system" for
+	// tokens issued to components, and empty for anonymous callers.
+	Actor string `json:"actor,omitempty"`
+
+	// Params are the route's parameters, other than the team and pipeline,
+	// and its query parameters, without any that look like secrets.
+	Params map[string]string `json:"params,omitempty"`
+
+	Status int `json:"status"`
+}
+
+const AuditActorSystem = "system"
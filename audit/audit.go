@@ -0,0 +1,117 @@
+package audit
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+type Config struct {
+	File string `long:"audit-file" description:"File to append audit events to, one JSON object per line, in addition to recording them in the database."`
+
+	SyslogAddress string `long:"audit-syslog-address" description:"Address of a syslog server to stream audit events to, e.g. logs.example.com:514."`
+	SyslogNetwork string `long:"audit-syslog-network" default:"udp" choice:"udp" choice:"tcp" description:"Network over which to stream audit events to the syslog server."`
+}
+
+// Sinks returns the configured sinks that audit events are streamed to.
+func (config Config) Sinks() ([]Sink, error) {
+	sinks := []Sink{}
+
+	if config.File != "" {
+		sink, err := NewFileSink(config.File)
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
+	if config.SyslogAddress != "" {
+		sinks = append(sinks, NewSyslogSink(config.SyslogNetwork, config.SyslogAddress))
+	}
+
+	return sinks, nil
+}
+
+//go:generate counterfeiter . Sink
+
+type Sink interface {
+	Write(atc.AuditEvent) error
+}
+
+//go:generate counterfeiter . Auditor
+
+type Auditor interface {
+	Record(lager.Logger, atc.AuditEvent)
+}
+
+// SinkBufferSize is how many events are buffered for each sink before
+// further events are dropped.
+const SinkBufferSize = 1000
+
+type auditor struct {
+	factory dbng.AuditFactory
+	queues  []*sinkQueue
+}
+
+// NewAuditor streams events to each sink from its own buffer of the given
+// size, so that a slow or failing sink neither delays the requests being
+// audited nor the other sinks.
+func NewAuditor(factory dbng.AuditFactory, sinks []Sink, bufferSize int) Auditor {
+	queues := make([]*sinkQueue, len(sinks))
+	for i, sink := range sinks {
+		queues[i] = &sinkQueue{
+			sink:   sink,
+			events: make(chan sinkEvent, bufferSize),
+		}
+
+		go queues[i].run()
+	}
+
+	return &auditor{
+		factory: factory,
+		queues:  queues,
+	}
+}
+
+// Record saves the event and queues it for each sink. Failures are logged
+// rather than failing the request being audited; an event that could not be
+// saved is still streamed, without an id.
+func (a *auditor) Record(logger lager.Logger, event atc.AuditEvent) {
+	recorded, err := a.factory.RecordEvent(event)
+	if err != nil {
+		logger.Error("failed-to-record-audit-event", err, lager.Data{"route": event.Route})
+		recorded = event
+	}
+
+	for _, queue := range a.queues {
+		queue.enqueue(logger, recorded)
+	}
+}
+
+type sinkEvent struct {
+	logger lager.Logger
+	event  atc.AuditEvent
+}
+
+type sinkQueue struct {
+	sink   Sink
+	events chan sinkEvent
+}
+
+func (queue *sinkQueue) enqueue(logger lager.Logger, event atc.AuditEvent) {
+	select {
+	case queue.events <- sinkEvent{logger: logger, event: event}:
+	default:
+		logger.Error("audit-sink-queue-full", nil, lager.Data{"route": event.Route})
+	}
+}
+
+func (queue *sinkQueue) run() {
+	for queued := range queue.events {
+		err := queue.sink.Write(queued.event)
+		if err != nil {
+			queued.logger.Error("failed-to-stream-audit-event", err, lager.Data{"route": queued.event.Route})
+		}
+	}
+}
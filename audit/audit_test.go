@@ -0,0 +1,103 @@
+package audit_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auditor", func() {
+	var (
+		fakeAuditFactory *dbngfakes.FakeAuditFactory
+		fakeSink         *auditfakes.FakeSink
+		logger           *lagertest.TestLogger
+		event            atc.AuditEvent
+
+		auditor audit.Auditor
+	)
+
+	BeforeEach(func() {
+		fakeAuditFactory = new(dbngfakes.FakeAuditFactory)
+		fakeSink = new(auditfakes.FakeSink)
+		logger = lagertest.NewTestLogger("test")
+
+		event = atc.AuditEvent{
+			Route:  atc.PausePipeline,
+			Method: "PUT",
+			Team:   "some-team",
+			Status: 200,
+		}
+
+		auditor = audit.NewAuditor(fakeAuditFactory, []audit.Sink{fakeSink}, 1)
+	})
+
+	Context("when the event is recorded", func() {
+		var recorded atc.AuditEvent
+
+		BeforeEach(func() {
+			recorded = event
+			recorded.ID = 42
+			recorded.Time = 123
+
+			fakeAuditFactory.RecordEventReturns(recorded, nil)
+		})
+
+		It("streams the recorded event to the sinks", func() {
+			auditor.Record(logger, event)
+
+			Expect(fakeAuditFactory.RecordEventArgsForCall(0)).To(Equal(event))
+
+			Eventually(fakeSink.WriteCallCount).Should(Equal(1))
+			Expect(fakeSink.WriteArgsForCall(0)).To(Equal(recorded))
+		})
+	})
+
+	Context("when the event can not be recorded", func() {
+		BeforeEach(func() {
+			fakeAuditFactory.RecordEventReturns(atc.AuditEvent{}, errors.New("nope"))
+		})
+
+		It("still streams it to the sinks", func() {
+			auditor.Record(logger, event)
+
+			Eventually(fakeSink.WriteCallCount).Should(Equal(1))
+			Expect(fakeSink.WriteArgsForCall(0)).To(Equal(event))
+		})
+	})
+
+	Context("when a sink is slow", func() {
+		var unblock chan struct{}
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+
+			fakeSink.WriteStub = func(atc.AuditEvent) error {
+				<-unblock
+				return nil
+			}
+		})
+
+		AfterEach(func() {
+			close(unblock)
+		})
+
+		It("doesn't wait for it, dropping events once its buffer is full", func() {
+			auditor.Record(logger, event)
+			Eventually(fakeSink.WriteCallCount).Should(Equal(1))
+
+			auditor.Record(logger, event)
+			auditor.Record(logger, event)
+
+			Expect(fakeAuditFactory.RecordEventCallCount()).To(Equal(3))
+			Expect(logger).To(gbytes.Say("audit-sink-queue-full"))
+		})
+	})
+})
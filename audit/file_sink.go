@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/concourse/atc"
+)
+
+type fileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileSink appends events to the file, one JSON object per line.
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileSink{
+		file: file,
+	}, nil
+}
+
+func (sink *fileSink) Write(event atc.AuditEvent) error {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	return json.NewEncoder(sink.file).Encode(event)
+}
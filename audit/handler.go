@@ -0,0 +1,119 @@
+package audit
+
+import (
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+var secretParams = []string{"token", "secret", "password", "key"}
+
+type AuditHandler struct {
+	Logger            lager.Logger
+	Auditor           Auditor
+	UserContextReader auth.UserContextReader
+	Route             rata.Route
+	Handler           http.Handler
+}
+
+func WrapHandler(logger lager.Logger, auditor Auditor, userContextReader auth.UserContextReader, route rata.Route, handler http.Handler) http.Handler {
+	return AuditHandler{
+		Logger:            logger,
+		Auditor:           auditor,
+		UserContextReader: userContextReader,
+		Route:             route,
+		Handler:           handler,
+	}
+}
+
+func (handler AuditHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event := atc.AuditEvent{
+		Route:  handler.Route.Name,
+		Method: handler.Route.Method,
+		Actor:  handler.actor(r),
+		Params: map[string]string{},
+	}
+
+	for _, segment := range strings.Split(handler.Route.Path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		name := strings.TrimPrefix(segment, ":")
+		value := rata.Param(r, name)
+
+		switch name {
+		case "team_name":
+			event.Team = value
+		case "pipeline_name":
+			event.Pipeline = value
+		default:
+			event.Params[name] = value
+		}
+	}
+
+	for name, values := range r.URL.Query() {
+		if strings.HasPrefix(name, ":") || isSecret(name) || len(values) == 0 {
+			continue
+		}
+
+		event.Params[name] = values[0]
+	}
+
+	if len(event.Params) == 0 {
+		event.Params = nil
+	}
+
+	// routes outside of a team act on the caller's team, e.g. one-off builds
+	if event.Team == "" && event.Actor != atc.AuditActorSystem {
+		event.Team = event.Actor
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	handler.Handler.ServeHTTP(recorder, r)
+
+	event.Status = recorder.status
+
+	handler.Auditor.Record(handler.Logger, event)
+}
+
+func (handler AuditHandler) actor(r *http.Request) string {
+	isSystem, found := handler.UserContextReader.GetSystem(r)
+	if found && isSystem {
+		return atc.AuditActorSystem
+	}
+
+	teamName, _, found := handler.UserContextReader.GetTeam(r)
+	if found {
+		return teamName
+	}
+
+	return ""
+}
+
+func isSecret(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, secret := range secretParams {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
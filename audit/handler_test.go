@@ -0,0 +1,129 @@
+package audit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditHandler", func() {
+	var (
+		fakeAuditor           *auditfakes.FakeAuditor
+		fakeUserContextReader *authfakes.FakeUserContextReader
+
+		route  rata.Route
+		path   string
+		status int
+
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		fakeAuditor = new(auditfakes.FakeAuditor)
+		fakeUserContextReader = new(authfakes.FakeUserContextReader)
+
+		route, _ = atc.Routes.FindRouteByName(atc.CheckResourceWebHook)
+		path = "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/check/webhook?webhook_token=some-token&from=v1"
+		status = http.StatusOK
+	})
+
+	JustBeforeEach(func() {
+		handler := audit.WrapHandler(
+			lagertest.NewTestLogger("test"),
+			fakeAuditor,
+			fakeUserContextReader,
+			route,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(status)
+			}),
+		)
+
+		router, err := rata.NewRouter(rata.Routes{route}, rata.Handlers{route.Name: handler})
+		Expect(err).NotTo(HaveOccurred())
+
+		request, err := http.NewRequest(route.Method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+	})
+
+	It("records the route, its parameters and the result", func() {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		Expect(fakeAuditor.RecordCallCount()).To(Equal(1))
+		_, event := fakeAuditor.RecordArgsForCall(0)
+		Expect(event).To(Equal(atc.AuditEvent{
+			Route:    atc.CheckResourceWebHook,
+			Method:   "POST",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Params: map[string]string{
+				"resource_name": "some-resource",
+				"from":          "v1",
+			},
+			Status: http.StatusOK,
+		}))
+	})
+
+	Context("when the request is rejected", func() {
+		BeforeEach(func() {
+			status = http.StatusUnauthorized
+		})
+
+		It("records the rejection", func() {
+			_, event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Status).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("when the caller has a team's token", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetTeamReturns("main", true, true)
+		})
+
+		It("records the team as the actor", func() {
+			_, event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Actor).To(Equal("main"))
+			Expect(event.Team).To(Equal("some-team"))
+		})
+
+		Context("when the route is not for a team", func() {
+			BeforeEach(func() {
+				route, _ = atc.Routes.FindRouteByName(atc.AbortBuild)
+				path = "/api/v1/builds/42/abort"
+			})
+
+			It("records the event for the actor's team", func() {
+				_, event := fakeAuditor.RecordArgsForCall(0)
+				Expect(event.Team).To(Equal("main"))
+				Expect(event.Params).To(Equal(map[string]string{"build_id": "42"}))
+			})
+		})
+	})
+
+	Context("when the caller has a system token", func() {
+		BeforeEach(func() {
+			fakeUserContextReader.GetSystemReturns(true, true)
+
+			route, _ = atc.Routes.FindRouteByName(atc.RegisterWorker)
+			path = "/api/v1/workers"
+		})
+
+		It("records the system as the actor, for no team", func() {
+			_, event := fakeAuditor.RecordArgsForCall(0)
+			Expect(event.Actor).To(Equal(atc.AuditActorSystem))
+			Expect(event.Team).To(BeEmpty())
+			Expect(event.Params).To(BeNil())
+		})
+	})
+})
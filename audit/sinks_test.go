@@ -0,0 +1,104 @@
+package audit_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sinks", func() {
+	var event atc.AuditEvent
+
+	BeforeEach(func() {
+		event = atc.AuditEvent{
+			ID:       42,
+			Time:     123,
+			Route:    atc.SaveConfig,
+			Method:   "PUT",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Actor:    "some-team",
+			Status:   200,
+		}
+	})
+
+	Describe("FileSink", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "audit")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("appends each event as a line of JSON", func() {
+			path := filepath.Join(dir, "audit.log")
+
+			err := ioutil.WriteFile(path, []byte("existing\n"), 0600)
+			Expect(err).NotTo(HaveOccurred())
+
+			sink, err := audit.NewFileSink(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sink.Write(event)).To(Succeed())
+			Expect(sink.Write(event)).To(Succeed())
+
+			contents, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(string(contents)), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(Equal("existing"))
+
+			var written atc.AuditEvent
+			Expect(json.Unmarshal([]byte(lines[2]), &written)).To(Succeed())
+			Expect(written).To(Equal(event))
+		})
+	})
+
+	Describe("SyslogSink", func() {
+		var listener net.PacketConn
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("sends each event as a syslog message", func() {
+			sink := audit.NewSyslogSink("udp", listener.LocalAddr().String())
+			Expect(sink.Write(event)).To(Succeed())
+
+			buf := make([]byte, 4096)
+			n, _, err := listener.ReadFrom(buf)
+			Expect(err).NotTo(HaveOccurred())
+
+			message := string(buf[:n])
+			Expect(message).To(HavePrefix("<134>1 "))
+			Expect(message).To(ContainSubstring(" atc - audit - "))
+
+			payload := message[strings.Index(message, "{"):]
+
+			var sent atc.AuditEvent
+			Expect(json.Unmarshal([]byte(payload), &sent)).To(Succeed())
+			Expect(sent).To(Equal(event))
+		})
+	})
+})
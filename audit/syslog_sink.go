@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+// facility local0, severity informational
+const syslogPriority = 16*8 + 6
+
+const syslogDialTimeout = 5 * time.Second
+
+const syslogWriteTimeout = 5 * time.Second
+
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+
+	lock sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink streams events as RFC 5424 messages whose body is the event as
+// JSON. The connection is made on the first event, and made again after it
+// fails; a write which takes longer than syslogWriteTimeout fails.
+func NewSyslogSink(network string, address string) Sink {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &syslogSink{
+		network:  network,
+		address:  address,
+		hostname: hostname,
+	}
+}
+
+func (sink *syslogSink) Write(event atc.AuditEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf(
+		"<%d>1 %s %s atc - audit - %s\n",
+		syslogPriority,
+		time.Now().UTC().Format(time.RFC3339),
+		sink.hostname,
+		payload,
+	)
+
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	if sink.conn == nil {
+		conn, err := net.DialTimeout(sink.network, sink.address, syslogDialTimeout)
+		if err != nil {
+			return err
+		}
+
+		sink.conn = conn
+	}
+
+	err = sink.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+	if err == nil {
+		_, err = sink.conn.Write([]byte(message))
+	}
+
+	if err != nil {
+		sink.conn.Close()
+		sink.conn = nil
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package blobstore_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/atc/blobstore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LocalStore", func() {
+	var (
+		dir   string
+		store blobstore.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "blobstore")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = blobstore.NewLocalStore(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Put", func() {
+		It("returns the size of the blob", func() {
+			size, err := store.Put("builds/1/some-blob", bytes.NewBufferString("some-contents"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(size).To(Equal(int64(len("some-contents"))))
+		})
+
+		It("replaces an existing blob", func() {
+			_, err := store.Put("some-blob", bytes.NewBufferString("some-contents"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = store.Put("some-blob", bytes.NewBufferString("some-other-contents"))
+			Expect(err).NotTo(HaveOccurred())
+
+			blob, err := store.Get("some-blob")
+			Expect(err).NotTo(HaveOccurred())
+			defer blob.Close()
+
+			Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-other-contents")))
+		})
+
+		It("refuses keys outside of the directory", func() {
+			_, err := store.Put("../some-blob", bytes.NewBufferString("some-contents"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Get", func() {
+		Context("when the blob exists", func() {
+			BeforeEach(func() {
+				_, err := store.Put("builds/1/some-blob", bytes.NewBufferString("some-contents"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns its contents", func() {
+				blob, err := store.Get("builds/1/some-blob")
+				Expect(err).NotTo(HaveOccurred())
+				defer blob.Close()
+
+				Expect(ioutil.ReadAll(blob)).To(Equal([]byte("some-contents")))
+			})
+		})
+
+		Context("when the blob does not exist", func() {
+			It("returns ErrBlobNotFound", func() {
+				_, err := store.Get("builds/1/some-blob")
+				Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+			})
+		})
+	})
+
+	Describe("Delete", func() {
+		It("removes the blob", func() {
+			_, err := store.Put("some-blob", bytes.NewBufferString("some-contents"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(store.Delete("some-blob")).To(Succeed())
+
+			_, err = store.Get("some-blob")
+			Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+		})
+
+		It("succeeds when the blob does not exist", func() {
+			Expect(store.Delete("some-blob")).To(Succeed())
+		})
+	})
+})
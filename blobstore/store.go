@@ -0,0 +1,17 @@
+package blobstore
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+//go:generate counterfeiter . Store
+
+// Store keeps blobs, such as archived build artifacts, under opaque keys.
+type Store interface {
+	Put(key string, blob io.Reader) (int64, error)
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
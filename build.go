@@ -23,6 +23,9 @@ type Build struct {
 	StartTime    int64  `json:"start_time,omitempty"`
 	EndTime      int64  `json:"end_time,omitempty"`
 	ReapTime     int64  `json:"reap_time,omitempty"`
+	Priority     int    `json:"priority,omitempty"`
+
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -60,4 +63,6 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
+	WorkerCapacity      BuildPreparationStatus            `json:"worker_capacity"`
+	QueuePosition       int                               `json:"queue_position,omitempty"`
 }
@@ -0,0 +1,7 @@
+package atc
+
+type BuildArtifact struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+}
@@ -24,6 +24,13 @@ type Config struct {
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	SerialGroups SerialGroupConfigs `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
+
+	// AdmissionWeight is the pipeline's share of its team's running builds
+	// relative to the team's other pipelines when the number of concurrent
+	// builds is limited. Pipelines default to a weight of 1.
+	AdmissionWeight int `yaml:"admission_weight,omitempty" json:"admission_weight,omitempty" mapstructure:"admission_weight"`
 }
 
 type RawConfig string
@@ -50,6 +57,59 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, bool) {
 	return GroupConfig{}, false
 }
 
+// SerialGroupConfig declares how many builds of the jobs in a serial group
+// may run at once. Groups that are not declared allow one.
+type SerialGroupConfig struct {
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+	Size int    `yaml:"size" json:"size" mapstructure:"size"`
+}
+
+type SerialGroupConfigs []SerialGroupConfig
+
+func (groups SerialGroupConfigs) Lookup(name string) (SerialGroupConfig, bool) {
+	for _, group := range groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+
+	return SerialGroupConfig{}, false
+}
+
+func (groups SerialGroupConfigs) Validate() error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, group := range groups {
+		var identifier string
+		if group.Name == "" {
+			identifier = fmt.Sprintf("serial_groups[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("serial_groups.%s", group.Name)
+		}
+
+		if other, exists := names[group.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"serial_groups[%d] and serial_groups[%d] have the same name ('%s')",
+					other, i, group.Name))
+		} else if group.Name != "" {
+			names[group.Name] = i
+		}
+
+		if group.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if group.Size < 1 {
+			errorMessages = append(errorMessages, identifier+" must have a size of at least 1")
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 type ResourceConfig struct {
 	Name         string `yaml:"name" json:"name" mapstructure:"name"`
 	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token" mapstructure:"webhook_token"`
@@ -57,6 +117,10 @@ type ResourceConfig struct {
 	Source       Source `yaml:"source" json:"source" mapstructure:"source"`
 	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
 	Tags         Tags   `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+
+	// Webhook verifies check webhooks by their signature instead of the
+	// webhook_token query parameter.
+	Webhook *WebhookConfig `yaml:"webhook,omitempty" json:"webhook,omitempty" mapstructure:"webhook"`
 }
 
 type ResourceType struct {
@@ -252,6 +316,8 @@ type PlanConfig struct {
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// whether to fetch an output published by the passed job instead of a resource
+	Artifact bool `yaml:"artifact,omitempty" json:"artifact,omitempty" mapstructure:"artifact"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
@@ -279,6 +345,18 @@ type PlanConfig struct {
 	// used to specify an image artifact from a previous build to be used as the image for a subsequent task container
 	ImageArtifactName string `yaml:"image,omitempty" json:"image,omitempty" mapstructure:"image"`
 
+	// used by Task to reuse the outputs of a previous successful run when the
+	// task's config, image and inputs have not changed
+	SkipIfUnchanged bool `yaml:"skip_if_unchanged,omitempty" json:"skip_if_unchanged,omitempty" mapstructure:"skip_if_unchanged"`
+	// mixed into the task's cache key, e.g. to invalidate previous runs
+	CacheKey string `yaml:"cache_key,omitempty" json:"cache_key,omitempty" mapstructure:"cache_key"`
+
+	// used by Task to archive the named outputs so they can be downloaded from the build
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty" mapstructure:"artifacts"`
+
+	// used by Task to record the test results found in one of its outputs
+	TestReports *TestReportsConfig `yaml:"test_reports,omitempty" json:"test_reports,omitempty" mapstructure:"test_reports"`
+
 	// used by Put to specify params for the subsequent Get
 	GetParams Params `yaml:"get_params,omitempty" json:"get_params,omitempty" mapstructure:"get_params"`
 
@@ -352,6 +430,13 @@ func (config PlanConfig) Hooks() Hooks {
 	return Hooks{config.Failure, config.Ensure, config.Success}
 }
 
+// TestReportsConfig locates the JUnit XML reports written by a task within
+// one of its outputs. Glob is matched against paths relative to the output.
+type TestReportsConfig struct {
+	Output string `yaml:"output" json:"output" mapstructure:"output"`
+	Glob   string `yaml:"glob" json:"glob" mapstructure:"glob"`
+}
+
 type ResourceConfigs []ResourceConfig
 
 func (resources ResourceConfigs) Lookup(name string) (ResourceConfig, bool) {
@@ -19,6 +19,9 @@ const (
 	LockTypeBatch
 	LockTypeVolumeCreating
 	LockTypeContainerCreating
+	LockTypeTeamSerialGroup
+	LockTypeTeamQuota
+	LockTypeAdmissionQueue
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -47,6 +50,26 @@ func NewContainerCreatingLockID(containerID int) LockID {
 	return LockID{LockTypeContainerCreating, containerID}
 }
 
+// NewTeamSerialGroupLockID identifies a serial group spanning the pipelines
+// of a team. It is only held within a transaction, while scheduling a build
+// in the group.
+func NewTeamSerialGroupLockID(teamID int, serialGroup string) LockID {
+	return LockID{LockTypeTeamSerialGroup, lockIDFromString(fmt.Sprintf("%d/%s", teamID, serialGroup))}
+}
+
+// NewTeamQuotaLockID identifies a team's quota on running builds. Like a
+// team's serial groups, it is only held within a transaction.
+func NewTeamQuotaLockID(teamID int) LockID {
+	return LockID{LockTypeTeamQuota, teamID}
+}
+
+// NewAdmissionQueueLockID identifies the slots of the admission queue, which
+// are shared by every team. It is only held within a transaction, while
+// scheduling an admitted build.
+func NewAdmissionQueueLockID() LockID {
+	return LockID{LockTypeAdmissionQueue, 0}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
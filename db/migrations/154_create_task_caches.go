@@ -0,0 +1,43 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateTaskCaches(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE task_caches (
+			id serial PRIMARY KEY,
+			team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			job_id int REFERENCES jobs (id) ON DELETE CASCADE,
+			build_id int REFERENCES builds (id) ON DELETE SET NULL,
+			step_name text NOT NULL,
+			key text NOT NULL,
+			outputs text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (team_id, key)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN task_cache_id int REFERENCES task_caches (id) ON DELETE SET NULL,
+		ADD COLUMN task_cache_output text
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX volumes_task_cache_id ON volumes (task_cache_id)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX task_caches_job_id_step_name ON task_caches (job_id, step_name)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateBuildArtifacts(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE build_artifacts (
+			id serial PRIMARY KEY,
+			build_id int NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (build_id, name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN build_artifact_id int REFERENCES build_artifacts (id) ON DELETE SET NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX volumes_build_artifact_id ON volumes (build_artifact_id)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
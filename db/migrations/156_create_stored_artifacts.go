@@ -0,0 +1,22 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateStoredArtifacts(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE stored_artifacts (
+			id serial PRIMARY KEY,
+			build_id int NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			name text NOT NULL,
+			blob_key text NOT NULL,
+			size bigint NOT NULL,
+			created_at timestamp with time zone NOT NULL DEFAULT now(),
+			UNIQUE (build_id, name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateTestResults(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE test_suites (
+			id serial PRIMARY KEY,
+			build_id int NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			plan_id text NOT NULL DEFAULT '',
+			name text NOT NULL,
+			duration bigint NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX test_suites_build_id_plan_id ON test_suites (build_id, plan_id)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE test_cases (
+			id serial PRIMARY KEY,
+			suite_id int NOT NULL REFERENCES test_suites (id) ON DELETE CASCADE,
+			class_name text NOT NULL,
+			name text NOT NULL,
+			status text NOT NULL,
+			duration bigint NOT NULL DEFAULT 0,
+			message text NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX test_cases_suite_id ON test_cases (suite_id)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
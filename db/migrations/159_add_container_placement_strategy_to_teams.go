@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddContainerPlacementStrategyToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN container_placement_strategy text NOT NULL DEFAULT '';
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddCapacityToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN cpu_capacity bigint,
+		ADD COLUMN memory_capacity bigint,
+		ADD COLUMN cpu_allocated bigint NOT NULL DEFAULT 0,
+		ADD COLUMN memory_allocated bigint NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN waiting_for_worker_capacity boolean NOT NULL DEFAULT false;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddQuotasToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN max_running_builds integer NOT NULL DEFAULT 0,
+		ADD COLUMN max_containers integer NOT NULL DEFAULT 0,
+		ADD COLUMN max_volumes integer NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddAdmissionRequestedAtToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN admission_requested_at timestamp with time zone;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX builds_admission_requested_at ON builds (admission_requested_at)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddPriorityToBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE builds
+		ADD COLUMN priority integer NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddSerialGroupsToTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN serial_groups json NOT NULL DEFAULT '[]';
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddDiskUsageToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN disk_used bigint,
+		ADD COLUMN disk_total bigint;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE volumes
+		ADD COLUMN last_used_at timestamp with time zone NOT NULL DEFAULT now();
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX volumes_worker_name_last_used_at ON volumes (worker_name, last_used_at)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddLandingStartedAtToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN landing_started_at timestamp with time zone;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE workers
+		SET landing_started_at = now()
+		WHERE state = 'landing'
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
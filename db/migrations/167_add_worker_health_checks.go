@@ -0,0 +1,34 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddWorkerHealthChecks(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE workers
+		ADD COLUMN health_check_failures integer NOT NULL DEFAULT 0;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE worker_health_checks (
+			id serial PRIMARY KEY,
+			worker_name text NOT NULL REFERENCES workers (name) ON DELETE CASCADE,
+			checked_at timestamp with time zone NOT NULL DEFAULT now(),
+			garden_latency bigint NOT NULL,
+			baggageclaim_latency bigint NOT NULL,
+			error text
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX worker_health_checks_worker_name_checked_at ON worker_health_checks (worker_name, checked_at)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateNotifications(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE notification_subscriptions (
+			id serial PRIMARY KEY,
+			team_id int NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+			pipeline_id int REFERENCES pipelines (id) ON DELETE CASCADE,
+			url text NOT NULL,
+			secret text,
+			nonce text,
+			jobs json NOT NULL DEFAULT '[]',
+			statuses json NOT NULL DEFAULT '[]',
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX notification_subscriptions_team_id ON notification_subscriptions (team_id)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE notification_outbox (
+			id serial PRIMARY KEY,
+			subscription_id int NOT NULL REFERENCES notification_subscriptions (id) ON DELETE CASCADE,
+			build_id int NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+			status text NOT NULL,
+			payload text NOT NULL,
+			attempts int NOT NULL DEFAULT 0,
+			next_attempt_at timestamp with time zone DEFAULT now(),
+			delivered_at timestamp with time zone,
+			created_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX notification_outbox_next_attempt_at ON notification_outbox (next_attempt_at) WHERE delivered_at IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE notification_deliveries (
+			id serial PRIMARY KEY,
+			notification_id int NOT NULL REFERENCES notification_outbox (id) ON DELETE CASCADE,
+			attempt int NOT NULL,
+			status_code int,
+			error text NOT NULL DEFAULT '',
+			duration bigint NOT NULL DEFAULT 0,
+			attempted_at timestamp with time zone NOT NULL DEFAULT now()
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX notification_deliveries_notification_id ON notification_deliveries (notification_id)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func CreateAuditEvents(tx migration.LimitedTx) error {
+	// names are recorded rather than ids so that events outlive the teams and
+	// pipelines they refer to
+	_, err := tx.Exec(`
+		CREATE TABLE audit_events (
+			id bigserial PRIMARY KEY,
+			time timestamp with time zone NOT NULL DEFAULT now(),
+			route text NOT NULL,
+			method text NOT NULL,
+			team_name text NOT NULL DEFAULT '',
+			pipeline_name text NOT NULL DEFAULT '',
+			actor text NOT NULL DEFAULT '',
+			params json NOT NULL DEFAULT '{}',
+			status int NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`CREATE INDEX audit_events_team_name ON audit_events (team_name, id)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'audit events are append-only';
+		END;
+		$$ LANGUAGE plpgsql
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		CREATE TRIGGER audit_events_append_only
+		BEFORE UPDATE OR DELETE ON audit_events
+		FOR EACH ROW EXECUTE PROCEDURE audit_events_append_only()
+	`)
+	if err != nil {
+		return err
+	}
+
+	// row triggers don't fire for TRUNCATE, so it is blocked separately
+	_, err = tx.Exec(`
+		CREATE TRIGGER audit_events_no_truncate
+		BEFORE TRUNCATE ON audit_events
+		FOR EACH STATEMENT EXECUTE PROCEDURE audit_events_append_only()
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import "github.com/concourse/atc/dbng/migration"
+
+func AddAdmissionWeights(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		ALTER TABLE teams
+		ADD COLUMN admission_weight integer NOT NULL DEFAULT 1;
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines
+		ADD COLUMN admission_weight integer NOT NULL DEFAULT 1;
+	`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -163,4 +163,21 @@ var Migrations = []migration.Migrator{
 	AddMetadataToResourceCache,
 	AddNonceToJobs,
 	AddNonceToPipelines,
+	CreateTaskCaches,
+	CreateBuildArtifacts,
+	CreateStoredArtifacts,
+	CreateTestResults,
+	AddMetadataToBuilds,
+	AddContainerPlacementStrategyToTeams,
+	AddCapacityToWorkers,
+	AddQuotasToTeams,
+	AddAdmissionRequestedAtToBuilds,
+	AddPriorityToBuilds,
+	AddSerialGroupsToTeams,
+	AddDiskUsageToWorkers,
+	AddLandingStartedAtToWorkers,
+	AddWorkerHealthChecks,
+	CreateNotifications,
+	CreateAuditEvents,
+	AddAdmissionWeights,
 }
@@ -0,0 +1,522 @@
+package dbng
+
+import (
+	"container/heap"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/lock"
+)
+
+// AdmissionRequestTTL is how long a pending build remains in the admission
+// queue after its scheduler last tried to start it. Builds that stop being
+// ready to start (e.g. because their job was paused) drop out of the queue
+// once it lapses.
+const AdmissionRequestTTL = time.Minute
+
+//go:generate counterfeiter . AdmissionQueue
+
+// AdmissionQueue orders the pending builds that are otherwise ready to start,
+// across all teams and pipelines, so that when the number of concurrently
+// running builds is limited the slots are shared fairly.
+//
+// Builds of a higher priority are always admitted first. Otherwise teams
+// share the running builds in proportion to their admission weights, and a
+// team's share is split between its pipelines in proportion to theirs; within
+// a pipeline builds start in the order they were created.
+type AdmissionQueue interface {
+	// Admit enqueues the build if it is not already queued, and returns
+	// whether it is near enough to the front of the queue to start.
+	Admit(build Build) (bool, error)
+
+	// Schedule schedules an admitted build in the given team serial groups,
+	// unless the builds scheduled since it was admitted have reached the
+	// limit on concurrent builds.
+	Schedule(build Build, serialGroups atc.SerialGroupConfigs) (bool, error)
+}
+
+type admissionQueue struct {
+	conn                Conn
+	maxConcurrentBuilds int
+}
+
+// NewAdmissionQueue constructs an AdmissionQueue allowing up to
+// maxConcurrentBuilds builds to run at once. If it is 0, builds are not
+// queued at all and every build is admitted.
+func NewAdmissionQueue(conn Conn, maxConcurrentBuilds int) AdmissionQueue {
+	return &admissionQueue{
+		conn:                conn,
+		maxConcurrentBuilds: maxConcurrentBuilds,
+	}
+}
+
+func (q *admissionQueue) Admit(build Build) (bool, error) {
+	if q.maxConcurrentBuilds == 0 {
+		return true, nil
+	}
+
+	_, err := psql.Update("builds").
+		Set("admission_requested_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": build.ID()}).
+		RunWith(q.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	running, err := loadRunningBuilds(q.conn)
+	if err != nil {
+		return false, err
+	}
+
+	available := q.maxConcurrentBuilds - running.total
+	if available <= 0 {
+		return false, nil
+	}
+
+	queue, err := loadAdmissionQueue(q.conn, available)
+	if err != nil {
+		return false, err
+	}
+
+	return newFairShareOrder(queue, running).position(build.ID(), available) != 0, nil
+}
+
+func (q *admissionQueue) Schedule(build Build, serialGroups atc.SerialGroupConfigs) (bool, error) {
+	if q.maxConcurrentBuilds == 0 {
+		return build.ScheduleInTeamSerialGroups(serialGroups)
+	}
+
+	tx, err := q.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	// the schedulers of every pipeline, on every ATC, admit builds into the
+	// same slots, so the slots are counted again and filled under one lock
+	lockID := lock.NewAdmissionQueueLockID()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lockID[0], lockID[1])
+	if err != nil {
+		return false, err
+	}
+
+	var running int
+	err = psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Or{
+			sq.Eq{"status": BuildStatusStarted},
+			sq.Eq{"status": BuildStatusPending, "scheduled": true},
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&running)
+	if err != nil {
+		return false, err
+	}
+
+	if running >= q.maxConcurrentBuilds {
+		return false, nil
+	}
+
+	scheduled, err := scheduleBuild(tx, build.ID(), build.TeamID(), serialGroups)
+	if err != nil {
+		return false, err
+	}
+
+	if !scheduled {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// admissionWeight returns the weight to store for a team or pipeline, which
+// defaults to 1.
+func admissionWeight(weight int) int {
+	if weight <= 0 {
+		return 1
+	}
+
+	return weight
+}
+
+type queuedBuild struct {
+	id             int
+	teamID         int
+	teamWeight     int
+	pipelineID     int
+	pipelineWeight int
+	priority       int
+}
+
+type runningBuilds struct {
+	total      int
+	byTeam     map[int]int
+	byPipeline map[int]int
+}
+
+// admissionQueuePosition returns the 1-based position of the build in the
+// admission queue, or 0 if it is not queued.
+func admissionQueuePosition(conn Conn, buildID int) (int, error) {
+	var queued int
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"id":        buildID,
+			"status":    BuildStatusPending,
+			"scheduled": false,
+		}).
+		Where(admissionRequested("admission_requested_at")).
+		RunWith(conn).
+		QueryRow().
+		Scan(&queued)
+	if err != nil {
+		return 0, err
+	}
+
+	if queued == 0 {
+		return 0, nil
+	}
+
+	running, err := loadRunningBuilds(conn)
+	if err != nil {
+		return 0, err
+	}
+
+	queue, err := loadAdmissionQueue(conn, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	return newFairShareOrder(queue, running).position(buildID, 0), nil
+}
+
+// admissionRequested matches the builds whose schedulers have tried to start
+// them recently enough for them to still be queued, given the column of their
+// admission_requested_at.
+func admissionRequested(column string) sq.Sqlizer {
+	return sq.Expr(fmt.Sprintf("%s > now() - interval '%d seconds'", column, int(AdmissionRequestTTL.Seconds())))
+}
+
+// loadAdmissionQueue returns the queued builds, highest priority first and
+// then oldest first. If limit isn't 0, only the first limit builds of each
+// pipeline are returned, since no later build of a pipeline can be within the
+// first limit builds of the queue.
+func loadAdmissionQueue(conn Conn, limit int) ([]queuedBuild, error) {
+	rows, err := conn.Query(`
+		SELECT id, team_id, team_weight, pipeline_id, pipeline_weight, priority
+		FROM (
+			SELECT b.id, b.team_id, t.admission_weight AS team_weight,
+				j.pipeline_id, p.admission_weight AS pipeline_weight, b.priority,
+				row_number() OVER (
+					PARTITION BY j.pipeline_id
+					ORDER BY b.priority DESC, b.id ASC
+				) AS pipeline_position
+			FROM builds b
+			JOIN jobs j ON b.job_id = j.id
+			JOIN pipelines p ON j.pipeline_id = p.id
+			JOIN teams t ON b.team_id = t.id
+			WHERE b.status = $1
+			AND NOT b.scheduled
+			AND b.admission_requested_at > now() - $2::interval
+		) q
+		WHERE $3 = 0 OR pipeline_position <= $3
+		ORDER BY priority DESC, id ASC
+	`, string(BuildStatusPending), fmt.Sprintf("%d seconds", int(AdmissionRequestTTL.Seconds())), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	queue := []queuedBuild{}
+	for rows.Next() {
+		var qb queuedBuild
+		err = rows.Scan(&qb.id, &qb.teamID, &qb.teamWeight, &qb.pipelineID, &qb.pipelineWeight, &qb.priority)
+		if err != nil {
+			return nil, err
+		}
+
+		queue = append(queue, qb)
+	}
+
+	return queue, nil
+}
+
+// loadRunningBuilds counts the running builds, in all and by team and
+// pipeline.
+func loadRunningBuilds(conn Conn) (runningBuilds, error) {
+	running := runningBuilds{
+		byTeam:     map[int]int{},
+		byPipeline: map[int]int{},
+	}
+
+	rows, err := psql.Select("b.team_id, j.pipeline_id, COUNT(*)").
+		From("builds b").
+		LeftJoin("jobs j ON b.job_id = j.id").
+		Where(sq.Or{
+			sq.Eq{"b.status": BuildStatusStarted},
+			sq.Eq{"b.status": BuildStatusPending, "b.scheduled": true},
+		}).
+		GroupBy("b.team_id", "j.pipeline_id").
+		RunWith(conn).
+		Query()
+	if err != nil {
+		return runningBuilds{}, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int
+		var pipelineID *int
+		var count int
+		err = rows.Scan(&teamID, &pipelineID, &count)
+		if err != nil {
+			return runningBuilds{}, err
+		}
+
+		running.total += count
+		running.byTeam[teamID] += count
+
+		if pipelineID != nil {
+			running.byPipeline[*pipelineID] += count
+		}
+	}
+
+	return running, nil
+}
+
+// fairShareOrder yields the queued builds in the order they would be started,
+// always picking, among the builds of the highest priority, the oldest build
+// of the pipeline with the smallest weighted share of its team's running
+// builds, within the team with the smallest weighted share of all running
+// builds.
+//
+// Builds are ordered incrementally, so that finding whether a build is near
+// the front of the queue does not order the whole queue.
+type fairShareOrder struct {
+	// queue is the builds not yet loaded into teams, ordered by priority
+	queue []queuedBuild
+
+	byTeam     map[int]int
+	byPipeline map[int]int
+
+	// teams holds the builds of the priority currently being ordered
+	teams teamQueues
+}
+
+func newFairShareOrder(queue []queuedBuild, running runningBuilds) *fairShareOrder {
+	byTeam := map[int]int{}
+	for team, count := range running.byTeam {
+		byTeam[team] = count
+	}
+
+	byPipeline := map[int]int{}
+	for pipeline, count := range running.byPipeline {
+		byPipeline[pipeline] = count
+	}
+
+	return &fairShareOrder{
+		queue:      queue,
+		byTeam:     byTeam,
+		byPipeline: byPipeline,
+	}
+}
+
+// position returns the 1-based position of the build in the order, or 0 if
+// it is not within the first limit builds. A limit of 0 means no limit.
+func (order *fairShareOrder) position(buildID int, limit int) int {
+	for position := 1; limit == 0 || position <= limit; position++ {
+		qb, ok := order.next()
+		if !ok {
+			break
+		}
+
+		if qb.id == buildID {
+			return position
+		}
+	}
+
+	return 0
+}
+
+func (order *fairShareOrder) next() (queuedBuild, bool) {
+	if len(order.teams) == 0 {
+		if len(order.queue) == 0 {
+			return queuedBuild{}, false
+		}
+
+		order.loadPriority()
+	}
+
+	team := order.teams[0]
+	pipeline := team.pipelines[0]
+
+	chosen := pipeline.builds[0]
+	pipeline.builds = pipeline.builds[1:]
+
+	order.byTeam[team.id]++
+	team.running++
+
+	order.byPipeline[pipeline.id]++
+	pipeline.running++
+
+	if len(pipeline.builds) == 0 {
+		heap.Pop(&team.pipelines)
+	} else {
+		heap.Fix(&team.pipelines, 0)
+	}
+
+	if len(team.pipelines) == 0 {
+		heap.Pop(&order.teams)
+	} else {
+		team.oldest = team.pipelines.oldest()
+		heap.Fix(&order.teams, 0)
+	}
+
+	return chosen, true
+}
+
+// loadPriority moves the builds of the highest remaining priority into teams.
+func (order *fairShareOrder) loadPriority() {
+	priority := order.queue[0].priority
+
+	teams := map[int]*teamQueue{}
+	pipelines := map[int]*pipelineQueue{}
+
+	for len(order.queue) > 0 && order.queue[0].priority == priority {
+		qb := order.queue[0]
+		order.queue = order.queue[1:]
+
+		team, found := teams[qb.teamID]
+		if !found {
+			team = &teamQueue{
+				id:      qb.teamID,
+				weight:  admissionWeight(qb.teamWeight),
+				running: order.byTeam[qb.teamID],
+				oldest:  qb.id,
+			}
+
+			teams[qb.teamID] = team
+			order.teams = append(order.teams, team)
+		}
+
+		pipeline, found := pipelines[qb.pipelineID]
+		if !found {
+			pipeline = &pipelineQueue{
+				id:      qb.pipelineID,
+				weight:  admissionWeight(qb.pipelineWeight),
+				running: order.byPipeline[qb.pipelineID],
+			}
+
+			pipelines[qb.pipelineID] = pipeline
+			team.pipelines = append(team.pipelines, pipeline)
+		}
+
+		pipeline.builds = append(pipeline.builds, qb)
+	}
+
+	for _, team := range order.teams {
+		heap.Init(&team.pipelines)
+	}
+
+	heap.Init(&order.teams)
+}
+
+type pipelineQueue struct {
+	id      int
+	weight  int
+	running int
+
+	// builds are the pipeline's queued builds, oldest first
+	builds []queuedBuild
+}
+
+type teamQueue struct {
+	id      int
+	weight  int
+	running int
+
+	// oldest is the id of the team's oldest queued build
+	oldest int
+
+	pipelines pipelineQueues
+}
+
+// lessShare returns whether running builds out of weight is a smaller share
+// than otherRunning out of otherWeight.
+func lessShare(running int, weight int, otherRunning int, otherWeight int) bool {
+	return running*otherWeight < otherRunning*weight
+}
+
+// pipelineQueues is a heap of a team's pipelines, ordered by their share of
+// the team's running builds and then by their oldest queued build.
+type pipelineQueues []*pipelineQueue
+
+func (qs pipelineQueues) Len() int      { return len(qs) }
+func (qs pipelineQueues) Swap(i, j int) { qs[i], qs[j] = qs[j], qs[i] }
+
+func (qs pipelineQueues) Less(i, j int) bool {
+	a, b := qs[i], qs[j]
+	if a.running*b.weight != b.running*a.weight {
+		return lessShare(a.running, a.weight, b.running, b.weight)
+	}
+
+	return a.builds[0].id < b.builds[0].id
+}
+
+func (qs *pipelineQueues) Push(x interface{}) { *qs = append(*qs, x.(*pipelineQueue)) }
+
+func (qs *pipelineQueues) Pop() interface{} {
+	old := *qs
+	last := old[len(old)-1]
+	*qs = old[:len(old)-1]
+	return last
+}
+
+func (qs pipelineQueues) oldest() int {
+	oldest := qs[0].builds[0].id
+	for _, q := range qs[1:] {
+		if q.builds[0].id < oldest {
+			oldest = q.builds[0].id
+		}
+	}
+
+	return oldest
+}
+
+// teamQueues is a heap of teams, ordered by their share of all running builds
+// and then by their oldest queued build.
+type teamQueues []*teamQueue
+
+func (qs teamQueues) Len() int      { return len(qs) }
+func (qs teamQueues) Swap(i, j int) { qs[i], qs[j] = qs[j], qs[i] }
+
+func (qs teamQueues) Less(i, j int) bool {
+	a, b := qs[i], qs[j]
+	if a.running*b.weight != b.running*a.weight {
+		return lessShare(a.running, a.weight, b.running, b.weight)
+	}
+
+	return a.oldest < b.oldest
+}
+
+func (qs *teamQueues) Push(x interface{}) { *qs = append(*qs, x.(*teamQueue)) }
+
+func (qs *teamQueues) Pop() interface{} {
+	old := *qs
+	last := old[len(old)-1]
+	*qs = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,274 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AdmissionQueue", func() {
+	var (
+		otherTeam     dbng.Team
+		otherPipeline dbng.Pipeline
+		otherJob      dbng.Job
+
+		defaultTeamBuild dbng.Build
+		otherTeamBuild   dbng.Build
+	)
+
+	BeforeEach(func() {
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).NotTo(HaveOccurred())
+
+		otherPipeline, _, err = otherTeam.SavePipeline("other-pipeline", atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "other-job"},
+			},
+		}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+		Expect(err).NotTo(HaveOccurred())
+
+		var found bool
+		otherJob, found, err = otherPipeline.Job("other-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		runningBuild, err := defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		started, err := runningBuild.Start("exec.v2", "{}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(started).To(BeTrue())
+
+		defaultTeamBuild, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		otherTeamBuild, err = otherJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("Admit", func() {
+		Context("when the number of concurrent builds is unlimited", func() {
+			It("admits every build", func() {
+				queue := dbng.NewAdmissionQueue(dbConn, 0)
+
+				admitted, err := queue.Admit(defaultTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+
+				admitted, err = queue.Admit(otherTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+		})
+
+		Context("when the number of concurrent builds is limited", func() {
+			var queue dbng.AdmissionQueue
+
+			BeforeEach(func() {
+				queue = dbng.NewAdmissionQueue(dbConn, 2)
+
+				admitted, err := queue.Admit(otherTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+
+			It("admits the builds of teams running fewer builds first", func() {
+				admitted, err := queue.Admit(defaultTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeFalse())
+
+				admitted, err = queue.Admit(otherTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+			})
+
+			It("exposes the position of each queued build in its preparation", func() {
+				_, err := queue.Admit(defaultTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+
+				preparation, found, err := otherTeamBuild.Preparation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(preparation.QueuePosition).To(Equal(1))
+
+				preparation, found, err = defaultTeamBuild.Preparation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(preparation.QueuePosition).To(Equal(2))
+			})
+
+			Context("when a build has a higher priority", func() {
+				var urgentBuild dbng.Build
+
+				BeforeEach(func() {
+					var err error
+					urgentBuild, err = defaultJob.CreateBuildWithPriority(10)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("admits it ahead of the fair share", func() {
+					admitted, err := queue.Admit(urgentBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admitted).To(BeTrue())
+
+					admitted, err = queue.Admit(otherTeamBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admitted).To(BeFalse())
+				})
+			})
+
+			Context("when a team has a greater admission weight", func() {
+				BeforeEach(func() {
+					runningBuild, err := otherJob.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					started, err := runningBuild.Start("exec.v2", "{}")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(started).To(BeTrue())
+
+					err = otherTeam.UpdateAdmissionWeight(2)
+					Expect(err).NotTo(HaveOccurred())
+
+					queue = dbng.NewAdmissionQueue(dbConn, 3)
+				})
+
+				It("admits its builds ahead of older builds of teams with a smaller weighted share", func() {
+					admitted, err := queue.Admit(defaultTeamBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admitted).To(BeFalse())
+
+					admitted, err = queue.Admit(otherTeamBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admitted).To(BeTrue())
+				})
+			})
+
+			Context("when a pipeline has a greater admission weight", func() {
+				var weightedBuild dbng.Build
+
+				BeforeEach(func() {
+					weightedPipeline, _, err := otherTeam.SavePipeline("weighted-pipeline", atc.Config{
+						AdmissionWeight: 2,
+						Jobs: atc.JobConfigs{
+							{Name: "weighted-job"},
+						},
+					}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+					Expect(err).NotTo(HaveOccurred())
+
+					weightedJob, found, err := weightedPipeline.Job("weighted-job")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+
+					for _, job := range []dbng.Job{otherJob, weightedJob} {
+						runningBuild, err := job.CreateBuild()
+						Expect(err).NotTo(HaveOccurred())
+
+						started, err := runningBuild.Start("exec.v2", "{}")
+						Expect(err).NotTo(HaveOccurred())
+						Expect(started).To(BeTrue())
+					}
+
+					weightedBuild, err = weightedJob.CreateBuild()
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = queue.Admit(defaultTeamBuild)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = queue.Admit(weightedBuild)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("orders its builds ahead of older builds of the team's other pipelines", func() {
+					preparation, _, err := defaultTeamBuild.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(preparation.QueuePosition).To(Equal(1))
+
+					preparation, _, err = weightedBuild.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(preparation.QueuePosition).To(Equal(2))
+
+					preparation, _, err = otherTeamBuild.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(preparation.QueuePosition).To(Equal(3))
+				})
+			})
+
+			Context("when the leading build has been scheduled", func() {
+				BeforeEach(func() {
+					scheduled, err := otherTeamBuild.Schedule()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(scheduled).To(BeTrue())
+				})
+
+				It("no longer queues it, and counts it as running", func() {
+					admitted, err := queue.Admit(defaultTeamBuild)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admitted).To(BeFalse())
+
+					preparation, _, err := defaultTeamBuild.Preparation()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(preparation.QueuePosition).To(Equal(1))
+				})
+			})
+		})
+	})
+
+	Describe("Schedule", func() {
+		Context("when the number of concurrent builds is unlimited", func() {
+			It("schedules every build", func() {
+				queue := dbng.NewAdmissionQueue(dbConn, 0)
+
+				scheduled, err := queue.Schedule(defaultTeamBuild, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				scheduled, err = queue.Schedule(otherTeamBuild, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+			})
+		})
+
+		Context("when the number of concurrent builds is limited", func() {
+			var queue dbng.AdmissionQueue
+
+			BeforeEach(func() {
+				queue = dbng.NewAdmissionQueue(dbConn, 2)
+			})
+
+			It("schedules builds while there are free slots", func() {
+				scheduled, err := queue.Schedule(otherTeamBuild, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				found, err := otherTeamBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(otherTeamBuild.IsScheduled()).To(BeTrue())
+			})
+
+			It("does not schedule builds admitted into a slot which has since been filled", func() {
+				admitted, err := queue.Admit(otherTeamBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admitted).To(BeTrue())
+
+				oneOffBuild, err := otherTeam.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				started, err := oneOffBuild.Start("exec.v2", "{}")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				scheduled, err := queue.Schedule(otherTeamBuild, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeFalse())
+
+				found, err := otherTeamBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(otherTeamBuild.IsScheduled()).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,148 @@
+package dbng
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// AuditFilter narrows the audit events returned. Empty fields match any
+// event. Events are returned most recent first, paging back through older
+// events with Page.Since.
+type AuditFilter struct {
+	Team     string
+	Pipeline string
+	Actor    string
+	Route    string
+
+	Page Page
+}
+
+//go:generate counterfeiter . AuditFactory
+
+type AuditFactory interface {
+	RecordEvent(event atc.AuditEvent) (atc.AuditEvent, error)
+	Events(filter AuditFilter) ([]atc.AuditEvent, Pagination, error)
+}
+
+type auditFactory struct {
+	conn Conn
+}
+
+func NewAuditFactory(conn Conn) AuditFactory {
+	return &auditFactory{
+		conn: conn,
+	}
+}
+
+func (f *auditFactory) RecordEvent(event atc.AuditEvent) (atc.AuditEvent, error) {
+	params := event.Params
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return atc.AuditEvent{}, err
+	}
+
+	var recordedAt time.Time
+	err = psql.Insert("audit_events").
+		Columns("route", "method", "team_name", "pipeline_name", "actor", "params", "status").
+		Values(event.Route, event.Method, event.Team, event.Pipeline, event.Actor, string(paramsJSON), event.Status).
+		Suffix("RETURNING id, time").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&event.ID, &recordedAt)
+	if err != nil {
+		return atc.AuditEvent{}, err
+	}
+
+	event.Time = recordedAt.Unix()
+
+	return event, nil
+}
+
+func (f *auditFactory) Events(filter AuditFilter) ([]atc.AuditEvent, Pagination, error) {
+	query := psql.Select("id, time, route, method, team_name, pipeline_name, actor, params, status").
+		From("audit_events").
+		OrderBy("id DESC")
+
+	conditions := sq.Eq{}
+	if filter.Team != "" {
+		conditions["team_name"] = filter.Team
+	}
+
+	if filter.Pipeline != "" {
+		conditions["pipeline_name"] = filter.Pipeline
+	}
+
+	if filter.Actor != "" {
+		conditions["actor"] = filter.Actor
+	}
+
+	if filter.Route != "" {
+		conditions["route"] = filter.Route
+	}
+
+	if len(conditions) > 0 {
+		query = query.Where(conditions)
+	}
+
+	if filter.Page.Since != 0 {
+		query = query.Where(sq.Lt{"id": filter.Page.Since})
+	}
+
+	if filter.Page.Limit != 0 {
+		// fetch one more than asked for to know whether there is a next page
+		query = query.Limit(uint64(filter.Page.Limit + 1))
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	events := []atc.AuditEvent{}
+	for rows.Next() {
+		var (
+			event      atc.AuditEvent
+			recordedAt time.Time
+			paramsJSON string
+		)
+
+		err := rows.Scan(&event.ID, &recordedAt, &event.Route, &event.Method, &event.Team, &event.Pipeline, &event.Actor, &paramsJSON, &event.Status)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		err = json.Unmarshal([]byte(paramsJSON), &event.Params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if len(event.Params) == 0 {
+			event.Params = nil
+		}
+
+		event.Time = recordedAt.Unix()
+
+		events = append(events, event)
+	}
+
+	var pagination Pagination
+	if filter.Page.Limit != 0 && len(events) > filter.Page.Limit {
+		events = events[:filter.Page.Limit]
+
+		pagination.Next = &Page{
+			Since: events[len(events)-1].ID,
+			Limit: filter.Page.Limit,
+		}
+	}
+
+	return events, pagination, nil
+}
@@ -0,0 +1,94 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditFactory", func() {
+	var auditFactory dbng.AuditFactory
+
+	BeforeEach(func() {
+		auditFactory = dbng.NewAuditFactory(dbConn)
+	})
+
+	record := func(event atc.AuditEvent) atc.AuditEvent {
+		recorded, err := auditFactory.RecordEvent(event)
+		Expect(err).NotTo(HaveOccurred())
+		return recorded
+	}
+
+	It("records events and returns them most recent first", func() {
+		paused := record(atc.AuditEvent{
+			Route:    atc.PausePipeline,
+			Method:   "PUT",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Actor:    "some-team",
+			Status:   200,
+		})
+		Expect(paused.ID).NotTo(BeZero())
+		Expect(paused.Time).NotTo(BeZero())
+
+		checked := record(atc.AuditEvent{
+			Route:    atc.CheckResource,
+			Method:   "POST",
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Params:   map[string]string{"resource_name": "some-resource"},
+			Status:   401,
+		})
+
+		events, pagination, err := auditFactory.Events(dbng.AuditFilter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(Equal([]atc.AuditEvent{checked, paused}))
+		Expect(pagination.Next).To(BeNil())
+	})
+
+	It("filters events", func() {
+		paused := record(atc.AuditEvent{Route: atc.PausePipeline, Method: "PUT", Team: "some-team", Pipeline: "some-pipeline", Actor: "main", Status: 200})
+		record(atc.AuditEvent{Route: atc.PausePipeline, Method: "PUT", Team: "other-team", Pipeline: "some-pipeline", Actor: "other-team", Status: 200})
+		record(atc.AuditEvent{Route: atc.UnpausePipeline, Method: "PUT", Team: "some-team", Pipeline: "some-pipeline", Actor: "main", Status: 200})
+		record(atc.AuditEvent{Route: atc.PausePipeline, Method: "PUT", Team: "some-team", Pipeline: "other-pipeline", Actor: "main", Status: 200})
+
+		events, _, err := auditFactory.Events(dbng.AuditFilter{
+			Team:     "some-team",
+			Pipeline: "some-pipeline",
+			Actor:    "main",
+			Route:    atc.PausePipeline,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(Equal([]atc.AuditEvent{paused}))
+	})
+
+	It("pages back through older events", func() {
+		first := record(atc.AuditEvent{Route: atc.PauseJob, Method: "PUT", Status: 200})
+		second := record(atc.AuditEvent{Route: atc.PauseJob, Method: "PUT", Status: 200})
+		third := record(atc.AuditEvent{Route: atc.PauseJob, Method: "PUT", Status: 200})
+
+		events, pagination, err := auditFactory.Events(dbng.AuditFilter{Page: dbng.Page{Limit: 2}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(Equal([]atc.AuditEvent{third, second}))
+		Expect(pagination.Next).To(Equal(&dbng.Page{Since: second.ID, Limit: 2}))
+
+		events, pagination, err = auditFactory.Events(dbng.AuditFilter{Page: *pagination.Next})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(Equal([]atc.AuditEvent{first}))
+		Expect(pagination.Next).To(BeNil())
+	})
+
+	It("does not allow events to be changed or removed", func() {
+		record(atc.AuditEvent{Route: atc.DestroyTeam, Method: "DELETE", Team: "some-team", Status: 204})
+
+		_, err := dbConn.Exec(`UPDATE audit_events SET actor = 'someone-else'`)
+		Expect(err).To(HaveOccurred())
+
+		_, err = dbConn.Exec(`DELETE FROM audit_events`)
+		Expect(err).To(HaveOccurred())
+
+		_, err = dbConn.Exec(`TRUNCATE audit_events`)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -27,14 +28,14 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name, p.id, p.name, t.name").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.metadata, b.waiting_for_worker_capacity, b.priority, j.name, p.id, p.name, t.name").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id").
 	JoinClause("LEFT OUTER JOIN teams t ON b.team_id = t.id")
 
 // XXX not something we want to keep
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, b.reap_time, b.metadata, b.waiting_for_worker_capacity, b.priority, j.name as job_name, p.id as pipeline_id, p.name as pipeline_name, t.name as team_name"
 
 //go:generate counterfeiter . Build
 
@@ -55,6 +56,9 @@ type Build interface {
 	ReapTime() time.Time
 	IsManuallyTriggered() bool
 	IsScheduled() bool
+	Metadata() map[string]string
+	IsWaitingForWorkerCapacity() bool
+	Priority() int
 
 	IsRunning() bool
 
@@ -67,6 +71,7 @@ type Build interface {
 	Start(string, string) (bool, error)
 	SaveStatus(s BuildStatus) error
 	SetInterceptible(bool) error
+	SetWaitingForWorkerCapacity(bool) error
 	MarkAsFailed(cause error) error
 
 	Events(uint) (EventSource, error)
@@ -79,6 +84,7 @@ type Build interface {
 	Resources() ([]BuildInput, []BuildOutput, error)
 	GetVersionedResources() (SavedVersionedResources, error)
 	SaveImageResourceVersion(planID atc.PlanID, resourceVersion atc.Version, resourceHash string) error
+	SaveMetadata(metadata map[string]string) error
 
 	Pipeline() (Pipeline, bool, error)
 
@@ -87,6 +93,7 @@ type Build interface {
 	Abort() error
 	AbortNotifier() (Notifier, error)
 	Schedule() (bool, error)
+	ScheduleInTeamSerialGroups(serialGroups atc.SerialGroupConfigs) (bool, error)
 }
 
 type build struct {
@@ -112,28 +119,38 @@ type build struct {
 	endTime   time.Time
 	reapTime  time.Time
 
+	metadata map[string]string
+
+	waitingForWorkerCapacity bool
+	priority                 int
+
 	conn        Conn
 	lockFactory lock.LockFactory
 }
 
 var ErrBuildDisappeared = errors.New("build-disappeared-from-db")
 
-func (b *build) ID() int                   { return b.id }
-func (b *build) Name() string              { return b.name }
-func (b *build) JobID() int                { return b.jobID }
-func (b *build) JobName() string           { return b.jobName }
-func (b *build) PipelineID() int           { return b.pipelineID }
-func (b *build) PipelineName() string      { return b.pipelineName }
-func (b *build) TeamID() int               { return b.teamID }
-func (b *build) TeamName() string          { return b.teamName }
-func (b *build) IsManuallyTriggered() bool { return b.isManuallyTriggered }
-func (b *build) Engine() string            { return b.engine }
-func (b *build) EngineMetadata() string    { return b.engineMetadata }
-func (b *build) StartTime() time.Time      { return b.startTime }
-func (b *build) EndTime() time.Time        { return b.endTime }
-func (b *build) ReapTime() time.Time       { return b.reapTime }
-func (b *build) Status() BuildStatus       { return b.status }
-func (b *build) IsScheduled() bool         { return b.scheduled }
+func (b *build) ID() int                     { return b.id }
+func (b *build) Name() string                { return b.name }
+func (b *build) JobID() int                  { return b.jobID }
+func (b *build) JobName() string             { return b.jobName }
+func (b *build) PipelineID() int             { return b.pipelineID }
+func (b *build) PipelineName() string        { return b.pipelineName }
+func (b *build) TeamID() int                 { return b.teamID }
+func (b *build) TeamName() string            { return b.teamName }
+func (b *build) IsManuallyTriggered() bool   { return b.isManuallyTriggered }
+func (b *build) Engine() string              { return b.engine }
+func (b *build) EngineMetadata() string      { return b.engineMetadata }
+func (b *build) StartTime() time.Time        { return b.startTime }
+func (b *build) EndTime() time.Time          { return b.endTime }
+func (b *build) ReapTime() time.Time         { return b.reapTime }
+func (b *build) Status() BuildStatus         { return b.status }
+func (b *build) IsScheduled() bool           { return b.scheduled }
+func (b *build) Metadata() map[string]string { return b.metadata }
+func (b *build) Priority() int               { return b.priority }
+func (b *build) IsWaitingForWorkerCapacity() bool {
+	return b.waitingForWorkerCapacity
+}
 
 func (b *build) IsRunning() bool {
 	switch b.status {
@@ -203,6 +220,34 @@ func (b *build) SetInterceptible(i bool) error {
 
 }
 
+// SetWaitingForWorkerCapacity records whether one of the build's steps is
+// waiting for a worker to have room for its container.
+func (b *build) SetWaitingForWorkerCapacity(waiting bool) error {
+	rows, err := psql.Update("builds").
+		Set("waiting_for_worker_capacity", waiting).
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.waitingForWorkerCapacity = waiting
+
+	return nil
+}
+
 func (b *build) Start(engine, metadata string) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -241,6 +286,11 @@ func (b *build) Start(engine, metadata string) (bool, error) {
 		return false, err
 	}
 
+	err = b.enqueueNotifications(tx, atc.StatusStarted, startTime)
+	if err != nil {
+		return false, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return false, err
@@ -320,6 +370,18 @@ func (b *build) Finish(s BuildStatus) error {
 		return err
 	}
 
+	err = b.enqueueNotifications(tx, atc.BuildStatus(s), endTime)
+	if err != nil {
+		return err
+	}
+
+	if s == BuildStatusSucceeded {
+		err = b.saveArtifactOutputs(tx)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -395,11 +457,134 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// Schedule schedules the build unless its team is already running as many
+// builds as its quota allows.
 func (b *build) Schedule() (bool, error) {
+	return b.ScheduleInTeamSerialGroups(nil)
+}
+
+// ScheduleInTeamSerialGroups schedules the build unless its team is already
+// running as many builds as its quota allows, or one of the given serial
+// groups, which span the pipelines of the build's team, is already running as
+// many builds as its size allows.
+func (b *build) ScheduleInTeamSerialGroups(serialGroups atc.SerialGroupConfigs) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	scheduled, err := scheduleBuild(tx, b.id, b.teamID, serialGroups)
+	if err != nil {
+		return false, err
+	}
+
+	if !scheduled {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// scheduleBuild marks the build as scheduled within the transaction, unless
+// its team has reached its max running builds or one of the serial groups is
+// full. The team's quota and each group are locked for the rest of the
+// transaction once they are checked, so that the schedulers of the team's
+// other pipelines can't fill them in the meantime.
+func scheduleBuild(tx Tx, buildID int, teamID int, serialGroups atc.SerialGroupConfigs) (bool, error) {
+	var maxRunningBuilds int
+	err := psql.Select("max_running_builds").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&maxRunningBuilds)
+	if err != nil {
+		return false, err
+	}
+
+	if maxRunningBuilds != 0 {
+		lockID := lock.NewTeamQuotaLockID(teamID)
+
+		_, err := tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lockID[0], lockID[1])
+		if err != nil {
+			return false, err
+		}
+
+		var running int
+		err = psql.Select("COUNT(*)").
+			From("builds").
+			Where(sq.Eq{"team_id": teamID}).
+			Where(sq.Or{
+				sq.Eq{"status": BuildStatusStarted},
+				sq.Eq{"status": BuildStatusPending, "scheduled": true},
+			}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&running)
+		if err != nil {
+			return false, err
+		}
+
+		if running >= maxRunningBuilds {
+			return false, nil
+		}
+	}
+
+	// lock the groups in the same order everywhere, so as not to deadlock
+	sizes := map[string]int{}
+	names := []string{}
+	for _, group := range serialGroups {
+		sizes[group.Name] = group.Size
+		names = append(names, group.Name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		lockID := lock.NewTeamSerialGroupLockID(teamID, name)
+
+		_, err := tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, lockID[0], lockID[1])
+		if err != nil {
+			return false, err
+		}
+
+		var running int
+		err = psql.Select("COUNT(DISTINCT b.id)").
+			From("builds b").
+			Join("jobs j ON b.job_id = j.id").
+			Join("pipelines p ON j.pipeline_id = p.id").
+			Join("jobs_serial_groups jsg ON j.id = jsg.job_id").
+			Where(sq.Eq{
+				"jsg.serial_group": name,
+				"p.team_id":        teamID,
+			}).
+			Where(sq.Or{
+				sq.Eq{"b.status": BuildStatusStarted},
+				sq.Eq{"b.status": BuildStatusPending, "b.scheduled": true},
+			}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&running)
+		if err != nil {
+			return false, err
+		}
+
+		if running >= sizes[name] {
+			return false, nil
+		}
+	}
+
 	result, err := psql.Update("builds").
 		Set("scheduled", true).
-		Where(sq.Eq{"id": b.id}).
-		RunWith(b.conn).
+		Where(sq.Eq{"id": buildID}).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return false, err
@@ -464,6 +649,67 @@ func (b *build) SaveImageResourceVersion(planID atc.PlanID, resourceVersion atc.
 	)
 }
 
+// SaveMetadata merges the given key/value pairs into the build's metadata,
+// replacing the values of any keys which were already set.
+func (b *build) SaveMetadata(metadata map[string]string) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var existing sql.NullString
+	err = psql.Select("metadata").
+		From("builds").
+		Where(sq.Eq{"id": b.id}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&existing)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrBuildDisappeared
+		}
+		return err
+	}
+
+	merged := map[string]string{}
+	if existing.Valid {
+		err = json.Unmarshal([]byte(existing.String), &merged)
+		if err != nil {
+			return err
+		}
+	}
+
+	for key, value := range metadata {
+		merged[key] = value
+	}
+
+	payload, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("metadata", string(payload)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.metadata = merged
+
+	return nil
+}
+
 func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
 	lock := b.lockFactory.NewLock(
 		logger.Session("lock", lager.Data{
@@ -486,6 +732,11 @@ func (b *build) AcquireTrackingLock(logger lager.Logger, interval time.Duration)
 
 func (b *build) Preparation() (BuildPreparation, bool, error) {
 	if b.jobID == 0 || b.status != BuildStatusPending {
+		workerCapacityStatus := BuildPreparationStatusNotBlocking
+		if b.waitingForWorkerCapacity {
+			workerCapacityStatus = BuildPreparationStatusBlocking
+		}
+
 		return BuildPreparation{
 			BuildID:             b.id,
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
@@ -494,6 +745,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
+			WorkerCapacity:      workerCapacityStatus,
 		}, true, nil
 	}
 
@@ -632,6 +884,11 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		}
 	}
 
+	queuePosition, err := admissionQueuePosition(b.conn, b.id)
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
 	buildPreparation := BuildPreparation{
 		BuildID:             b.id,
 		PausedPipeline:      pausedPipelineStatus,
@@ -640,6 +897,8 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
+		WorkerCapacity:      BuildPreparationStatusNotBlocking,
+		QueuePosition:       queuePosition,
 	}
 
 	return buildPreparation, true, nil
@@ -971,11 +1230,12 @@ func scanBuild(b *build, row scannable) error {
 		jobID, pipelineID                             sql.NullInt64
 		engine, engineMetadata, jobName, pipelineName sql.NullString
 		startTime, endTime, reapTime                  pq.NullTime
+		metadata                                      sql.NullString
 
 		status string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &engine, &engineMetadata, &startTime, &endTime, &reapTime, &metadata, &b.waitingForWorkerCapacity, &b.priority, &jobName, &pipelineID, &pipelineName, &b.teamName)
 	if err != nil {
 		return err
 	}
@@ -991,6 +1251,14 @@ func scanBuild(b *build, row scannable) error {
 	b.endTime = endTime.Time
 	b.reapTime = reapTime.Time
 
+	b.metadata = nil
+	if metadata.Valid {
+		err = json.Unmarshal([]byte(metadata.String), &b.metadata)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,327 @@
+package dbng
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// BuildArtifact is a task output published by a build so that it can be
+// fetched by downstream jobs.
+type BuildArtifact struct {
+	ID      int
+	BuildID int
+	Name    string
+	Volume  CreatedVolume
+}
+
+//go:generate counterfeiter . BuildArtifactFactory
+
+type BuildArtifactFactory interface {
+	CreateBuildArtifact(buildID int, name string, volumeHandle string) (*BuildArtifact, error)
+	FindBuildArtifact(buildID int, name string) (*BuildArtifact, bool, error)
+
+	ExpireJobArtifacts(jobID int, buildsToRetain int) error
+}
+
+type buildArtifactFactory struct {
+	conn Conn
+}
+
+func NewBuildArtifactFactory(conn Conn) BuildArtifactFactory {
+	return &buildArtifactFactory{
+		conn: conn,
+	}
+}
+
+// CreateBuildArtifact publishes the volume under the given name for the
+// build. Publishing the same name twice for a build (e.g. from a retried
+// step) replaces the previous volume.
+func (f *buildArtifactFactory) CreateBuildArtifact(buildID int, name string, volumeHandle string) (*BuildArtifact, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	artifact := &BuildArtifact{
+		BuildID: buildID,
+		Name:    name,
+	}
+
+	err = psql.Select("id").
+		From("build_artifacts").
+		Where(sq.Eq{
+			"build_id": buildID,
+			"name":     name,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&artifact.ID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, err
+		}
+
+		err = psql.Insert("build_artifacts").
+			Columns("build_id", "name").
+			Values(buildID, name).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&artifact.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = psql.Update("volumes").
+		Set("build_artifact_id", nil).
+		Where(sq.Eq{"build_artifact_id": artifact.ID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := psql.Update("volumes").
+		Set("build_artifact_id", artifact.ID).
+		Where(sq.Eq{
+			"handle": volumeHandle,
+			"state":  VolumeStateCreated,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, ErrVolumeMissing
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeFactory := &volumeFactory{conn: f.conn}
+	volume, found, err := volumeFactory.FindCreatedVolume(volumeHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrVolumeMissing
+	}
+
+	artifact.Volume = volume
+
+	return artifact, nil
+}
+
+// FindBuildArtifact finds the artifact with the given name published by the
+// build, provided its volume is still present. The build is the one that
+// published the version of the artifact resource a downstream build was
+// given as its input.
+func (f *buildArtifactFactory) FindBuildArtifact(buildID int, name string) (*BuildArtifact, bool, error) {
+	artifact := &BuildArtifact{
+		Name: name,
+	}
+
+	var handle string
+	err := psql.Select("ba.id, ba.build_id, v.handle").
+		From("build_artifacts ba").
+		Join("volumes v ON v.build_artifact_id = ba.id").
+		Where(sq.Eq{
+			"ba.build_id": buildID,
+			"ba.name":     name,
+			"v.state":     VolumeStateCreated,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&artifact.ID, &artifact.BuildID, &handle)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	volumeFactory := &volumeFactory{conn: f.conn}
+	volume, found, err := volumeFactory.FindCreatedVolume(handle)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	artifact.Volume = volume
+
+	return artifact, true, nil
+}
+
+// ExpireJobArtifacts removes the artifacts of every build of the job older
+// than its most recent buildsToRetain succeeded builds that published
+// artifacts. Their volumes are left for the volume collector to reap once
+// they become orphaned.
+func (f *buildArtifactFactory) ExpireJobArtifacts(jobID int, buildsToRetain int) error {
+	_, err := f.conn.Exec(`
+		DELETE FROM build_artifacts ba
+		USING builds b
+		WHERE b.id = ba.build_id
+		AND b.job_id = $1
+		AND ba.build_id < (
+			SELECT min(retained.build_id)
+			FROM (
+				SELECT DISTINCT a.build_id
+				FROM build_artifacts a
+				JOIN builds sb ON sb.id = a.build_id
+				WHERE sb.job_id = $1
+				AND sb.status = $2
+				ORDER BY a.build_id DESC
+				LIMIT $3
+			) retained
+		)
+	`, jobID, string(BuildStatusSucceeded), buildsToRetain)
+	return err
+}
+
+// saveArtifactOutputs records each artifact published by the build as an
+// output of its artifact resource, creating the resource if this is the first
+// time the artifact is published in the pipeline. The versions then flow
+// through build inputs and outputs like those of any other resource, so that
+// downstream jobs get them with passed constraints and are triggered by them.
+func (b *build) saveArtifactOutputs(tx Tx) error {
+	if b.pipelineID == 0 {
+		return nil
+	}
+
+	rows, err := psql.Select("name").
+		From("build_artifacts").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		err = rows.Scan(&name)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		names = append(names, name)
+	}
+
+	err = rows.Close()
+	if err != nil {
+		return err
+	}
+
+	pipeline := &pipeline{id: b.pipelineID, conn: b.conn, lockFactory: b.lockFactory}
+
+	for _, name := range names {
+		vr := VersionedResource{
+			Resource: atc.ArtifactResourceName(name),
+			Type:     atc.ArtifactResourceType,
+			Version:  ResourceVersion(atc.ArtifactVersion(b.id)),
+		}
+
+		resourceID, err := b.saveArtifactResource(tx, vr.Resource)
+		if err != nil {
+			return err
+		}
+
+		svr, created, err := pipeline.saveVersionedResource(tx, resourceID, vr)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			versionJSON, err := json.Marshal(vr.Version)
+			if err != nil {
+				return err
+			}
+
+			err = pipeline.incrementCheckOrderWhenNewerVersion(tx, resourceID, vr.Type, string(versionJSON))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = psql.Insert("build_outputs").
+			Columns("build_id", "versioned_resource_id", "explicit").
+			Values(b.id, svr.ID, true).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// saveArtifactResource returns the ID of the artifact resource, creating it
+// if needed. It is never active, so it is neither checked nor listed with the
+// pipeline's resources.
+func (b *build) saveArtifactResource(tx Tx, resourceName string) (int, error) {
+	var resourceID int
+	err := psql.Select("id").
+		From("resources").
+		Where(sq.Eq{
+			"name":        resourceName,
+			"pipeline_id": b.pipelineID,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceID)
+	if err == nil {
+		return resourceID, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	configPayload, err := json.Marshal(atc.ResourceConfig{
+		Name: resourceName,
+		Type: atc.ArtifactResourceType,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	encryptedPayload, nonce, err := b.conn.EncryptionStrategy().Encrypt(configPayload)
+	if err != nil {
+		return 0, err
+	}
+
+	err = psql.Insert("resources").
+		Columns("name", "pipeline_id", "config", "source_hash", "active", "nonce").
+		Values(resourceName, b.pipelineID, encryptedPayload, mapHash(nil), false, nonce).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&resourceID)
+	if err != nil {
+		return 0, err
+	}
+
+	return resourceID, nil
+}
@@ -0,0 +1,244 @@
+package dbng_test
+
+import (
+	"strconv"
+
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArtifactFactory", func() {
+	var artifactFactory dbng.BuildArtifactFactory
+
+	createVolume := func(build dbng.Build) dbng.CreatedVolume {
+		creatingContainer, err := defaultTeam.CreateBuildContainer(defaultWorker.Name(), build.ID(), "some-plan", dbng.ContainerMetadata{
+			Type:     dbng.ContainerTypeTask,
+			StepName: "some-task",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker, creatingContainer, "some-output-path")
+		Expect(err).NotTo(HaveOccurred())
+
+		createdVolume, err := creatingVolume.Created()
+		Expect(err).NotTo(HaveOccurred())
+
+		return createdVolume
+	}
+
+	publish := func(status dbng.BuildStatus) (dbng.Build, dbng.CreatedVolume) {
+		build, err := defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		volume := createVolume(build)
+
+		_, err = artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", volume.Handle())
+		Expect(err).NotTo(HaveOccurred())
+
+		err = build.Finish(status)
+		Expect(err).NotTo(HaveOccurred())
+
+		return build, volume
+	}
+
+	BeforeEach(func() {
+		artifactFactory = dbng.NewBuildArtifactFactory(dbConn)
+	})
+
+	Describe("CreateBuildArtifact", func() {
+		var build dbng.Build
+		var volume dbng.CreatedVolume
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			volume = createVolume(build)
+		})
+
+		It("returns the artifact with its volume", func() {
+			artifact, err := artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", volume.Handle())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.BuildID).To(Equal(build.ID()))
+			Expect(artifact.Name).To(Equal("some-binary"))
+			Expect(artifact.Volume.Handle()).To(Equal(volume.Handle()))
+		})
+
+		It("keeps the volume from being orphaned", func() {
+			_, err := artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", volume.Handle())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = psql.Delete("containers").RunWith(dbConn).Exec()
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdVolumes).To(BeEmpty())
+		})
+
+		Context("when the volume does not exist", func() {
+			It("returns ErrVolumeMissing", func() {
+				_, err := artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", "bogus-handle")
+				Expect(err).To(Equal(dbng.ErrVolumeMissing))
+			})
+		})
+
+		Context("when the build already published an artifact with the same name", func() {
+			It("replaces its volume", func() {
+				_, err := artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", volume.Handle())
+				Expect(err).NotTo(HaveOccurred())
+
+				otherVolume := createVolume(build)
+				artifact, err := artifactFactory.CreateBuildArtifact(build.ID(), "some-binary", otherVolume.Handle())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifact.Volume.Handle()).To(Equal(otherVolume.Handle()))
+
+				_, err = psql.Delete("containers").RunWith(dbConn).Exec()
+				Expect(err).NotTo(HaveOccurred())
+
+				createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createdVolumes).To(HaveLen(1))
+				Expect(createdVolumes[0].Handle()).To(Equal(volume.Handle()))
+			})
+		})
+	})
+
+	Describe("FindBuildArtifact", func() {
+		Context("when the build has not published the artifact", func() {
+			It("is not found", func() {
+				build, err := defaultJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := artifactFactory.FindBuildArtifact(build.ID(), "some-binary")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when builds have published the artifact", func() {
+			var build dbng.Build
+			var volume dbng.CreatedVolume
+
+			BeforeEach(func() {
+				build, volume = publish(dbng.BuildStatusSucceeded)
+				publish(dbng.BuildStatusSucceeded)
+			})
+
+			It("finds the artifact of the given build", func() {
+				artifact, found, err := artifactFactory.FindBuildArtifact(build.ID(), "some-binary")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(artifact.BuildID).To(Equal(build.ID()))
+				Expect(artifact.Volume.Handle()).To(Equal(volume.Handle()))
+			})
+
+			It("is not found under a different name", func() {
+				_, found, err := artifactFactory.FindBuildArtifact(build.ID(), "some-other-binary")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("finishing a build which published an artifact", func() {
+		Context("when it succeeds", func() {
+			It("records the artifact as an output of its artifact resource", func() {
+				build, _ := publish(dbng.BuildStatusSucceeded)
+
+				_, outputs, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(HaveLen(1))
+				Expect(outputs[0].Resource).To(Equal("artifact:some-binary"))
+				Expect(outputs[0].Type).To(Equal("artifact"))
+				Expect(outputs[0].Version).To(Equal(dbng.ResourceVersion{"build": strconv.Itoa(build.ID())}))
+			})
+
+			It("makes the artifact's versions available to downstream jobs", func() {
+				firstBuild, _ := publish(dbng.BuildStatusSucceeded)
+				secondBuild, _ := publish(dbng.BuildStatusSucceeded)
+
+				versions, err := defaultPipeline.LoadVersionsDB()
+				Expect(err).NotTo(HaveOccurred())
+
+				resourceID, found := versions.ResourceIDs["artifact:some-binary"]
+				Expect(found).To(BeTrue())
+
+				outputBuildIDs := []int{}
+				for _, output := range versions.BuildOutputs {
+					if output.ResourceID == resourceID {
+						Expect(output.JobID).To(Equal(defaultJob.ID()))
+						outputBuildIDs = append(outputBuildIDs, output.BuildID)
+					}
+				}
+				Expect(outputBuildIDs).To(ConsistOf(firstBuild.ID(), secondBuild.ID()))
+			})
+
+			It("does not list the artifact resource with the pipeline's resources", func() {
+				publish(dbng.BuildStatusSucceeded)
+
+				resources, err := defaultPipeline.Resources()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found := resources.Lookup("artifact:some-binary")
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when it fails", func() {
+			It("records no output", func() {
+				build, _ := publish(dbng.BuildStatusFailed)
+
+				_, outputs, err := build.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(outputs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ExpireJobArtifacts", func() {
+		var (
+			firstBuild  dbng.Build
+			secondBuild dbng.Build
+			thirdBuild  dbng.Build
+		)
+
+		BeforeEach(func() {
+			firstBuild, _ = publish(dbng.BuildStatusSucceeded)
+			secondBuild, _ = publish(dbng.BuildStatusSucceeded)
+			thirdBuild, _ = publish(dbng.BuildStatusFailed)
+		})
+
+		countArtifacts := func(build dbng.Build) int {
+			var count int
+			err := psql.Select("count(*)").
+				From("build_artifacts").
+				Where("build_id = ?", build.ID()).
+				RunWith(dbConn).
+				QueryRow().
+				Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			return count
+		}
+
+		It("keeps artifacts from the retained succeeded builds onward", func() {
+			err := artifactFactory.ExpireJobArtifacts(defaultJob.ID(), 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(countArtifacts(firstBuild)).To(BeZero())
+			Expect(countArtifacts(secondBuild)).To(Equal(1))
+			Expect(countArtifacts(thirdBuild)).To(Equal(1))
+		})
+
+		It("keeps everything within retention", func() {
+			err := artifactFactory.ExpireJobArtifacts(defaultJob.ID(), 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(countArtifacts(firstBuild)).To(Equal(1))
+			Expect(countArtifacts(secondBuild)).To(Equal(1))
+			Expect(countArtifacts(thirdBuild)).To(Equal(1))
+		})
+	})
+})
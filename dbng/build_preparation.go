@@ -38,4 +38,9 @@ type BuildPreparation struct {
 	Inputs              map[string]BuildPreparationStatus
 	InputsSatisfied     BuildPreparationStatus
 	MissingInputReasons MissingInputReasons
+	WorkerCapacity      BuildPreparationStatus
+
+	// QueuePosition is the build's 1-based position in the admission queue,
+	// or 0 if it is not queued.
+	QueuePosition int
 }
@@ -668,6 +668,7 @@ var _ = Describe("Build", func() {
 				Inputs:              map[string]dbng.BuildPreparationStatus{},
 				InputsSatisfied:     dbng.BuildPreparationStatusNotBlocking,
 				MissingInputReasons: dbng.MissingInputReasons{},
+				WorkerCapacity:      dbng.BuildPreparationStatusNotBlocking,
 			}
 		})
 
@@ -703,6 +704,27 @@ var _ = Describe("Build", func() {
 					Expect(found).To(BeTrue())
 					Expect(buildPrep).To(Equal(expectedBuildPrep))
 				})
+
+				Context("when the build is waiting for worker capacity", func() {
+					BeforeEach(func() {
+						err := build.SetWaitingForWorkerCapacity(true)
+						Expect(err).NotTo(HaveOccurred())
+
+						_, err = build.Reload()
+						Expect(err).NotTo(HaveOccurred())
+
+						expectedBuildPrep.WorkerCapacity = dbng.BuildPreparationStatusBlocking
+					})
+
+					It("returns build preparation with worker capacity blocking", func() {
+						Expect(build.IsWaitingForWorkerCapacity()).To(BeTrue())
+
+						buildPrep, found, err := build.Preparation()
+						Expect(err).NotTo(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(buildPrep).To(Equal(expectedBuildPrep))
+					})
+				})
 			})
 		})
 
@@ -1018,6 +1040,38 @@ var _ = Describe("Build", func() {
 				})
 			})
 
+			Context("when the team has reached its max running builds", func() {
+				BeforeEach(func() {
+					err := team.UpdateQuotas(atc.TeamQuotas{MaxRunningBuilds: 1})
+					Expect(err).ToNot(HaveOccurred())
+
+					runningBuild, err := team.CreateOneOffBuild()
+					Expect(err).ToNot(HaveOccurred())
+
+					started, err := runningBuild.Start("some-engine", "some-metadata")
+					Expect(err).ToNot(HaveOccurred())
+					Expect(started).To(BeTrue())
+				})
+
+				It("leaves the build unscheduled", func() {
+					Expect(f).To(BeTrue())
+					Expect(found).To(BeFalse())
+					Expect(build.IsScheduled()).To(BeFalse())
+				})
+			})
+
+			Context("when the team is below its max running builds", func() {
+				BeforeEach(func() {
+					err := team.UpdateQuotas(atc.TeamQuotas{MaxRunningBuilds: 1})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("sets the build to scheduled", func() {
+					Expect(found).To(BeTrue())
+					Expect(build.IsScheduled()).To(BeTrue())
+				})
+			})
+
 			Context("when the build does not exist", func() {
 				var found2 bool
 				BeforeEach(func() {
@@ -1148,6 +1202,46 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveMetadata", func() {
+		var build dbng.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("starts out with no metadata", func() {
+			Expect(build.Metadata()).To(BeEmpty())
+		})
+
+		It("merges the metadata into any previously saved", func() {
+			err := build.SaveMetadata(map[string]string{
+				"version":  "1.2.3",
+				"coverage": "80%",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveMetadata(map[string]string{
+				"coverage":   "85%",
+				"deploy_url": "https://example.com",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := map[string]string{
+				"version":    "1.2.3",
+				"coverage":   "85%",
+				"deploy_url": "https://example.com",
+			}
+			Expect(build.Metadata()).To(Equal(expected))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Metadata()).To(Equal(expected))
+		})
+	})
+
 	Describe("MarkAsFailed", func() {
 		var cause error
 		var build dbng.Build
@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
 	"github.com/concourse/atc/postgresrunner"
 	"github.com/tedsuo/ifrit"
 )
@@ -34,6 +35,7 @@ var (
 	teamFactory                   dbng.TeamFactory
 	workerFactory                 dbng.WorkerFactory
 	workerLifecycle               dbng.WorkerLifecycle
+	fakeWorkerStateObserver       *dbngfakes.FakeWorkerStateObserver
 	resourceConfigFactory         dbng.ResourceConfigFactory
 	resourceCacheFactory          dbng.ResourceCacheFactory
 	baseResourceTypeFactory       dbng.BaseResourceTypeFactory
@@ -93,7 +95,8 @@ var _ = BeforeEach(func() {
 	containerFactory = dbng.NewContainerFactory(dbConn)
 	teamFactory = dbng.NewTeamFactory(dbConn, lockFactory)
 	workerFactory = dbng.NewWorkerFactory(dbConn)
-	workerLifecycle = dbng.NewWorkerLifecycle(dbConn)
+	fakeWorkerStateObserver = new(dbngfakes.FakeWorkerStateObserver)
+	workerLifecycle = dbng.NewWorkerLifecycle(dbConn, fakeWorkerStateObserver)
 	resourceConfigFactory = dbng.NewResourceConfigFactory(dbConn, lockFactory)
 	resourceCacheFactory = dbng.NewResourceCacheFactory(dbConn, lockFactory)
 	baseResourceTypeFactory = dbng.NewBaseResourceTypeFactory(dbConn)
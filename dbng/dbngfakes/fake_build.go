@@ -424,6 +424,68 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	MetadataStub        func() map[string]string
+	metadataMutex       sync.RWMutex
+	metadataArgsForCall []struct{}
+	metadataReturns     struct {
+		result1 map[string]string
+	}
+	metadataReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
+	SaveMetadataStub        func(metadata map[string]string) error
+	saveMetadataMutex       sync.RWMutex
+	saveMetadataArgsForCall []struct {
+		metadata map[string]string
+	}
+	saveMetadataReturns struct {
+		result1 error
+	}
+	saveMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IsWaitingForWorkerCapacityStub        func() bool
+	isWaitingForWorkerCapacityMutex       sync.RWMutex
+	isWaitingForWorkerCapacityArgsForCall []struct{}
+	isWaitingForWorkerCapacityReturns     struct {
+		result1 bool
+	}
+	isWaitingForWorkerCapacityReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	SetWaitingForWorkerCapacityStub        func(arg1 bool) error
+	setWaitingForWorkerCapacityMutex       sync.RWMutex
+	setWaitingForWorkerCapacityArgsForCall []struct {
+		arg1 bool
+	}
+	setWaitingForWorkerCapacityReturns struct {
+		result1 error
+	}
+	setWaitingForWorkerCapacityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct{}
+	priorityReturns     struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ScheduleInTeamSerialGroupsStub        func(serialGroups atc.SerialGroupConfigs) (bool, error)
+	scheduleInTeamSerialGroupsMutex       sync.RWMutex
+	scheduleInTeamSerialGroupsArgsForCall []struct {
+		serialGroups atc.SerialGroupConfigs
+	}
+	scheduleInTeamSerialGroupsReturns struct {
+		result1 bool
+		result2 error
+	}
+	scheduleInTeamSerialGroupsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2142,6 +2204,273 @@ func (fake *FakeBuild) ScheduleReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) Metadata() map[string]string {
+	fake.metadataMutex.Lock()
+	ret, specificReturn := fake.metadataReturnsOnCall[len(fake.metadataArgsForCall)]
+	fake.metadataArgsForCall = append(fake.metadataArgsForCall, struct{}{})
+	fake.recordInvocation("Metadata", []interface{}{})
+	fake.metadataMutex.Unlock()
+	if fake.MetadataStub != nil {
+		return fake.MetadataStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.metadataReturns.result1
+}
+
+func (fake *FakeBuild) MetadataCallCount() int {
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	return len(fake.metadataArgsForCall)
+}
+
+func (fake *FakeBuild) MetadataReturns(result1 map[string]string) {
+	fake.MetadataStub = nil
+	fake.metadataReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) MetadataReturnsOnCall(i int, result1 map[string]string) {
+	fake.MetadataStub = nil
+	if fake.metadataReturnsOnCall == nil {
+		fake.metadataReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.metadataReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadata(metadata map[string]string) error {
+	fake.saveMetadataMutex.Lock()
+	ret, specificReturn := fake.saveMetadataReturnsOnCall[len(fake.saveMetadataArgsForCall)]
+	fake.saveMetadataArgsForCall = append(fake.saveMetadataArgsForCall, struct {
+		metadata map[string]string
+	}{metadata})
+	fake.recordInvocation("SaveMetadata", []interface{}{metadata})
+	fake.saveMetadataMutex.Unlock()
+	if fake.SaveMetadataStub != nil {
+		return fake.SaveMetadataStub(metadata)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveMetadataReturns.result1
+}
+
+func (fake *FakeBuild) SaveMetadataCallCount() int {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return len(fake.saveMetadataArgsForCall)
+}
+
+func (fake *FakeBuild) SaveMetadataArgsForCall(i int) map[string]string {
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	return fake.saveMetadataArgsForCall[i].metadata
+}
+
+func (fake *FakeBuild) SaveMetadataReturns(result1 error) {
+	fake.SaveMetadataStub = nil
+	fake.saveMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveMetadataReturnsOnCall(i int, result1 error) {
+	fake.SaveMetadataStub = nil
+	if fake.saveMetadataReturnsOnCall == nil {
+		fake.saveMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) IsWaitingForWorkerCapacity() bool {
+	fake.isWaitingForWorkerCapacityMutex.Lock()
+	ret, specificReturn := fake.isWaitingForWorkerCapacityReturnsOnCall[len(fake.isWaitingForWorkerCapacityArgsForCall)]
+	fake.isWaitingForWorkerCapacityArgsForCall = append(fake.isWaitingForWorkerCapacityArgsForCall, struct{}{})
+	fake.recordInvocation("IsWaitingForWorkerCapacity", []interface{}{})
+	fake.isWaitingForWorkerCapacityMutex.Unlock()
+	if fake.IsWaitingForWorkerCapacityStub != nil {
+		return fake.IsWaitingForWorkerCapacityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isWaitingForWorkerCapacityReturns.result1
+}
+
+func (fake *FakeBuild) IsWaitingForWorkerCapacityCallCount() int {
+	fake.isWaitingForWorkerCapacityMutex.RLock()
+	defer fake.isWaitingForWorkerCapacityMutex.RUnlock()
+	return len(fake.isWaitingForWorkerCapacityArgsForCall)
+}
+
+func (fake *FakeBuild) IsWaitingForWorkerCapacityReturns(result1 bool) {
+	fake.IsWaitingForWorkerCapacityStub = nil
+	fake.isWaitingForWorkerCapacityReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) IsWaitingForWorkerCapacityReturnsOnCall(i int, result1 bool) {
+	fake.IsWaitingForWorkerCapacityStub = nil
+	if fake.isWaitingForWorkerCapacityReturnsOnCall == nil {
+		fake.isWaitingForWorkerCapacityReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isWaitingForWorkerCapacityReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCapacity(arg1 bool) error {
+	fake.setWaitingForWorkerCapacityMutex.Lock()
+	ret, specificReturn := fake.setWaitingForWorkerCapacityReturnsOnCall[len(fake.setWaitingForWorkerCapacityArgsForCall)]
+	fake.setWaitingForWorkerCapacityArgsForCall = append(fake.setWaitingForWorkerCapacityArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetWaitingForWorkerCapacity", []interface{}{arg1})
+	fake.setWaitingForWorkerCapacityMutex.Unlock()
+	if fake.SetWaitingForWorkerCapacityStub != nil {
+		return fake.SetWaitingForWorkerCapacityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.setWaitingForWorkerCapacityReturns.result1
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCapacityCallCount() int {
+	fake.setWaitingForWorkerCapacityMutex.RLock()
+	defer fake.setWaitingForWorkerCapacityMutex.RUnlock()
+	return len(fake.setWaitingForWorkerCapacityArgsForCall)
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCapacityArgsForCall(i int) bool {
+	fake.setWaitingForWorkerCapacityMutex.RLock()
+	defer fake.setWaitingForWorkerCapacityMutex.RUnlock()
+	return fake.setWaitingForWorkerCapacityArgsForCall[i].arg1
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCapacityReturns(result1 error) {
+	fake.SetWaitingForWorkerCapacityStub = nil
+	fake.setWaitingForWorkerCapacityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetWaitingForWorkerCapacityReturnsOnCall(i int, result1 error) {
+	fake.SetWaitingForWorkerCapacityStub = nil
+	if fake.setWaitingForWorkerCapacityReturnsOnCall == nil {
+		fake.setWaitingForWorkerCapacityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWaitingForWorkerCapacityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct{}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.priorityReturns.result1
+}
+
+func (fake *FakeBuild) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeBuild) PriorityReturns(result1 int) {
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PriorityReturnsOnCall(i int, result1 int) {
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) ScheduleInTeamSerialGroups(serialGroups atc.SerialGroupConfigs) (bool, error) {
+	fake.scheduleInTeamSerialGroupsMutex.Lock()
+	ret, specificReturn := fake.scheduleInTeamSerialGroupsReturnsOnCall[len(fake.scheduleInTeamSerialGroupsArgsForCall)]
+	fake.scheduleInTeamSerialGroupsArgsForCall = append(fake.scheduleInTeamSerialGroupsArgsForCall, struct {
+		serialGroups atc.SerialGroupConfigs
+	}{serialGroups})
+	fake.recordInvocation("ScheduleInTeamSerialGroups", []interface{}{serialGroups})
+	fake.scheduleInTeamSerialGroupsMutex.Unlock()
+	if fake.ScheduleInTeamSerialGroupsStub != nil {
+		return fake.ScheduleInTeamSerialGroupsStub(serialGroups)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.scheduleInTeamSerialGroupsReturns.result1, fake.scheduleInTeamSerialGroupsReturns.result2
+}
+
+func (fake *FakeBuild) ScheduleInTeamSerialGroupsCallCount() int {
+	fake.scheduleInTeamSerialGroupsMutex.RLock()
+	defer fake.scheduleInTeamSerialGroupsMutex.RUnlock()
+	return len(fake.scheduleInTeamSerialGroupsArgsForCall)
+}
+
+func (fake *FakeBuild) ScheduleInTeamSerialGroupsArgsForCall(i int) atc.SerialGroupConfigs {
+	fake.scheduleInTeamSerialGroupsMutex.RLock()
+	defer fake.scheduleInTeamSerialGroupsMutex.RUnlock()
+	return fake.scheduleInTeamSerialGroupsArgsForCall[i].serialGroups
+}
+
+func (fake *FakeBuild) ScheduleInTeamSerialGroupsReturns(result1 bool, result2 error) {
+	fake.ScheduleInTeamSerialGroupsStub = nil
+	fake.scheduleInTeamSerialGroupsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ScheduleInTeamSerialGroupsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ScheduleInTeamSerialGroupsStub = nil
+	if fake.scheduleInTeamSerialGroupsReturnsOnCall == nil {
+		fake.scheduleInTeamSerialGroupsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.scheduleInTeamSerialGroupsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -2223,6 +2552,18 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	fake.saveMetadataMutex.RLock()
+	defer fake.saveMetadataMutex.RUnlock()
+	fake.isWaitingForWorkerCapacityMutex.RLock()
+	defer fake.isWaitingForWorkerCapacityMutex.RUnlock()
+	fake.setWaitingForWorkerCapacityMutex.RLock()
+	defer fake.setWaitingForWorkerCapacityMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	fake.scheduleInTeamSerialGroupsMutex.RLock()
+	defer fake.scheduleInTeamSerialGroupsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -299,6 +299,48 @@ type FakeJob struct {
 		result2 bool
 		result3 error
 	}
+	CreateBuildWithPriorityStub        func(priority int) (dbng.Build, error)
+	createBuildWithPriorityMutex       sync.RWMutex
+	createBuildWithPriorityArgsForCall []struct {
+		priority int
+	}
+	createBuildWithPriorityReturns struct {
+		result1 dbng.Build
+		result2 error
+	}
+	createBuildWithPriorityReturnsOnCall map[int]struct {
+		result1 dbng.Build
+		result2 error
+	}
+	CountRunningBuildsInSerialGroupStub        func(serialGroup string, teamWide bool) (int, error)
+	countRunningBuildsInSerialGroupMutex       sync.RWMutex
+	countRunningBuildsInSerialGroupArgsForCall []struct {
+		serialGroup string
+		teamWide    bool
+	}
+	countRunningBuildsInSerialGroupReturns struct {
+		result1 int
+		result2 error
+	}
+	countRunningBuildsInSerialGroupReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	GetNextPendingBuildInTeamSerialGroupStub        func(serialGroup string) (dbng.Build, bool, error)
+	getNextPendingBuildInTeamSerialGroupMutex       sync.RWMutex
+	getNextPendingBuildInTeamSerialGroupArgsForCall []struct {
+		serialGroup string
+	}
+	getNextPendingBuildInTeamSerialGroupReturns struct {
+		result1 dbng.Build
+		result2 bool
+		result3 error
+	}
+	getNextPendingBuildInTeamSerialGroupReturnsOnCall map[int]struct {
+		result1 dbng.Build
+		result2 bool
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1502,6 +1544,163 @@ func (fake *FakeJob) GetNextPendingBuildBySerialGroupReturnsOnCall(i int, result
 	}{result1, result2, result3}
 }
 
+func (fake *FakeJob) CreateBuildWithPriority(priority int) (dbng.Build, error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	ret, specificReturn := fake.createBuildWithPriorityReturnsOnCall[len(fake.createBuildWithPriorityArgsForCall)]
+	fake.createBuildWithPriorityArgsForCall = append(fake.createBuildWithPriorityArgsForCall, struct {
+		priority int
+	}{priority})
+	fake.recordInvocation("CreateBuildWithPriority", []interface{}{priority})
+	fake.createBuildWithPriorityMutex.Unlock()
+	if fake.CreateBuildWithPriorityStub != nil {
+		return fake.CreateBuildWithPriorityStub(priority)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createBuildWithPriorityReturns.result1, fake.createBuildWithPriorityReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityCallCount() int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	return len(fake.createBuildWithPriorityArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityArgsForCall(i int) int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	return fake.createBuildWithPriorityArgsForCall[i].priority
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturns(result1 dbng.Build, result2 error) {
+	fake.CreateBuildWithPriorityStub = nil
+	fake.createBuildWithPriorityReturns = struct {
+		result1 dbng.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturnsOnCall(i int, result1 dbng.Build, result2 error) {
+	fake.CreateBuildWithPriorityStub = nil
+	if fake.createBuildWithPriorityReturnsOnCall == nil {
+		fake.createBuildWithPriorityReturnsOnCall = make(map[int]struct {
+			result1 dbng.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithPriorityReturnsOnCall[i] = struct {
+		result1 dbng.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CountRunningBuildsInSerialGroup(serialGroup string, teamWide bool) (int, error) {
+	fake.countRunningBuildsInSerialGroupMutex.Lock()
+	ret, specificReturn := fake.countRunningBuildsInSerialGroupReturnsOnCall[len(fake.countRunningBuildsInSerialGroupArgsForCall)]
+	fake.countRunningBuildsInSerialGroupArgsForCall = append(fake.countRunningBuildsInSerialGroupArgsForCall, struct {
+		serialGroup string
+		teamWide    bool
+	}{serialGroup, teamWide})
+	fake.recordInvocation("CountRunningBuildsInSerialGroup", []interface{}{serialGroup, teamWide})
+	fake.countRunningBuildsInSerialGroupMutex.Unlock()
+	if fake.CountRunningBuildsInSerialGroupStub != nil {
+		return fake.CountRunningBuildsInSerialGroupStub(serialGroup, teamWide)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.countRunningBuildsInSerialGroupReturns.result1, fake.countRunningBuildsInSerialGroupReturns.result2
+}
+
+func (fake *FakeJob) CountRunningBuildsInSerialGroupCallCount() int {
+	fake.countRunningBuildsInSerialGroupMutex.RLock()
+	defer fake.countRunningBuildsInSerialGroupMutex.RUnlock()
+	return len(fake.countRunningBuildsInSerialGroupArgsForCall)
+}
+
+func (fake *FakeJob) CountRunningBuildsInSerialGroupArgsForCall(i int) (string, bool) {
+	fake.countRunningBuildsInSerialGroupMutex.RLock()
+	defer fake.countRunningBuildsInSerialGroupMutex.RUnlock()
+	return fake.countRunningBuildsInSerialGroupArgsForCall[i].serialGroup, fake.countRunningBuildsInSerialGroupArgsForCall[i].teamWide
+}
+
+func (fake *FakeJob) CountRunningBuildsInSerialGroupReturns(result1 int, result2 error) {
+	fake.CountRunningBuildsInSerialGroupStub = nil
+	fake.countRunningBuildsInSerialGroupReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CountRunningBuildsInSerialGroupReturnsOnCall(i int, result1 int, result2 error) {
+	fake.CountRunningBuildsInSerialGroupStub = nil
+	if fake.countRunningBuildsInSerialGroupReturnsOnCall == nil {
+		fake.countRunningBuildsInSerialGroupReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.countRunningBuildsInSerialGroupReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) GetNextPendingBuildInTeamSerialGroup(serialGroup string) (dbng.Build, bool, error) {
+	fake.getNextPendingBuildInTeamSerialGroupMutex.Lock()
+	ret, specificReturn := fake.getNextPendingBuildInTeamSerialGroupReturnsOnCall[len(fake.getNextPendingBuildInTeamSerialGroupArgsForCall)]
+	fake.getNextPendingBuildInTeamSerialGroupArgsForCall = append(fake.getNextPendingBuildInTeamSerialGroupArgsForCall, struct {
+		serialGroup string
+	}{serialGroup})
+	fake.recordInvocation("GetNextPendingBuildInTeamSerialGroup", []interface{}{serialGroup})
+	fake.getNextPendingBuildInTeamSerialGroupMutex.Unlock()
+	if fake.GetNextPendingBuildInTeamSerialGroupStub != nil {
+		return fake.GetNextPendingBuildInTeamSerialGroupStub(serialGroup)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.getNextPendingBuildInTeamSerialGroupReturns.result1, fake.getNextPendingBuildInTeamSerialGroupReturns.result2, fake.getNextPendingBuildInTeamSerialGroupReturns.result3
+}
+
+func (fake *FakeJob) GetNextPendingBuildInTeamSerialGroupCallCount() int {
+	fake.getNextPendingBuildInTeamSerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildInTeamSerialGroupMutex.RUnlock()
+	return len(fake.getNextPendingBuildInTeamSerialGroupArgsForCall)
+}
+
+func (fake *FakeJob) GetNextPendingBuildInTeamSerialGroupArgsForCall(i int) string {
+	fake.getNextPendingBuildInTeamSerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildInTeamSerialGroupMutex.RUnlock()
+	return fake.getNextPendingBuildInTeamSerialGroupArgsForCall[i].serialGroup
+}
+
+func (fake *FakeJob) GetNextPendingBuildInTeamSerialGroupReturns(result1 dbng.Build, result2 bool, result3 error) {
+	fake.GetNextPendingBuildInTeamSerialGroupStub = nil
+	fake.getNextPendingBuildInTeamSerialGroupReturns = struct {
+		result1 dbng.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeJob) GetNextPendingBuildInTeamSerialGroupReturnsOnCall(i int, result1 dbng.Build, result2 bool, result3 error) {
+	fake.GetNextPendingBuildInTeamSerialGroupStub = nil
+	if fake.getNextPendingBuildInTeamSerialGroupReturnsOnCall == nil {
+		fake.getNextPendingBuildInTeamSerialGroupReturnsOnCall = make(map[int]struct {
+			result1 dbng.Build
+			result2 bool
+			result3 error
+		})
+	}
+	fake.getNextPendingBuildInTeamSerialGroupReturnsOnCall[i] = struct {
+		result1 dbng.Build
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1559,6 +1758,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.getRunningBuildsBySerialGroupMutex.RUnlock()
 	fake.getNextPendingBuildBySerialGroupMutex.RLock()
 	defer fake.getNextPendingBuildBySerialGroupMutex.RUnlock()
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	fake.countRunningBuildsInSerialGroupMutex.RLock()
+	defer fake.countRunningBuildsInSerialGroupMutex.RUnlock()
+	fake.getNextPendingBuildInTeamSerialGroupMutex.RLock()
+	defer fake.getNextPendingBuildInTeamSerialGroupMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -603,6 +603,30 @@ type FakePipeline struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SchedulingNotifierStub        func() (dbng.Notifier, error)
+	schedulingNotifierMutex       sync.RWMutex
+	schedulingNotifierArgsForCall []struct{}
+	schedulingNotifierReturns     struct {
+		result1 dbng.Notifier
+		result2 error
+	}
+	schedulingNotifierReturnsOnCall map[int]struct {
+		result1 dbng.Notifier
+		result2 error
+	}
+	SaveResourceVersionsWithMetadataStub        func(arg1 lager.Logger, arg2 atc.ResourceConfig, arg3 []atc.VersionWithMetadata) error
+	saveResourceVersionsWithMetadataMutex       sync.RWMutex
+	saveResourceVersionsWithMetadataArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceConfig
+		arg3 []atc.VersionWithMetadata
+	}
+	saveResourceVersionsWithMetadataReturns struct {
+		result1 error
+	}
+	saveResourceVersionsWithMetadataReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -2934,6 +2958,104 @@ func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) SchedulingNotifier() (dbng.Notifier, error) {
+	fake.schedulingNotifierMutex.Lock()
+	ret, specificReturn := fake.schedulingNotifierReturnsOnCall[len(fake.schedulingNotifierArgsForCall)]
+	fake.schedulingNotifierArgsForCall = append(fake.schedulingNotifierArgsForCall, struct{}{})
+	fake.recordInvocation("SchedulingNotifier", []interface{}{})
+	fake.schedulingNotifierMutex.Unlock()
+	if fake.SchedulingNotifierStub != nil {
+		return fake.SchedulingNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.schedulingNotifierReturns.result1, fake.schedulingNotifierReturns.result2
+}
+
+func (fake *FakePipeline) SchedulingNotifierCallCount() int {
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
+	return len(fake.schedulingNotifierArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturns(result1 dbng.Notifier, result2 error) {
+	fake.SchedulingNotifierStub = nil
+	fake.schedulingNotifierReturns = struct {
+		result1 dbng.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturnsOnCall(i int, result1 dbng.Notifier, result2 error) {
+	fake.SchedulingNotifierStub = nil
+	if fake.schedulingNotifierReturnsOnCall == nil {
+		fake.schedulingNotifierReturnsOnCall = make(map[int]struct {
+			result1 dbng.Notifier
+			result2 error
+		})
+	}
+	fake.schedulingNotifierReturnsOnCall[i] = struct {
+		result1 dbng.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SaveResourceVersionsWithMetadata(arg1 lager.Logger, arg2 atc.ResourceConfig, arg3 []atc.VersionWithMetadata) error {
+	var arg3Copy []atc.VersionWithMetadata
+	if arg3 != nil {
+		arg3Copy = make([]atc.VersionWithMetadata, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.saveResourceVersionsWithMetadataMutex.Lock()
+	ret, specificReturn := fake.saveResourceVersionsWithMetadataReturnsOnCall[len(fake.saveResourceVersionsWithMetadataArgsForCall)]
+	fake.saveResourceVersionsWithMetadataArgsForCall = append(fake.saveResourceVersionsWithMetadataArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceConfig
+		arg3 []atc.VersionWithMetadata
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("SaveResourceVersionsWithMetadata", []interface{}{arg1, arg2, arg3Copy})
+	fake.saveResourceVersionsWithMetadataMutex.Unlock()
+	if fake.SaveResourceVersionsWithMetadataStub != nil {
+		return fake.SaveResourceVersionsWithMetadataStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveResourceVersionsWithMetadataReturns.result1
+}
+
+func (fake *FakePipeline) SaveResourceVersionsWithMetadataCallCount() int {
+	fake.saveResourceVersionsWithMetadataMutex.RLock()
+	defer fake.saveResourceVersionsWithMetadataMutex.RUnlock()
+	return len(fake.saveResourceVersionsWithMetadataArgsForCall)
+}
+
+func (fake *FakePipeline) SaveResourceVersionsWithMetadataArgsForCall(i int) (lager.Logger, atc.ResourceConfig, []atc.VersionWithMetadata) {
+	fake.saveResourceVersionsWithMetadataMutex.RLock()
+	defer fake.saveResourceVersionsWithMetadataMutex.RUnlock()
+	return fake.saveResourceVersionsWithMetadataArgsForCall[i].arg1, fake.saveResourceVersionsWithMetadataArgsForCall[i].arg2, fake.saveResourceVersionsWithMetadataArgsForCall[i].arg3
+}
+
+func (fake *FakePipeline) SaveResourceVersionsWithMetadataReturns(result1 error) {
+	fake.SaveResourceVersionsWithMetadataStub = nil
+	fake.saveResourceVersionsWithMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) SaveResourceVersionsWithMetadataReturnsOnCall(i int, result1 error) {
+	fake.SaveResourceVersionsWithMetadataStub = nil
+	if fake.saveResourceVersionsWithMetadataReturnsOnCall == nil {
+		fake.saveResourceVersionsWithMetadataReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveResourceVersionsWithMetadataReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -3035,6 +3157,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.destroyMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
+	fake.saveResourceVersionsWithMetadataMutex.RLock()
+	defer fake.saveResourceVersionsWithMetadataMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
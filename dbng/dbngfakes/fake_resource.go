@@ -137,6 +137,15 @@ type FakeResource struct {
 		result1 bool
 		result2 error
 	}
+	WebhookStub        func() *atc.WebhookConfig
+	webhookMutex       sync.RWMutex
+	webhookArgsForCall []struct{}
+	webhookReturns     struct {
+		result1 *atc.WebhookConfig
+	}
+	webhookReturnsOnCall map[int]struct {
+		result1 *atc.WebhookConfig
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -704,6 +713,46 @@ func (fake *FakeResource) ReloadReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
+func (fake *FakeResource) Webhook() *atc.WebhookConfig {
+	fake.webhookMutex.Lock()
+	ret, specificReturn := fake.webhookReturnsOnCall[len(fake.webhookArgsForCall)]
+	fake.webhookArgsForCall = append(fake.webhookArgsForCall, struct{}{})
+	fake.recordInvocation("Webhook", []interface{}{})
+	fake.webhookMutex.Unlock()
+	if fake.WebhookStub != nil {
+		return fake.WebhookStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.webhookReturns.result1
+}
+
+func (fake *FakeResource) WebhookCallCount() int {
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
+	return len(fake.webhookArgsForCall)
+}
+
+func (fake *FakeResource) WebhookReturns(result1 *atc.WebhookConfig) {
+	fake.WebhookStub = nil
+	fake.webhookReturns = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
+func (fake *FakeResource) WebhookReturnsOnCall(i int, result1 *atc.WebhookConfig) {
+	fake.WebhookStub = nil
+	if fake.webhookReturnsOnCall == nil {
+		fake.webhookReturnsOnCall = make(map[int]struct {
+			result1 *atc.WebhookConfig
+		})
+	}
+	fake.webhookReturnsOnCall[i] = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -735,6 +784,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.unpauseMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
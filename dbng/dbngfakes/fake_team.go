@@ -399,6 +399,103 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FindContainerPlacementStrategyStub        func() (string, error)
+	findContainerPlacementStrategyMutex       sync.RWMutex
+	findContainerPlacementStrategyArgsForCall []struct{}
+	findContainerPlacementStrategyReturns     struct {
+		result1 string
+		result2 error
+	}
+	findContainerPlacementStrategyReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	UpdateContainerPlacementStrategyStub        func(strategy string) error
+	updateContainerPlacementStrategyMutex       sync.RWMutex
+	updateContainerPlacementStrategyArgsForCall []struct {
+		strategy string
+	}
+	updateContainerPlacementStrategyReturns struct {
+		result1 error
+	}
+	updateContainerPlacementStrategyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FindQuotasStub        func() (atc.TeamQuotas, error)
+	findQuotasMutex       sync.RWMutex
+	findQuotasArgsForCall []struct{}
+	findQuotasReturns     struct {
+		result1 atc.TeamQuotas
+		result2 error
+	}
+	findQuotasReturnsOnCall map[int]struct {
+		result1 atc.TeamQuotas
+		result2 error
+	}
+	UpdateQuotasStub        func(quotas atc.TeamQuotas) error
+	updateQuotasMutex       sync.RWMutex
+	updateQuotasArgsForCall []struct {
+		quotas atc.TeamQuotas
+	}
+	updateQuotasReturns struct {
+		result1 error
+	}
+	updateQuotasReturnsOnCall map[int]struct {
+		result1 error
+	}
+	QuotaUsageStub        func() (atc.TeamQuotaUsage, error)
+	quotaUsageMutex       sync.RWMutex
+	quotaUsageArgsForCall []struct{}
+	quotaUsageReturns     struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}
+	quotaUsageReturnsOnCall map[int]struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}
+	FindSerialGroupsStub        func() (atc.SerialGroupConfigs, error)
+	findSerialGroupsMutex       sync.RWMutex
+	findSerialGroupsArgsForCall []struct{}
+	findSerialGroupsReturns     struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}
+	findSerialGroupsReturnsOnCall map[int]struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}
+	UpdateSerialGroupsStub        func(serialGroups atc.SerialGroupConfigs) error
+	updateSerialGroupsMutex       sync.RWMutex
+	updateSerialGroupsArgsForCall []struct {
+		serialGroups atc.SerialGroupConfigs
+	}
+	updateSerialGroupsReturns struct {
+		result1 error
+	}
+	updateSerialGroupsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateAdmissionWeightStub        func(weight int) error
+	updateAdmissionWeightMutex       sync.RWMutex
+	updateAdmissionWeightArgsForCall []struct {
+		weight int
+	}
+	updateAdmissionWeightReturns struct {
+		result1 error
+	}
+	updateAdmissionWeightReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ContainerPlacementStrategyStub        func() string
+	containerPlacementStrategyMutex       sync.RWMutex
+	containerPlacementStrategyArgsForCall []struct{}
+	containerPlacementStrategyReturns     struct {
+		result1 string
+	}
+	containerPlacementStrategyReturnsOnCall map[int]struct {
+		result1 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1870,6 +1967,410 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) FindContainerPlacementStrategy() (string, error) {
+	fake.findContainerPlacementStrategyMutex.Lock()
+	ret, specificReturn := fake.findContainerPlacementStrategyReturnsOnCall[len(fake.findContainerPlacementStrategyArgsForCall)]
+	fake.findContainerPlacementStrategyArgsForCall = append(fake.findContainerPlacementStrategyArgsForCall, struct{}{})
+	fake.recordInvocation("FindContainerPlacementStrategy", []interface{}{})
+	fake.findContainerPlacementStrategyMutex.Unlock()
+	if fake.FindContainerPlacementStrategyStub != nil {
+		return fake.FindContainerPlacementStrategyStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findContainerPlacementStrategyReturns.result1, fake.findContainerPlacementStrategyReturns.result2
+}
+
+func (fake *FakeTeam) FindContainerPlacementStrategyCallCount() int {
+	fake.findContainerPlacementStrategyMutex.RLock()
+	defer fake.findContainerPlacementStrategyMutex.RUnlock()
+	return len(fake.findContainerPlacementStrategyArgsForCall)
+}
+
+func (fake *FakeTeam) FindContainerPlacementStrategyReturns(result1 string, result2 error) {
+	fake.FindContainerPlacementStrategyStub = nil
+	fake.findContainerPlacementStrategyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindContainerPlacementStrategyReturnsOnCall(i int, result1 string, result2 error) {
+	fake.FindContainerPlacementStrategyStub = nil
+	if fake.findContainerPlacementStrategyReturnsOnCall == nil {
+		fake.findContainerPlacementStrategyReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.findContainerPlacementStrategyReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateContainerPlacementStrategy(strategy string) error {
+	fake.updateContainerPlacementStrategyMutex.Lock()
+	ret, specificReturn := fake.updateContainerPlacementStrategyReturnsOnCall[len(fake.updateContainerPlacementStrategyArgsForCall)]
+	fake.updateContainerPlacementStrategyArgsForCall = append(fake.updateContainerPlacementStrategyArgsForCall, struct {
+		strategy string
+	}{strategy})
+	fake.recordInvocation("UpdateContainerPlacementStrategy", []interface{}{strategy})
+	fake.updateContainerPlacementStrategyMutex.Unlock()
+	if fake.UpdateContainerPlacementStrategyStub != nil {
+		return fake.UpdateContainerPlacementStrategyStub(strategy)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateContainerPlacementStrategyReturns.result1
+}
+
+func (fake *FakeTeam) UpdateContainerPlacementStrategyCallCount() int {
+	fake.updateContainerPlacementStrategyMutex.RLock()
+	defer fake.updateContainerPlacementStrategyMutex.RUnlock()
+	return len(fake.updateContainerPlacementStrategyArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateContainerPlacementStrategyArgsForCall(i int) string {
+	fake.updateContainerPlacementStrategyMutex.RLock()
+	defer fake.updateContainerPlacementStrategyMutex.RUnlock()
+	return fake.updateContainerPlacementStrategyArgsForCall[i].strategy
+}
+
+func (fake *FakeTeam) UpdateContainerPlacementStrategyReturns(result1 error) {
+	fake.UpdateContainerPlacementStrategyStub = nil
+	fake.updateContainerPlacementStrategyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateContainerPlacementStrategyReturnsOnCall(i int, result1 error) {
+	fake.UpdateContainerPlacementStrategyStub = nil
+	if fake.updateContainerPlacementStrategyReturnsOnCall == nil {
+		fake.updateContainerPlacementStrategyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateContainerPlacementStrategyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) FindQuotas() (atc.TeamQuotas, error) {
+	fake.findQuotasMutex.Lock()
+	ret, specificReturn := fake.findQuotasReturnsOnCall[len(fake.findQuotasArgsForCall)]
+	fake.findQuotasArgsForCall = append(fake.findQuotasArgsForCall, struct{}{})
+	fake.recordInvocation("FindQuotas", []interface{}{})
+	fake.findQuotasMutex.Unlock()
+	if fake.FindQuotasStub != nil {
+		return fake.FindQuotasStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findQuotasReturns.result1, fake.findQuotasReturns.result2
+}
+
+func (fake *FakeTeam) FindQuotasCallCount() int {
+	fake.findQuotasMutex.RLock()
+	defer fake.findQuotasMutex.RUnlock()
+	return len(fake.findQuotasArgsForCall)
+}
+
+func (fake *FakeTeam) FindQuotasReturns(result1 atc.TeamQuotas, result2 error) {
+	fake.FindQuotasStub = nil
+	fake.findQuotasReturns = struct {
+		result1 atc.TeamQuotas
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindQuotasReturnsOnCall(i int, result1 atc.TeamQuotas, result2 error) {
+	fake.FindQuotasStub = nil
+	if fake.findQuotasReturnsOnCall == nil {
+		fake.findQuotasReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuotas
+			result2 error
+		})
+	}
+	fake.findQuotasReturnsOnCall[i] = struct {
+		result1 atc.TeamQuotas
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateQuotas(quotas atc.TeamQuotas) error {
+	fake.updateQuotasMutex.Lock()
+	ret, specificReturn := fake.updateQuotasReturnsOnCall[len(fake.updateQuotasArgsForCall)]
+	fake.updateQuotasArgsForCall = append(fake.updateQuotasArgsForCall, struct {
+		quotas atc.TeamQuotas
+	}{quotas})
+	fake.recordInvocation("UpdateQuotas", []interface{}{quotas})
+	fake.updateQuotasMutex.Unlock()
+	if fake.UpdateQuotasStub != nil {
+		return fake.UpdateQuotasStub(quotas)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateQuotasReturns.result1
+}
+
+func (fake *FakeTeam) UpdateQuotasCallCount() int {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	return len(fake.updateQuotasArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateQuotasArgsForCall(i int) atc.TeamQuotas {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	return fake.updateQuotasArgsForCall[i].quotas
+}
+
+func (fake *FakeTeam) UpdateQuotasReturns(result1 error) {
+	fake.UpdateQuotasStub = nil
+	fake.updateQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuotasReturnsOnCall(i int, result1 error) {
+	fake.UpdateQuotasStub = nil
+	if fake.updateQuotasReturnsOnCall == nil {
+		fake.updateQuotasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuotasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotaUsage() (atc.TeamQuotaUsage, error) {
+	fake.quotaUsageMutex.Lock()
+	ret, specificReturn := fake.quotaUsageReturnsOnCall[len(fake.quotaUsageArgsForCall)]
+	fake.quotaUsageArgsForCall = append(fake.quotaUsageArgsForCall, struct{}{})
+	fake.recordInvocation("QuotaUsage", []interface{}{})
+	fake.quotaUsageMutex.Unlock()
+	if fake.QuotaUsageStub != nil {
+		return fake.QuotaUsageStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.quotaUsageReturns.result1, fake.quotaUsageReturns.result2
+}
+
+func (fake *FakeTeam) QuotaUsageCallCount() int {
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	return len(fake.quotaUsageArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaUsageReturns(result1 atc.TeamQuotaUsage, result2 error) {
+	fake.QuotaUsageStub = nil
+	fake.quotaUsageReturns = struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) QuotaUsageReturnsOnCall(i int, result1 atc.TeamQuotaUsage, result2 error) {
+	fake.QuotaUsageStub = nil
+	if fake.quotaUsageReturnsOnCall == nil {
+		fake.quotaUsageReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuotaUsage
+			result2 error
+		})
+	}
+	fake.quotaUsageReturnsOnCall[i] = struct {
+		result1 atc.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindSerialGroups() (atc.SerialGroupConfigs, error) {
+	fake.findSerialGroupsMutex.Lock()
+	ret, specificReturn := fake.findSerialGroupsReturnsOnCall[len(fake.findSerialGroupsArgsForCall)]
+	fake.findSerialGroupsArgsForCall = append(fake.findSerialGroupsArgsForCall, struct{}{})
+	fake.recordInvocation("FindSerialGroups", []interface{}{})
+	fake.findSerialGroupsMutex.Unlock()
+	if fake.FindSerialGroupsStub != nil {
+		return fake.FindSerialGroupsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findSerialGroupsReturns.result1, fake.findSerialGroupsReturns.result2
+}
+
+func (fake *FakeTeam) FindSerialGroupsCallCount() int {
+	fake.findSerialGroupsMutex.RLock()
+	defer fake.findSerialGroupsMutex.RUnlock()
+	return len(fake.findSerialGroupsArgsForCall)
+}
+
+func (fake *FakeTeam) FindSerialGroupsReturns(result1 atc.SerialGroupConfigs, result2 error) {
+	fake.FindSerialGroupsStub = nil
+	fake.findSerialGroupsReturns = struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) FindSerialGroupsReturnsOnCall(i int, result1 atc.SerialGroupConfigs, result2 error) {
+	fake.FindSerialGroupsStub = nil
+	if fake.findSerialGroupsReturnsOnCall == nil {
+		fake.findSerialGroupsReturnsOnCall = make(map[int]struct {
+			result1 atc.SerialGroupConfigs
+			result2 error
+		})
+	}
+	fake.findSerialGroupsReturnsOnCall[i] = struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) UpdateSerialGroups(serialGroups atc.SerialGroupConfigs) error {
+	fake.updateSerialGroupsMutex.Lock()
+	ret, specificReturn := fake.updateSerialGroupsReturnsOnCall[len(fake.updateSerialGroupsArgsForCall)]
+	fake.updateSerialGroupsArgsForCall = append(fake.updateSerialGroupsArgsForCall, struct {
+		serialGroups atc.SerialGroupConfigs
+	}{serialGroups})
+	fake.recordInvocation("UpdateSerialGroups", []interface{}{serialGroups})
+	fake.updateSerialGroupsMutex.Unlock()
+	if fake.UpdateSerialGroupsStub != nil {
+		return fake.UpdateSerialGroupsStub(serialGroups)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateSerialGroupsReturns.result1
+}
+
+func (fake *FakeTeam) UpdateSerialGroupsCallCount() int {
+	fake.updateSerialGroupsMutex.RLock()
+	defer fake.updateSerialGroupsMutex.RUnlock()
+	return len(fake.updateSerialGroupsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateSerialGroupsArgsForCall(i int) atc.SerialGroupConfigs {
+	fake.updateSerialGroupsMutex.RLock()
+	defer fake.updateSerialGroupsMutex.RUnlock()
+	return fake.updateSerialGroupsArgsForCall[i].serialGroups
+}
+
+func (fake *FakeTeam) UpdateSerialGroupsReturns(result1 error) {
+	fake.UpdateSerialGroupsStub = nil
+	fake.updateSerialGroupsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateSerialGroupsReturnsOnCall(i int, result1 error) {
+	fake.UpdateSerialGroupsStub = nil
+	if fake.updateSerialGroupsReturnsOnCall == nil {
+		fake.updateSerialGroupsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSerialGroupsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateAdmissionWeight(weight int) error {
+	fake.updateAdmissionWeightMutex.Lock()
+	ret, specificReturn := fake.updateAdmissionWeightReturnsOnCall[len(fake.updateAdmissionWeightArgsForCall)]
+	fake.updateAdmissionWeightArgsForCall = append(fake.updateAdmissionWeightArgsForCall, struct {
+		weight int
+	}{weight})
+	fake.recordInvocation("UpdateAdmissionWeight", []interface{}{weight})
+	fake.updateAdmissionWeightMutex.Unlock()
+	if fake.UpdateAdmissionWeightStub != nil {
+		return fake.UpdateAdmissionWeightStub(weight)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.updateAdmissionWeightReturns.result1
+}
+
+func (fake *FakeTeam) UpdateAdmissionWeightCallCount() int {
+	fake.updateAdmissionWeightMutex.RLock()
+	defer fake.updateAdmissionWeightMutex.RUnlock()
+	return len(fake.updateAdmissionWeightArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateAdmissionWeightArgsForCall(i int) int {
+	fake.updateAdmissionWeightMutex.RLock()
+	defer fake.updateAdmissionWeightMutex.RUnlock()
+	return fake.updateAdmissionWeightArgsForCall[i].weight
+}
+
+func (fake *FakeTeam) UpdateAdmissionWeightReturns(result1 error) {
+	fake.UpdateAdmissionWeightStub = nil
+	fake.updateAdmissionWeightReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateAdmissionWeightReturnsOnCall(i int, result1 error) {
+	fake.UpdateAdmissionWeightStub = nil
+	if fake.updateAdmissionWeightReturnsOnCall == nil {
+		fake.updateAdmissionWeightReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateAdmissionWeightReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerPlacementStrategy() string {
+	fake.containerPlacementStrategyMutex.Lock()
+	ret, specificReturn := fake.containerPlacementStrategyReturnsOnCall[len(fake.containerPlacementStrategyArgsForCall)]
+	fake.containerPlacementStrategyArgsForCall = append(fake.containerPlacementStrategyArgsForCall, struct{}{})
+	fake.recordInvocation("ContainerPlacementStrategy", []interface{}{})
+	fake.containerPlacementStrategyMutex.Unlock()
+	if fake.ContainerPlacementStrategyStub != nil {
+		return fake.ContainerPlacementStrategyStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.containerPlacementStrategyReturns.result1
+}
+
+func (fake *FakeTeam) ContainerPlacementStrategyCallCount() int {
+	fake.containerPlacementStrategyMutex.RLock()
+	defer fake.containerPlacementStrategyMutex.RUnlock()
+	return len(fake.containerPlacementStrategyArgsForCall)
+}
+
+func (fake *FakeTeam) ContainerPlacementStrategyReturns(result1 string) {
+	fake.ContainerPlacementStrategyStub = nil
+	fake.containerPlacementStrategyReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeTeam) ContainerPlacementStrategyReturnsOnCall(i int, result1 string) {
+	fake.ContainerPlacementStrategyStub = nil
+	if fake.containerPlacementStrategyReturnsOnCall == nil {
+		fake.containerPlacementStrategyReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.containerPlacementStrategyReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1933,6 +2434,24 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.updateBasicAuthMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.findContainerPlacementStrategyMutex.RLock()
+	defer fake.findContainerPlacementStrategyMutex.RUnlock()
+	fake.updateContainerPlacementStrategyMutex.RLock()
+	defer fake.updateContainerPlacementStrategyMutex.RUnlock()
+	fake.findQuotasMutex.RLock()
+	defer fake.findQuotasMutex.RUnlock()
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	fake.findSerialGroupsMutex.RLock()
+	defer fake.findSerialGroupsMutex.RUnlock()
+	fake.updateSerialGroupsMutex.RLock()
+	defer fake.updateSerialGroupsMutex.RUnlock()
+	fake.updateAdmissionWeightMutex.RLock()
+	defer fake.updateAdmissionWeightMutex.RUnlock()
+	fake.containerPlacementStrategyMutex.RLock()
+	defer fake.containerPlacementStrategyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
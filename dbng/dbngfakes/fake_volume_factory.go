@@ -187,6 +187,20 @@ type FakeVolumeFactory struct {
 		result2 bool
 		result3 error
 	}
+	EvictCacheVolumesStub        func(workerName string, limit int) (int, error)
+	evictCacheVolumesMutex       sync.RWMutex
+	evictCacheVolumesArgsForCall []struct {
+		workerName string
+		limit      int
+	}
+	evictCacheVolumesReturns struct {
+		result1 int
+		result2 error
+	}
+	evictCacheVolumesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -822,6 +836,58 @@ func (fake *FakeVolumeFactory) FindCreatedVolumeReturnsOnCall(i int, result1 dbn
 	}{result1, result2, result3}
 }
 
+func (fake *FakeVolumeFactory) EvictCacheVolumes(workerName string, limit int) (int, error) {
+	fake.evictCacheVolumesMutex.Lock()
+	ret, specificReturn := fake.evictCacheVolumesReturnsOnCall[len(fake.evictCacheVolumesArgsForCall)]
+	fake.evictCacheVolumesArgsForCall = append(fake.evictCacheVolumesArgsForCall, struct {
+		workerName string
+		limit      int
+	}{workerName, limit})
+	fake.recordInvocation("EvictCacheVolumes", []interface{}{workerName, limit})
+	fake.evictCacheVolumesMutex.Unlock()
+	if fake.EvictCacheVolumesStub != nil {
+		return fake.EvictCacheVolumesStub(workerName, limit)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.evictCacheVolumesReturns.result1, fake.evictCacheVolumesReturns.result2
+}
+
+func (fake *FakeVolumeFactory) EvictCacheVolumesCallCount() int {
+	fake.evictCacheVolumesMutex.RLock()
+	defer fake.evictCacheVolumesMutex.RUnlock()
+	return len(fake.evictCacheVolumesArgsForCall)
+}
+
+func (fake *FakeVolumeFactory) EvictCacheVolumesArgsForCall(i int) (string, int) {
+	fake.evictCacheVolumesMutex.RLock()
+	defer fake.evictCacheVolumesMutex.RUnlock()
+	return fake.evictCacheVolumesArgsForCall[i].workerName, fake.evictCacheVolumesArgsForCall[i].limit
+}
+
+func (fake *FakeVolumeFactory) EvictCacheVolumesReturns(result1 int, result2 error) {
+	fake.EvictCacheVolumesStub = nil
+	fake.evictCacheVolumesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeFactory) EvictCacheVolumesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.EvictCacheVolumesStub = nil
+	if fake.evictCacheVolumesReturnsOnCall == nil {
+		fake.evictCacheVolumesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.evictCacheVolumesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -849,6 +915,8 @@ func (fake *FakeVolumeFactory) Invocations() map[string][][]interface{} {
 	defer fake.getDuplicateResourceCacheVolumesMutex.RUnlock()
 	fake.findCreatedVolumeMutex.RLock()
 	defer fake.findCreatedVolumeMutex.RUnlock()
+	fake.evictCacheVolumesMutex.RLock()
+	defer fake.evictCacheVolumesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
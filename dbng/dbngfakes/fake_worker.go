@@ -201,6 +201,84 @@ type FakeWorker struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CapacityStub        func() *atc.WorkerResources
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct{}
+	capacityReturns     struct {
+		result1 *atc.WorkerResources
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
+	AllocatedStub        func() atc.WorkerResources
+	allocatedMutex       sync.RWMutex
+	allocatedArgsForCall []struct{}
+	allocatedReturns     struct {
+		result1 atc.WorkerResources
+	}
+	allocatedReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+	}
+	DiskStub        func() *atc.WorkerDisk
+	diskMutex       sync.RWMutex
+	diskArgsForCall []struct{}
+	diskReturns     struct {
+		result1 *atc.WorkerDisk
+	}
+	diskReturnsOnCall map[int]struct {
+		result1 *atc.WorkerDisk
+	}
+	LandingStartedAtStub        func() time.Time
+	landingStartedAtMutex       sync.RWMutex
+	landingStartedAtArgsForCall []struct{}
+	landingStartedAtReturns     struct {
+		result1 time.Time
+	}
+	landingStartedAtReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	DrainProgressStub        func() (dbng.WorkerDrainProgress, error)
+	drainProgressMutex       sync.RWMutex
+	drainProgressArgsForCall []struct{}
+	drainProgressReturns     struct {
+		result1 dbng.WorkerDrainProgress
+		result2 error
+	}
+	drainProgressReturnsOnCall map[int]struct {
+		result1 dbng.WorkerDrainProgress
+		result2 error
+	}
+	HealthCheckFailuresStub        func() int
+	healthCheckFailuresMutex       sync.RWMutex
+	healthCheckFailuresArgsForCall []struct{}
+	healthCheckFailuresReturns     struct {
+		result1 int
+	}
+	healthCheckFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
+	RecordHealthCheckStub        func(arg1 atc.WorkerHealthCheck) error
+	recordHealthCheckMutex       sync.RWMutex
+	recordHealthCheckArgsForCall []struct {
+		arg1 atc.WorkerHealthCheck
+	}
+	recordHealthCheckReturns struct {
+		result1 error
+	}
+	recordHealthCheckReturnsOnCall map[int]struct {
+		result1 error
+	}
+	HealthChecksStub        func() ([]atc.WorkerHealthCheck, error)
+	healthChecksMutex       sync.RWMutex
+	healthChecksArgsForCall []struct{}
+	healthChecksReturns     struct {
+		result1 []atc.WorkerHealthCheck
+		result2 error
+	}
+	healthChecksReturnsOnCall map[int]struct {
+		result1 []atc.WorkerHealthCheck
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1048,6 +1126,340 @@ func (fake *FakeWorker) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capacity() *atc.WorkerResources {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct{}{})
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if fake.CapacityStub != nil {
+		return fake.CapacityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.capacityReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 *atc.WorkerResources) {
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) Allocated() atc.WorkerResources {
+	fake.allocatedMutex.Lock()
+	ret, specificReturn := fake.allocatedReturnsOnCall[len(fake.allocatedArgsForCall)]
+	fake.allocatedArgsForCall = append(fake.allocatedArgsForCall, struct{}{})
+	fake.recordInvocation("Allocated", []interface{}{})
+	fake.allocatedMutex.Unlock()
+	if fake.AllocatedStub != nil {
+		return fake.AllocatedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.allocatedReturns.result1
+}
+
+func (fake *FakeWorker) AllocatedCallCount() int {
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	return len(fake.allocatedArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatedReturns(result1 atc.WorkerResources) {
+	fake.AllocatedStub = nil
+	fake.allocatedReturns = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatedReturnsOnCall(i int, result1 atc.WorkerResources) {
+	fake.AllocatedStub = nil
+	if fake.allocatedReturnsOnCall == nil {
+		fake.allocatedReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+		})
+	}
+	fake.allocatedReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) Disk() *atc.WorkerDisk {
+	fake.diskMutex.Lock()
+	ret, specificReturn := fake.diskReturnsOnCall[len(fake.diskArgsForCall)]
+	fake.diskArgsForCall = append(fake.diskArgsForCall, struct{}{})
+	fake.recordInvocation("Disk", []interface{}{})
+	fake.diskMutex.Unlock()
+	if fake.DiskStub != nil {
+		return fake.DiskStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.diskReturns.result1
+}
+
+func (fake *FakeWorker) DiskCallCount() int {
+	fake.diskMutex.RLock()
+	defer fake.diskMutex.RUnlock()
+	return len(fake.diskArgsForCall)
+}
+
+func (fake *FakeWorker) DiskReturns(result1 *atc.WorkerDisk) {
+	fake.DiskStub = nil
+	fake.diskReturns = struct {
+		result1 *atc.WorkerDisk
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskReturnsOnCall(i int, result1 *atc.WorkerDisk) {
+	fake.DiskStub = nil
+	if fake.diskReturnsOnCall == nil {
+		fake.diskReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerDisk
+		})
+	}
+	fake.diskReturnsOnCall[i] = struct {
+		result1 *atc.WorkerDisk
+	}{result1}
+}
+
+func (fake *FakeWorker) LandingStartedAt() time.Time {
+	fake.landingStartedAtMutex.Lock()
+	ret, specificReturn := fake.landingStartedAtReturnsOnCall[len(fake.landingStartedAtArgsForCall)]
+	fake.landingStartedAtArgsForCall = append(fake.landingStartedAtArgsForCall, struct{}{})
+	fake.recordInvocation("LandingStartedAt", []interface{}{})
+	fake.landingStartedAtMutex.Unlock()
+	if fake.LandingStartedAtStub != nil {
+		return fake.LandingStartedAtStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.landingStartedAtReturns.result1
+}
+
+func (fake *FakeWorker) LandingStartedAtCallCount() int {
+	fake.landingStartedAtMutex.RLock()
+	defer fake.landingStartedAtMutex.RUnlock()
+	return len(fake.landingStartedAtArgsForCall)
+}
+
+func (fake *FakeWorker) LandingStartedAtReturns(result1 time.Time) {
+	fake.LandingStartedAtStub = nil
+	fake.landingStartedAtReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeWorker) LandingStartedAtReturnsOnCall(i int, result1 time.Time) {
+	fake.LandingStartedAtStub = nil
+	if fake.landingStartedAtReturnsOnCall == nil {
+		fake.landingStartedAtReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.landingStartedAtReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeWorker) DrainProgress() (dbng.WorkerDrainProgress, error) {
+	fake.drainProgressMutex.Lock()
+	ret, specificReturn := fake.drainProgressReturnsOnCall[len(fake.drainProgressArgsForCall)]
+	fake.drainProgressArgsForCall = append(fake.drainProgressArgsForCall, struct{}{})
+	fake.recordInvocation("DrainProgress", []interface{}{})
+	fake.drainProgressMutex.Unlock()
+	if fake.DrainProgressStub != nil {
+		return fake.DrainProgressStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.drainProgressReturns.result1, fake.drainProgressReturns.result2
+}
+
+func (fake *FakeWorker) DrainProgressCallCount() int {
+	fake.drainProgressMutex.RLock()
+	defer fake.drainProgressMutex.RUnlock()
+	return len(fake.drainProgressArgsForCall)
+}
+
+func (fake *FakeWorker) DrainProgressReturns(result1 dbng.WorkerDrainProgress, result2 error) {
+	fake.DrainProgressStub = nil
+	fake.drainProgressReturns = struct {
+		result1 dbng.WorkerDrainProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) DrainProgressReturnsOnCall(i int, result1 dbng.WorkerDrainProgress, result2 error) {
+	fake.DrainProgressStub = nil
+	if fake.drainProgressReturnsOnCall == nil {
+		fake.drainProgressReturnsOnCall = make(map[int]struct {
+			result1 dbng.WorkerDrainProgress
+			result2 error
+		})
+	}
+	fake.drainProgressReturnsOnCall[i] = struct {
+		result1 dbng.WorkerDrainProgress
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) HealthCheckFailures() int {
+	fake.healthCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.healthCheckFailuresReturnsOnCall[len(fake.healthCheckFailuresArgsForCall)]
+	fake.healthCheckFailuresArgsForCall = append(fake.healthCheckFailuresArgsForCall, struct{}{})
+	fake.recordInvocation("HealthCheckFailures", []interface{}{})
+	fake.healthCheckFailuresMutex.Unlock()
+	if fake.HealthCheckFailuresStub != nil {
+		return fake.HealthCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.healthCheckFailuresReturns.result1
+}
+
+func (fake *FakeWorker) HealthCheckFailuresCallCount() int {
+	fake.healthCheckFailuresMutex.RLock()
+	defer fake.healthCheckFailuresMutex.RUnlock()
+	return len(fake.healthCheckFailuresArgsForCall)
+}
+
+func (fake *FakeWorker) HealthCheckFailuresReturns(result1 int) {
+	fake.HealthCheckFailuresStub = nil
+	fake.healthCheckFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthCheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.HealthCheckFailuresStub = nil
+	if fake.healthCheckFailuresReturnsOnCall == nil {
+		fake.healthCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.healthCheckFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) RecordHealthCheck(arg1 atc.WorkerHealthCheck) error {
+	fake.recordHealthCheckMutex.Lock()
+	ret, specificReturn := fake.recordHealthCheckReturnsOnCall[len(fake.recordHealthCheckArgsForCall)]
+	fake.recordHealthCheckArgsForCall = append(fake.recordHealthCheckArgsForCall, struct {
+		arg1 atc.WorkerHealthCheck
+	}{arg1})
+	fake.recordInvocation("RecordHealthCheck", []interface{}{arg1})
+	fake.recordHealthCheckMutex.Unlock()
+	if fake.RecordHealthCheckStub != nil {
+		return fake.RecordHealthCheckStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.recordHealthCheckReturns.result1
+}
+
+func (fake *FakeWorker) RecordHealthCheckCallCount() int {
+	fake.recordHealthCheckMutex.RLock()
+	defer fake.recordHealthCheckMutex.RUnlock()
+	return len(fake.recordHealthCheckArgsForCall)
+}
+
+func (fake *FakeWorker) RecordHealthCheckArgsForCall(i int) atc.WorkerHealthCheck {
+	fake.recordHealthCheckMutex.RLock()
+	defer fake.recordHealthCheckMutex.RUnlock()
+	return fake.recordHealthCheckArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) RecordHealthCheckReturns(result1 error) {
+	fake.RecordHealthCheckStub = nil
+	fake.recordHealthCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) RecordHealthCheckReturnsOnCall(i int, result1 error) {
+	fake.RecordHealthCheckStub = nil
+	if fake.recordHealthCheckReturnsOnCall == nil {
+		fake.recordHealthCheckReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordHealthCheckReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthChecks() ([]atc.WorkerHealthCheck, error) {
+	fake.healthChecksMutex.Lock()
+	ret, specificReturn := fake.healthChecksReturnsOnCall[len(fake.healthChecksArgsForCall)]
+	fake.healthChecksArgsForCall = append(fake.healthChecksArgsForCall, struct{}{})
+	fake.recordInvocation("HealthChecks", []interface{}{})
+	fake.healthChecksMutex.Unlock()
+	if fake.HealthChecksStub != nil {
+		return fake.HealthChecksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.healthChecksReturns.result1, fake.healthChecksReturns.result2
+}
+
+func (fake *FakeWorker) HealthChecksCallCount() int {
+	fake.healthChecksMutex.RLock()
+	defer fake.healthChecksMutex.RUnlock()
+	return len(fake.healthChecksArgsForCall)
+}
+
+func (fake *FakeWorker) HealthChecksReturns(result1 []atc.WorkerHealthCheck, result2 error) {
+	fake.HealthChecksStub = nil
+	fake.healthChecksReturns = struct {
+		result1 []atc.WorkerHealthCheck
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) HealthChecksReturnsOnCall(i int, result1 []atc.WorkerHealthCheck, result2 error) {
+	fake.HealthChecksStub = nil
+	if fake.healthChecksReturnsOnCall == nil {
+		fake.healthChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.WorkerHealthCheck
+			result2 error
+		})
+	}
+	fake.healthChecksReturnsOnCall[i] = struct {
+		result1 []atc.WorkerHealthCheck
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1093,6 +1505,22 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.pruneMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	fake.diskMutex.RLock()
+	defer fake.diskMutex.RUnlock()
+	fake.landingStartedAtMutex.RLock()
+	defer fake.landingStartedAtMutex.RUnlock()
+	fake.drainProgressMutex.RLock()
+	defer fake.drainProgressMutex.RUnlock()
+	fake.healthCheckFailuresMutex.RLock()
+	defer fake.healthCheckFailuresMutex.RUnlock()
+	fake.recordHealthCheckMutex.RLock()
+	defer fake.recordHealthCheckMutex.RUnlock()
+	fake.healthChecksMutex.RLock()
+	defer fake.healthChecksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,7 @@ package dbngfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/atc/dbng"
 )
@@ -41,6 +42,42 @@ type FakeWorkerLifecycle struct {
 		result1 []string
 		result2 error
 	}
+	AbortBuildsOnOverdueLandingWorkersStub        func(deadline time.Duration, requeue bool) ([]int, error)
+	abortBuildsOnOverdueLandingWorkersMutex       sync.RWMutex
+	abortBuildsOnOverdueLandingWorkersArgsForCall []struct {
+		deadline time.Duration
+		requeue  bool
+	}
+	abortBuildsOnOverdueLandingWorkersReturns struct {
+		result1 []int
+		result2 error
+	}
+	abortBuildsOnOverdueLandingWorkersReturnsOnCall map[int]struct {
+		result1 []int
+		result2 error
+	}
+	LandWorkerStub        func(workerName string) error
+	landWorkerMutex       sync.RWMutex
+	landWorkerArgsForCall []struct {
+		workerName string
+	}
+	landWorkerReturns struct {
+		result1 error
+	}
+	landWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RetireWorkerStub        func(workerName string) error
+	retireWorkerMutex       sync.RWMutex
+	retireWorkerArgsForCall []struct {
+		workerName string
+	}
+	retireWorkerReturns struct {
+		result1 error
+	}
+	retireWorkerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -174,6 +211,154 @@ func (fake *FakeWorkerLifecycle) DeleteFinishedRetiringWorkersReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerLifecycle) AbortBuildsOnOverdueLandingWorkers(deadline time.Duration, requeue bool) ([]int, error) {
+	fake.abortBuildsOnOverdueLandingWorkersMutex.Lock()
+	ret, specificReturn := fake.abortBuildsOnOverdueLandingWorkersReturnsOnCall[len(fake.abortBuildsOnOverdueLandingWorkersArgsForCall)]
+	fake.abortBuildsOnOverdueLandingWorkersArgsForCall = append(fake.abortBuildsOnOverdueLandingWorkersArgsForCall, struct {
+		deadline time.Duration
+		requeue  bool
+	}{deadline, requeue})
+	fake.recordInvocation("AbortBuildsOnOverdueLandingWorkers", []interface{}{deadline, requeue})
+	fake.abortBuildsOnOverdueLandingWorkersMutex.Unlock()
+	if fake.AbortBuildsOnOverdueLandingWorkersStub != nil {
+		return fake.AbortBuildsOnOverdueLandingWorkersStub(deadline, requeue)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.abortBuildsOnOverdueLandingWorkersReturns.result1, fake.abortBuildsOnOverdueLandingWorkersReturns.result2
+}
+
+func (fake *FakeWorkerLifecycle) AbortBuildsOnOverdueLandingWorkersCallCount() int {
+	fake.abortBuildsOnOverdueLandingWorkersMutex.RLock()
+	defer fake.abortBuildsOnOverdueLandingWorkersMutex.RUnlock()
+	return len(fake.abortBuildsOnOverdueLandingWorkersArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) AbortBuildsOnOverdueLandingWorkersArgsForCall(i int) (time.Duration, bool) {
+	fake.abortBuildsOnOverdueLandingWorkersMutex.RLock()
+	defer fake.abortBuildsOnOverdueLandingWorkersMutex.RUnlock()
+	return fake.abortBuildsOnOverdueLandingWorkersArgsForCall[i].deadline, fake.abortBuildsOnOverdueLandingWorkersArgsForCall[i].requeue
+}
+
+func (fake *FakeWorkerLifecycle) AbortBuildsOnOverdueLandingWorkersReturns(result1 []int, result2 error) {
+	fake.AbortBuildsOnOverdueLandingWorkersStub = nil
+	fake.abortBuildsOnOverdueLandingWorkersReturns = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) AbortBuildsOnOverdueLandingWorkersReturnsOnCall(i int, result1 []int, result2 error) {
+	fake.AbortBuildsOnOverdueLandingWorkersStub = nil
+	if fake.abortBuildsOnOverdueLandingWorkersReturnsOnCall == nil {
+		fake.abortBuildsOnOverdueLandingWorkersReturnsOnCall = make(map[int]struct {
+			result1 []int
+			result2 error
+		})
+	}
+	fake.abortBuildsOnOverdueLandingWorkersReturnsOnCall[i] = struct {
+		result1 []int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorker(workerName string) error {
+	fake.landWorkerMutex.Lock()
+	ret, specificReturn := fake.landWorkerReturnsOnCall[len(fake.landWorkerArgsForCall)]
+	fake.landWorkerArgsForCall = append(fake.landWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("LandWorker", []interface{}{workerName})
+	fake.landWorkerMutex.Unlock()
+	if fake.LandWorkerStub != nil {
+		return fake.LandWorkerStub(workerName)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.landWorkerReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkerCallCount() int {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return len(fake.landWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkerArgsForCall(i int) string {
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	return fake.landWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkerReturns(result1 error) {
+	fake.LandWorkerStub = nil
+	fake.landWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) LandWorkerReturnsOnCall(i int, result1 error) {
+	fake.LandWorkerStub = nil
+	if fake.landWorkerReturnsOnCall == nil {
+		fake.landWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.landWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) RetireWorker(workerName string) error {
+	fake.retireWorkerMutex.Lock()
+	ret, specificReturn := fake.retireWorkerReturnsOnCall[len(fake.retireWorkerArgsForCall)]
+	fake.retireWorkerArgsForCall = append(fake.retireWorkerArgsForCall, struct {
+		workerName string
+	}{workerName})
+	fake.recordInvocation("RetireWorker", []interface{}{workerName})
+	fake.retireWorkerMutex.Unlock()
+	if fake.RetireWorkerStub != nil {
+		return fake.RetireWorkerStub(workerName)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.retireWorkerReturns.result1
+}
+
+func (fake *FakeWorkerLifecycle) RetireWorkerCallCount() int {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return len(fake.retireWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerLifecycle) RetireWorkerArgsForCall(i int) string {
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
+	return fake.retireWorkerArgsForCall[i].workerName
+}
+
+func (fake *FakeWorkerLifecycle) RetireWorkerReturns(result1 error) {
+	fake.RetireWorkerStub = nil
+	fake.retireWorkerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorkerLifecycle) RetireWorkerReturnsOnCall(i int, result1 error) {
+	fake.RetireWorkerStub = nil
+	if fake.retireWorkerReturnsOnCall == nil {
+		fake.retireWorkerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.retireWorkerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -183,6 +368,12 @@ func (fake *FakeWorkerLifecycle) Invocations() map[string][][]interface{} {
 	defer fake.landFinishedLandingWorkersMutex.RUnlock()
 	fake.deleteFinishedRetiringWorkersMutex.RLock()
 	defer fake.deleteFinishedRetiringWorkersMutex.RUnlock()
+	fake.abortBuildsOnOverdueLandingWorkersMutex.RLock()
+	defer fake.abortBuildsOnOverdueLandingWorkersMutex.RUnlock()
+	fake.landWorkerMutex.RLock()
+	defer fake.landWorkerMutex.RUnlock()
+	fake.retireWorkerMutex.RLock()
+	defer fake.retireWorkerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
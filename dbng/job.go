@@ -31,6 +31,7 @@ type Job interface {
 	Unpause() error
 
 	CreateBuild() (Build, error)
+	CreateBuildWithPriority(priority int) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
@@ -47,6 +48,8 @@ type Job interface {
 	SetMaxInFlightReached(bool) error
 	GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error)
 	GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bool, error)
+	CountRunningBuildsInSerialGroup(serialGroup string, teamWide bool) (int, error)
+	GetNextPendingBuildInTeamSerialGroup(serialGroup string) (Build, bool, error)
 }
 
 var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce").
@@ -342,7 +345,7 @@ func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bo
 		WHERE b.status = 'pending'
 			AND j.inputs_determined = true
 			AND j.pipeline_id = $1
-		ORDER BY b.id ASC
+		ORDER BY b.priority DESC, b.id ASC
 		LIMIT 1
 	`, args...)
 
@@ -358,6 +361,38 @@ func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bo
 	return build, true, nil
 }
 
+// GetNextPendingBuildInTeamSerialGroup returns the next pending build in the
+// serial group across all of the unpaused pipelines of the job's team.
+func (j *job) GetNextPendingBuildInTeamSerialGroup(serialGroup string) (Build, bool, error) {
+	row := j.conn.QueryRow(`
+		SELECT DISTINCT `+qualifiedBuildColumns+`
+		FROM builds b
+		INNER JOIN jobs j ON b.job_id = j.id
+		INNER JOIN pipelines p ON j.pipeline_id = p.id
+		INNER JOIN teams t ON b.team_id = t.id
+		INNER JOIN jobs_serial_groups jsg ON j.id = jsg.job_id
+				AND jsg.serial_group = $2
+		WHERE b.status = 'pending'
+			AND j.inputs_determined = true
+			AND j.paused = false
+			AND p.paused = false
+			AND p.team_id = $1
+		ORDER BY b.priority DESC, b.id ASC
+		LIMIT 1
+	`, j.teamID, serialGroup)
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err := scanBuild(build, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return build, true, nil
+}
+
 func (j *job) GetRunningBuildsBySerialGroup(serialGroups []string) ([]Build, error) {
 	err := j.updateSerialGroups(serialGroups)
 	if err != nil {
@@ -519,12 +554,12 @@ func (j *job) EnsurePendingBuildExists() error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, team_id, status)
-		SELECT $1, $2, $3, 'pending'
+		INSERT INTO builds (name, job_id, team_id, status, priority)
+		SELECT $1, $2, $3, 'pending', $4
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.teamID)
+	`, buildName, j.id, j.teamID, j.config.Priority)
 	if err != nil {
 		return err
 	}
@@ -571,7 +606,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("b.id ASC").
+		OrderBy("b.priority DESC", "b.id ASC").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -594,6 +629,12 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.CreateBuildWithPriority(j.config.Priority)
+}
+
+// CreateBuildWithPriority creates a manually triggered build with the given
+// priority rather than the one configured on the job.
+func (j *job) CreateBuildWithPriority(priority int) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -608,8 +649,8 @@ func (j *job) CreateBuild() (Build, error) {
 
 	var buildID int
 	err = psql.Insert("builds").
-		Columns("name", "job_id", "team_id", "status", "manually_triggered").
-		Values(buildName, j.id, j.teamID, BuildStatusPending, true).
+		Columns("name", "job_id", "team_id", "status", "manually_triggered", "priority").
+		Values(buildName, j.id, j.teamID, BuildStatusPending, true, priority).
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -641,6 +682,37 @@ func (j *job) CreateBuild() (Build, error) {
 	return build, nil
 }
 
+// CountRunningBuildsInSerialGroup counts the running builds of the jobs in
+// the serial group, either within the job's pipeline or, if teamWide, across
+// all of its team's pipelines.
+func (j *job) CountRunningBuildsInSerialGroup(serialGroup string, teamWide bool) (int, error) {
+	scope := sq.Eq{"j.pipeline_id": j.pipelineID}
+	if teamWide {
+		scope = sq.Eq{"p.team_id": j.teamID}
+	}
+
+	var count int
+	err := psql.Select("COUNT(DISTINCT b.id)").
+		From("builds b").
+		Join("jobs j ON b.job_id = j.id").
+		Join("pipelines p ON j.pipeline_id = p.id").
+		Join("jobs_serial_groups jsg ON j.id = jsg.job_id").
+		Where(sq.Eq{"jsg.serial_group": serialGroup}).
+		Where(sq.Or{
+			sq.Eq{"b.status": BuildStatusStarted},
+			sq.Eq{"b.status": BuildStatusPending, "b.scheduled": true},
+		}).
+		Where(scope).
+		RunWith(j.conn).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
 func (j *job) updateSerialGroups(serialGroups []string) error {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -1164,4 +1164,127 @@ var _ = Describe("Job", func() {
 			})
 		})
 	})
+
+	Describe("CreateBuildWithPriority", func() {
+		It("creates a manually triggered build with the priority", func() {
+			build, err := job.CreateBuildWithPriority(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.IsManuallyTriggered()).To(BeTrue())
+			Expect(build.Priority()).To(Equal(10))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Priority()).To(Equal(10))
+		})
+
+		It("orders pending builds by priority, then by age", func() {
+			build1, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			build2, err := job.CreateBuildWithPriority(10)
+			Expect(err).NotTo(HaveOccurred())
+
+			build3, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(3))
+			Expect(pendingBuilds[0].ID()).To(Equal(build2.ID()))
+			Expect(pendingBuilds[1].ID()).To(Equal(build1.ID()))
+			Expect(pendingBuilds[2].ID()).To(Equal(build3.ID()))
+		})
+	})
+
+	Describe("CountRunningBuildsInSerialGroup", func() {
+		var pendingBuild dbng.Build
+
+		BeforeEach(func() {
+			startedBuild, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = startedBuild.Start("", "")
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduledBuild, err := job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			scheduled, err := scheduledBuild.Schedule()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+
+			pendingBuild, err = job.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			startRunningBuildInSerialGroup := func(team dbng.Team) {
+				otherPipeline, _, err := team.SavePipeline("other-serial-pipeline", atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:         "other-serial-job",
+							SerialGroups: []string{"serial-group"},
+						},
+					},
+				}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherJob, found, err := otherPipeline.Job("other-serial-job")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				otherBuild, err := otherJob.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = otherBuild.Start("", "")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			startRunningBuildInSerialGroup(team)
+
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			startRunningBuildInSerialGroup(otherTeam)
+		})
+
+		It("counts the running builds in the group within the pipeline", func() {
+			count, err := job.CountRunningBuildsInSerialGroup("serial-group", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+
+		It("counts the running builds in the group across the team's pipelines", func() {
+			count, err := job.CountRunningBuildsInSerialGroup("serial-group", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(3))
+		})
+
+		It("does not count builds in other groups", func() {
+			count, err := job.CountRunningBuildsInSerialGroup("some-other-serial-group", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		Describe("scheduling a build in the team's serial groups", func() {
+			It("does not schedule it once a group is running as many builds as its size", func() {
+				scheduled, err := pendingBuild.ScheduleInTeamSerialGroups(atc.SerialGroupConfigs{
+					{Name: "serial-group", Size: 3},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeFalse())
+			})
+
+			It("schedules it while the groups have room", func() {
+				scheduled, err := pendingBuild.ScheduleInTeamSerialGroups(atc.SerialGroupConfigs{
+					{Name: "serial-group", Size: 4},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scheduled).To(BeTrue())
+
+				count, err := job.CountRunningBuildsInSerialGroup("serial-group", true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(4))
+			})
+		})
+	})
 })
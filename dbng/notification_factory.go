@@ -0,0 +1,452 @@
+package dbng
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+// Notification is a build status change waiting in the outbox to be
+// delivered to a subscription.
+type Notification struct {
+	ID             int
+	SubscriptionID int
+	URL            string
+	Secret         string
+	Payload        atc.BuildNotification
+	Attempts       int
+}
+
+// NotificationAttempt is the outcome of one attempt to deliver a
+// notification.
+type NotificationAttempt struct {
+	Delivered  bool
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+}
+
+//go:generate counterfeiter . NotificationFactory
+
+type NotificationFactory interface {
+	CreateSubscription(teamID int, pipelineID int, subscription atc.NotificationSubscription) (atc.NotificationSubscription, error)
+	Subscriptions(teamID int) ([]atc.NotificationSubscription, error)
+	DeleteSubscription(teamID int, subscriptionID int) (bool, error)
+	Deliveries(teamID int, subscriptionID int, limit int) ([]atc.NotificationDelivery, bool, error)
+
+	DueNotifications(limit int) ([]Notification, error)
+	RecordAttempt(notificationID int, attempt NotificationAttempt, retryAt *time.Time) error
+	PruneNotifications(before time.Time) error
+}
+
+type notificationFactory struct {
+	conn Conn
+}
+
+func NewNotificationFactory(conn Conn) NotificationFactory {
+	return &notificationFactory{
+		conn: conn,
+	}
+}
+
+var subscriptionsQuery = psql.Select("s.id, p.name, s.url, s.jobs, s.statuses").
+	From("notification_subscriptions s").
+	LeftJoin("pipelines p ON p.id = s.pipeline_id")
+
+func (f *notificationFactory) CreateSubscription(teamID int, pipelineID int, subscription atc.NotificationSubscription) (atc.NotificationSubscription, error) {
+	jobs, err := json.Marshal(nonNilStrings(subscription.Jobs))
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	statuses, err := json.Marshal(nonNilStatuses(subscription.Statuses))
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	var secret, nonce *string
+	if subscription.Secret != "" {
+		encrypted, encryptionNonce, err := f.conn.EncryptionStrategy().Encrypt([]byte(subscription.Secret))
+		if err != nil {
+			return atc.NotificationSubscription{}, err
+		}
+
+		secret, nonce = &encrypted, encryptionNonce
+	}
+
+	var pipeline *int
+	if pipelineID != 0 {
+		pipeline = &pipelineID
+	}
+
+	var id int
+	err = psql.Insert("notification_subscriptions").
+		Columns("team_id", "pipeline_id", "url", "secret", "nonce", "jobs", "statuses").
+		Values(teamID, pipeline, subscription.URL, secret, nonce, string(jobs), string(statuses)).
+		Suffix("RETURNING id").
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	subscription.ID = id
+	subscription.Secret = ""
+
+	return subscription, nil
+}
+
+func (f *notificationFactory) Subscriptions(teamID int) ([]atc.NotificationSubscription, error) {
+	rows, err := subscriptionsQuery.
+		Where(sq.Eq{"s.team_id": teamID}).
+		OrderBy("s.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subscriptions := []atc.NotificationSubscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (f *notificationFactory) DeleteSubscription(teamID int, subscriptionID int) (bool, error) {
+	result, err := psql.Delete("notification_subscriptions").
+		Where(sq.Eq{
+			"id":      subscriptionID,
+			"team_id": teamID,
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Deliveries returns the most recent attempts to deliver notifications to the
+// subscription, most recent first.
+func (f *notificationFactory) Deliveries(teamID int, subscriptionID int, limit int) ([]atc.NotificationDelivery, bool, error) {
+	var id int
+	err := psql.Select("id").
+		From("notification_subscriptions").
+		Where(sq.Eq{
+			"id":      subscriptionID,
+			"team_id": teamID,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	rows, err := psql.Select("o.id, o.build_id, o.status, d.attempt, d.status_code, d.error, d.duration, d.attempted_at").
+		From("notification_deliveries d").
+		Join("notification_outbox o ON o.id = d.notification_id").
+		Where(sq.Eq{"o.subscription_id": subscriptionID}).
+		OrderBy("d.id DESC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	deliveries := []atc.NotificationDelivery{}
+	for rows.Next() {
+		var (
+			delivery    atc.NotificationDelivery
+			status      string
+			statusCode  sql.NullInt64
+			duration    int64
+			attemptedAt time.Time
+		)
+
+		err := rows.Scan(&delivery.NotificationID, &delivery.BuildID, &status, &delivery.Attempt, &statusCode, &delivery.Error, &duration, &attemptedAt)
+		if err != nil {
+			return nil, false, err
+		}
+
+		delivery.Status = atc.BuildStatus(status)
+		delivery.StatusCode = int(statusCode.Int64)
+		delivery.Duration = time.Duration(duration).Seconds()
+		delivery.AttemptedAt = attemptedAt.Unix()
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, true, nil
+}
+
+// DueNotifications returns the undelivered notifications whose next attempt
+// is due, oldest first. A notification is held back while an earlier one to
+// the same subscription is still waiting to be retried, so that each
+// subscription receives its notifications in order.
+func (f *notificationFactory) DueNotifications(limit int) ([]Notification, error) {
+	rows, err := psql.Select("o.id, o.subscription_id, s.url, s.secret, s.nonce, o.payload, o.attempts").
+		From("notification_outbox o").
+		Join("notification_subscriptions s ON s.id = o.subscription_id").
+		Where(sq.Eq{"o.delivered_at": nil}).
+		Where(sq.Expr("o.next_attempt_at <= now()")).
+		Where(sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM notification_outbox e
+			WHERE e.subscription_id = o.subscription_id
+			AND e.id < o.id
+			AND e.delivered_at IS NULL
+			AND e.next_attempt_at > now()
+		)`)).
+		OrderBy("o.id ASC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	es := f.conn.EncryptionStrategy()
+
+	notifications := []Notification{}
+	for rows.Next() {
+		var (
+			notification Notification
+			secret       sql.NullString
+			nonce        sql.NullString
+			payload      string
+		)
+
+		err := rows.Scan(&notification.ID, &notification.SubscriptionID, &notification.URL, &secret, &nonce, &payload, &notification.Attempts)
+		if err != nil {
+			return nil, err
+		}
+
+		if secret.Valid {
+			var noncense *string
+			if nonce.Valid {
+				noncense = &nonce.String
+			}
+
+			decrypted, err := es.Decrypt(secret.String, noncense)
+			if err != nil {
+				return nil, err
+			}
+
+			notification.Secret = string(decrypted)
+		}
+
+		err = json.Unmarshal([]byte(payload), &notification.Payload)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// RecordAttempt logs an attempt to deliver the notification. Undelivered
+// notifications are attempted again at retryAt, or never if it is nil.
+func (f *notificationFactory) RecordAttempt(notificationID int, attempt NotificationAttempt, retryAt *time.Time) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	update := psql.Update("notification_outbox").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Where(sq.Eq{"id": notificationID}).
+		Suffix("RETURNING attempts")
+
+	if attempt.Delivered {
+		update = update.
+			Set("delivered_at", sq.Expr("now()")).
+			Set("next_attempt_at", nil)
+	} else {
+		update = update.Set("next_attempt_at", retryAt)
+	}
+
+	var attempts int
+	err = update.RunWith(tx).QueryRow().Scan(&attempts)
+	if err != nil {
+		return err
+	}
+
+	var statusCode *int
+	if attempt.StatusCode != 0 {
+		statusCode = &attempt.StatusCode
+	}
+
+	_, err = psql.Insert("notification_deliveries").
+		Columns("notification_id", "attempt", "status_code", "error", "duration").
+		Values(notificationID, attempts, statusCode, attempt.Error, int64(attempt.Duration)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// PruneNotifications removes delivered and abandoned notifications, along
+// with their delivery log, created before the given time.
+func (f *notificationFactory) PruneNotifications(before time.Time) error {
+	_, err := psql.Delete("notification_outbox").
+		Where(sq.Or{
+			sq.Expr("delivered_at IS NOT NULL"),
+			sq.Eq{"next_attempt_at": nil},
+		}).
+		Where(sq.Expr("created_at < ?", before)).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+// enqueueNotifications adds a notification to the outbox for each of the
+// build's team's subscriptions matching the status change. It is called
+// within the transaction changing the status so that no change is missed.
+func (b *build) enqueueNotifications(tx Tx, status atc.BuildStatus, at time.Time) error {
+	pipelines := sq.Or{sq.Eq{"s.pipeline_id": nil}}
+	if b.pipelineID != 0 {
+		pipelines = append(pipelines, sq.Eq{"s.pipeline_id": b.pipelineID})
+	}
+
+	rows, err := subscriptionsQuery.
+		Where(sq.Eq{"s.team_id": b.teamID}).
+		Where(pipelines).
+		OrderBy("s.id ASC").
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	subscriptions := []atc.NotificationSubscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+
+		if subscription.Matches(b.jobName, status) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	rows.Close()
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(atc.BuildNotification{
+		Team:      b.teamName,
+		Pipeline:  b.pipelineName,
+		Job:       b.jobName,
+		BuildID:   b.id,
+		BuildName: b.name,
+		Status:    status,
+		Time:      at.Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		_, err := psql.Insert("notification_outbox").
+			Columns("subscription_id", "build_id", "status", "payload").
+			Values(subscription.ID, b.id, string(status), string(payload)).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanSubscription(row scannable) (atc.NotificationSubscription, error) {
+	var (
+		subscription atc.NotificationSubscription
+		pipelineName sql.NullString
+		jobs         []byte
+		statuses     []byte
+	)
+
+	err := row.Scan(&subscription.ID, &pipelineName, &subscription.URL, &jobs, &statuses)
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	subscription.Pipeline = pipelineName.String
+
+	err = json.Unmarshal(jobs, &subscription.Jobs)
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	err = json.Unmarshal(statuses, &subscription.Statuses)
+	if err != nil {
+		return atc.NotificationSubscription{}, err
+	}
+
+	if len(subscription.Jobs) == 0 {
+		subscription.Jobs = nil
+	}
+
+	if len(subscription.Statuses) == 0 {
+		subscription.Statuses = nil
+	}
+
+	return subscription, nil
+}
+
+func nonNilStrings(strings []string) []string {
+	if strings == nil {
+		return []string{}
+	}
+
+	return strings
+}
+
+func nonNilStatuses(statuses []atc.BuildStatus) []atc.BuildStatus {
+	if statuses == nil {
+		return []atc.BuildStatus{}
+	}
+
+	return statuses
+}
@@ -0,0 +1,281 @@
+package dbng_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationFactory", func() {
+	var notificationFactory dbng.NotificationFactory
+
+	BeforeEach(func() {
+		notificationFactory = dbng.NewNotificationFactory(dbConn)
+	})
+
+	subscribe := func(pipelineID int, subscription atc.NotificationSubscription) atc.NotificationSubscription {
+		created, err := notificationFactory.CreateSubscription(defaultTeam.ID(), pipelineID, subscription)
+		Expect(err).NotTo(HaveOccurred())
+		return created
+	}
+
+	Describe("subscriptions", func() {
+		It("lists the team's subscriptions without their secrets", func() {
+			teamWide := subscribe(0, atc.NotificationSubscription{
+				URL:    "https://example.com/team",
+				Secret: "some-secret",
+			})
+			Expect(teamWide.ID).NotTo(BeZero())
+			Expect(teamWide.Secret).To(BeEmpty())
+
+			pipelineWide := subscribe(defaultPipeline.ID(), atc.NotificationSubscription{
+				Pipeline: "default-pipeline",
+				URL:      "https://example.com/pipeline",
+				Jobs:     []string{"some-job"},
+				Statuses: []atc.BuildStatus{atc.StatusFailed},
+			})
+
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = notificationFactory.CreateSubscription(otherTeam.ID(), 0, atc.NotificationSubscription{URL: "https://example.com/other"})
+			Expect(err).NotTo(HaveOccurred())
+
+			subscriptions, err := notificationFactory.Subscriptions(defaultTeam.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subscriptions).To(Equal([]atc.NotificationSubscription{teamWide, pipelineWide}))
+		})
+
+		It("only deletes subscriptions belonging to the team", func() {
+			subscription := subscribe(0, atc.NotificationSubscription{URL: "https://example.com"})
+
+			deleted, err := notificationFactory.DeleteSubscription(defaultTeam.ID()+1, subscription.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+
+			deleted, err = notificationFactory.DeleteSubscription(defaultTeam.ID(), subscription.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			subscriptions, err := notificationFactory.Subscriptions(defaultTeam.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subscriptions).To(BeEmpty())
+		})
+	})
+
+	Describe("build status changes", func() {
+		var build dbng.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("queues a notification for each matching subscription", func() {
+			all := subscribe(0, atc.NotificationSubscription{URL: "https://example.com/all", Secret: "some-secret"})
+			failures := subscribe(defaultPipeline.ID(), atc.NotificationSubscription{
+				URL:      "https://example.com/failures",
+				Jobs:     []string{"some-job"},
+				Statuses: []atc.BuildStatus{atc.StatusFailed},
+			})
+			subscribe(defaultPipeline.ID(), atc.NotificationSubscription{
+				URL:  "https://example.com/other-job",
+				Jobs: []string{"some-other-job"},
+			})
+
+			started, err := build.Start("exec.v2", "{}")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.Finish(dbng.BuildStatusFailed)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(HaveLen(3))
+
+			Expect(due[0].SubscriptionID).To(Equal(all.ID))
+			Expect(due[0].URL).To(Equal("https://example.com/all"))
+			Expect(due[0].Secret).To(Equal("some-secret"))
+			Expect(due[0].Payload).To(Equal(atc.BuildNotification{
+				Team:      "default-team",
+				Pipeline:  "default-pipeline",
+				Job:       "some-job",
+				BuildID:   build.ID(),
+				BuildName: build.Name(),
+				Status:    atc.StatusStarted,
+				Time:      due[0].Payload.Time,
+			}))
+
+			Expect(due[1].SubscriptionID).To(Equal(all.ID))
+			Expect(due[1].Payload.Status).To(Equal(atc.StatusFailed))
+
+			Expect(due[2].SubscriptionID).To(Equal(failures.ID))
+			Expect(due[2].Secret).To(BeEmpty())
+			Expect(due[2].Payload.Status).To(Equal(atc.StatusFailed))
+		})
+
+		It("does not queue notifications for other pipelines' subscriptions", func() {
+			otherPipeline, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			subscribe(otherPipeline.ID(), atc.NotificationSubscription{URL: "https://example.com"})
+
+			_, err = build.Start("exec.v2", "{}")
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeEmpty())
+		})
+	})
+
+	Describe("delivery", func() {
+		var subscription atc.NotificationSubscription
+		var notification dbng.Notification
+		var build dbng.Build
+
+		BeforeEach(func() {
+			subscription = subscribe(0, atc.NotificationSubscription{URL: "https://example.com"})
+
+			var err error
+			build, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = build.Start("exec.v2", "{}")
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(HaveLen(1))
+
+			notification = due[0]
+			Expect(notification.Payload.Job).To(BeEmpty())
+		})
+
+		It("no longer returns delivered notifications as due", func() {
+			err := notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				Delivered:  true,
+				StatusCode: 200,
+				Duration:   time.Second,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeEmpty())
+		})
+
+		It("returns failed notifications as due again once their retry is due", func() {
+			retryAt := time.Now().Add(time.Hour)
+
+			err := notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				StatusCode: 500,
+				Error:      "endpoint responded with 500 Internal Server Error",
+			}, &retryAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeEmpty())
+
+			retryAt = time.Now().Add(-time.Second)
+
+			err = notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				Error: "connection refused",
+			}, &retryAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err = notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(HaveLen(1))
+			Expect(due[0].Attempts).To(Equal(2))
+		})
+
+		It("holds back later notifications to the subscription while an earlier one waits to be retried", func() {
+			retryAt := time.Now().Add(time.Hour)
+
+			err := notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				Error: "failed to reach endpoint",
+			}, &retryAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(dbng.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err := notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(BeEmpty())
+
+			err = notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				Error: "failed to reach endpoint",
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			due, err = notificationFactory.DueNotifications(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(due).To(HaveLen(1))
+			Expect(due[0].Payload.Status).To(Equal(atc.StatusSucceeded))
+		})
+
+		It("logs each attempt, most recent first", func() {
+			retryAt := time.Now()
+
+			err := notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				StatusCode: 500,
+				Error:      "endpoint responded with 500 Internal Server Error",
+				Duration:   time.Second,
+			}, &retryAt)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{
+				Delivered:  true,
+				StatusCode: 204,
+				Duration:   2 * time.Second,
+			}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, found, err := notificationFactory.Deliveries(defaultTeam.ID(), subscription.ID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(deliveries).To(HaveLen(2))
+
+			Expect(deliveries[0].NotificationID).To(Equal(notification.ID))
+			Expect(deliveries[0].Status).To(Equal(atc.StatusStarted))
+			Expect(deliveries[0].Attempt).To(Equal(2))
+			Expect(deliveries[0].StatusCode).To(Equal(204))
+			Expect(deliveries[0].Error).To(BeEmpty())
+			Expect(deliveries[0].Duration).To(Equal(2.0))
+
+			Expect(deliveries[1].Attempt).To(Equal(1))
+			Expect(deliveries[1].Error).To(Equal("endpoint responded with 500 Internal Server Error"))
+
+			_, found, err = notificationFactory.Deliveries(defaultTeam.ID()+1, subscription.ID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("prunes notifications which are no longer being delivered", func() {
+			err := notificationFactory.RecordAttempt(notification.ID, dbng.NotificationAttempt{Error: "nope"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = notificationFactory.PruneNotifications(time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, _, err := notificationFactory.Deliveries(defaultTeam.ID(), subscription.ID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+
+			err = notificationFactory.PruneNotifications(time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			deliveries, _, err = notificationFactory.Deliveries(defaultTeam.ID(), subscription.ID, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(BeEmpty())
+		})
+	})
+})
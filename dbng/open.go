@@ -98,11 +98,12 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *E
 }
 
 var encryptedColumns = map[string]string{
-	"teams":          "auth",
-	"resources":      "config",
-	"jobs":           "config",
-	"resource_types": "config",
-	"pipelines":      "config",
+	"teams":                      "auth",
+	"resources":                  "config",
+	"jobs":                       "config",
+	"resource_types":             "config",
+	"pipelines":                  "config",
+	"notification_subscriptions": "secret",
 }
 
 func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *EncryptionKey) error {
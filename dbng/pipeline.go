@@ -46,6 +46,7 @@ type Pipeline interface {
 	GetAllPendingBuilds() (map[string][]Build, error)
 
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
+	SaveResourceVersionsWithMetadata(lager.Logger, atc.ResourceConfig, []atc.VersionWithMetadata) error
 	GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error)
 	GetLatestVersionedResource(resourceName string) (SavedVersionedResource, bool, error)
 	GetVersionedResourceByVersion(atcVersion atc.Version, resourceName string) (SavedVersionedResource, bool, error)
@@ -59,6 +60,7 @@ type Pipeline interface {
 
 	// Needs test (from db/lock_test.go)
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
+	SchedulingNotifier() (Notifier, error)
 
 	AcquireResourceCheckingLockWithIntervalCheck(
 		logger lager.Logger,
@@ -347,7 +349,7 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 			"j.active": true,
 			"p.id":     p.id,
 		}).
-		OrderBy("b.id").
+		OrderBy("b.priority DESC", "b.id").
 		RunWith(p.conn).
 		Query()
 	if err != nil {
@@ -370,6 +372,29 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 }
 
 func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
+	versionsWithMetadata := make([]atc.VersionWithMetadata, len(versions))
+	for i, version := range versions {
+		versionsWithMetadata[i] = atc.VersionWithMetadata{Version: version}
+	}
+
+	return p.saveResourceVersions(config, versionsWithMetadata)
+}
+
+func (p *pipeline) SaveResourceVersionsWithMetadata(logger lager.Logger, config atc.ResourceConfig, versions []atc.VersionWithMetadata) error {
+	err := p.saveResourceVersions(config, versions)
+	if err != nil {
+		return err
+	}
+
+	err = p.conn.Bus().Notify(pipelineSchedulingChannel(p.id))
+	if err != nil {
+		logger.Error("failed-to-notify-scheduler", err)
+	}
+
+	return nil
+}
+
+func (p *pipeline) saveResourceVersions(config atc.ResourceConfig, versions []atc.VersionWithMetadata) error {
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return err
@@ -378,10 +403,19 @@ func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []at
 	defer tx.Rollback()
 
 	for _, version := range versions {
+		metadata := make(ResourceMetadataFields, len(version.Metadata))
+		for i, field := range version.Metadata {
+			metadata[i] = ResourceMetadataField{
+				Name:  field.Name,
+				Value: field.Value,
+			}
+		}
+
 		vr := VersionedResource{
 			Resource: config.Name,
 			Type:     config.Type,
-			Version:  ResourceVersion(version),
+			Version:  ResourceVersion(version.Version),
+			Metadata: metadata,
 		}
 
 		versionJSON, err := json.Marshal(vr.Version)
@@ -411,12 +445,7 @@ func (p *pipeline) SaveResourceVersions(config atc.ResourceConfig, versions []at
 		}
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return tx.Commit()
 }
 
 func (p *pipeline) GetResourceVersions(resourceName string, page Page) ([]SavedVersionedResource, Pagination, bool, error) {
@@ -1237,6 +1266,14 @@ func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Dura
 	return lock, true, nil
 }
 
+// SchedulingNotifier notifies when new versions have been saved for the
+// pipeline, so that it can be scheduled without waiting for its interval.
+func (p *pipeline) SchedulingNotifier() (Notifier, error) {
+	return newConditionNotifier(p.conn.Bus(), pipelineSchedulingChannel(p.id), func() (bool, error) {
+		return false, nil
+	})
+}
+
 func (p *pipeline) saveOutput(buildID int, vr VersionedResource, explicit bool) error {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -1705,3 +1742,7 @@ func getNewBuildNameForJob(tx Tx, jobName string, pipelineID int) (string, int,
 	`, jobName, pipelineID).Scan(&buildName, &jobID)
 	return buildName, jobID, err
 }
+
+func pipelineSchedulingChannel(pipelineID int) string {
+	return fmt.Sprintf("pipeline_scheduling_%d", pipelineID)
+}
@@ -602,6 +602,36 @@ var _ = Describe("Pipeline", func() {
 			Expect(latestVR.CheckOrder).To(Equal(4))
 		})
 
+		It("saves the versions' metadata", func() {
+			err := pipeline.SaveResourceVersionsWithMetadata(logger, resourceConfig, []atc.VersionWithMetadata{
+				{
+					Version:  atc.Version{"ref": "v1"},
+					Metadata: []atc.MetadataField{{Name: "commit", Value: "some-commit"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			latestVR, found, err := pipeline.GetLatestVersionedResource(resource.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(latestVR.Version).To(Equal(dbng.ResourceVersion{"ref": "v1"}))
+			Expect(latestVR.Metadata).To(Equal(dbng.ResourceMetadataFields{{Name: "commit", Value: "some-commit"}}))
+		})
+
+		It("notifies the pipeline's scheduler when saving versions with metadata", func() {
+			notifier, err := pipeline.SchedulingNotifier()
+			Expect(err).NotTo(HaveOccurred())
+			defer notifier.Close()
+
+			err = pipeline.SaveResourceVersionsWithMetadata(logger, resourceConfig, []atc.VersionWithMetadata{
+				{Version: atc.Version{"ref": "v1"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(notifier.Notify()).Should(Receive())
+		})
+
 		Context("resource not found", func() {
 			BeforeEach(func() {
 				resourceConfig = atc.ResourceConfig{
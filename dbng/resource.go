@@ -23,6 +23,7 @@ type Resource interface {
 	CheckError() error
 	Paused() bool
 	WebhookToken() string
+	Webhook() *atc.WebhookConfig
 	FailingToCheck() bool
 
 	Pause() error
@@ -48,6 +49,7 @@ type resource struct {
 	checkError   error
 	paused       bool
 	webhookToken string
+	webhook      *atc.WebhookConfig
 
 	conn Conn
 }
@@ -83,6 +85,9 @@ func (r *resource) Tags() atc.Tags       { return r.tags }
 func (r *resource) CheckError() error    { return r.checkError }
 func (r *resource) Paused() bool         { return r.paused }
 func (r *resource) WebhookToken() string { return r.webhookToken }
+func (r *resource) Webhook() *atc.WebhookConfig {
+	return r.webhook
+}
 func (r *resource) FailingToCheck() bool {
 	return r.checkError != nil
 }
@@ -161,6 +166,7 @@ func scanResource(r *resource, row scannable) error {
 	r.checkEvery = config.CheckEvery
 	r.tags = config.Tags
 	r.webhookToken = config.WebhookToken
+	r.webhook = config.Webhook
 
 	if checkErr.Valid {
 		r.checkError = errors.New(checkErr.String)
@@ -0,0 +1,187 @@
+package dbng
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// StoredArtifact is a task output which was archived to the blob store so
+// that it can be downloaded from the build.
+type StoredArtifact struct {
+	ID        int
+	BuildID   int
+	Name      string
+	BlobKey   string
+	Size      int64
+	CreatedAt time.Time
+}
+
+//go:generate counterfeiter . StoredArtifactFactory
+
+type StoredArtifactFactory interface {
+	CreateStoredArtifact(buildID int, name string, blobKey string, size int64) (StoredArtifact, error)
+	FindStoredArtifact(buildID int, name string) (StoredArtifact, bool, error)
+	FindStoredArtifacts(buildIDs []int) ([]StoredArtifact, error)
+
+	FindExpiredJobStoredArtifacts(jobID int, maxAge time.Duration) ([]StoredArtifact, error)
+	FindExpiredOneOffStoredArtifacts(maxAge time.Duration) ([]StoredArtifact, error)
+
+	DeleteStoredArtifacts(ids []int) error
+}
+
+type storedArtifactFactory struct {
+	conn Conn
+}
+
+func NewStoredArtifactFactory(conn Conn) StoredArtifactFactory {
+	return &storedArtifactFactory{
+		conn: conn,
+	}
+}
+
+var storedArtifactsQuery = psql.Select("id, build_id, name, blob_key, size, created_at").
+	From("stored_artifacts")
+
+// CreateStoredArtifact records the blob holding the named artifact of the
+// build. Storing the same name twice for a build (e.g. from a retried step)
+// replaces the previous record.
+func (f *storedArtifactFactory) CreateStoredArtifact(buildID int, name string, blobKey string, size int64) (StoredArtifact, error) {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return StoredArtifact{}, err
+	}
+
+	defer tx.Rollback()
+
+	_, err = psql.Delete("stored_artifacts").
+		Where(sq.Eq{
+			"build_id": buildID,
+			"name":     name,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return StoredArtifact{}, err
+	}
+
+	artifact, err := scanStoredArtifact(psql.Insert("stored_artifacts").
+		Columns("build_id", "name", "blob_key", "size").
+		Values(buildID, name, blobKey, size).
+		Suffix("RETURNING id, build_id, name, blob_key, size, created_at").
+		RunWith(tx).
+		QueryRow())
+	if err != nil {
+		return StoredArtifact{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return StoredArtifact{}, err
+	}
+
+	return artifact, nil
+}
+
+func (f *storedArtifactFactory) FindStoredArtifact(buildID int, name string) (StoredArtifact, bool, error) {
+	artifact, err := scanStoredArtifact(storedArtifactsQuery.
+		Where(sq.Eq{
+			"build_id": buildID,
+			"name":     name,
+		}).
+		RunWith(f.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return StoredArtifact{}, false, nil
+		}
+		return StoredArtifact{}, false, err
+	}
+
+	return artifact, true, nil
+}
+
+func (f *storedArtifactFactory) FindStoredArtifacts(buildIDs []int) ([]StoredArtifact, error) {
+	artifacts := []StoredArtifact{}
+	if len(buildIDs) == 0 {
+		return artifacts, nil
+	}
+
+	return f.queryStoredArtifacts(storedArtifactsQuery.
+		Where(sq.Eq{"build_id": buildIDs}).
+		OrderBy("build_id ASC, name ASC"))
+}
+
+// FindExpiredJobStoredArtifacts returns the artifacts of the job's builds
+// which were stored more than maxAge ago.
+func (f *storedArtifactFactory) FindExpiredJobStoredArtifacts(jobID int, maxAge time.Duration) ([]StoredArtifact, error) {
+	return f.queryStoredArtifacts(expiredStoredArtifactsQuery(maxAge).
+		Where(sq.Eq{"b.job_id": jobID}))
+}
+
+// FindExpiredOneOffStoredArtifacts returns the artifacts of one-off builds
+// which were stored more than maxAge ago.
+func (f *storedArtifactFactory) FindExpiredOneOffStoredArtifacts(maxAge time.Duration) ([]StoredArtifact, error) {
+	return f.queryStoredArtifacts(expiredStoredArtifactsQuery(maxAge).
+		Where(sq.Eq{"b.job_id": nil}))
+}
+
+func expiredStoredArtifactsQuery(maxAge time.Duration) sq.SelectBuilder {
+	return psql.Select("a.id, a.build_id, a.name, a.blob_key, a.size, a.created_at").
+		From("stored_artifacts a").
+		Join("builds b ON b.id = a.build_id").
+		Where(sq.Expr(fmt.Sprintf("a.created_at < now() - interval '%d seconds'", int(maxAge.Seconds())))).
+		OrderBy("a.id ASC")
+}
+
+func (f *storedArtifactFactory) queryStoredArtifacts(query sq.SelectBuilder) ([]StoredArtifact, error) {
+	rows, err := query.
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	artifacts := []StoredArtifact{}
+	for rows.Next() {
+		artifact, err := scanStoredArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+// DeleteStoredArtifacts removes the records of the given artifacts. Their
+// blobs are expected to have been removed from the blob store already.
+func (f *storedArtifactFactory) DeleteStoredArtifacts(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := psql.Delete("stored_artifacts").
+		Where(sq.Eq{"id": ids}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+func scanStoredArtifact(row scannable) (StoredArtifact, error) {
+	var artifact StoredArtifact
+	err := row.Scan(
+		&artifact.ID,
+		&artifact.BuildID,
+		&artifact.Name,
+		&artifact.BlobKey,
+		&artifact.Size,
+		&artifact.CreatedAt,
+	)
+	return artifact, err
+}
@@ -0,0 +1,157 @@
+package dbng_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoredArtifactFactory", func() {
+	var (
+		artifactFactory dbng.StoredArtifactFactory
+		build           dbng.Build
+	)
+
+	BeforeEach(func() {
+		artifactFactory = dbng.NewStoredArtifactFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateStoredArtifact", func() {
+		It("returns the artifact", func() {
+			artifact, err := artifactFactory.CreateStoredArtifact(build.ID(), "some-reports", "some-key", 1024)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifact.ID).NotTo(BeZero())
+			Expect(artifact.BuildID).To(Equal(build.ID()))
+			Expect(artifact.Name).To(Equal("some-reports"))
+			Expect(artifact.BlobKey).To(Equal("some-key"))
+			Expect(artifact.Size).To(Equal(int64(1024)))
+			Expect(artifact.CreatedAt).NotTo(BeZero())
+		})
+
+		Context("when the build already stored an artifact with the same name", func() {
+			BeforeEach(func() {
+				_, err := artifactFactory.CreateStoredArtifact(build.ID(), "some-reports", "some-key", 1024)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("replaces it", func() {
+				_, err := artifactFactory.CreateStoredArtifact(build.ID(), "some-reports", "some-other-key", 2048)
+				Expect(err).NotTo(HaveOccurred())
+
+				artifacts, err := artifactFactory.FindStoredArtifacts([]int{build.ID()})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(HaveLen(1))
+				Expect(artifacts[0].BlobKey).To(Equal("some-other-key"))
+				Expect(artifacts[0].Size).To(Equal(int64(2048)))
+			})
+		})
+	})
+
+	Describe("FindStoredArtifact", func() {
+		BeforeEach(func() {
+			_, err := artifactFactory.CreateStoredArtifact(build.ID(), "some-reports", "some-key", 1024)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the artifact by name", func() {
+			artifact, found, err := artifactFactory.FindStoredArtifact(build.ID(), "some-reports")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(artifact.BlobKey).To(Equal("some-key"))
+		})
+
+		It("does not find artifacts of other builds", func() {
+			otherBuild, err := defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, found, err := artifactFactory.FindStoredArtifact(otherBuild.ID(), "some-reports")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("FindStoredArtifacts and DeleteStoredArtifacts", func() {
+		var otherBuild dbng.Build
+
+		BeforeEach(func() {
+			var err error
+			otherBuild, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = artifactFactory.CreateStoredArtifact(build.ID(), "some-reports", "some-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = artifactFactory.CreateStoredArtifact(build.ID(), "other-reports", "other-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = artifactFactory.CreateStoredArtifact(otherBuild.ID(), "some-reports", "another-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the artifacts of the given builds", func() {
+			artifacts, err := artifactFactory.FindStoredArtifacts([]int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(HaveLen(2))
+			Expect(artifacts[0].Name).To(Equal("other-reports"))
+			Expect(artifacts[1].Name).To(Equal("some-reports"))
+
+			artifacts, err = artifactFactory.FindStoredArtifacts([]int{build.ID(), otherBuild.ID()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(HaveLen(3))
+		})
+
+		It("deletes only the given artifacts", func() {
+			artifacts, err := artifactFactory.FindStoredArtifacts([]int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = artifactFactory.DeleteStoredArtifacts([]int{artifacts[0].ID, artifacts[1].ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			artifacts, err = artifactFactory.FindStoredArtifacts([]int{build.ID(), otherBuild.ID()})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(HaveLen(1))
+			Expect(artifacts[0].BuildID).To(Equal(otherBuild.ID()))
+		})
+	})
+
+	Describe("FindExpiredJobStoredArtifacts and FindExpiredOneOffStoredArtifacts", func() {
+		var oneOffBuild dbng.Build
+
+		BeforeEach(func() {
+			var err error
+			oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = artifactFactory.CreateStoredArtifact(build.ID(), "old-reports", "old-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = artifactFactory.CreateStoredArtifact(oneOffBuild.ID(), "old-reports", "old-one-off-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE stored_artifacts SET created_at = now() - interval '2 hours'`)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = artifactFactory.CreateStoredArtifact(build.ID(), "new-reports", "new-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = artifactFactory.CreateStoredArtifact(oneOffBuild.ID(), "new-reports", "new-one-off-key", 1)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("finds the job's artifacts stored longer ago than the max age", func() {
+			artifacts, err := artifactFactory.FindExpiredJobStoredArtifacts(defaultJob.ID(), time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(HaveLen(1))
+			Expect(artifacts[0].BlobKey).To(Equal("old-key"))
+		})
+
+		It("finds the one-off builds' artifacts stored longer ago than the max age", func() {
+			artifacts, err := artifactFactory.FindExpiredOneOffStoredArtifacts(time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(HaveLen(1))
+			Expect(artifacts[0].BlobKey).To(Equal("old-one-off-key"))
+		})
+	})
+})
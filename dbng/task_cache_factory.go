@@ -0,0 +1,255 @@
+package dbng
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+)
+
+// TaskCache records the output volumes of a successful task run, keyed by a
+// hash of everything that went into the run (config, image and inputs).
+type TaskCache struct {
+	ID       int
+	TeamID   int
+	BuildID  int
+	StepName string
+	Key      string
+
+	// Volumes maps each of the task's output names to the volume holding it.
+	Volumes map[string]CreatedVolume
+}
+
+//go:generate counterfeiter . TaskCacheFactory
+
+type TaskCacheFactory interface {
+	FindTaskCache(teamID int, key string) (*TaskCache, bool, error)
+	CreateTaskCache(teamID int, metadata ContainerMetadata, key string, outputHandles map[string]string) (*TaskCache, error)
+
+	CleanUpSupersededCaches() error
+}
+
+type taskCacheFactory struct {
+	conn Conn
+}
+
+func NewTaskCacheFactory(conn Conn) TaskCacheFactory {
+	return &taskCacheFactory{
+		conn: conn,
+	}
+}
+
+// FindTaskCache looks up the cache for the given key. A cache is only found
+// if a volume for each of its outputs is still present.
+func (f *taskCacheFactory) FindTaskCache(teamID int, key string) (*TaskCache, bool, error) {
+	cache := &TaskCache{
+		TeamID:  teamID,
+		Key:     key,
+		Volumes: map[string]CreatedVolume{},
+	}
+
+	var buildID sql.NullInt64
+	var outputsJSON string
+	err := psql.Select("id, build_id, step_name, outputs").
+		From("task_caches").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"key":     key,
+		}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&cache.ID, &buildID, &cache.StepName, &outputsJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if buildID.Valid {
+		cache.BuildID = int(buildID.Int64)
+	}
+
+	var outputs []string
+	err = json.Unmarshal([]byte(outputsJSON), &outputs)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rows, err := psql.Select("handle, task_cache_output").
+		From("volumes").
+		Where(sq.Eq{
+			"task_cache_id": cache.ID,
+			"state":         VolumeStateCreated,
+		}).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer rows.Close()
+
+	handles := map[string]string{}
+	for rows.Next() {
+		var handle, output string
+		err = rows.Scan(&handle, &output)
+		if err != nil {
+			return nil, false, err
+		}
+
+		handles[output] = handle
+	}
+
+	volumeFactory := &volumeFactory{conn: f.conn}
+	for _, output := range outputs {
+		handle, found := handles[output]
+		if !found {
+			return nil, false, nil
+		}
+
+		volume, found, err := volumeFactory.FindCreatedVolume(handle)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !found {
+			return nil, false, nil
+		}
+
+		cache.Volumes[output] = volume
+	}
+
+	return cache, true, nil
+}
+
+// CreateTaskCache saves the given output volumes under the key, replacing any
+// previous (presumably incomplete) cache for the same key.
+func (f *taskCacheFactory) CreateTaskCache(teamID int, metadata ContainerMetadata, key string, outputHandles map[string]string) (*TaskCache, error) {
+	outputs := []string{}
+	for output := range outputHandles {
+		outputs = append(outputs, output)
+	}
+
+	sort.Strings(outputs)
+
+	outputsJSON, err := json.Marshal(outputs)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	_, err = psql.Delete("task_caches").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"key":     key,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	var jobID, buildID interface{}
+	if metadata.JobID != 0 {
+		jobID = metadata.JobID
+	}
+
+	if metadata.BuildID != 0 {
+		buildID = metadata.BuildID
+	}
+
+	cache := &TaskCache{
+		TeamID:   teamID,
+		BuildID:  metadata.BuildID,
+		StepName: metadata.StepName,
+		Key:      key,
+		Volumes:  map[string]CreatedVolume{},
+	}
+
+	err = psql.Insert("task_caches").
+		Columns("team_id", "job_id", "build_id", "step_name", "key", "outputs").
+		Values(teamID, jobID, buildID, metadata.StepName, key, string(outputsJSON)).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&cache.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			// another build with identical inputs got there first; keep theirs
+			tx.Rollback()
+
+			existing, found, err := f.FindTaskCache(teamID, key)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				return existing, nil
+			}
+		}
+
+		return nil, err
+	}
+
+	for output, handle := range outputHandles {
+		_, err := psql.Update("volumes").
+			Set("task_cache_id", cache.ID).
+			Set("task_cache_output", output).
+			Where(sq.Eq{
+				"handle": handle,
+				"state":  VolumeStateCreated,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeFactory := &volumeFactory{conn: f.conn}
+	for output, handle := range outputHandles {
+		volume, found, err := volumeFactory.FindCreatedVolume(handle)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			cache.Volumes[output] = volume
+		}
+	}
+
+	return cache, nil
+}
+
+// CleanUpSupersededCaches removes every task cache but the most recent one
+// for each job's step, treating the steps of a team's one-off builds (which
+// have no job) as belonging to a single job. Their volumes are left for the
+// volume collector to reap once they become orphaned.
+func (f *taskCacheFactory) CleanUpSupersededCaches() error {
+	_, err := f.conn.Exec(`
+		DELETE FROM task_caches tc
+		WHERE EXISTS (
+			SELECT 1
+			FROM task_caches newer
+			WHERE newer.team_id = tc.team_id
+			AND newer.job_id IS NOT DISTINCT FROM tc.job_id
+			AND newer.step_name = tc.step_name
+			AND newer.id > tc.id
+		)
+	`)
+	return err
+}
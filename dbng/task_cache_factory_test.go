@@ -0,0 +1,176 @@
+package dbng_test
+
+import (
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheFactory", func() {
+	var (
+		taskCacheFactory dbng.TaskCacheFactory
+
+		build         dbng.Build
+		metadata      dbng.ContainerMetadata
+		createdVolume dbng.CreatedVolume
+	)
+
+	BeforeEach(func() {
+		taskCacheFactory = dbng.NewTaskCacheFactory(dbConn)
+
+		var err error
+		build, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		metadata = dbng.ContainerMetadata{
+			Type:     dbng.ContainerTypeTask,
+			StepName: "some-task",
+			JobID:    defaultJob.ID(),
+			BuildID:  build.ID(),
+		}
+
+		creatingContainer, err := defaultTeam.CreateBuildContainer(defaultWorker.Name(), build.ID(), "some-plan", metadata)
+		Expect(err).NotTo(HaveOccurred())
+
+		creatingVolume, err := volumeFactory.CreateContainerVolume(defaultTeam.ID(), defaultWorker, creatingContainer, "some-output-path")
+		Expect(err).NotTo(HaveOccurred())
+
+		createdVolume, err = creatingVolume.Created()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("CreateTaskCache", func() {
+		It("can be found by its key", func() {
+			created, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-key", map[string]string{
+				"some-output": createdVolume.Handle(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(created.BuildID).To(Equal(build.ID()))
+
+			found, ok, err := taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(found.ID).To(Equal(created.ID))
+			Expect(found.BuildID).To(Equal(build.ID()))
+			Expect(found.StepName).To(Equal("some-task"))
+			Expect(found.Volumes).To(HaveKey("some-output"))
+			Expect(found.Volumes["some-output"].Handle()).To(Equal(createdVolume.Handle()))
+		})
+
+		It("is not found by other teams", func() {
+			_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-key", map[string]string{
+				"some-output": createdVolume.Handle(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok, err := taskCacheFactory.FindTaskCache(defaultTeam.ID()+1, "some-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("keeps its volumes from being orphaned", func() {
+			_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-key", map[string]string{
+				"some-output": createdVolume.Handle(),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = psql.Delete("containers").RunWith(dbConn).Exec()
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolumes, destroyingVolumes, err := volumeFactory.GetOrphanedVolumes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createdVolumes).To(BeEmpty())
+			Expect(destroyingVolumes).To(BeEmpty())
+		})
+
+		Context("when an output volume has gone away", func() {
+			BeforeEach(func() {
+				_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-key", map[string]string{
+					"some-output": createdVolume.Handle(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = createdVolume.Destroying()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is not found", func() {
+				_, ok, err := taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		Context("when the task has no outputs", func() {
+			It("can still be found", func() {
+				_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-key", map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				found, ok, err := taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(found.Volumes).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("CleanUpSupersededCaches", func() {
+		BeforeEach(func() {
+			_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-old-key", map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = taskCacheFactory.CreateTaskCache(defaultTeam.ID(), metadata, "some-new-key", map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			otherStepMetadata := metadata
+			otherStepMetadata.StepName = "some-other-task"
+			_, err = taskCacheFactory.CreateTaskCache(defaultTeam.ID(), otherStepMetadata, "some-other-key", map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("keeps only the latest cache for each of the job's steps", func() {
+			Expect(taskCacheFactory.CleanUpSupersededCaches()).To(Succeed())
+
+			_, found, err := taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-old-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-new-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, found, err = taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-other-key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the caches are from one-off builds", func() {
+			BeforeEach(func() {
+				oneOffMetadata := metadata
+				oneOffMetadata.JobID = 0
+
+				_, err := taskCacheFactory.CreateTaskCache(defaultTeam.ID(), oneOffMetadata, "some-old-one-off-key", map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = taskCacheFactory.CreateTaskCache(defaultTeam.ID(), oneOffMetadata, "some-new-one-off-key", map[string]string{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("keeps only the latest cache for each of their steps", func() {
+				Expect(taskCacheFactory.CleanUpSupersededCaches()).To(Succeed())
+
+				_, found, err := taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-old-one-off-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				_, found, err = taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-new-one-off-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, found, err = taskCacheFactory.FindTaskCache(defaultTeam.ID(), "some-new-key")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+	})
+})
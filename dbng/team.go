@@ -32,6 +32,10 @@ type Team interface {
 	BasicAuth() *atc.BasicAuth
 	Auth() map[string]*json.RawMessage
 
+	// ContainerPlacementStrategy is the strategy the team overrides the
+	// default with, as of when the team was loaded, or "" if it doesn't.
+	ContainerPlacementStrategy() string
+
 	Delete() error
 
 	SavePipeline(
@@ -72,6 +76,18 @@ type Team interface {
 
 	UpdateBasicAuth(basicAuth *atc.BasicAuth) error
 	UpdateProviderAuth(auth map[string]*json.RawMessage) error
+
+	FindContainerPlacementStrategy() (string, error)
+	UpdateContainerPlacementStrategy(strategy string) error
+
+	FindQuotas() (atc.TeamQuotas, error)
+	UpdateQuotas(quotas atc.TeamQuotas) error
+	QuotaUsage() (atc.TeamQuotaUsage, error)
+
+	UpdateAdmissionWeight(weight int) error
+
+	FindSerialGroups() (atc.SerialGroupConfigs, error)
+	UpdateSerialGroups(serialGroups atc.SerialGroupConfigs) error
 }
 
 type team struct {
@@ -85,6 +101,8 @@ type team struct {
 	basicAuth *atc.BasicAuth
 
 	auth map[string]*json.RawMessage
+
+	containerPlacementStrategy string
 }
 
 func (t *team) ID() int                           { return t.id }
@@ -93,6 +111,8 @@ func (t *team) Admin() bool                       { return t.admin }
 func (t *team) BasicAuth() *atc.BasicAuth         { return t.basicAuth }
 func (t *team) Auth() map[string]*json.RawMessage { return t.auth }
 
+func (t *team) ContainerPlacementStrategy() string { return t.containerPlacementStrategy }
+
 func (t *team) Delete() error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -561,13 +581,14 @@ func (t *team) SavePipeline(
 
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"config":   encryptedPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("(SELECT COUNT(1) + 1 FROM pipelines)"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
-				"nonce":    nonce,
+				"name":             pipelineName,
+				"config":           encryptedPayload,
+				"version":          sq.Expr("nextval('config_version_seq')"),
+				"ordering":         sq.Expr("(SELECT COUNT(1) + 1 FROM pipelines)"),
+				"paused":           pausedState.Bool(),
+				"team_id":          t.id,
+				"nonce":            nonce,
+				"admission_weight": admissionWeight(config.AdmissionWeight),
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -604,6 +625,7 @@ func (t *team) SavePipeline(
 			Set("config", encryptedPayload).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Set("nonce", nonce).
+			Set("admission_weight", admissionWeight(config.AdmissionWeight)).
 			Where(sq.Eq{
 				"name":    pipelineName,
 				"version": from,
@@ -940,7 +962,7 @@ func (t *team) UpdateBasicAuth(basicAuth *atc.BasicAuth) error {
 		UPDATE teams
 		SET basic_auth = $1
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, auth, nonce
+		RETURNING id, name, admin, basic_auth, auth, nonce, container_placement_strategy
 	`
 
 	params := []interface{}{encryptedBasicAuth, t.name}
@@ -964,12 +986,170 @@ func (t *team) UpdateProviderAuth(auth map[string]*json.RawMessage) error {
 		UPDATE teams
 		SET auth = $1, nonce = $3
 		WHERE LOWER(name) = LOWER($2)
-		RETURNING id, name, admin, basic_auth, auth, nonce
+		RETURNING id, name, admin, basic_auth, auth, nonce, container_placement_strategy
 	`
 	params := []interface{}{string(encryptedAuth), t.name, nonce}
 	return t.queryTeam(query, params)
 }
 
+// FindContainerPlacementStrategy returns the name of the strategy with which
+// to place the team's containers, or "" if the team has not overridden the
+// default.
+func (t *team) FindContainerPlacementStrategy() (string, error) {
+	var strategy string
+	err := psql.Select("container_placement_strategy").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&strategy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return strategy, nil
+}
+
+func (t *team) UpdateContainerPlacementStrategy(strategy string) error {
+	_, err := psql.Update("teams").
+		Set("container_placement_strategy", strategy).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	t.containerPlacementStrategy = strategy
+
+	return nil
+}
+
+func (t *team) FindQuotas() (atc.TeamQuotas, error) {
+	var quotas atc.TeamQuotas
+	err := psql.Select("max_running_builds, max_containers, max_volumes").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&quotas.MaxRunningBuilds, &quotas.MaxContainers, &quotas.MaxVolumes)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.TeamQuotas{}, nil
+		}
+		return atc.TeamQuotas{}, err
+	}
+
+	return quotas, nil
+}
+
+func (t *team) UpdateQuotas(quotas atc.TeamQuotas) error {
+	_, err := psql.Update("teams").
+		Set("max_running_builds", quotas.MaxRunningBuilds).
+		Set("max_containers", quotas.MaxContainers).
+		Set("max_volumes", quotas.MaxVolumes).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+func (t *team) UpdateAdmissionWeight(weight int) error {
+	_, err := psql.Update("teams").
+		Set("admission_weight", admissionWeight(weight)).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
+// QuotaUsage counts the team's running builds, including those scheduled but
+// not yet started, and its containers and volumes in any state.
+func (t *team) QuotaUsage() (atc.TeamQuotaUsage, error) {
+	var usage atc.TeamQuotaUsage
+
+	err := psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{"team_id": t.id}).
+		Where(sq.Or{
+			sq.Eq{"status": string(BuildStatusStarted)},
+			sq.Eq{"status": string(BuildStatusPending), "scheduled": true},
+		}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&usage.RunningBuilds)
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	err = psql.Select("COUNT(*)").
+		From("containers").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&usage.Containers)
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	err = psql.Select("COUNT(*)").
+		From("volumes").
+		Where(sq.Eq{"team_id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&usage.Volumes)
+	if err != nil {
+		return atc.TeamQuotaUsage{}, err
+	}
+
+	return usage, nil
+}
+
+func (t *team) FindSerialGroups() (atc.SerialGroupConfigs, error) {
+	var serialGroupsJSON []byte
+	err := psql.Select("serial_groups").
+		From("teams").
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&serialGroupsJSON)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.SerialGroupConfigs{}, nil
+		}
+		return nil, err
+	}
+
+	var serialGroups atc.SerialGroupConfigs
+	err = json.Unmarshal(serialGroupsJSON, &serialGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	return serialGroups, nil
+}
+
+func (t *team) UpdateSerialGroups(serialGroups atc.SerialGroupConfigs) error {
+	if serialGroups == nil {
+		serialGroups = atc.SerialGroupConfigs{}
+	}
+
+	serialGroupsJSON, err := json.Marshal(serialGroups)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("teams").
+		Set("serial_groups", serialGroupsJSON).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	return err
+}
+
 func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(job)
 	if err != nil {
@@ -1203,6 +1383,7 @@ func (t *team) queryTeam(query string, params []interface{}) error {
 		&basicAuth,
 		&providerAuth,
 		&nonce,
+		&t.containerPlacementStrategy,
 	)
 	if err != nil {
 		return err
@@ -68,10 +68,25 @@ func (factory *teamFactory) CreateTeam(t atc.Team) (Team, error) {
 		return nil, err
 	}
 
+	var quotas atc.TeamQuotas
+	if t.Quotas != nil {
+		quotas = *t.Quotas
+	}
+
+	serialGroups := t.SerialGroups
+	if serialGroups == nil {
+		serialGroups = atc.SerialGroupConfigs{}
+	}
+
+	serialGroupsJSON, err := json.Marshal(serialGroups)
+	if err != nil {
+		return nil, err
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, basic_auth, auth, nonce").
-		Values(t.Name, encryptedBasicAuthJSON, encryptedAuth, nonce).
-		Suffix("RETURNING id, name, admin, basic_auth, auth, nonce").
+		Columns("name, basic_auth, auth, nonce, container_placement_strategy, max_running_builds, max_containers, max_volumes, serial_groups, admission_weight").
+		Values(t.Name, encryptedBasicAuthJSON, encryptedAuth, nonce, t.ContainerPlacementStrategy, quotas.MaxRunningBuilds, quotas.MaxContainers, quotas.MaxVolumes, serialGroupsJSON, admissionWeight(t.AdmissionWeight)).
+		Suffix("RETURNING id, name, admin, basic_auth, auth, nonce, container_placement_strategy").
 		RunWith(tx).
 		QueryRow()
 
@@ -115,7 +130,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, basic_auth, auth, nonce").
+	row := psql.Select("id, name, admin, basic_auth, auth, nonce, container_placement_strategy").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -134,7 +149,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, basic_auth, auth, nonce").
+	rows, err := psql.Select("id, name, admin, basic_auth, auth, nonce, container_placement_strategy").
 		From("teams").
 		RunWith(factory.conn).
 		Query()
@@ -172,6 +187,7 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&basicAuth,
 		&providerAuth,
 		&nonce,
+		&t.containerPlacementStrategy,
 	)
 
 	if basicAuth.Valid {
@@ -459,7 +459,7 @@ var _ = Describe("Team", func() {
 			build, err := job.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			creatingContainer, err := defaultTeam.CreateBuildContainer(defaultWorker.Name(), build.ID(), atc.PlanID("some-job"), dbng.ContainerMetadata{Type: "task", StepName: "some-task"})
+			creatingContainer, err := defaultTeam.CreateBuildContainer(defaultWorker.Name(), build.ID(), atc.PlanID("some-job"), dbng.ContainerMetadata{Type: dbng.ContainerTypeTask, StepName: "some-task"})
 			Expect(err).NotTo(HaveOccurred())
 
 			createdContainer, err = creatingContainer.Created()
@@ -963,6 +963,133 @@ var _ = Describe("Team", func() {
 		})
 	})
 
+	Describe("UpdateContainerPlacementStrategy", func() {
+		It("starts out without overriding the default", func() {
+			strategy, err := team.FindContainerPlacementStrategy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategy).To(BeEmpty())
+		})
+
+		It("saves the strategy, which can be found by team ID", func() {
+			err := team.UpdateContainerPlacementStrategy("fewest-containers")
+			Expect(err).NotTo(HaveOccurred())
+
+			strategy, err := teamFactory.GetByID(team.ID()).FindContainerPlacementStrategy()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strategy).To(Equal("fewest-containers"))
+		})
+
+		It("loads the strategy with the team", func() {
+			err := team.UpdateContainerPlacementStrategy("fewest-containers")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(team.ContainerPlacementStrategy()).To(Equal("fewest-containers"))
+
+			foundTeam, found, err := teamFactory.FindTeam(team.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(foundTeam.ContainerPlacementStrategy()).To(Equal("fewest-containers"))
+		})
+	})
+
+	Describe("Quotas", func() {
+		It("starts out without any quotas", func() {
+			quotas, err := team.FindQuotas()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(BeZero())
+		})
+
+		It("saves the quotas, which can be found by team ID", func() {
+			err := team.UpdateQuotas(atc.TeamQuotas{
+				MaxRunningBuilds: 3,
+				MaxContainers:    20,
+				MaxVolumes:       50,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			quotas, err := teamFactory.GetByID(team.ID()).FindQuotas()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(Equal(atc.TeamQuotas{
+				MaxRunningBuilds: 3,
+				MaxContainers:    20,
+				MaxVolumes:       50,
+			}))
+		})
+
+		It("can be set when the team is created", func() {
+			createdTeam, err := teamFactory.CreateTeam(atc.Team{
+				Name:   "limited-team",
+				Quotas: &atc.TeamQuotas{MaxContainers: 5},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			quotas, err := createdTeam.FindQuotas()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(quotas).To(Equal(atc.TeamQuotas{MaxContainers: 5}))
+		})
+	})
+
+	Describe("SerialGroups", func() {
+		It("starts out without any serial groups", func() {
+			serialGroups, err := team.FindSerialGroups()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serialGroups).To(BeEmpty())
+		})
+
+		It("saves the serial groups, which can be found by team ID", func() {
+			err := team.UpdateSerialGroups(atc.SerialGroupConfigs{
+				{Name: "staging", Size: 3},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			serialGroups, err := teamFactory.GetByID(team.ID()).FindSerialGroups()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serialGroups).To(Equal(atc.SerialGroupConfigs{
+				{Name: "staging", Size: 3},
+			}))
+		})
+
+		It("can be set when the team is created", func() {
+			createdTeam, err := teamFactory.CreateTeam(atc.Team{
+				Name:         "staging-team",
+				SerialGroups: atc.SerialGroupConfigs{{Name: "staging", Size: 2}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			serialGroups, err := createdTeam.FindSerialGroups()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serialGroups).To(Equal(atc.SerialGroupConfigs{{Name: "staging", Size: 2}}))
+		})
+	})
+
+	Describe("QuotaUsage", func() {
+		It("counts the team's running builds and containers", func() {
+			usage, err := team.QuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(BeZero())
+
+			runningBuild, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := runningBuild.Start("some-engine", "some-metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = team.CreateBuildContainer(defaultWorker.Name(), runningBuild.ID(), atc.PlanID("some-plan"), dbng.ContainerMetadata{Type: dbng.ContainerTypeTask, StepName: "some-task"})
+			Expect(err).NotTo(HaveOccurred())
+
+			usage, err = team.QuotaUsage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(atc.TeamQuotaUsage{
+				RunningBuilds: 1,
+				Containers:    1,
+			}))
+		})
+	})
+
 	Describe("Pipelines", func() {
 		var (
 			pipelines []dbng.Pipeline
@@ -0,0 +1,288 @@
+package dbng
+
+import (
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/atc"
+)
+
+type TestStatus string
+
+const (
+	TestStatusPassed  TestStatus = "passed"
+	TestStatusFailed  TestStatus = "failed"
+	TestStatusErrored TestStatus = "errored"
+	TestStatusSkipped TestStatus = "skipped"
+)
+
+type TestSuite struct {
+	Name     string
+	Duration time.Duration
+	Cases    []TestCase
+}
+
+type TestCase struct {
+	ClassName string
+	Name      string
+	Status    TestStatus
+	Duration  time.Duration
+	Message   string
+}
+
+// TestHistory is the result of a single test case across the recent builds
+// of a job, most recent first.
+type TestHistory struct {
+	Suite     string
+	ClassName string
+	Name      string
+	Runs      []TestCaseRun
+
+	// Flakiness is the fraction of consecutive runs in which the test flipped
+	// between passing and failing, ignoring skipped runs.
+	Flakiness float64
+}
+
+type TestCaseRun struct {
+	BuildID   int
+	BuildName string
+	Status    TestStatus
+	Duration  time.Duration
+}
+
+//go:generate counterfeiter . TestResultFactory
+
+type TestResultFactory interface {
+	SaveTestSuites(buildID int, planID atc.PlanID, suites []TestSuite) error
+	FindBuildTestSuites(buildID int) ([]TestSuite, error)
+	FindJobTestHistory(jobID int, builds int) ([]TestHistory, error)
+}
+
+type testResultFactory struct {
+	conn Conn
+}
+
+func NewTestResultFactory(conn Conn) TestResultFactory {
+	return &testResultFactory{
+		conn: conn,
+	}
+}
+
+// SaveTestSuites records the given suites against the build's step, in
+// addition to any recorded by its other steps. Suites already recorded for
+// the step, e.g. when it is retried, are replaced.
+func (f *testResultFactory) SaveTestSuites(buildID int, planID atc.PlanID, suites []TestSuite) error {
+	tx, err := f.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = psql.Delete("test_suites").
+		Where(sq.Eq{
+			"build_id": buildID,
+			"plan_id":  string(planID),
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, suite := range suites {
+		var suiteID int
+		err := psql.Insert("test_suites").
+			Columns("build_id", "plan_id", "name", "duration").
+			Values(buildID, string(planID), suite.Name, int64(suite.Duration)).
+			Suffix("RETURNING id").
+			RunWith(tx).
+			QueryRow().
+			Scan(&suiteID)
+		if err != nil {
+			return err
+		}
+
+		for _, testCase := range suite.Cases {
+			_, err := psql.Insert("test_cases").
+				Columns("suite_id", "class_name", "name", "status", "duration", "message").
+				Values(suiteID, testCase.ClassName, testCase.Name, string(testCase.Status), int64(testCase.Duration), testCase.Message).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (f *testResultFactory) FindBuildTestSuites(buildID int) ([]TestSuite, error) {
+	rows, err := psql.Select("s.id, s.name, s.duration, c.class_name, c.name, c.status, c.duration, c.message").
+		From("test_suites s").
+		LeftJoin("test_cases c ON c.suite_id = s.id").
+		Where(sq.Eq{"s.build_id": buildID}).
+		OrderBy("s.id ASC, c.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	suites := []TestSuite{}
+	lastSuiteID := 0
+
+	for rows.Next() {
+		var (
+			suiteID       int
+			suiteName     string
+			suiteDuration int64
+			className     *string
+			name          *string
+			status        *string
+			duration      *int64
+			message       *string
+		)
+
+		err := rows.Scan(&suiteID, &suiteName, &suiteDuration, &className, &name, &status, &duration, &message)
+		if err != nil {
+			return nil, err
+		}
+
+		if suiteID != lastSuiteID {
+			suites = append(suites, TestSuite{
+				Name:     suiteName,
+				Duration: time.Duration(suiteDuration),
+				Cases:    []TestCase{},
+			})
+
+			lastSuiteID = suiteID
+		}
+
+		if name == nil {
+			continue
+		}
+
+		suite := &suites[len(suites)-1]
+		suite.Cases = append(suite.Cases, TestCase{
+			ClassName: *className,
+			Name:      *name,
+			Status:    TestStatus(*status),
+			Duration:  time.Duration(*duration),
+			Message:   *message,
+		})
+	}
+
+	return suites, nil
+}
+
+// FindJobTestHistory returns the history of each test case reported by the
+// most recent builds of the job which reported any test results, flakiest
+// first.
+func (f *testResultFactory) FindJobTestHistory(jobID int, builds int) ([]TestHistory, error) {
+	rows, err := f.conn.Query(`
+		SELECT b.id, b.name, s.name, c.class_name, c.name, c.status, c.duration
+		FROM test_cases c
+		JOIN test_suites s ON s.id = c.suite_id
+		JOIN builds b ON b.id = s.build_id
+		WHERE s.build_id IN (
+			SELECT DISTINCT ts.build_id
+			FROM test_suites ts
+			JOIN builds tb ON tb.id = ts.build_id
+			WHERE tb.job_id = $1
+			ORDER BY ts.build_id DESC
+			LIMIT $2
+		)
+		ORDER BY b.id DESC, c.id ASC
+	`, jobID, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	type testKey struct {
+		suite     string
+		className string
+		name      string
+	}
+
+	histories := []*TestHistory{}
+	historiesByKey := map[testKey]*TestHistory{}
+
+	for rows.Next() {
+		var (
+			key      testKey
+			run      TestCaseRun
+			status   string
+			duration int64
+		)
+
+		err := rows.Scan(&run.BuildID, &run.BuildName, &key.suite, &key.className, &key.name, &status, &duration)
+		if err != nil {
+			return nil, err
+		}
+
+		run.Status = TestStatus(status)
+		run.Duration = time.Duration(duration)
+
+		history, found := historiesByKey[key]
+		if !found {
+			history = &TestHistory{
+				Suite:     key.suite,
+				ClassName: key.className,
+				Name:      key.name,
+				Runs:      []TestCaseRun{},
+			}
+
+			historiesByKey[key] = history
+			histories = append(histories, history)
+		}
+
+		history.Runs = append(history.Runs, run)
+	}
+
+	result := make([]TestHistory, len(histories))
+	for i, history := range histories {
+		history.Flakiness = flakiness(history.Runs)
+		result[i] = *history
+	}
+
+	sort.Stable(byFlakiness(result))
+
+	return result, nil
+}
+
+func flakiness(runs []TestCaseRun) float64 {
+	var outcomes []bool
+	for _, run := range runs {
+		if run.Status == TestStatusSkipped {
+			continue
+		}
+
+		outcomes = append(outcomes, run.Status == TestStatusPassed)
+	}
+
+	if len(outcomes) < 2 {
+		return 0
+	}
+
+	flips := 0
+	for i := 1; i < len(outcomes); i++ {
+		if outcomes[i] != outcomes[i-1] {
+			flips++
+		}
+	}
+
+	return float64(flips) / float64(len(outcomes)-1)
+}
+
+type byFlakiness []TestHistory
+
+func (hs byFlakiness) Len() int           { return len(hs) }
+func (hs byFlakiness) Swap(i, j int)      { hs[i], hs[j] = hs[j], hs[i] }
+func (hs byFlakiness) Less(i, j int) bool { return hs[i].Flakiness > hs[j].Flakiness }
@@ -0,0 +1,155 @@
+package dbng_test
+
+import (
+	"time"
+
+	"github.com/concourse/atc/dbng"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TestResultFactory", func() {
+	var testResultFactory dbng.TestResultFactory
+
+	BeforeEach(func() {
+		testResultFactory = dbng.NewTestResultFactory(dbConn)
+	})
+
+	report := func(statuses ...dbng.TestStatus) dbng.Build {
+		build, err := defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+
+		cases := []dbng.TestCase{}
+		for i, status := range statuses {
+			cases = append(cases, dbng.TestCase{
+				ClassName: "some.Class",
+				Name:      []string{"first", "second", "third"}[i],
+				Status:    status,
+				Duration:  time.Second,
+			})
+		}
+
+		err = testResultFactory.SaveTestSuites(build.ID(), "some-plan-id", []dbng.TestSuite{
+			{Name: "some-suite", Duration: 3 * time.Second, Cases: cases},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return build
+	}
+
+	Describe("SaveTestSuites and FindBuildTestSuites", func() {
+		var build dbng.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns nothing for a build without test results", func() {
+			suites, err := testResultFactory.FindBuildTestSuites(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites).To(BeEmpty())
+		})
+
+		It("returns the suites saved by each step of the build", func() {
+			err := testResultFactory.SaveTestSuites(build.ID(), "some-plan-id", []dbng.TestSuite{
+				{
+					Name:     "some-suite",
+					Duration: 2 * time.Second,
+					Cases: []dbng.TestCase{
+						{ClassName: "some.Class", Name: "passes", Status: dbng.TestStatusPassed, Duration: time.Second},
+						{ClassName: "some.Class", Name: "fails", Status: dbng.TestStatusFailed, Duration: time.Second, Message: "nope"},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = testResultFactory.SaveTestSuites(build.ID(), "other-plan-id", []dbng.TestSuite{
+				{Name: "empty-suite"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			suites, err := testResultFactory.FindBuildTestSuites(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites).To(Equal([]dbng.TestSuite{
+				{
+					Name:     "some-suite",
+					Duration: 2 * time.Second,
+					Cases: []dbng.TestCase{
+						{ClassName: "some.Class", Name: "passes", Status: dbng.TestStatusPassed, Duration: time.Second},
+						{ClassName: "some.Class", Name: "fails", Status: dbng.TestStatusFailed, Duration: time.Second, Message: "nope"},
+					},
+				},
+				{
+					Name:  "empty-suite",
+					Cases: []dbng.TestCase{},
+				},
+			}))
+		})
+
+		It("replaces the suites saved by the step before", func() {
+			err := testResultFactory.SaveTestSuites(build.ID(), "some-plan-id", []dbng.TestSuite{
+				{Name: "first-attempt"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = testResultFactory.SaveTestSuites(build.ID(), "some-plan-id", []dbng.TestSuite{
+				{Name: "second-attempt"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			suites, err := testResultFactory.FindBuildTestSuites(build.ID())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites).To(Equal([]dbng.TestSuite{
+				{Name: "second-attempt", Cases: []dbng.TestCase{}},
+			}))
+		})
+	})
+
+	Describe("FindJobTestHistory", func() {
+		var builds []dbng.Build
+
+		BeforeEach(func() {
+			builds = []dbng.Build{
+				report(dbng.TestStatusPassed, dbng.TestStatusPassed, dbng.TestStatusSkipped),
+				report(dbng.TestStatusPassed, dbng.TestStatusFailed, dbng.TestStatusPassed),
+				report(dbng.TestStatusPassed, dbng.TestStatusPassed, dbng.TestStatusSkipped),
+			}
+		})
+
+		It("returns each test's runs, most recent first, flakiest first", func() {
+			histories, err := testResultFactory.FindJobTestHistory(defaultJob.ID(), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(histories).To(HaveLen(3))
+
+			Expect(histories[0].Name).To(Equal("second"))
+			Expect(histories[0].Suite).To(Equal("some-suite"))
+			Expect(histories[0].ClassName).To(Equal("some.Class"))
+			Expect(histories[0].Flakiness).To(Equal(1.0))
+			Expect(histories[0].Runs).To(HaveLen(3))
+			Expect(histories[0].Runs[0].BuildID).To(Equal(builds[2].ID()))
+			Expect(histories[0].Runs[0].BuildName).To(Equal(builds[2].Name()))
+			Expect(histories[0].Runs[1].Status).To(Equal(dbng.TestStatusFailed))
+			Expect(histories[0].Runs[1].Duration).To(Equal(time.Second))
+
+			Expect(histories[1].Name).To(Equal("first"))
+			Expect(histories[1].Flakiness).To(BeZero())
+
+			Expect(histories[2].Name).To(Equal("third"))
+			Expect(histories[2].Flakiness).To(BeZero())
+		})
+
+		It("only considers the most recent builds", func() {
+			histories, err := testResultFactory.FindJobTestHistory(defaultJob.ID(), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(histories).To(HaveLen(3))
+
+			for _, history := range histories {
+				Expect(history.Runs).To(HaveLen(1))
+				Expect(history.Runs[0].BuildID).To(Equal(builds[2].ID()))
+				Expect(history.Flakiness).To(BeZero())
+			}
+		})
+	})
+})
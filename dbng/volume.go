@@ -40,10 +40,12 @@ const (
 type VolumeType string
 
 const (
-	VolumeTypeContainer    = "container"
-	VolumeTypeResource     = "resource"
-	VolumeTypeResourceType = "resource-type"
-	VolumeTypeUknown       = "unknown" // for migration to life
+	VolumeTypeContainer     = "container"
+	VolumeTypeResource      = "resource"
+	VolumeTypeResourceType  = "resource-type"
+	VolumeTypeTaskCache     = "task-cache"
+	VolumeTypeBuildArtifact = "build-artifact"
+	VolumeTypeUknown        = "unknown" // for migration to life
 )
 
 //go:generate counterfeiter . CreatingVolume
@@ -356,6 +358,17 @@ func (volume *createdVolume) CreateChildForContainer(container CreatingContainer
 		columnValues = append(columnValues, volume.teamID)
 	}
 
+	_, err = psql.Update("volumes").
+		Set("last_used_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"id": volume.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
 	var volumeID int
 	err = psql.Insert("volumes").
 		Columns(columnNames...).
@@ -26,6 +26,7 @@ type VolumeFactory interface {
 	FindVolumesForContainer(CreatedContainer) ([]CreatedVolume, error)
 	GetOrphanedVolumes() ([]CreatedVolume, []DestroyingVolume, error)
 	GetDuplicateResourceCacheVolumes() ([]CreatingVolume, []CreatedVolume, []DestroyingVolume, error)
+	EvictCacheVolumes(workerName string, limit int) (int, error)
 
 	FindCreatedVolume(handle string) (CreatedVolume, bool, error)
 }
@@ -268,6 +269,8 @@ func (factory *volumeFactory) GetOrphanedVolumes() ([]CreatedVolume, []Destroyin
 			"v.worker_resource_cache_id":     nil,
 			"v.worker_base_resource_type_id": nil,
 			"v.container_id":                 nil,
+			"v.task_cache_id":                nil,
+			"v.build_artifact_id":            nil,
 		}).
 		Where(sq.Or{
 			sq.Eq{"w.state": string(WorkerStateRunning)},
@@ -456,6 +459,46 @@ func (factory *volumeFactory) findVolume(teamID int, worker Worker, columns map[
 	return creatingVolume, createdVolume, nil
 }
 
+// EvictCacheVolumes detaches up to limit of the least recently used resource
+// cache and task cache volumes on the worker from their caches, leaving them
+// orphaned for the volume collector to destroy. Volumes which are still being
+// initialized, belong to a container or have children are in use and are
+// never evicted. It returns the number of volumes evicted.
+func (factory *volumeFactory) EvictCacheVolumes(workerName string, limit int) (int, error) {
+	result, err := factory.conn.Exec(`
+		UPDATE volumes
+		SET worker_resource_cache_id = NULL,
+			task_cache_id = NULL,
+			task_cache_output = NULL
+		WHERE id IN (
+			SELECT v.id
+			FROM volumes v
+			WHERE v.worker_name = $1
+			AND v.state = $2
+			AND v.initialized
+			AND v.container_id IS NULL
+			AND (v.worker_resource_cache_id IS NOT NULL OR v.task_cache_id IS NOT NULL)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM volumes child
+				WHERE child.parent_id = v.id
+			)
+			ORDER BY v.last_used_at, v.id
+			LIMIT $3
+		)
+	`, workerName, VolumeStateCreated, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	evicted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(evicted), nil
+}
+
 var volumeColumns = []string{
 	"v.id",
 	"v.handle",
@@ -472,6 +515,8 @@ var volumeColumns = []string{
 	`case when v.container_id is not NULL then 'container'
 	  when v.worker_resource_cache_id is not NULL then 'resource'
 		when v.worker_base_resource_type_id is not NULL then 'resource-type'
+		when v.task_cache_id is not NULL then 'task-cache'
+		when v.build_artifact_id is not NULL then 'build-artifact'
 		else 'unknown'
 	end`,
 }
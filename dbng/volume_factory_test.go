@@ -624,4 +624,100 @@ var _ = Describe("VolumeFactory", func() {
 			})
 		})
 	})
+
+	Describe("EvictCacheVolumes", func() {
+		var (
+			leastRecentlyUsedVolume dbng.CreatedVolume
+			recentlyUsedVolume      dbng.CreatedVolume
+		)
+
+		createCacheVolume := func(version string) dbng.CreatedVolume {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+			defer setupTx.Rollback()
+
+			cache, err := dbng.ForBuild(build.ID()).UseResourceCache(logger, setupTx, lockFactory, dbng.ResourceCache{
+				ResourceConfig: dbng.ResourceConfig{
+					CreatedByBaseResourceType: &baseResourceType,
+				},
+				Version: atc.Version{"some": version},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(setupTx.Commit()).To(Succeed())
+
+			creatingVolume, err := volumeFactory.CreateResourceCacheVolume(defaultWorker, cache)
+			Expect(err).NotTo(HaveOccurred())
+
+			createdVolume, err := creatingVolume.Created()
+			Expect(err).NotTo(HaveOccurred())
+
+			return createdVolume
+		}
+
+		BeforeEach(func() {
+			recentlyUsedVolume = createCacheVolume("recently-used")
+			Expect(recentlyUsedVolume.Initialize()).To(Succeed())
+
+			leastRecentlyUsedVolume = createCacheVolume("least-recently-used")
+			Expect(leastRecentlyUsedVolume.Initialize()).To(Succeed())
+
+			_, err := dbConn.Exec(`UPDATE volumes SET last_used_at = now() - '1 hour'::interval WHERE handle = $1`, leastRecentlyUsedVolume.Handle())
+			Expect(err).NotTo(HaveOccurred())
+
+			usedVolume := createCacheVolume("in-use")
+			Expect(usedVolume.Initialize()).To(Succeed())
+
+			_, err = dbConn.Exec(`UPDATE volumes SET last_used_at = now() - '2 hours'::interval WHERE handle = $1`, usedVolume.Handle())
+			Expect(err).NotTo(HaveOccurred())
+
+			creatingContainer, err := defaultTeam.CreateBuildContainer(defaultWorker.Name(), build.ID(), "some-plan", dbng.ContainerMetadata{
+				Type:     "task",
+				StepName: "some-task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = usedVolume.CreateChildForContainer(creatingContainer, "some-path")
+			Expect(err).NotTo(HaveOccurred())
+
+			createCacheVolume("uninitialized")
+		})
+
+		orphanedHandles := func() []string {
+			createdVolumes, _, err := volumeFactory.GetOrphanedVolumes()
+			Expect(err).NotTo(HaveOccurred())
+
+			handles := []string{}
+			for _, volume := range createdVolumes {
+				handles = append(handles, volume.Handle())
+			}
+
+			return handles
+		}
+
+		It("evicts the least recently used cache volumes first", func() {
+			evicted, err := volumeFactory.EvictCacheVolumes(defaultWorker.Name(), 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evicted).To(Equal(1))
+
+			Expect(orphanedHandles()).To(ConsistOf(leastRecentlyUsedVolume.Handle()))
+		})
+
+		It("never evicts volumes which are in use or still being initialized", func() {
+			evicted, err := volumeFactory.EvictCacheVolumes(defaultWorker.Name(), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evicted).To(Equal(2))
+
+			Expect(orphanedHandles()).To(ConsistOf(
+				leastRecentlyUsedVolume.Handle(),
+				recentlyUsedVolume.Handle(),
+			))
+		})
+
+		It("does not evict volumes on other workers", func() {
+			evicted, err := volumeFactory.EvictCacheVolumes("some-other-worker", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evicted).To(BeZero())
+		})
+	})
 })
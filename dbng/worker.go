@@ -9,6 +9,10 @@ import (
 	"github.com/concourse/atc"
 )
 
+// WorkerHealthCheckHistory is how many of a worker's most recent health checks
+// are kept.
+const WorkerHealthCheckHistory = 20
+
 var (
 	ErrWorkerNotPresent         = errors.New("worker-not-present-in-db")
 	ErrCannotPruneRunningWorker = errors.New("worker-not-stalled-for-pruning")
@@ -22,6 +26,10 @@ const (
 	WorkerStateLanding  = WorkerState("landing")
 	WorkerStateLanded   = WorkerState("landed")
 	WorkerStateRetiring = WorkerState("retiring")
+
+	// WorkerStateRetired is not stored, but reported for workers deleted once
+	// they have retired.
+	WorkerStateRetired = WorkerState("retired")
 )
 
 //go:generate counterfeiter . Worker
@@ -36,6 +44,9 @@ type Worker interface {
 	HTTPSProxyURL() string
 	NoProxy() string
 	ActiveContainers() int
+	Capacity() *atc.WorkerResources
+	Allocated() atc.WorkerResources
+	Disk() *atc.WorkerDisk
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
@@ -43,8 +54,14 @@ type Worker interface {
 	TeamName() string
 	StartTime() int64
 	ExpiresAt() time.Time
+	LandingStartedAt() time.Time
+	HealthCheckFailures() int
 
 	Reload() (bool, error)
+	DrainProgress() (WorkerDrainProgress, error)
+
+	RecordHealthCheck(atc.WorkerHealthCheck) error
+	HealthChecks() ([]atc.WorkerHealthCheck, error)
 
 	Land() error
 	Retire() error
@@ -64,6 +81,9 @@ type worker struct {
 	httpsProxyURL    string
 	noProxy          string
 	activeContainers int
+	capacity         *atc.WorkerResources
+	allocated        atc.WorkerResources
+	disk             *atc.WorkerDisk
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
@@ -71,6 +91,17 @@ type worker struct {
 	teamName         string
 	startTime        int64
 	expiresAt        time.Time
+	landingStartedAt time.Time
+
+	healthCheckFailures int
+}
+
+// WorkerDrainProgress is what a landing or retiring worker is still waiting
+// on: the containers still on it, and the builds which must finish before it
+// can land or retire.
+type WorkerDrainProgress struct {
+	Containers int
+	Builds     int
 }
 
 func (worker *worker) Name() string                            { return worker.name }
@@ -82,6 +113,9 @@ func (worker *worker) HTTPProxyURL() string                    { return worker.h
 func (worker *worker) HTTPSProxyURL() string                   { return worker.httpsProxyURL }
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
+func (worker *worker) Capacity() *atc.WorkerResources          { return worker.capacity }
+func (worker *worker) Allocated() atc.WorkerResources          { return worker.allocated }
+func (worker *worker) Disk() *atc.WorkerDisk                   { return worker.disk }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
@@ -92,6 +126,14 @@ func (worker *worker) TeamName() string                        { return worker.t
 func (worker *worker) StartTime() int64     { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
 
+// LandingStartedAt is when the worker started landing, or the zero time if it
+// is not landing.
+func (worker *worker) LandingStartedAt() time.Time { return worker.landingStartedAt }
+
+// HealthCheckFailures is the number of consecutive failed health checks of the
+// worker, which is reset by a successful one.
+func (worker *worker) HealthCheckFailures() int { return worker.healthCheckFailures }
+
 func (worker *worker) Reload() (bool, error) {
 	row := workersQuery.Where(sq.Eq{"w.name": worker.name}).
 		RunWith(worker.conn).
@@ -109,58 +151,238 @@ func (worker *worker) Reload() (bool, error) {
 }
 
 func (worker *worker) Land() error {
+	_, err := worker.land()
+	return err
+}
+
+// land marks the worker as landing, unless it has already landed, returning
+// the state it is left in.
+func (worker *worker) land() (WorkerState, error) {
 	cSql, _, err := sq.Case("state").
 		When("'landed'::worker_state", "'landed'::worker_state").
 		Else("'landing'::worker_state").
 		ToSql()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	result, err := psql.Update("workers").
+	startedSql, _, err := sq.Case("state").
+		When("'landing'::worker_state", "COALESCE(landing_started_at, now())").
+		When("'landed'::worker_state", "NULL").
+		Else("now()").
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var state string
+	err = psql.Update("workers").
 		Set("state", sq.Expr("("+cSql+")")).
+		Set("landing_started_at", sq.Expr("("+startedSql+")")).
 		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING state").
 		RunWith(worker.conn).
-		Exec()
+		QueryRow().
+		Scan(&state)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", ErrWorkerNotPresent
+		}
+		return "", err
+	}
+
+	return WorkerState(state), nil
+}
+
+// Retire marks the worker as retiring, to be deleted once its builds have
+// finished. A stalled worker cannot run anything, so it is deleted straight
+// away rather than left behind as retiring.
+func (worker *worker) Retire() error {
+	_, err := worker.retire()
+	return err
+}
 
+// retire returns WorkerStateRetired if the worker was deleted straight away,
+// or otherwise WorkerStateRetiring.
+func (worker *worker) retire() (WorkerState, error) {
+	result, err := psql.Delete("workers").
+		Where(sq.Eq{
+			"name":  worker.name,
+			"state": string(WorkerStateStalled),
+		}).
+		RunWith(worker.conn).
+		Exec()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	count, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if count != 0 {
+		return WorkerStateRetired, nil
+	}
+
+	result, err = psql.Update("workers").
+		SetMap(map[string]interface{}{
+			"state":              string(WorkerStateRetiring),
+			"landing_started_at": nil,
+		}).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(worker.conn).
+		Exec()
+	if err != nil {
+		return "", err
+	}
+
+	count, err = result.RowsAffected()
+	if err != nil {
+		return "", err
 	}
 
 	if count == 0 {
-		return ErrWorkerNotPresent
+		return "", ErrWorkerNotPresent
 	}
 
-	return nil
+	return WorkerStateRetiring, nil
 }
 
-func (worker *worker) Retire() error {
-	result, err := psql.Update("workers").
-		SetMap(map[string]interface{}{
-			"state": string(WorkerStateRetiring),
+func (worker *worker) DrainProgress() (WorkerDrainProgress, error) {
+	var progress WorkerDrainProgress
+
+	err := psql.Select("COUNT(*)").
+		From("containers").
+		Where(sq.Eq{"worker_name": worker.name}).
+		RunWith(worker.conn).
+		QueryRow().
+		Scan(&progress.Containers)
+	if err != nil {
+		return WorkerDrainProgress{}, err
+	}
+
+	err = psql.Select("COUNT(DISTINCT b.id)").
+		From("builds b").
+		Join("containers c ON b.id = c.build_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{"c.worker_name": worker.name}).
+		Where(sq.Or{
+			sq.Eq{"b.status": string(BuildStatusStarted)},
+			sq.Eq{"b.status": string(BuildStatusPending)},
+		}).
+		Where(sq.Or{
+			sq.Eq{"j.interruptible": false},
+			sq.Eq{"b.job_id": nil},
 		}).
-		Where(sq.Eq{"name": worker.name}).
 		RunWith(worker.conn).
+		QueryRow().
+		Scan(&progress.Builds)
+	if err != nil {
+		return WorkerDrainProgress{}, err
+	}
+
+	return progress, nil
+}
+
+// RecordHealthCheck saves the result of a health check, keeping only the most
+// recent WorkerHealthCheckHistory, and counts it towards the worker's
+// consecutive failures.
+func (worker *worker) RecordHealthCheck(check atc.WorkerHealthCheck) error {
+	tx, err := worker.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var checkError *string
+	if !check.Healthy() {
+		checkError = &check.Error
+	}
+
+	_, err = psql.Insert("worker_health_checks").
+		Columns("worker_name", "checked_at", "garden_latency", "baggageclaim_latency", "error").
+		Values(worker.name, time.Unix(check.CheckedAt, 0), check.GardenLatency, check.BaggageclaimLatency, checkError).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return err
 	}
 
-	count, err := result.RowsAffected()
+	_, err = tx.Exec(`
+		DELETE FROM worker_health_checks
+		WHERE worker_name = $1
+		AND id NOT IN (
+			SELECT id
+			FROM worker_health_checks
+			WHERE worker_name = $1
+			ORDER BY checked_at DESC, id DESC
+			LIMIT $2
+		)
+	`, worker.name, WorkerHealthCheckHistory)
 	if err != nil {
 		return err
 	}
 
-	if count == 0 {
-		return ErrWorkerNotPresent
+	failures := sq.Expr("health_check_failures + 1")
+	if check.Healthy() {
+		failures = sq.Expr("0")
 	}
 
-	return nil
+	err = psql.Update("workers").
+		Set("health_check_failures", failures).
+		Where(sq.Eq{"name": worker.name}).
+		Suffix("RETURNING health_check_failures").
+		RunWith(tx).
+		QueryRow().
+		Scan(&worker.healthCheckFailures)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerNotPresent
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// HealthChecks returns the worker's most recent health checks, newest first.
+func (worker *worker) HealthChecks() ([]atc.WorkerHealthCheck, error) {
+	rows, err := psql.Select("checked_at, garden_latency, baggageclaim_latency, error").
+		From("worker_health_checks").
+		Where(sq.Eq{"worker_name": worker.name}).
+		OrderBy("checked_at DESC, id DESC").
+		RunWith(worker.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	checks := []atc.WorkerHealthCheck{}
+	for rows.Next() {
+		var (
+			check      atc.WorkerHealthCheck
+			checkedAt  time.Time
+			checkError sql.NullString
+		)
+
+		err = rows.Scan(&checkedAt, &check.GardenLatency, &check.BaggageclaimLatency, &checkError)
+		if err != nil {
+			return nil, err
+		}
+
+		check.CheckedAt = checkedAt.Unix()
+		if checkError.Valid {
+			check.Error = checkError.String
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
 }
 
 func (worker *worker) Prune() error {
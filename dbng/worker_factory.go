@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/atc"
+	"github.com/lib/pq"
 )
 
 //go:generate counterfeiter . WorkerFactory
@@ -40,6 +41,14 @@ var workersQuery = psql.Select(`
 		w.https_proxy_url,
 		w.no_proxy,
 		w.active_containers,
+		w.cpu_capacity,
+		w.memory_capacity,
+		w.cpu_allocated,
+		w.memory_allocated,
+		w.disk_used,
+		w.disk_total,
+		w.landing_started_at,
+		w.health_check_failures,
 		w.resource_types,
 		w.platform,
 		w.tags,
@@ -100,20 +109,25 @@ func getWorkers(conn Conn, query sq.SelectBuilder) ([]Worker, error) {
 
 func scanWorker(worker *worker, row scannable) error {
 	var (
-		version       sql.NullString
-		addStr        sql.NullString
-		state         string
-		bcURLStr      sql.NullString
-		httpProxyURL  sql.NullString
-		httpsProxyURL sql.NullString
-		noProxy       sql.NullString
-		resourceTypes []byte
-		platform      sql.NullString
-		tags          []byte
-		teamName      sql.NullString
-		teamID        sql.NullInt64
-		startTime     sql.NullInt64
-		expiresAt     *time.Time
+		version          sql.NullString
+		addStr           sql.NullString
+		state            string
+		bcURLStr         sql.NullString
+		httpProxyURL     sql.NullString
+		httpsProxyURL    sql.NullString
+		noProxy          sql.NullString
+		cpuCapacity      sql.NullInt64
+		memCapacity      sql.NullInt64
+		diskUsed         sql.NullInt64
+		diskTotal        sql.NullInt64
+		landingStartedAt pq.NullTime
+		resourceTypes    []byte
+		platform         sql.NullString
+		tags             []byte
+		teamName         sql.NullString
+		teamID           sql.NullInt64
+		startTime        sql.NullInt64
+		expiresAt        *time.Time
 	)
 
 	err := row.Scan(
@@ -126,6 +140,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&httpsProxyURL,
 		&noProxy,
 		&worker.activeContainers,
+		&cpuCapacity,
+		&memCapacity,
+		&worker.allocated.CPU,
+		&worker.allocated.Memory,
+		&diskUsed,
+		&diskTotal,
+		&landingStartedAt,
+		&worker.healthCheckFailures,
 		&resourceTypes,
 		&platform,
 		&tags,
@@ -184,6 +206,24 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.platform = platform.String
 	}
 
+	if cpuCapacity.Valid && memCapacity.Valid {
+		worker.capacity = &atc.WorkerResources{
+			CPU:    cpuCapacity.Int64,
+			Memory: memCapacity.Int64,
+		}
+	}
+
+	if landingStartedAt.Valid {
+		worker.landingStartedAt = landingStartedAt.Time
+	}
+
+	if diskUsed.Valid && diskTotal.Valid {
+		worker.disk = &atc.WorkerDisk{
+			Used:  diskUsed.Int64,
+			Total: diskTotal.Int64,
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -209,6 +249,9 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 	}
 	defer tx.Rollback()
 
+	cpuCapacity, memCapacity, allocated := workerCapacityColumns(atcWorker)
+	diskUsed, diskTotal := workerDiskColumns(atcWorker)
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -246,6 +289,12 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("addr", sq.Expr("("+addrSql+")")).
 		Set("baggageclaim_url", sq.Expr("("+bcSql+")")).
 		Set("active_containers", atcWorker.ActiveContainers).
+		Set("cpu_capacity", cpuCapacity).
+		Set("memory_capacity", memCapacity).
+		Set("cpu_allocated", allocated.CPU).
+		Set("memory_allocated", allocated.Memory).
+		Set("disk_used", diskUsed).
+		Set("disk_total", diskTotal).
 		Set("state", sq.Expr("("+cSql+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -315,6 +364,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
 	}
 
+	cpuCapacity, memCapacity, allocated := workerCapacityColumns(atcWorker)
+	diskUsed, diskTotal := workerDiskColumns(atcWorker)
+
 	var oldTeamID sql.NullInt64
 
 	var workerState WorkerState
@@ -324,6 +376,14 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		workerState = WorkerStateRunning
 	}
 
+	// a worker saved as landing keeps the time it started landing, so that
+	// re-registering does not push back its landing deadline
+	var insertLandingStartedAt, updateLandingStartedAt interface{}
+	if workerState == WorkerStateLanding {
+		insertLandingStartedAt = sq.Expr("now()")
+		updateLandingStartedAt = sq.Expr("COALESCE(landing_started_at, now())")
+	}
+
 	var workerVersion *string
 	if atcWorker.Version != "" {
 		workerVersion = &atcWorker.Version
@@ -340,6 +400,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"addr",
 					"expires",
 					"active_containers",
+					"cpu_capacity",
+					"memory_capacity",
+					"cpu_allocated",
+					"memory_allocated",
+					"disk_used",
+					"disk_total",
 					"resource_types",
 					"tags",
 					"platform",
@@ -352,11 +418,18 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					"start_time",
 					"team_id",
 					"state",
+					"landing_started_at",
 				).
 				Values(
 					atcWorker.GardenAddr,
 					sq.Expr(expires),
 					atcWorker.ActiveContainers,
+					cpuCapacity,
+					memCapacity,
+					allocated.CPU,
+					allocated.Memory,
+					diskUsed,
+					diskTotal,
 					resourceTypes,
 					tags,
 					atcWorker.Platform,
@@ -369,6 +442,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 					atcWorker.StartTime,
 					teamID,
 					string(workerState),
+					insertLandingStartedAt,
 				).
 				RunWith(tx).
 				Exec()
@@ -388,6 +462,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("addr", atcWorker.GardenAddr).
 			Set("expires", sq.Expr(expires)).
 			Set("active_containers", atcWorker.ActiveContainers).
+			Set("cpu_capacity", cpuCapacity).
+			Set("memory_capacity", memCapacity).
+			Set("cpu_allocated", allocated.CPU).
+			Set("memory_allocated", allocated.Memory).
+			Set("disk_used", diskUsed).
+			Set("disk_total", diskTotal).
 			Set("resource_types", resourceTypes).
 			Set("tags", tags).
 			Set("platform", atcWorker.Platform).
@@ -399,6 +479,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			Set("version", workerVersion).
 			Set("start_time", atcWorker.StartTime).
 			Set("state", string(workerState)).
+			Set("landing_started_at", updateLandingStartedAt).
 			Where(sq.Eq{
 				"name": atcWorker.Name,
 			}).
@@ -424,6 +505,9 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		httpsProxyURL:    atcWorker.HTTPSProxyURL,
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
+		capacity:         atcWorker.Capacity,
+		allocated:        allocated,
+		disk:             atcWorker.Disk,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
@@ -489,3 +573,30 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 
 	return savedWorker, nil
 }
+
+// workerCapacityColumns returns the values to store for a worker's capacity,
+// which is NULL if the worker did not report it, and allocation.
+func workerCapacityColumns(atcWorker atc.Worker) (*int64, *int64, atc.WorkerResources) {
+	var cpuCapacity, memCapacity *int64
+	if atcWorker.Capacity != nil {
+		cpuCapacity = &atcWorker.Capacity.CPU
+		memCapacity = &atcWorker.Capacity.Memory
+	}
+
+	var allocated atc.WorkerResources
+	if atcWorker.Allocated != nil {
+		allocated = *atcWorker.Allocated
+	}
+
+	return cpuCapacity, memCapacity, allocated
+}
+
+// workerDiskColumns returns the values to store for a worker's disk usage,
+// which are NULL if the worker did not report it.
+func workerDiskColumns(atcWorker atc.Worker) (*int64, *int64) {
+	if atcWorker.Disk == nil {
+		return nil, nil
+	}
+
+	return &atcWorker.Disk.Used, &atcWorker.Disk.Total
+}
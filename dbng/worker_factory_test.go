@@ -224,6 +224,23 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.State()).To(Equal(dbng.WorkerStateRunning))
 			})
 
+			It("has no capacity when the worker did not report any", func() {
+				foundWorker, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(foundWorker.Capacity()).To(BeNil())
+				Expect(foundWorker.Allocated()).To(BeZero())
+			})
+
+			It("has no disk usage when the worker did not report any", func() {
+				foundWorker, found, err := workerFactory.GetWorker("some-name")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(foundWorker.Disk()).To(BeNil())
+			})
+
 			Context("when worker is stalled", func() {
 				BeforeEach(func() {
 					var err error
@@ -337,6 +354,34 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates the capacity and allocation of the worker", func() {
+				atcWorker.Capacity = &atc.WorkerResources{CPU: 4000, Memory: 8 * 1024 * 1024 * 1024}
+				atcWorker.Allocated = &atc.WorkerResources{CPU: 1500, Memory: 1024 * 1024 * 1024}
+
+				_, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(foundWorker.Capacity()).To(Equal(atcWorker.Capacity))
+				Expect(foundWorker.Allocated()).To(Equal(*atcWorker.Allocated))
+			})
+
+			It("updates the disk usage of the worker", func() {
+				atcWorker.Disk = &atc.WorkerDisk{Used: 90 * 1024 * 1024 * 1024, Total: 100 * 1024 * 1024 * 1024}
+
+				_, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+
+				foundWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(foundWorker.Disk()).To(Equal(atcWorker.Disk))
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(dbng.WorkerStateLanding)
@@ -2,6 +2,7 @@ package dbng
 
 import (
 	"database/sql"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 )
@@ -9,18 +10,64 @@ import (
 //go:generate counterfeiter . WorkerLifecycle
 
 type WorkerLifecycle interface {
+	LandWorker(workerName string) error
+	RetireWorker(workerName string) error
+
 	StallUnresponsiveWorkers() ([]string, error)
 	LandFinishedLandingWorkers() ([]string, error)
 	DeleteFinishedRetiringWorkers() ([]string, error)
+	AbortBuildsOnOverdueLandingWorkers(deadline time.Duration, requeue bool) ([]int, error)
+}
+
+//go:generate counterfeiter . WorkerStateObserver
+
+// WorkerStateObserver is told of each worker moved to a new state by the
+// WorkerLifecycle.
+type WorkerStateObserver interface {
+	WorkerStateChanged(workerName string, state WorkerState)
 }
 
 type workerLifecycle struct {
-	conn Conn
+	conn     Conn
+	observer WorkerStateObserver
 }
 
-func NewWorkerLifecycle(conn Conn) WorkerLifecycle {
+func NewWorkerLifecycle(conn Conn, observer WorkerStateObserver) WorkerLifecycle {
 	return &workerLifecycle{
-		conn: conn,
+		conn:     conn,
+		observer: observer,
+	}
+}
+
+func (lifecycle *workerLifecycle) LandWorker(workerName string) error {
+	w := &worker{conn: lifecycle.conn, name: workerName}
+
+	state, err := w.land()
+	if err != nil {
+		return err
+	}
+
+	lifecycle.observer.WorkerStateChanged(workerName, state)
+
+	return nil
+}
+
+func (lifecycle *workerLifecycle) RetireWorker(workerName string) error {
+	w := &worker{conn: lifecycle.conn, name: workerName}
+
+	state, err := w.retire()
+	if err != nil {
+		return err
+	}
+
+	lifecycle.observer.WorkerStateChanged(workerName, state)
+
+	return nil
+}
+
+func (lifecycle *workerLifecycle) observe(workerNames []string, state WorkerState) {
+	for _, name := range workerNames {
+		lifecycle.observer.WorkerStateChanged(name, state)
 	}
 }
 
@@ -45,7 +92,14 @@ func (lifecycle *workerLifecycle) StallUnresponsiveWorkers() ([]string, error) {
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	stalled, err := workersAffected(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.observe(stalled, WorkerStateStalled)
+
+	return stalled, nil
 }
 
 func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, error) {
@@ -107,7 +161,14 @@ func (lifecycle *workerLifecycle) DeleteFinishedRetiringWorkers() ([]string, err
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	retired, err := workersAffected(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.observe(retired, WorkerStateRetired)
+
+	return retired, nil
 }
 
 func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error) {
@@ -142,6 +203,7 @@ func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 		Set("state", string(WorkerStateLanded)).
 		Set("addr", nil).
 		Set("baggageclaim_url", nil).
+		Set("landing_started_at", nil).
 		Where(sq.Eq{
 			"state": string(WorkerStateLanding),
 		}).
@@ -159,7 +221,176 @@ func (lifecycle *workerLifecycle) LandFinishedLandingWorkers() ([]string, error)
 		return nil, err
 	}
 
-	return workersAffected(rows)
+	landed, err := workersAffected(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	lifecycle.observe(landed, WorkerStateLanded)
+
+	return landed, nil
+}
+
+type overdueBuild struct {
+	id                int
+	jobID             int
+	teamID            int
+	priority          int
+	manuallyTriggered bool
+	engine            string
+}
+
+// AbortBuildsOnOverdueLandingWorkers aborts every build of an interruptible
+// job still running on a worker which started landing longer ago than the
+// deadline, so that it can land. If requeue is true, a new build of each
+// aborted build's job is queued in its place, with the same inputs, to be run
+// on another worker. It returns the IDs of the aborted builds.
+//
+// Builds being run by an engine finish once it notices they were aborted, as
+// when they are aborted through the API; the rest are finished here.
+func (lifecycle *workerLifecycle) AbortBuildsOnOverdueLandingWorkers(deadline time.Duration, requeue bool) ([]int, error) {
+	tx, err := lifecycle.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	rows, err := tx.Query(`
+		UPDATE builds
+		SET status = $1
+		WHERE id IN (
+			SELECT DISTINCT b.id
+			FROM builds b
+			JOIN jobs j ON j.id = b.job_id
+			JOIN containers c ON b.id = c.build_id
+			JOIN workers w ON w.name = c.worker_name
+			WHERE w.state = $2
+			AND w.landing_started_at < now() - $5::integer * interval '1 second'
+			AND b.status IN ($3, $4)
+			AND j.interruptible
+		)
+		RETURNING id, job_id, team_id, priority, manually_triggered, engine
+	`,
+		string(BuildStatusAborted),
+		string(WorkerStateLanding),
+		string(BuildStatusStarted),
+		string(BuildStatusPending),
+		int(deadline.Seconds()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	builds := []overdueBuild{}
+	for rows.Next() {
+		var build overdueBuild
+		var engine sql.NullString
+		err = rows.Scan(&build.id, &build.jobID, &build.teamID, &build.priority, &build.manuallyTriggered, &engine)
+		if err != nil {
+			return nil, err
+		}
+
+		build.engine = engine.String
+
+		builds = append(builds, build)
+	}
+
+	abortedIDs := []int{}
+	for _, build := range builds {
+		abortedIDs = append(abortedIDs, build.id)
+
+		if !requeue {
+			continue
+		}
+
+		err = requeueBuild(tx, build)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, aborted := range builds {
+		err = lifecycle.conn.Bus().Notify(buildAbortChannel(aborted.id))
+		if err != nil {
+			return nil, err
+		}
+
+		if aborted.engine != "" {
+			continue
+		}
+
+		err = lifecycle.finishUnstartedBuild(aborted.id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return abortedIDs, nil
+}
+
+// finishUnstartedBuild finishes an aborted build which no engine had started,
+// so that its aborted event is saved and its notifications are sent.
+func (lifecycle *workerLifecycle) finishUnstartedBuild(buildID int) error {
+	b := &build{id: buildID, conn: lifecycle.conn}
+
+	found, err := b.Reload()
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return nil
+	}
+
+	return b.Finish(BuildStatusAborted)
+}
+
+func requeueBuild(tx Tx, aborted overdueBuild) error {
+	var buildName string
+	err := psql.Update("jobs").
+		Set("build_number_seq", sq.Expr("build_number_seq + 1")).
+		Where(sq.Eq{"id": aborted.jobID}).
+		Suffix("RETURNING build_number_seq").
+		RunWith(tx).
+		QueryRow().
+		Scan(&buildName)
+	if err != nil {
+		return err
+	}
+
+	var buildID int
+	err = psql.Insert("builds").
+		Columns("name", "job_id", "team_id", "status", "manually_triggered", "priority").
+		Values(buildName, aborted.jobID, aborted.teamID, BuildStatusPending, aborted.manuallyTriggered, aborted.priority).
+		Suffix("RETURNING id").
+		RunWith(tx).
+		QueryRow().
+		Scan(&buildID)
+	if err != nil {
+		return err
+	}
+
+	// the requeued build runs with the inputs of the build it replaces, rather
+	// than whatever versions are next by the time it is scheduled
+	_, err = tx.Exec(`
+		INSERT INTO build_inputs (build_id, versioned_resource_id, name)
+		SELECT $1, versioned_resource_id, name
+		FROM build_inputs
+		WHERE build_id = $2
+	`, buildID, aborted.id)
+	if err != nil {
+		return err
+	}
+
+	return createBuildEventSeq(tx, buildID)
 }
 
 func workersAffected(rows *sql.Rows) ([]string, error) {
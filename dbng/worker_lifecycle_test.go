@@ -44,6 +44,77 @@ var _ = Describe("Worker Lifecycle", func() {
 		}
 	})
 
+	Describe("LandWorker", func() {
+		BeforeEach(func() {
+			_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lands the worker and reports it as landing", func() {
+			err := workerLifecycle.LandWorker(atcWorker.Name)
+			Expect(err).NotTo(HaveOccurred())
+
+			dbWorker, found, err := workerFactory.GetWorker(atcWorker.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(dbWorker.State()).To(Equal(dbng.WorkerStateLanding))
+
+			Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(Equal(1))
+			name, state := fakeWorkerStateObserver.WorkerStateChangedArgsForCall(0)
+			Expect(name).To(Equal(atcWorker.Name))
+			Expect(state).To(Equal(dbng.WorkerStateLanding))
+		})
+
+		Context("when the worker does not exist", func() {
+			It("returns ErrWorkerNotPresent", func() {
+				err := workerLifecycle.LandWorker("bogus-worker")
+				Expect(err).To(Equal(dbng.ErrWorkerNotPresent))
+				Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("RetireWorker", func() {
+		Context("when the worker is running", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the worker as retiring", func() {
+				err := workerLifecycle.RetireWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(Equal(1))
+				_, state := fakeWorkerStateObserver.WorkerStateChangedArgsForCall(0)
+				Expect(state).To(Equal(dbng.WorkerStateRetiring))
+			})
+		})
+
+		Context("when the worker is stalled", func() {
+			BeforeEach(func() {
+				_, err := workerFactory.SaveWorker(atcWorker, -1*time.Minute)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = workerLifecycle.StallUnresponsiveWorkers()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("deletes the worker and reports it as retired", func() {
+				err := workerLifecycle.RetireWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, found, err := workerFactory.GetWorker(atcWorker.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(Equal(2))
+				_, state := fakeWorkerStateObserver.WorkerStateChangedArgsForCall(1)
+				Expect(state).To(Equal(dbng.WorkerStateRetired))
+			})
+		})
+	})
+
 	Describe("StallUnresponsiveWorkers", func() {
 		Context("when the worker has heartbeated recently", func() {
 			BeforeEach(func() {
@@ -70,6 +141,16 @@ var _ = Describe("Worker Lifecycle", func() {
 				Expect(len(stalledWorkers)).To(Equal(1))
 				Expect(stalledWorkers[0]).To(Equal("some-name"))
 			})
+
+			It("reports the worker as stalled", func() {
+				_, err := workerLifecycle.StallUnresponsiveWorkers()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(Equal(1))
+				name, state := fakeWorkerStateObserver.WorkerStateChangedArgsForCall(0)
+				Expect(name).To(Equal("some-name"))
+				Expect(state).To(Equal(dbng.WorkerStateStalled))
+			})
 		})
 	})
 
@@ -128,6 +209,16 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeFalse())
 				})
+
+				It("reports the worker as retired", func() {
+					_, err := workerLifecycle.DeleteFinishedRetiringWorkers()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeWorkerStateObserver.WorkerStateChangedCallCount()).To(Equal(1))
+					name, state := fakeWorkerStateObserver.WorkerStateChangedArgsForCall(0)
+					Expect(name).To(Equal(atcWorker.Name))
+					Expect(state).To(Equal(dbng.WorkerStateRetired))
+				})
 			})
 
 			DescribeTable("deleting workers with builds that are",
@@ -485,4 +576,198 @@ var _ = Describe("Worker Lifecycle", func() {
 		})
 	})
 
+	Describe("AbortBuildsOnOverdueLandingWorkers", func() {
+		var (
+			job           dbng.Job
+			dbBuild       dbng.Build
+			interruptible bool
+		)
+
+		BeforeEach(func() {
+			interruptible = true
+		})
+
+		JustBeforeEach(func() {
+			atcWorker.State = string(dbng.WorkerStateLanding)
+
+			dbWorker, err := workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE workers SET landing_started_at = now() - '1 hour'::interval WHERE name = $1`, dbWorker.Name())
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, _, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "some-type"},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-job", Interruptible: interruptible},
+				},
+			}, dbng.ConfigVersion(0), dbng.PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			var found bool
+			job, found, err = pipeline.Job("some-job")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			dbBuild, err = job.CreateBuildWithPriority(5)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = dbBuild.SaveInput(dbng.BuildInput{
+				Name: "some-input",
+				VersionedResource: dbng.VersionedResource{
+					Resource: "some-resource",
+					Type:     "some-type",
+					Version:  dbng.ResourceVersion{"some": "version"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := dbBuild.Start("some-engine", "some-metadata")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			_, err = defaultTeam.CreateBuildContainer(dbWorker.Name(), dbBuild.ID(), atc.PlanID(4), dbng.ContainerMetadata{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the worker has been landing for longer than the deadline", func() {
+			It("aborts the builds running on it", func() {
+				aborted, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(aborted).To(ConsistOf(dbBuild.ID()))
+
+				_, err = dbBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dbBuild.Status()).To(Equal(dbng.BuildStatusAborted))
+			})
+
+			It("lets the worker land", func() {
+				_, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				landed, err := workerLifecycle.LandFinishedLandingWorkers()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(landed).To(ConsistOf(atcWorker.Name))
+			})
+
+			It("does not queue new builds", func() {
+				_, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				pendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuilds).To(BeEmpty())
+			})
+
+			Context("when re-queueing builds", func() {
+				It("queues a new build of the job with the same priority", func() {
+					_, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					pendingBuilds, err := job.GetPendingBuilds()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pendingBuilds).To(HaveLen(1))
+					Expect(pendingBuilds[0].ID()).NotTo(Equal(dbBuild.ID()))
+					Expect(pendingBuilds[0].Priority()).To(Equal(5))
+				})
+
+				It("gives the new build the inputs of the aborted build", func() {
+					_, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					pendingBuilds, err := job.GetPendingBuilds()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pendingBuilds).To(HaveLen(1))
+
+					inputs, _, err := pendingBuilds[0].Resources()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(inputs).To(HaveLen(1))
+					Expect(inputs[0].Name).To(Equal("some-input"))
+					Expect(inputs[0].Version).To(Equal(dbng.ResourceVersion{"some": "version"}))
+				})
+			})
+
+			Context("when the job is not interruptible", func() {
+				BeforeEach(func() {
+					interruptible = false
+				})
+
+				It("does not abort its builds", func() {
+					aborted, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, true)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(aborted).To(BeEmpty())
+
+					_, err = dbBuild.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(dbBuild.Status()).To(Equal(dbng.BuildStatusStarted))
+				})
+			})
+		})
+
+		Context("when a build which no engine has started is on the overdue worker", func() {
+			var pendingBuild dbng.Build
+
+			JustBeforeEach(func() {
+				var err error
+				pendingBuild, err = job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = defaultTeam.CreateBuildContainer(atcWorker.Name, pendingBuild.ID(), atc.PlanID("5"), dbng.ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("finishes it as aborted", func() {
+				aborted, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(aborted).To(ContainElement(pendingBuild.ID()))
+
+				_, err = pendingBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pendingBuild.Status()).To(Equal(dbng.BuildStatusAborted))
+				Expect(pendingBuild.EndTime()).NotTo(BeZero())
+
+				events, err := pendingBuild.Events(0)
+				Expect(err).NotTo(HaveOccurred())
+
+				defer events.Close()
+
+				ev, err := events.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ev.Event).To(Equal(atc.EventType("status")))
+			})
+
+			It("notifies the team's subscriptions that it was aborted", func() {
+				notificationFactory := dbng.NewNotificationFactory(dbConn)
+
+				_, err := notificationFactory.CreateSubscription(defaultTeam.ID(), 0, atc.NotificationSubscription{
+					URL:      "https://example.com",
+					Statuses: []atc.BuildStatus{atc.StatusAborted},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = workerLifecycle.AbortBuildsOnOverdueLandingWorkers(10*time.Minute, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				due, err := notificationFactory.DueNotifications(10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(due).To(HaveLen(1))
+				Expect(due[0].Payload.BuildID).To(Equal(pendingBuild.ID()))
+				Expect(due[0].Payload.Status).To(Equal(atc.StatusAborted))
+			})
+		})
+
+		Context("when the worker has not been landing for longer than the deadline", func() {
+			It("does not abort any builds", func() {
+				aborted, err := workerLifecycle.AbortBuildsOnOverdueLandingWorkers(2*time.Hour, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(aborted).To(BeEmpty())
+
+				_, err = dbBuild.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(dbBuild.Status()).To(Equal(dbng.BuildStatusStarted))
+			})
+		})
+	})
 })
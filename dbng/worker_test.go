@@ -65,6 +65,37 @@ var _ = Describe("Worker", func() {
 				Expect(worker.State()).To(Equal(WorkerStateLanding))
 			})
 
+			It("records when the worker started landing", func() {
+				err := worker.Land()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(worker.LandingStartedAt()).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+
+			Context("when worker is already landing", func() {
+				var startedAt time.Time
+
+				BeforeEach(func() {
+					err := worker.Land()
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = worker.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					startedAt = worker.LandingStartedAt()
+				})
+
+				It("keeps the time it started landing", func() {
+					err := worker.Land()
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = worker.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(worker.LandingStartedAt()).To(Equal(startedAt))
+				})
+			})
+
 			Context("when worker is already landed", func() {
 				BeforeEach(func() {
 					err := worker.Land()
@@ -114,6 +145,26 @@ var _ = Describe("Worker", func() {
 				Expect(worker.Name()).To(Equal(atcWorker.Name))
 				Expect(worker.State()).To(Equal(WorkerStateRetiring))
 			})
+
+			Context("when the worker is stalled", func() {
+				BeforeEach(func() {
+					var err error
+					worker, err = workerFactory.SaveWorker(atcWorker, -5*time.Minute)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = workerLifecycle.StallUnresponsiveWorkers()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("deletes the worker", func() {
+					err := worker.Retire()
+					Expect(err).NotTo(HaveOccurred())
+
+					found, err := worker.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeFalse())
+				})
+			})
 		})
 
 		Context("when the worker is not present", func() {
@@ -205,4 +256,104 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("DrainProgress", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+
+			pipeline, _, err := defaultTeam.SavePipeline("drain-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{
+					{Name: "uninterruptible-job"},
+					{Name: "interruptible-job", Interruptible: true},
+				},
+			}, ConfigVersion(0), PipelineUnpaused)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, jobName := range []string{"uninterruptible-job", "interruptible-job"} {
+				job, found, err := pipeline.Job(jobName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = defaultTeam.CreateBuildContainer(worker.Name(), build.ID(), atc.PlanID("some-plan"), ContainerMetadata{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("counts the containers and the builds the worker must wait for", func() {
+			progress, err := worker.DrainProgress()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(progress).To(Equal(WorkerDrainProgress{
+				Containers: 2,
+				Builds:     1,
+			}))
+		})
+	})
+
+	Describe("RecordHealthCheck", func() {
+		BeforeEach(func() {
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("counts consecutive failures and resets them on success", func() {
+			err := worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 100, Error: "garden timed out"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.HealthCheckFailures()).To(Equal(1))
+
+			err = worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 200, Error: "garden timed out"})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = worker.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.HealthCheckFailures()).To(Equal(2))
+
+			err = worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 300, GardenLatency: 12, BaggageclaimLatency: 34})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(worker.HealthCheckFailures()).To(Equal(0))
+		})
+
+		It("returns the history newest first", func() {
+			err := worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 100, Error: "garden timed out"})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 200, GardenLatency: 12, BaggageclaimLatency: 34})
+			Expect(err).NotTo(HaveOccurred())
+
+			checks, err := worker.HealthChecks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(Equal([]atc.WorkerHealthCheck{
+				{CheckedAt: 200, GardenLatency: 12, BaggageclaimLatency: 34},
+				{CheckedAt: 100, Error: "garden timed out"},
+			}))
+		})
+
+		It("keeps only the most recent checks", func() {
+			for i := 0; i < WorkerHealthCheckHistory+5; i++ {
+				err := worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: int64(i)})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			checks, err := worker.HealthChecks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(checks).To(HaveLen(WorkerHealthCheckHistory))
+			Expect(checks[0].CheckedAt).To(Equal(int64(WorkerHealthCheckHistory + 4)))
+		})
+
+		Context("when the worker is not present", func() {
+			BeforeEach(func() {
+				err := worker.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns an error", func() {
+				err := worker.RecordHealthCheck(atc.WorkerHealthCheck{CheckedAt: 100})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -121,6 +121,13 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
 		plan.Task.ImageArtifactName,
+		exec.TaskCacheConfig{
+			SkipIfUnchanged: plan.Task.SkipIfUnchanged,
+			Key:             plan.Task.CacheKey,
+		},
+		plan.Task.Artifacts,
+		plan.Task.TestReports,
+		build.priority,
 		clock,
 	)
 }
@@ -220,6 +227,21 @@ func (build *execBuild) buildDependentGetStep(logger lager.Logger, plan atc.Plan
 	)
 }
 
+func (build *execBuild) buildGetArtifactStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
+	logger = logger.Session("get-artifact", lager.Data{
+		"name": plan.GetArtifact.Name,
+		"job":  plan.GetArtifact.Job,
+	})
+
+	return build.factory.GetArtifact(
+		logger,
+		plan.GetArtifact.BuildID,
+		worker.ArtifactName(plan.GetArtifact.Name),
+		plan.GetArtifact.Job,
+		build.delegate.InputDelegate(logger, plan.GetArtifact.GetPlan(), event.OriginID(plan.ID)),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.StepFactory {
 	logger = logger.Session("retry")
 
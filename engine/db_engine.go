@@ -272,6 +272,7 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 	metric.BuildStarted{
 		PipelineName: build.build.PipelineName(),
 		JobName:      build.build.JobName(),
+		TeamName:     build.build.TeamName(),
 		BuildName:    build.build.Name(),
 		BuildID:      build.build.ID(),
 	}.Emit(logger)
@@ -298,6 +299,7 @@ func (build *dbBuild) Resume(logger lager.Logger) {
 		metric.BuildFinished{
 			PipelineName:  build.build.PipelineName(),
 			JobName:       build.build.JobName(),
+			TeamName:      build.build.TeamName(),
 			BuildName:     build.build.Name(),
 			BuildID:       build.build.ID(),
 			BuildStatus:   build.build.Status(),
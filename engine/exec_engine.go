@@ -13,12 +13,17 @@ import (
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
 )
 
 type execMetadata struct {
 	Plan atc.Plan
+
+	// Traceparent identifies the build's span, so that it is kept when the
+	// build is resumed by another ATC.
+	Traceparent string `json:",omitempty"`
 }
 
 const execEngineName = "exec.v2"
@@ -51,6 +56,11 @@ func (engine *execEngine) Name() string {
 }
 
 func (engine *execEngine) CreateBuild(logger lager.Logger, build dbng.Build, plan atc.Plan) (Build, error) {
+	var traceparent string
+	if tracing.Configured() {
+		traceparent = tracing.NewSpanContext().Traceparent()
+	}
+
 	return &execBuild{
 		teamName:     build.TeamName(),
 		teamID:       build.TeamID(),
@@ -60,13 +70,15 @@ func (engine *execEngine) CreateBuild(logger lager.Logger, build dbng.Build, pla
 		jobName:      build.JobName(),
 		buildID:      build.ID(),
 		buildName:    build.Name(),
+		priority:     build.Priority(),
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
 		factory:  engine.factory,
 		delegate: engine.delegateFactory.Delegate(build),
 		metadata: execMetadata{
-			Plan: plan,
+			Plan:        plan,
+			Traceparent: traceparent,
 		},
 
 		releaseCh: engine.releaseCh,
@@ -91,6 +103,7 @@ func (engine *execEngine) LookupBuild(logger lager.Logger, build dbng.Build) (Bu
 		jobName:      build.JobName(),
 		buildID:      build.ID(),
 		buildName:    build.Name(),
+		priority:     build.Priority(),
 
 		stepMetadata: buildMetadata(build, engine.externalURL),
 
@@ -128,6 +141,7 @@ type execBuild struct {
 	jobName      string
 	buildID      int
 	buildName    string
+	priority     int
 
 	stepMetadata StepMetadata
 
@@ -138,6 +152,8 @@ type execBuild struct {
 	releaseCh chan struct{}
 
 	metadata execMetadata
+
+	trace tracing.SpanContext
 }
 
 func (build *execBuild) Metadata() string {
@@ -162,6 +178,18 @@ func (build *execBuild) Abort(lager.Logger) error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	trace, _ := tracing.ParseTraceparent(build.metadata.Traceparent)
+
+	span := tracing.ResumeSpan("build", trace, map[string]string{
+		"team":     build.teamName,
+		"pipeline": build.pipelineName,
+		"job":      build.jobName,
+		"build":    build.buildName,
+		"build_id": strconv.Itoa(build.buildID),
+	})
+
+	build.trace = span.Context()
+
 	stepFactory := build.buildStepFactory(logger, build.metadata.Plan)
 	source := stepFactory.Using(&exec.NoopStep{}, worker.NewArtifactRepository())
 
@@ -176,6 +204,11 @@ func (build *execBuild) Resume(logger lager.Logger) {
 		select {
 		case <-build.releaseCh:
 			logger.Info("releasing")
+
+			// the ATC resuming the build records the rest of it in its own span
+			span.SetAttribute("released", "true")
+			span.End()
+
 			return
 		case err := <-exited:
 			if aborted {
@@ -186,6 +219,12 @@ func (build *execBuild) Resume(logger lager.Logger) {
 			}
 
 			build.delegate.Finish(logger.Session("finish"), err, succeeded, aborted)
+
+			span.RecordError(err)
+			span.SetAttribute("succeeded", strconv.FormatBool(bool(succeeded)))
+			span.SetAttribute("aborted", strconv.FormatBool(aborted))
+			span.End()
+
 			return
 
 		case sig := <-build.signals:
@@ -228,19 +267,23 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 	}
 
 	if plan.Task != nil {
-		return build.buildTaskStep(logger, plan)
+		return build.traced("task", plan.Task.Name, build.buildTaskStep(logger, plan))
 	}
 
 	if plan.Get != nil {
-		return build.buildGetStep(logger, plan)
+		return build.traced("get", plan.Get.Name, build.buildGetStep(logger, plan))
 	}
 
 	if plan.Put != nil {
-		return build.buildPutStep(logger, plan)
+		return build.traced("put", plan.Put.Name, build.buildPutStep(logger, plan))
 	}
 
 	if plan.DependentGet != nil {
-		return build.buildDependentGetStep(logger, plan)
+		return build.traced("get", plan.DependentGet.Name, build.buildDependentGetStep(logger, plan))
+	}
+
+	if plan.GetArtifact != nil {
+		return build.traced("get-artifact", plan.GetArtifact.Name, build.buildGetArtifactStep(logger, plan))
 	}
 
 	if plan.Retry != nil {
@@ -250,6 +293,18 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan) exe
 	return exec.Identity{}
 }
 
+func (build *execBuild) traced(stepType string, stepName string, factory exec.StepFactory) exec.StepFactory {
+	return tracedStepFactory{
+		factory: factory,
+		name:    stepType,
+		parent:  build.trace,
+		attributes: map[string]string{
+			"name":     stepName,
+			"build_id": strconv.Itoa(build.buildID),
+		},
+	}
+}
+
 func (build *execBuild) workerMetadata(
 	containerType dbng.ContainerType,
 	stepName string,
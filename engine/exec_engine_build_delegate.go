@@ -167,6 +167,35 @@ func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	}
 }
 
+func (delegate *delegate) saveCacheHit(logger lager.Logger, key string, buildID int, origin event.Origin) {
+	err := delegate.build.SaveEvent(event.TaskCacheHit{
+		Time:    time.Now().Unix(),
+		Key:     key,
+		BuildID: buildID,
+		Origin:  origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-cache-hit-event", err)
+	}
+}
+
+func (delegate *delegate) saveMetadata(logger lager.Logger, metadata map[string]string, origin event.Origin) {
+	err := delegate.build.SaveMetadata(metadata)
+	if err != nil {
+		logger.Error("failed-to-save-build-metadata", err)
+		return
+	}
+
+	err = delegate.build.SaveEvent(event.SetBuildMetadata{
+		Time:     time.Now().Unix(),
+		Metadata: metadata,
+		Origin:   origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-build-metadata-event", err)
+	}
+}
+
 func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
 	err := delegate.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(status),
@@ -418,6 +447,32 @@ func (execution *executionDelegate) Started() {
 	execution.logger.Info("started")
 }
 
+func (execution *executionDelegate) CacheHit(key string, buildID int) {
+	execution.delegate.saveCacheHit(execution.logger, key, buildID, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("cache-hit", lager.Data{"key": key, "build-id": buildID})
+}
+
+func (execution *executionDelegate) MetadataSet(metadata map[string]string) {
+	execution.delegate.saveMetadata(execution.logger, metadata, event.Origin{
+		ID: execution.id,
+	})
+
+	execution.logger.Info("metadata-set", lager.Data{"keys": len(metadata)})
+}
+
+func (execution *executionDelegate) WaitingForWorkerCapacity(waiting bool) {
+	err := execution.delegate.build.SetWaitingForWorkerCapacity(waiting)
+	if err != nil {
+		execution.logger.Error("failed-to-save-waiting-for-worker-capacity", err)
+		return
+	}
+
+	execution.logger.Info("waiting-for-worker-capacity", lager.Data{"waiting": waiting})
+}
+
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 	execution.delegate.saveFinish(execution.logger, status, event.Origin{
 		ID: execution.id,
@@ -537,6 +537,50 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("MetadataSet", func() {
+			JustBeforeEach(func() {
+				executionDelegate.MetadataSet(map[string]string{"version": "1.2.3"})
+			})
+
+			It("saves the metadata to the build", func() {
+				Expect(fakeBuild.SaveMetadataCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveMetadataArgsForCall(0)).To(Equal(map[string]string{"version": "1.2.3"}))
+			})
+
+			It("saves a set-build-metadata event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+
+				savedEvent := fakeBuild.SaveEventArgsForCall(0)
+				Expect(savedEvent).To(BeAssignableToTypeOf(event.SetBuildMetadata{}))
+				Expect(savedEvent.(event.SetBuildMetadata).Time).To(BeNumerically("~", time.Now().Unix(), 1))
+				Expect(savedEvent.(event.SetBuildMetadata).Metadata).To(Equal(map[string]string{"version": "1.2.3"}))
+				Expect(savedEvent.(event.SetBuildMetadata).Origin).To(Equal(event.Origin{
+					ID: originID,
+				}))
+			})
+
+			Context("when saving the metadata fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveMetadataReturns(errors.New("nope"))
+				})
+
+				It("does not save an event", func() {
+					Expect(fakeBuild.SaveEventCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Describe("WaitingForWorkerCapacity", func() {
+			It("records on the build whether it is waiting", func() {
+				executionDelegate.WaitingForWorkerCapacity(true)
+				executionDelegate.WaitingForWorkerCapacity(false)
+
+				Expect(fakeBuild.SetWaitingForWorkerCapacityCallCount()).To(Equal(2))
+				Expect(fakeBuild.SetWaitingForWorkerCapacityArgsForCall(0)).To(BeTrue())
+				Expect(fakeBuild.SetWaitingForWorkerCapacityArgsForCall(1)).To(BeFalse())
+			})
+		})
+
 		Describe("Finished", func() {
 			var exitStatus exec.ExitStatus
 
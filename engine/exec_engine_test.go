@@ -1,6 +1,8 @@
 package engine_test
 
 import (
+	"encoding/json"
+
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/atc/event"
 	"github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 
 	. "github.com/onsi/ginkgo"
@@ -430,7 +433,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(teamID).To(Equal(expectedTeamID))
 				Expect(buildID).To(Equal(expectedBuildID))
@@ -452,7 +455,7 @@ var _ = Describe("ExecEngine", func() {
 				Expect(tags).To(Equal(atc.Tags{"some", "task", "tags"}))
 				Expect(configSource).To(Equal(exec.ValidatingConfigSource{exec.FileConfigSource{"some-config-path"}}))
 
-				logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(teamID).To(Equal(expectedTeamID))
 				Expect(buildID).To(Equal(expectedBuildID))
@@ -519,13 +522,13 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(workerMetadata.Attempt).To(Equal("1"))
-				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(workerMetadata.Attempt).To(Equal("1"))
-				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(workerMetadata.Attempt).To(Equal("1"))
-				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, _, workerMetadata, _, _, _, _, _, _, _, _, _, _, _, _, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(workerMetadata.Attempt).To(Equal("1"))
 			})
 		})
@@ -584,6 +587,43 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains artifact inputs", func() {
+				BeforeEach(func() {
+					fakeFactory.GetArtifactReturns(inputStepFactory)
+
+					plan = planFactory.NewPlan(atc.GetArtifactPlan{
+						Name:    "some-binary",
+						Job:     "some-upstream-job",
+						BuildID: 42,
+					})
+				})
+
+				It("constructs artifact inputs correctly", func() {
+					var err error
+					build, err := execEngine.CreateBuild(logger, dbBuild, plan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.GetArtifactCallCount()).To(Equal(1))
+
+					logger, buildID, sourceName, jobName, delegate := fakeFactory.GetArtifactArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(buildID).To(Equal(42))
+					Expect(sourceName).To(Equal(worker.ArtifactName("some-binary")))
+					Expect(jobName).To(Equal("some-upstream-job"))
+					Expect(delegate).To(Equal(fakeInputDelegate))
+
+					_, getPlan, originID := fakeDelegate.InputDelegateArgsForCall(0)
+					Expect(getPlan).To(Equal(atc.GetPlan{
+						Type:     "artifact",
+						Name:     "some-binary",
+						Resource: "artifact:some-binary",
+						Version:  atc.Version{"build": "42"},
+					}))
+					Expect(originID).To(Equal(event.OriginID(plan.ID)))
+				})
+			})
+
 			Context("that contains tasks", func() {
 				var (
 					inputMapping  map[string]string
@@ -615,7 +655,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-					logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, actualInputMapping, actualOutputMapping, _, _ := fakeFactory.TaskArgsForCall(0)
+					logger, teamID, buildID, planID, sourceName, workerMetadata, delegate, privileged, tags, configSource, _, actualInputMapping, actualOutputMapping, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(teamID).To(Equal(expectedTeamID))
 					Expect(buildID).To(Equal(expectedBuildID))
@@ -654,11 +694,71 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _ := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, _, _, _, _, actualImageArtifactName, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						Expect(actualImageArtifactName).To(Equal("some-image-artifact-name"))
 					})
 				})
 
+				Context("when the plan names outputs as artifacts", func() {
+					BeforeEach(func() {
+						taskPlan.Artifacts = []string{"some-reports"}
+					})
+
+					It("constructs the task with the artifacts", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, actualArtifacts, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						Expect(actualArtifacts).To(Equal([]string{"some-reports"}))
+					})
+				})
+
+				Context("when the plan declares test reports", func() {
+					BeforeEach(func() {
+						taskPlan.TestReports = &atc.TestReportsConfig{
+							Output: "some-reports",
+							Glob:   "*.xml",
+						}
+					})
+
+					It("constructs the task with the test reports", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, _, actualTestReports, _, _ := fakeFactory.TaskArgsForCall(0)
+						Expect(actualTestReports).To(Equal(&atc.TestReportsConfig{
+							Output: "some-reports",
+							Glob:   "*.xml",
+						}))
+					})
+				})
+
+				Context("when the build has a priority", func() {
+					BeforeEach(func() {
+						dbBuild.PriorityReturns(10)
+					})
+
+					It("constructs the task with the priority", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, _, _, _, _, _, _, _, _, _, _, _, actualPriority, _ := fakeFactory.TaskArgsForCall(0)
+						Expect(actualPriority).To(Equal(10))
+					})
+				})
+
 				Context("when the plan contains params and config path", func() {
 					BeforeEach(func() {
 						taskPlan.Params = map[string]interface{}{
@@ -674,7 +774,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						vcs, ok := configSource.(exec.ValidatingConfigSource)
 						Expect(ok).To(BeTrue())
 						_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -701,7 +801,7 @@ var _ = Describe("ExecEngine", func() {
 						build.Resume(logger)
 						Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-						_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
+						_, _, _, _, _, _, _, _, _, configSource, _, _, _, _, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 						vcs, ok := configSource.(exec.ValidatingConfigSource)
 						Expect(ok).To(BeTrue())
 						_, ok = vcs.ConfigSource.(exec.MergedConfigSource)
@@ -869,6 +969,56 @@ var _ = Describe("ExecEngine", func() {
 		})
 	})
 
+	Describe("Metadata", func() {
+		var dbBuild *dbngfakes.FakeBuild
+
+		BeforeEach(func() {
+			dbBuild = new(dbngfakes.FakeBuild)
+		})
+
+		It("does not persist a span context when tracing is not configured", func() {
+			build, err := execEngine.CreateBuild(logger, dbBuild, atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.Metadata()).NotTo(ContainSubstring("Traceparent"))
+		})
+
+		Context("when tracing is configured", func() {
+			BeforeEach(func() {
+				err := tracing.Initialize(logger, tracing.Config{
+					OTLPEndpoint: "http://127.0.0.1:4318",
+					BatchSize:    1000,
+				}, "")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(tracing.Initialize(logger, tracing.Config{}, "")).To(Succeed())
+			})
+
+			It("persists the build's span context so that it survives the build being resumed", func() {
+				build, err := execEngine.CreateBuild(logger, dbBuild, atc.Plan{})
+				Expect(err).NotTo(HaveOccurred())
+
+				var metadata struct {
+					Traceparent string
+				}
+
+				err = json.Unmarshal([]byte(build.Metadata()), &metadata)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, ok := tracing.ParseTraceparent(metadata.Traceparent)
+				Expect(ok).To(BeTrue())
+
+				dbBuild.EngineMetadataReturns(build.Metadata())
+
+				lookedUp, err := execEngine.LookupBuild(logger, dbBuild)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(lookedUp.Metadata()).To(Equal(build.Metadata()))
+			})
+		})
+	})
+
 	Describe("LookupBuild", func() {
 		var dbBuild *dbngfakes.FakeBuild
 
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/concourse/atc/exec"
+	"github.com/concourse/atc/tracing"
+	"github.com/concourse/atc/worker"
+)
+
+// tracedStepFactory records a span for each run of the steps it constructs,
+// as a child of the build's span.
+type tracedStepFactory struct {
+	factory    exec.StepFactory
+	name       string
+	parent     tracing.SpanContext
+	attributes map[string]string
+}
+
+func (factory tracedStepFactory) Using(prev exec.Step, repo *worker.ArtifactRepository) exec.Step {
+	return &tracedStep{
+		Step:    factory.factory.Using(prev, repo),
+		factory: factory,
+	}
+}
+
+type tracedStep struct {
+	exec.Step
+
+	factory tracedStepFactory
+}
+
+func (step *tracedStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	span := tracing.StartSpan(step.factory.name, step.factory.parent, step.factory.attributes)
+	defer span.End()
+
+	if traced, ok := step.Step.(exec.TracedStep); ok {
+		traced.SetTraceContext(span.Context())
+	}
+
+	err := step.Step.Run(signals, ready)
+	span.RecordError(err)
+
+	var succeeded exec.Success
+	if step.Step.Result(&succeeded) {
+		span.SetAttribute("succeeded", strconv.FormatBool(bool(succeeded)))
+	}
+
+	return err
+}
@@ -0,0 +1,13 @@
+package errorreporting_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestErrorReporting(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Error Reporting Suite")
+}
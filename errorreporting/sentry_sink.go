@@ -0,0 +1,467 @@
+package errorreporting
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+)
+
+// SentryQueueSize is how many errors are queued to be reported before further
+// errors are dropped.
+const SentryQueueSize = 100
+
+// maxDedupeKeys bounds how many distinct errors are remembered for
+// de-duplication.
+const maxDedupeKeys = 1000
+
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	ServerName  string
+
+	// RateLimit is the maximum number of errors reported per minute. 0 means
+	// unlimited.
+	RateLimit int
+
+	// DedupeInterval is how long after an error is reported that the same
+	// error is not reported again.
+	DedupeInterval time.Duration
+}
+
+// SentrySink reports errors logged through lager to a Sentry-compatible
+// endpoint. Errors are reported in the background; errors which can not be
+// reported are dropped, as logging them would feed back into the sink.
+type SentrySink struct {
+	storeURL string
+	auth     string
+
+	environment string
+	serverName  string
+
+	rateLimit      int
+	dedupeInterval time.Duration
+
+	clock  clock.Clock
+	client *http.Client
+	events chan sentryEvent
+
+	lock        sync.Mutex
+	windowStart time.Time
+	windowCount int
+	reported    map[string]time.Time
+	suppressed  map[string]int
+	pausedUntil time.Time
+}
+
+func NewSentrySink(config SentryConfig, clock clock.Clock) (*SentrySink, error) {
+	storeURL, auth, err := parseDSN(config.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &SentrySink{
+		storeURL: storeURL,
+		auth:     auth,
+
+		environment: config.Environment,
+		serverName:  config.ServerName,
+
+		rateLimit:      config.RateLimit,
+		dedupeInterval: config.DedupeInterval,
+
+		clock:  clock,
+		client: &http.Client{Timeout: 10 * time.Second},
+		events: make(chan sentryEvent, SentryQueueSize),
+
+		reported:   map[string]time.Time{},
+		suppressed: map[string]int{},
+	}
+
+	go sink.send()
+
+	return sink, nil
+}
+
+// parseDSN determines the store endpoint and auth header for a DSN of the
+// form https://<key>[:<secret>]@<host>[/<path>]/<project>.
+func parseDSN(dsn string) (string, string, error) {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" || parsed.User == nil || parsed.User.Username() == "" {
+		return "", "", errors.New("DSN must be of the form https://<key>@<host>/<project>")
+	}
+
+	path := strings.TrimSuffix(parsed.Path, "/")
+
+	slash := strings.LastIndex(path, "/")
+	if slash == -1 || slash == len(path)-1 {
+		return "", "", errors.New("DSN is missing a project ID")
+	}
+
+	prefix, project := path[:slash], path[slash+1:]
+
+	storeURL := fmt.Sprintf("%s://%s%s/api/%s/store/", parsed.Scheme, parsed.Host, prefix, project)
+
+	auth := "Sentry sentry_version=7, sentry_client=concourse-atc/1.0, sentry_key=" + parsed.User.Username()
+	if secret, ok := parsed.User.Password(); ok {
+		auth += ", sentry_secret=" + secret
+	}
+
+	return storeURL, auth, nil
+}
+
+func (sink *SentrySink) Log(log lager.LogFormat) {
+	if log.LogLevel < lager.ERROR {
+		return
+	}
+
+	errMessage, _ := log.Data["error"].(string)
+
+	key := log.Message + "\x00" + errMessage
+
+	suppressed, ok := sink.admit(key)
+	if !ok {
+		return
+	}
+
+	event := sink.event(log, errMessage, stackFrames())
+
+	if suppressed > 0 {
+		event.Extra["suppressed_duplicates"] = suppressed
+	}
+
+	// lager panics after logging a fatal error, so it is reported before
+	// returning
+	if log.LogLevel == lager.FATAL {
+		sink.post(event)
+		return
+	}
+
+	select {
+	case sink.events <- event:
+	default:
+	}
+}
+
+// admit decides whether the error with the given key is reported, returning
+// how many times it was suppressed as a duplicate since it was last reported.
+func (sink *SentrySink) admit(key string) (int, bool) {
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+
+	now := sink.clock.Now()
+
+	if now.Before(sink.pausedUntil) {
+		return 0, false
+	}
+
+	if sink.dedupeInterval > 0 {
+		last, found := sink.reported[key]
+		if found && now.Sub(last) < sink.dedupeInterval {
+			sink.suppressed[key]++
+			return 0, false
+		}
+	}
+
+	if sink.rateLimit > 0 {
+		if now.Sub(sink.windowStart) >= time.Minute {
+			sink.windowStart = now
+			sink.windowCount = 0
+		}
+
+		if sink.windowCount >= sink.rateLimit {
+			return 0, false
+		}
+
+		sink.windowCount++
+	}
+
+	if sink.dedupeInterval > 0 {
+		if len(sink.reported) >= maxDedupeKeys {
+			sink.forgetExpired(now)
+		}
+
+		sink.reported[key] = now
+	}
+
+	suppressed := sink.suppressed[key]
+	delete(sink.suppressed, key)
+
+	return suppressed, true
+}
+
+func (sink *SentrySink) forgetExpired(now time.Time) {
+	for key, last := range sink.reported {
+		if now.Sub(last) >= sink.dedupeInterval {
+			delete(sink.reported, key)
+			delete(sink.suppressed, key)
+		}
+	}
+}
+
+func (sink *SentrySink) event(log lager.LogFormat, errMessage string, frames []sentryFrame) sentryEvent {
+	segments := strings.Split(log.Message, ".")
+	action := segments[len(segments)-1]
+
+	event := sentryEvent{
+		EventID:     eventID(),
+		Timestamp:   sink.clock.Now().UTC().Format("2006-01-02T15:04:05"),
+		Level:       "error",
+		Logger:      strings.Join(segments[:len(segments)-1], "."),
+		Platform:    "go",
+		Message:     log.Message,
+		ServerName:  sink.serverName,
+		Environment: sink.environment,
+		Tags:        map[string]string{},
+		Extra:       map[string]interface{}{},
+		Fingerprint: []string{log.Message, errMessage},
+	}
+
+	if log.LogLevel == lager.FATAL {
+		event.Level = "fatal"
+	}
+
+	if errMessage != "" {
+		event.Message += ": " + errMessage
+	}
+
+	if len(segments) > 1 {
+		event.Tags["component"] = segments[1]
+	}
+
+	for key, value := range log.Data {
+		if key == "error" {
+			continue
+		}
+
+		tag, found := contextTags[key]
+		if found {
+			event.Tags[tag] = fmt.Sprintf("%v", value)
+		} else {
+			event.Extra[key] = scrubExtra(key, value)
+		}
+	}
+
+	event.Exception = &sentryExceptions{
+		Values: []sentryException{
+			{
+				Type:       action,
+				Value:      errMessage,
+				Stacktrace: &sentryStacktrace{Frames: frames},
+			},
+		},
+	}
+
+	return event
+}
+
+// contextTags maps the log data keys naming the build, pipeline, etc. an
+// error occurred for to the tags they are reported under.
+var contextTags = map[string]string{
+	"team":          "team",
+	"team-name":     "team",
+	"pipeline":      "pipeline",
+	"pipeline-name": "pipeline",
+	"job":           "job",
+	"job-name":      "job",
+	"build":         "build",
+	"build-id":      "build_id",
+	"build_id":      "build_id",
+	"resource":      "resource",
+	"resource-name": "resource",
+	"worker":        "worker",
+	"worker-name":   "worker",
+}
+
+// filteredValue replaces log data which may carry credentials.
+const filteredValue = "[FILTERED]"
+
+// sensitiveKeys are the substrings of log data keys whose values are never
+// reported, as they may be or contain credentials.
+var sensitiveKeys = []string{
+	"auth",
+	"cert",
+	"config",
+	"cookie",
+	"credential",
+	"env",
+	"key",
+	"params",
+	"passw",
+	"private",
+	"secret",
+	"source",
+	"token",
+}
+
+// scrubExtra returns the log data value to report under the given key. Only
+// scalar values are reported, as anything structured (a resource's source, a
+// task's params, etc.) may carry credentials under keys that don't give it
+// away, and any credentials in URLs are removed.
+func scrubExtra(key string, value interface{}) interface{} {
+	lowerKey := strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(lowerKey, sensitive) {
+			return filteredValue
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return scrubURL(v)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return v
+	default:
+		return filteredValue
+	}
+}
+
+// scrubURL removes the user info from the string if it is a URL with any.
+func scrubURL(value string) string {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.User == nil {
+		return value
+	}
+
+	parsed.User = nil
+	return parsed.String()
+}
+
+func (sink *SentrySink) send() {
+	for event := range sink.events {
+		sink.post(event)
+	}
+}
+
+func (sink *SentrySink) post(event sentryEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	request, err := http.NewRequest("POST", sink.storeURL, bytes.NewReader(payload))
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Sentry-Auth", sink.auth+", sentry_timestamp="+strconv.FormatInt(sink.clock.Now().Unix(), 10))
+
+	response, err := sink.client.Do(request)
+	if err != nil {
+		return
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		sink.pause(response.Header.Get("Retry-After"))
+	}
+}
+
+// pause stops errors being reported for as long as the endpoint asked.
+func (sink *SentrySink) pause(retryAfter string) {
+	seconds, err := strconv.Atoi(retryAfter)
+	if err != nil || seconds <= 0 {
+		seconds = 60
+	}
+
+	sink.lock.Lock()
+	sink.pausedUntil = sink.clock.Now().Add(time.Duration(seconds) * time.Second)
+	sink.lock.Unlock()
+}
+
+// stackFrames returns the stack of the goroutine that logged the error,
+// outermost call first, leaving out the frames within the runtime, lager and
+// the sink.
+func stackFrames() []sentryFrame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(1, pcs)
+
+	frames := runtime.CallersFrames(pcs[:n])
+
+	stack := []sentryFrame{}
+	for {
+		frame, more := frames.Next()
+
+		if !strings.HasPrefix(frame.Function, "runtime.") &&
+			!strings.HasPrefix(frame.Function, "code.cloudfoundry.org/lager") &&
+			!strings.HasPrefix(frame.Function, "github.com/concourse/atc/errorreporting.") {
+			stack = append([]sentryFrame{{
+				Function: frame.Function,
+				Filename: frame.File,
+				LineNo:   frame.Line,
+				InApp:    strings.HasPrefix(frame.Function, "github.com/concourse/"),
+			}}, stack...)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+func eventID() string {
+	id := make([]byte, 16)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		panic("failed to generate event id: " + err.Error())
+	}
+
+	return hex.EncodeToString(id)
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger"`
+	Platform    string                 `json:"platform"`
+	Message     string                 `json:"message"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Fingerprint []string               `json:"fingerprint"`
+	Exception   *sentryExceptions      `json:"exception,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string            `json:"type"`
+	Value      string            `json:"value"`
+	Stacktrace *sentryStacktrace `json:"stacktrace,omitempty"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Filename string `json:"filename"`
+	LineNo   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
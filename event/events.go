@@ -19,6 +19,25 @@ type FinishTask struct {
 func (FinishTask) EventType() atc.EventType  { return EventTypeFinishTask }
 func (FinishTask) Version() atc.EventVersion { return "4.0" }
 
+type TaskCacheHit struct {
+	Time    int64  `json:"time"`
+	Key     string `json:"key"`
+	BuildID int    `json:"build_id,omitempty"`
+	Origin  Origin `json:"origin"`
+}
+
+func (TaskCacheHit) EventType() atc.EventType  { return EventTypeTaskCacheHit }
+func (TaskCacheHit) Version() atc.EventVersion { return "1.0" }
+
+type SetBuildMetadata struct {
+	Time     int64             `json:"time"`
+	Metadata map[string]string `json:"metadata"`
+	Origin   Origin            `json:"origin"`
+}
+
+func (SetBuildMetadata) EventType() atc.EventType  { return EventTypeSetBuildMetadata }
+func (SetBuildMetadata) Version() atc.EventVersion { return "1.0" }
+
 type InitializeTask struct {
 	TaskConfig TaskConfig `json:"config"`
 	Origin     Origin     `json:"origin"`
@@ -36,6 +36,8 @@ func init() {
 	registerEvent(InitializeTask{})
 	registerEvent(StartTask{})
 	registerEvent(FinishTask{})
+	registerEvent(TaskCacheHit{})
+	registerEvent(SetBuildMetadata{})
 	registerEvent(InitializeGet{})
 	registerEvent(FinishGet{})
 	registerEvent(InitializePut{})
@@ -18,6 +18,12 @@ const (
 	// task execution finished
 	EventTypeFinishTask atc.EventType = "finish-task"
 
+	// task skipped; outputs reused from a previous run with identical inputs
+	EventTypeTaskCacheHit atc.EventType = "task-cache-hit"
+
+	// task wrote key/value metadata to attach to the build
+	EventTypeSetBuildMetadata atc.EventType = "set-build-metadata"
+
 	// get step initializing
 	EventTypeInitializeGet atc.EventType = "initialize-get"
 
@@ -1,6 +1,10 @@
 package exec
 
-import "os"
+import (
+	"os"
+
+	"github.com/concourse/atc/tracing"
+)
 
 type errorReporter struct {
 	Step
@@ -16,3 +20,9 @@ func (reporter errorReporter) Run(signals <-chan os.Signal, ready chan<- struct{
 
 	return err
 }
+
+func (reporter errorReporter) SetTraceContext(trace tracing.SpanContext) {
+	if traced, ok := reporter.Step.(TracedStep); ok {
+		traced.SetTraceContext(trace)
+	}
+}
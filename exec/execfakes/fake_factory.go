@@ -79,7 +79,22 @@ type FakeFactory struct {
 	dependentGetReturnsOnCall map[int]struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(lager.Logger, int, int, atc.PlanID, worker.ArtifactName, dbng.ContainerMetadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock) exec.StepFactory
+	GetArtifactStub        func(arg1 lager.Logger, arg2 int, arg3 worker.ArtifactName, arg4 string, arg5 exec.GetDelegate) exec.StepFactory
+	getArtifactMutex       sync.RWMutex
+	getArtifactArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 worker.ArtifactName
+		arg4 string
+		arg5 exec.GetDelegate
+	}
+	getArtifactReturns struct {
+		result1 exec.StepFactory
+	}
+	getArtifactReturnsOnCall map[int]struct {
+		result1 exec.StepFactory
+	}
+	TaskStub        func(lager.Logger, int, int, atc.PlanID, worker.ArtifactName, dbng.ContainerMetadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, exec.TaskCacheConfig, []string, *atc.TestReportsConfig, int, clock.Clock) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1  lager.Logger
@@ -96,7 +111,11 @@ type FakeFactory struct {
 		arg12 map[string]string
 		arg13 map[string]string
 		arg14 string
-		arg15 clock.Clock
+		arg15 exec.TaskCacheConfig
+		arg16 []string
+		arg17 *atc.TestReportsConfig
+		arg18 int
+		arg19 clock.Clock
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -285,7 +304,64 @@ func (fake *FakeFactory) DependentGetReturnsOnCall(i int, result1 exec.StepFacto
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 int, arg3 int, arg4 atc.PlanID, arg5 worker.ArtifactName, arg6 dbng.ContainerMetadata, arg7 exec.TaskDelegate, arg8 exec.Privileged, arg9 atc.Tags, arg10 exec.TaskConfigSource, arg11 atc.VersionedResourceTypes, arg12 map[string]string, arg13 map[string]string, arg14 string, arg15 clock.Clock) exec.StepFactory {
+func (fake *FakeFactory) GetArtifact(arg1 lager.Logger, arg2 int, arg3 worker.ArtifactName, arg4 string, arg5 exec.GetDelegate) exec.StepFactory {
+	fake.getArtifactMutex.Lock()
+	ret, specificReturn := fake.getArtifactReturnsOnCall[len(fake.getArtifactArgsForCall)]
+	fake.getArtifactArgsForCall = append(fake.getArtifactArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 worker.ArtifactName
+		arg4 string
+		arg5 exec.GetDelegate
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("GetArtifact", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getArtifactMutex.Unlock()
+	if fake.GetArtifactStub != nil {
+		return fake.GetArtifactStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.getArtifactReturns.result1
+}
+
+func (fake *FakeFactory) GetArtifactCallCount() int {
+	fake.getArtifactMutex.RLock()
+	defer fake.getArtifactMutex.RUnlock()
+	return len(fake.getArtifactArgsForCall)
+}
+
+func (fake *FakeFactory) GetArtifactArgsForCall(i int) (lager.Logger, int, worker.ArtifactName, string, exec.GetDelegate) {
+	fake.getArtifactMutex.RLock()
+	defer fake.getArtifactMutex.RUnlock()
+	return fake.getArtifactArgsForCall[i].arg1, fake.getArtifactArgsForCall[i].arg2, fake.getArtifactArgsForCall[i].arg3, fake.getArtifactArgsForCall[i].arg4, fake.getArtifactArgsForCall[i].arg5
+}
+
+func (fake *FakeFactory) GetArtifactReturns(result1 exec.StepFactory) {
+	fake.GetArtifactStub = nil
+	fake.getArtifactReturns = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) GetArtifactReturnsOnCall(i int, result1 exec.StepFactory) {
+	fake.GetArtifactStub = nil
+	if fake.getArtifactReturnsOnCall == nil {
+		fake.getArtifactReturnsOnCall = make(map[int]struct {
+			result1 exec.StepFactory
+		})
+	}
+	fake.getArtifactReturnsOnCall[i] = struct {
+		result1 exec.StepFactory
+	}{result1}
+}
+
+func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 int, arg3 int, arg4 atc.PlanID, arg5 worker.ArtifactName, arg6 dbng.ContainerMetadata, arg7 exec.TaskDelegate, arg8 exec.Privileged, arg9 atc.Tags, arg10 exec.TaskConfigSource, arg11 atc.VersionedResourceTypes, arg12 map[string]string, arg13 map[string]string, arg14 string, arg15 exec.TaskCacheConfig, arg16 []string, arg17 *atc.TestReportsConfig, arg18 int, arg19 clock.Clock) exec.StepFactory {
+	var arg16Copy []string
+	if arg16 != nil {
+		arg16Copy = make([]string, len(arg16))
+		copy(arg16Copy, arg16)
+	}
 	fake.taskMutex.Lock()
 	ret, specificReturn := fake.taskReturnsOnCall[len(fake.taskArgsForCall)]
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
@@ -303,12 +379,16 @@ func (fake *FakeFactory) Task(arg1 lager.Logger, arg2 int, arg3 int, arg4 atc.Pl
 		arg12 map[string]string
 		arg13 map[string]string
 		arg14 string
-		arg15 clock.Clock
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15})
-	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15})
+		arg15 exec.TaskCacheConfig
+		arg16 []string
+		arg17 *atc.TestReportsConfig
+		arg18 int
+		arg19 clock.Clock
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16Copy, arg17, arg18, arg19})
+	fake.recordInvocation("Task", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16Copy, arg17, arg18, arg19})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11, arg12, arg13, arg14, arg15, arg16, arg17, arg18, arg19)
 	}
 	if specificReturn {
 		return ret.result1
@@ -322,10 +402,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, int, int, atc.PlanID, worker.ArtifactName, dbng.ContainerMetadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, clock.Clock) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (lager.Logger, int, int, atc.PlanID, worker.ArtifactName, dbng.ContainerMetadata, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, atc.VersionedResourceTypes, map[string]string, map[string]string, string, exec.TaskCacheConfig, []string, *atc.TestReportsConfig, int, clock.Clock) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8, fake.taskArgsForCall[i].arg9, fake.taskArgsForCall[i].arg10, fake.taskArgsForCall[i].arg11, fake.taskArgsForCall[i].arg12, fake.taskArgsForCall[i].arg13, fake.taskArgsForCall[i].arg14, fake.taskArgsForCall[i].arg15, fake.taskArgsForCall[i].arg16, fake.taskArgsForCall[i].arg17, fake.taskArgsForCall[i].arg18, fake.taskArgsForCall[i].arg19
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
@@ -356,6 +436,8 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.putMutex.RUnlock()
 	fake.dependentGetMutex.RLock()
 	defer fake.dependentGetMutex.RUnlock()
+	fake.getArtifactMutex.RLock()
+	defer fake.getArtifactMutex.RUnlock()
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -58,6 +58,22 @@ type FakeTaskDelegate struct {
 	stderrReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	CacheHitStub        func(key string, buildID int)
+	cacheHitMutex       sync.RWMutex
+	cacheHitArgsForCall []struct {
+		key     string
+		buildID int
+	}
+	MetadataSetStub        func(metadata map[string]string)
+	metadataSetMutex       sync.RWMutex
+	metadataSetArgsForCall []struct {
+		metadata map[string]string
+	}
+	WaitingForWorkerCapacityStub        func(waiting bool)
+	waitingForWorkerCapacityMutex       sync.RWMutex
+	waitingForWorkerCapacityArgsForCall []struct {
+		waiting bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -278,6 +294,79 @@ func (fake *FakeTaskDelegate) StderrReturnsOnCall(i int, result1 io.Writer) {
 	}{result1}
 }
 
+func (fake *FakeTaskDelegate) CacheHit(key string, buildID int) {
+	fake.cacheHitMutex.Lock()
+	fake.cacheHitArgsForCall = append(fake.cacheHitArgsForCall, struct {
+		key     string
+		buildID int
+	}{key, buildID})
+	fake.recordInvocation("CacheHit", []interface{}{key, buildID})
+	fake.cacheHitMutex.Unlock()
+	if fake.CacheHitStub != nil {
+		fake.CacheHitStub(key, buildID)
+	}
+}
+
+func (fake *FakeTaskDelegate) CacheHitCallCount() int {
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	return len(fake.cacheHitArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) CacheHitArgsForCall(i int) (string, int) {
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	return fake.cacheHitArgsForCall[i].key, fake.cacheHitArgsForCall[i].buildID
+}
+
+func (fake *FakeTaskDelegate) MetadataSet(metadata map[string]string) {
+	fake.metadataSetMutex.Lock()
+	fake.metadataSetArgsForCall = append(fake.metadataSetArgsForCall, struct {
+		metadata map[string]string
+	}{metadata})
+	fake.recordInvocation("MetadataSet", []interface{}{metadata})
+	fake.metadataSetMutex.Unlock()
+	if fake.MetadataSetStub != nil {
+		fake.MetadataSetStub(metadata)
+	}
+}
+
+func (fake *FakeTaskDelegate) MetadataSetCallCount() int {
+	fake.metadataSetMutex.RLock()
+	defer fake.metadataSetMutex.RUnlock()
+	return len(fake.metadataSetArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) MetadataSetArgsForCall(i int) map[string]string {
+	fake.metadataSetMutex.RLock()
+	defer fake.metadataSetMutex.RUnlock()
+	return fake.metadataSetArgsForCall[i].metadata
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCapacity(waiting bool) {
+	fake.waitingForWorkerCapacityMutex.Lock()
+	fake.waitingForWorkerCapacityArgsForCall = append(fake.waitingForWorkerCapacityArgsForCall, struct {
+		waiting bool
+	}{waiting})
+	fake.recordInvocation("WaitingForWorkerCapacity", []interface{}{waiting})
+	fake.waitingForWorkerCapacityMutex.Unlock()
+	if fake.WaitingForWorkerCapacityStub != nil {
+		fake.WaitingForWorkerCapacityStub(waiting)
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCapacityCallCount() int {
+	fake.waitingForWorkerCapacityMutex.RLock()
+	defer fake.waitingForWorkerCapacityMutex.RUnlock()
+	return len(fake.waitingForWorkerCapacityArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCapacityArgsForCall(i int) bool {
+	fake.waitingForWorkerCapacityMutex.RLock()
+	defer fake.waitingForWorkerCapacityMutex.RUnlock()
+	return fake.waitingForWorkerCapacityArgsForCall[i].waiting
+}
+
 func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -295,6 +384,12 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.stderrMutex.RLock()
 	defer fake.stderrMutex.RUnlock()
+	fake.cacheHitMutex.RLock()
+	defer fake.cacheHitMutex.RUnlock()
+	fake.metadataSetMutex.RLock()
+	defer fake.metadataSetMutex.RUnlock()
+	fake.waitingForWorkerCapacityMutex.RLock()
+	defer fake.waitingForWorkerCapacityMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -63,6 +63,15 @@ type Factory interface {
 		atc.VersionedResourceTypes,
 	) StepFactory
 
+	// GetArtifact constructs a GetArtifactStep factory.
+	GetArtifact(
+		lager.Logger,
+		int, // buildID
+		worker.ArtifactName,
+		string, // jobName
+		GetDelegate,
+	) StepFactory
+
 	// Task constructs a TaskStep factory.
 	Task(
 		lager.Logger,
@@ -79,6 +88,10 @@ type Factory interface {
 		map[string]string,
 		map[string]string,
 		string,
+		TaskCacheConfig,
+		[]string, // artifacts
+		*atc.TestReportsConfig,
+		int, // priority
 		clock.Clock,
 	) StepFactory
 }
@@ -104,6 +117,19 @@ type TaskDelegate interface {
 
 	Stdout() io.Writer
 	Stderr() io.Writer
+
+	// CacheHit is called instead of Started when the outputs of a previous
+	// run, identified by the cache key and build ID, are reused.
+	CacheHit(key string, buildID int)
+
+	// MetadataSet is called with the key/value pairs the task wrote to its
+	// build metadata file, to be attached to the build.
+	MetadataSet(metadata map[string]string)
+
+	// WaitingForWorkerCapacity is called with true when no worker has room
+	// for the task's container limits, and with false once one does or the
+	// step gives up waiting.
+	WaitingForWorkerCapacity(waiting bool)
 }
 
 // ResourceDelegate is used to record events related to a resource's runtime
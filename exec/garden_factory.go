@@ -9,16 +9,22 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
 
 type gardenFactory struct {
-	workerClient           worker.Client
-	resourceFetcher        resource.Fetcher
-	resourceFactory        resource.ResourceFactory
-	dbResourceCacheFactory dbng.ResourceCacheFactory
+	workerClient            worker.Client
+	resourceFetcher         resource.Fetcher
+	resourceFactory         resource.ResourceFactory
+	dbResourceCacheFactory  dbng.ResourceCacheFactory
+	dbTaskCacheFactory      dbng.TaskCacheFactory
+	dbArtifactFactory       dbng.BuildArtifactFactory
+	dbStoredArtifactFactory dbng.StoredArtifactFactory
+	artifactStore           blobstore.Store
+	dbTestResultFactory     dbng.TestResultFactory
 }
 
 func NewGardenFactory(
@@ -26,12 +32,22 @@ func NewGardenFactory(
 	resourceFetcher resource.Fetcher,
 	resourceFactory resource.ResourceFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
+	dbTaskCacheFactory dbng.TaskCacheFactory,
+	dbArtifactFactory dbng.BuildArtifactFactory,
+	dbStoredArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	dbTestResultFactory dbng.TestResultFactory,
 ) Factory {
 	return &gardenFactory{
-		workerClient:           workerClient,
-		resourceFetcher:        resourceFetcher,
-		resourceFactory:        resourceFactory,
-		dbResourceCacheFactory: dbResourceCacheFactory,
+		workerClient:            workerClient,
+		resourceFetcher:         resourceFetcher,
+		resourceFactory:         resourceFactory,
+		dbResourceCacheFactory:  dbResourceCacheFactory,
+		dbTaskCacheFactory:      dbTaskCacheFactory,
+		dbArtifactFactory:       dbArtifactFactory,
+		dbStoredArtifactFactory: dbStoredArtifactFactory,
+		artifactStore:           artifactStore,
+		dbTestResultFactory:     dbTestResultFactory,
 	}
 }
 
@@ -143,6 +159,24 @@ func (factory *gardenFactory) Put(
 	)
 }
 
+func (factory *gardenFactory) GetArtifact(
+	logger lager.Logger,
+	buildID int,
+	sourceName worker.ArtifactName,
+	jobName string,
+	delegate GetDelegate,
+) StepFactory {
+	return newGetArtifactStep(
+		logger,
+		buildID,
+		sourceName,
+		jobName,
+		delegate,
+		factory.workerClient,
+		factory.dbArtifactFactory,
+	)
+}
+
 func (factory *gardenFactory) Task(
 	logger lager.Logger,
 	teamID int,
@@ -158,6 +192,10 @@ func (factory *gardenFactory) Task(
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	cacheConfig TaskCacheConfig,
+	artifacts []string,
+	testReports *atc.TestReportsConfig,
+	priority int,
 	clock clock.Clock,
 ) StepFactory {
 	workingDirectory := factory.taskWorkingDirectory(sourceName)
@@ -173,11 +211,21 @@ func (factory *gardenFactory) Task(
 		privileged,
 		configSource,
 		factory.workerClient,
+		factory.resourceFactory,
 		workingDirectory,
 		resourceTypes,
 		inputMapping,
 		outputMapping,
 		imageArtifactName,
+		cacheConfig,
+		factory.dbTaskCacheFactory,
+		factory.dbArtifactFactory,
+		artifacts,
+		factory.dbStoredArtifactFactory,
+		factory.artifactStore,
+		testReports,
+		factory.dbTestResultFactory,
+		priority,
 		clock,
 	)
 }
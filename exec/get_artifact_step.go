@@ -0,0 +1,143 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker"
+)
+
+// ArtifactNotFoundError is returned when the upstream build that published
+// the version given to the step no longer has the artifact available.
+type ArtifactNotFoundError struct {
+	Name    string
+	JobName string
+}
+
+func (err ArtifactNotFoundError) Error() string {
+	return fmt.Sprintf(`artifact '%s' not found for job '%s'
+make sure the job has succeeded and one of its tasks publishes an output with that name`, err.Name, err.JobName)
+}
+
+// GetArtifactStep registers an output published by the upstream build whose
+// version of the artifact was chosen as the build's input.
+type GetArtifactStep struct {
+	logger          lager.Logger
+	buildID         int
+	sourceName      worker.ArtifactName
+	jobName         string
+	delegate        GetDelegate
+	workerClient    worker.Client
+	artifactFactory dbng.BuildArtifactFactory
+
+	repository *worker.ArtifactRepository
+
+	succeeded bool
+}
+
+func newGetArtifactStep(
+	logger lager.Logger,
+	buildID int,
+	sourceName worker.ArtifactName,
+	jobName string,
+	delegate GetDelegate,
+	workerClient worker.Client,
+	artifactFactory dbng.BuildArtifactFactory,
+) GetArtifactStep {
+	return GetArtifactStep{
+		logger:          logger,
+		buildID:         buildID,
+		sourceName:      sourceName,
+		jobName:         jobName,
+		delegate:        delegate,
+		workerClient:    workerClient,
+		artifactFactory: artifactFactory,
+	}
+}
+
+// Using finishes construction of the GetArtifactStep and returns a
+// *GetArtifactStep. If the *GetArtifactStep errors, its error is reported to
+// the delegate.
+func (step GetArtifactStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
+	step.repository = repo
+
+	return errorReporter{
+		Step:          &step,
+		ReportFailure: step.delegate.Failed,
+	}
+}
+
+// Run looks up the artifact's volume on its worker and registers it under the
+// step's SourceName. No container is created; later steps stream from or
+// mount the volume directly.
+func (step *GetArtifactStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	step.delegate.Initializing()
+
+	artifact, found, err := step.artifactFactory.FindBuildArtifact(step.buildID, string(step.sourceName))
+	if err != nil {
+		step.logger.Error("failed-to-find-artifact", err)
+		return err
+	}
+
+	if !found {
+		return ArtifactNotFoundError{
+			Name:    string(step.sourceName),
+			JobName: step.jobName,
+		}
+	}
+
+	volume, found, err := step.lookupVolume(artifact.Volume)
+	if err != nil {
+		step.logger.Error("failed-to-lookup-artifact-volume", err)
+		return err
+	}
+
+	if !found {
+		return ArtifactNotFoundError{
+			Name:    string(step.sourceName),
+			JobName: step.jobName,
+		}
+	}
+
+	step.repository.RegisterSource(step.sourceName, newVolumeSource(step.logger, volume))
+
+	step.logger.Debug("completing-get-artifact-step", lager.Data{"build-id": artifact.BuildID})
+	step.succeeded = true
+	step.delegate.Completed(ExitStatus(0), &VersionInfo{
+		Version: atc.ArtifactVersion(artifact.BuildID),
+	})
+
+	return nil
+}
+
+func (step *GetArtifactStep) lookupVolume(createdVolume dbng.CreatedVolume) (worker.Volume, bool, error) {
+	workers, err := step.workerClient.RunningWorkers(step.logger)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, w := range workers {
+		if w.Name() == createdVolume.Worker().Name() {
+			return w.LookupVolume(step.logger, createdVolume.Handle())
+		}
+	}
+
+	return nil, false, nil
+}
+
+// Result indicates Success as true if the artifact was found.
+//
+// All other types are ignored.
+func (step *GetArtifactStep) Result(x interface{}) bool {
+	switch v := x.(type) {
+	case *Success:
+		*v = Success(step.succeeded)
+		return true
+
+	default:
+		return false
+	}
+}
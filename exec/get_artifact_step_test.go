@@ -0,0 +1,201 @@
+package exec_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	. "github.com/concourse/atc/exec"
+	"github.com/concourse/atc/exec/execfakes"
+	"github.com/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("GetArtifact", func() {
+	var (
+		fakeWorkerClient      *workerfakes.FakeClient
+		fakeDBArtifactFactory *dbngfakes.FakeBuildArtifactFactory
+		getDelegate           *execfakes.FakeGetDelegate
+
+		inStep *execfakes.FakeStep
+		repo   *worker.ArtifactRepository
+
+		step    Step
+		process ifrit.Process
+
+		factory Factory
+
+		sourceName worker.ArtifactName = "some-binary"
+	)
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(workerfakes.FakeClient)
+		fakeDBArtifactFactory = new(dbngfakes.FakeBuildArtifactFactory)
+
+		factory = NewGardenFactory(
+			fakeWorkerClient,
+			new(resourcefakes.FakeFetcher),
+			new(resourcefakes.FakeResourceFactory),
+			new(dbngfakes.FakeResourceCacheFactory),
+			new(dbngfakes.FakeTaskCacheFactory),
+			fakeDBArtifactFactory,
+			new(dbngfakes.FakeStoredArtifactFactory),
+			new(blobstorefakes.FakeStore),
+			new(dbngfakes.FakeTestResultFactory),
+		)
+
+		getDelegate = new(execfakes.FakeGetDelegate)
+
+		inStep = new(execfakes.FakeStep)
+		repo = worker.NewArtifactRepository()
+	})
+
+	JustBeforeEach(func() {
+		step = factory.GetArtifact(
+			lagertest.NewTestLogger("test"),
+			42,
+			sourceName,
+			"some-upstream-job",
+			getDelegate,
+		).Using(inStep, repo)
+
+		process = ifrit.Invoke(step)
+	})
+
+	It("looks up the artifact published by the upstream build", func() {
+		<-process.Wait()
+
+		Expect(fakeDBArtifactFactory.FindBuildArtifactCallCount()).To(Equal(1))
+		buildID, name := fakeDBArtifactFactory.FindBuildArtifactArgsForCall(0)
+		Expect(buildID).To(Equal(42))
+		Expect(name).To(Equal("some-binary"))
+	})
+
+	Context("when the artifact is found", func() {
+		var fakeWorker *workerfakes.FakeWorker
+
+		BeforeEach(func() {
+			fakeDBWorker := new(dbngfakes.FakeWorker)
+			fakeDBWorker.NameReturns("some-worker")
+
+			fakeCreatedVolume := new(dbngfakes.FakeCreatedVolume)
+			fakeCreatedVolume.HandleReturns("some-handle")
+			fakeCreatedVolume.WorkerReturns(fakeDBWorker)
+
+			fakeDBArtifactFactory.FindBuildArtifactReturns(&dbng.BuildArtifact{
+				BuildID: 42,
+				Name:    "some-binary",
+				Volume:  fakeCreatedVolume,
+			}, true, nil)
+
+			fakeOtherWorker := new(workerfakes.FakeWorker)
+			fakeOtherWorker.NameReturns("some-other-worker")
+
+			fakeWorker = new(workerfakes.FakeWorker)
+			fakeWorker.NameReturns("some-worker")
+
+			fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeOtherWorker, fakeWorker}, nil)
+		})
+
+		Context("when the volume is still on its worker", func() {
+			BeforeEach(func() {
+				fakeVolume := new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-handle")
+				fakeWorker.LookupVolumeReturns(fakeVolume, true, nil)
+			})
+
+			It("looks up the volume on the worker that has it", func() {
+				Expect(<-process.Wait()).To(Succeed())
+
+				Expect(fakeWorker.LookupVolumeCallCount()).To(Equal(1))
+				_, handle := fakeWorker.LookupVolumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+			})
+
+			It("registers the volume as a source", func() {
+				Expect(<-process.Wait()).To(Succeed())
+
+				_, found := repo.SourceFor(sourceName)
+				Expect(found).To(BeTrue())
+			})
+
+			It("is successful", func() {
+				Expect(<-process.Wait()).To(Succeed())
+
+				var success Success
+				Expect(step.Result(&success)).To(BeTrue())
+				Expect(bool(success)).To(BeTrue())
+			})
+
+			It("completes via the delegate with the artifact's version", func() {
+				Expect(<-process.Wait()).To(Succeed())
+
+				Expect(getDelegate.InitializingCallCount()).To(Equal(1))
+				Expect(getDelegate.CompletedCallCount()).To(Equal(1))
+
+				status, versionInfo := getDelegate.CompletedArgsForCall(0)
+				Expect(status).To(Equal(ExitStatus(0)))
+				Expect(versionInfo).To(Equal(&VersionInfo{
+					Version: atc.Version{"build": "42"},
+				}))
+			})
+		})
+
+		Context("when the volume is no longer on its worker", func() {
+			BeforeEach(func() {
+				fakeWorker.LookupVolumeReturns(nil, false, nil)
+			})
+
+			It("fails with an error", func() {
+				Expect(<-process.Wait()).To(Equal(ArtifactNotFoundError{
+					Name:    "some-binary",
+					JobName: "some-upstream-job",
+				}))
+			})
+
+			It("does not register a source", func() {
+				<-process.Wait()
+
+				Expect(repo.AsMap()).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the artifact is not found", func() {
+		BeforeEach(func() {
+			fakeDBArtifactFactory.FindBuildArtifactReturns(nil, false, nil)
+		})
+
+		It("fails with an error", func() {
+			Expect(<-process.Wait()).To(Equal(ArtifactNotFoundError{
+				Name:    "some-binary",
+				JobName: "some-upstream-job",
+			}))
+		})
+
+		It("reports the failure via the delegate", func() {
+			<-process.Wait()
+
+			Expect(getDelegate.FailedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when looking up the artifact fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDBArtifactFactory.FindBuildArtifactReturns(nil, false, disaster)
+		})
+
+		It("fails with the error", func() {
+			Expect(<-process.Wait()).To(Equal(disaster))
+		})
+	})
+})
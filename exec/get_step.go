@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -153,6 +154,12 @@ func (step *GetStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	return nil
 }
 
+// SetTraceContext traces the creation of the step's container beneath the
+// given span.
+func (step *GetStep) SetTraceContext(trace tracing.SpanContext) {
+	step.session.Trace = trace
+}
+
 // Result indicates Success as true if the script completed successfully (or
 // didn't have to run) and everything else worked fine.
 //
@@ -184,6 +191,31 @@ func (step *GetStep) VolumeOn(worker worker.Worker) (worker.Volume, bool, error)
 	return step.resourceInstance.FindInitializedOn(step.logger.Session("volume-on"), worker)
 }
 
+// CacheIdentifier identifies the fetched resource by its type, source, params
+// and version.
+func (step *GetStep) CacheIdentifier() (string, bool) {
+	if step.versionedSource == nil {
+		return "", false
+	}
+
+	payload, err := json.Marshal(getStepCacheIdentifier{
+		ResourceHash: resource.GenerateResourceHash(step.resourceConfig.Source, step.resourceConfig.Type),
+		Params:       step.params,
+		Version:      step.versionedSource.Version(),
+	})
+	if err != nil {
+		return "", false
+	}
+
+	return "resource:" + string(payload), true
+}
+
+type getStepCacheIdentifier struct {
+	ResourceHash string      `json:"resource_hash"`
+	Params       atc.Params  `json:"params"`
+	Version      atc.Version `json:"version"`
+}
+
 // StreamTo streams the resource's data to the destination.
 func (step *GetStep) StreamTo(destination worker.ArtifactDestination) error {
 	out, err := step.versionedSource.StreamOut(".")
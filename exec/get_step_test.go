@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/exec"
@@ -112,7 +113,7 @@ var _ = Describe("Get", func() {
 
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, new(dbngfakes.FakeTaskCacheFactory), new(dbngfakes.FakeBuildArtifactFactory), new(dbngfakes.FakeStoredArtifactFactory), new(blobstorefakes.FakeStore), new(dbngfakes.FakeTestResultFactory))
 	})
 
 	JustBeforeEach(func() {
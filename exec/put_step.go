@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -94,6 +95,7 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		},
 		Tags:   step.tags,
 		TeamID: step.teamID,
+		Trace:  step.session.Trace,
 
 		Dir: resource.ResourcesDir("put"),
 
@@ -156,6 +158,12 @@ func (step *PutStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	return nil
 }
 
+// SetTraceContext traces the creation of the step's container beneath the
+// given span.
+func (step *PutStep) SetTraceContext(trace tracing.SpanContext) {
+	step.session.Trace = trace
+}
+
 // Result indicates Success as true if the script completed with exit status 0.
 //
 // It also indicates VersionInfo returned by the script.
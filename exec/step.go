@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 	"github.com/tedsuo/ifrit"
 )
@@ -60,6 +61,13 @@ type Step interface {
 	Result(interface{}) bool
 }
 
+// TracedStep is implemented by steps which create containers, so that the
+// work done on the worker is traced beneath the step's span.
+type TracedStep interface {
+	// SetTraceContext is called with the step's span context before it runs.
+	SetTraceContext(tracing.SpanContext)
+}
+
 // Success indicates whether a step completed successfully.
 type Success bool
 
@@ -0,0 +1,76 @@
+package exec
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+)
+
+// storeArtifacts archives each of the outputs named as artifacts of the step
+// to the artifact store as a gzipped tarball, so that they can be downloaded
+// from the build regardless of whether the task succeeded.
+//
+// Failing to store an artifact does not fail the step.
+func (step *TaskStep) storeArtifacts(logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	if len(step.artifacts) == 0 {
+		return
+	}
+
+	if step.artifactStore == nil {
+		logger.Info("no-artifact-store-configured")
+		return
+	}
+
+	for _, name := range step.artifacts {
+		volume, found := step.outputVolume(config, container, name)
+		if !found {
+			logger.Info("artifact-output-not-found", lager.Data{"artifact": name})
+			continue
+		}
+
+		err := step.storeArtifact(name, volume)
+		if err != nil {
+			logger.Error("failed-to-store-artifact", err, lager.Data{"artifact": name})
+		}
+	}
+}
+
+func (step *TaskStep) storeArtifact(name string, volume worker.Volume) error {
+	tarStream, err := volume.StreamOut(".")
+	if err != nil {
+		return err
+	}
+
+	defer tarStream.Close()
+
+	compressed, writer := io.Pipe()
+
+	go func() {
+		gzWriter := gzip.NewWriter(writer)
+
+		_, err := io.Copy(gzWriter, tarStream)
+		if err == nil {
+			err = gzWriter.Close()
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	key := fmt.Sprintf("builds/%d/%s.tgz", step.buildID, name)
+
+	size, err := step.artifactStore.Put(key, compressed)
+
+	// unblock the compressing goroutine if the store gave up early
+	compressed.Close()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = step.storedArtifactFactory.CreateStoredArtifact(step.buildID, name, key, size)
+	return err
+}
@@ -0,0 +1,248 @@
+package exec
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/image"
+)
+
+// TaskCacheConfig configures whether a TaskStep may skip running its script
+// when its config, image and inputs are identical to a previous successful
+// run, reusing that run's outputs instead.
+type TaskCacheConfig struct {
+	SkipIfUnchanged bool
+
+	// Key is mixed into the computed cache key, allowing previous runs to be
+	// invalidated by changing it.
+	Key string
+}
+
+// CacheIdentifiableSource is implemented by artifact sources whose contents
+// can be identified without streaming them, e.g. a resource version or a
+// volume that is never modified after being produced.
+//
+// Tasks with skip_if_unchanged consuming a source that does not implement
+// this interface will always run.
+type CacheIdentifiableSource interface {
+	CacheIdentifier() (string, bool)
+}
+
+type taskCacheKeyPayload struct {
+	Config     atc.TaskConfig    `json:"config"`
+	Privileged bool              `json:"privileged"`
+	Image      string            `json:"image,omitempty"`
+	Inputs     map[string]string `json:"inputs"`
+	Key        string            `json:"key,omitempty"`
+}
+
+// cacheKey hashes the task's config, image and inputs. If the identity of the
+// image or any input cannot be determined, false is returned and the task
+// must run.
+func (step *TaskStep) cacheKey(config atc.TaskConfig, imageVersion *worker.ResourceCacheIdentifier) (string, bool) {
+	payload := taskCacheKeyPayload{
+		Config:     config,
+		Privileged: bool(step.privileged),
+		Inputs:     map[string]string{},
+		Key:        step.cacheConfig.Key,
+	}
+
+	if step.imageArtifactName != "" {
+		source, found := step.repo.SourceFor(worker.ArtifactName(step.imageArtifactName))
+		if !found {
+			return "", false
+		}
+
+		identifier, ok := sourceCacheIdentifier(source)
+		if !ok {
+			return "", false
+		}
+
+		payload.Image = identifier
+	} else if config.ImageResource != nil {
+		if imageVersion == nil {
+			return "", false
+		}
+
+		versionJSON, err := json.Marshal(imageVersion.ResourceVersion)
+		if err != nil {
+			return "", false
+		}
+
+		payload.Image = imageVersion.ResourceHash + string(versionJSON)
+	}
+
+	for _, input := range config.Inputs {
+		inputName := input.Name
+		if sourceName, ok := step.inputMapping[inputName]; ok {
+			inputName = sourceName
+		}
+
+		source, found := step.repo.SourceFor(worker.ArtifactName(inputName))
+		if !found {
+			return "", false
+		}
+
+		identifier, ok := sourceCacheIdentifier(source)
+		if !ok {
+			return "", false
+		}
+
+		payload.Inputs[input.Name] = identifier
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(payloadJSON)), true
+}
+
+func sourceCacheIdentifier(source worker.ArtifactSource) (string, bool) {
+	identifiable, ok := source.(CacheIdentifiableSource)
+	if !ok {
+		return "", false
+	}
+
+	return identifiable.CacheIdentifier()
+}
+
+// findCachedOutputs looks up a previous run for the key and locates each of
+// its output volumes on their workers. Any missing volume is treated as a
+// miss.
+func (step *TaskStep) findCachedOutputs(logger lager.Logger, config atc.TaskConfig, key string) (*dbng.TaskCache, map[string]worker.Volume, bool) {
+	cache, found, err := step.taskCacheFactory.FindTaskCache(step.teamID, key)
+	if err != nil {
+		logger.Error("failed-to-find-task-cache", err)
+		return nil, nil, false
+	}
+
+	if !found {
+		return nil, nil, false
+	}
+
+	workers, err := step.workerPool.RunningWorkers(logger)
+	if err != nil {
+		logger.Error("failed-to-get-running-workers", err)
+		return nil, nil, false
+	}
+
+	workersByName := map[string]worker.Worker{}
+	for _, w := range workers {
+		workersByName[w.Name()] = w
+	}
+
+	volumes := map[string]worker.Volume{}
+	for _, output := range config.Outputs {
+		cachedVolume, found := cache.Volumes[output.Name]
+		if !found {
+			return nil, nil, false
+		}
+
+		w, found := workersByName[cachedVolume.Worker().Name()]
+		if !found {
+			return nil, nil, false
+		}
+
+		volume, found, err := w.LookupVolume(logger, cachedVolume.Handle())
+		if err != nil {
+			logger.Error("failed-to-lookup-cached-volume", err)
+			return nil, nil, false
+		}
+
+		if !found {
+			return nil, nil, false
+		}
+
+		volumes[output.Name] = volume
+	}
+
+	return cache, volumes, true
+}
+
+// useCachedOutputs registers the cached output volumes in place of running
+// the task.
+func (step *TaskStep) useCachedOutputs(config atc.TaskConfig, cache *dbng.TaskCache, volumes map[string]worker.Volume) {
+	for outputName, volume := range volumes {
+		if destinationName, ok := step.outputMapping[outputName]; ok {
+			outputName = destinationName
+		}
+
+		step.repo.RegisterSource(worker.ArtifactName(outputName), newVolumeSource(step.logger, volume))
+	}
+
+	outputHandles := map[string]string{}
+	for outputName, volume := range volumes {
+		outputHandles[outputName] = volume.Handle()
+	}
+
+	step.publishOutputs(step.logger.Session("publish-outputs"), config, outputHandles)
+
+	step.exitStatus = 0
+
+	step.delegate.CacheHit(cache.Key, cache.BuildID)
+	step.delegate.Finished(ExitStatus(0))
+}
+
+// saveCachedOutputs records the task's output volumes under the key so that
+// later runs with identical inputs can reuse them.
+func (step *TaskStep) saveCachedOutputs(logger lager.Logger, key string, outputHandles map[string]string) {
+	_, err := step.taskCacheFactory.CreateTaskCache(step.teamID, step.metadata, key, outputHandles)
+	if err != nil {
+		logger.Error("failed-to-save-task-cache", err)
+	}
+}
+
+// resolveImageVersion checks for the latest version of the task's image
+// resource. It's fetched for the container as that version, so that the cache
+// can be consulted before the container is created.
+func (step *TaskStep) resolveImageVersion(signals <-chan os.Signal, config atc.TaskConfig) (*worker.ResourceCacheIdentifier, error) {
+	logger := step.logger.Session("resolve-image-version")
+
+	checkingResource, err := step.resourceFactory.NewCheckResource(
+		logger,
+		signals,
+		dbng.ForBuild(step.buildID),
+		config.ImageResource.Type,
+		config.ImageResource.Source,
+		dbng.ContainerMetadata{
+			Type: dbng.ContainerTypeCheck,
+		},
+		worker.ContainerSpec{
+			ImageSpec: worker.ImageSpec{
+				ResourceType: config.ImageResource.Type,
+			},
+			Tags:   step.tags,
+			TeamID: step.teamID,
+		},
+		step.resourceTypes,
+		step.delegate,
+	)
+	if err != nil {
+		logger.Error("failed-to-create-check-resource", err)
+		return nil, err
+	}
+
+	versions, err := checkingResource.Check(config.ImageResource.Source, nil)
+	if err != nil {
+		logger.Error("failed-to-check", err)
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, image.ErrImageUnavailable
+	}
+
+	return &worker.ResourceCacheIdentifier{
+		ResourceVersion: versions[0],
+		ResourceHash:    resource.GenerateResourceHash(config.ImageResource.Source, config.ImageResource.Type),
+	}, nil
+}
@@ -0,0 +1,94 @@
+package exec
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/worker"
+)
+
+// TaskMetadataFileName is the file, relative to the task's working directory
+// root, to which a task may write a JSON object of string keys and values to
+// attach to the build.
+const TaskMetadataFileName = ".build-metadata.json"
+
+const maxTaskMetadataSize = 64 * 1024
+
+// recordMetadata reads the build metadata file written by the task, if any,
+// and reports it to the delegate so that it can be attached to the build.
+//
+// A missing file is ignored; an invalid or oversized one is reported on
+// stderr, but does not fail the step.
+func (step *TaskStep) recordMetadata(logger lager.Logger, container worker.Container) {
+	metadataPath := path.Join(step.artifactsRoot, TaskMetadataFileName)
+
+	contents, found, err := readMetadataFile(container, metadataPath)
+	if err != nil {
+		logger.Info("failed-to-read-metadata", lager.Data{"error": err.Error()})
+		fmt.Fprintf(step.delegate.Stderr(), "ignoring %s: %s\n", TaskMetadataFileName, err)
+		return
+	}
+
+	if !found {
+		return
+	}
+
+	var metadata map[string]string
+	err = json.Unmarshal(contents, &metadata)
+	if err != nil {
+		logger.Info("invalid-metadata", lager.Data{"error": err.Error()})
+		fmt.Fprintf(step.delegate.Stderr(), "ignoring invalid %s: must be a JSON object of string values (%s)\n", TaskMetadataFileName, err)
+		return
+	}
+
+	if _, ok := metadata[""]; ok {
+		fmt.Fprintf(step.delegate.Stderr(), "ignoring empty key in %s\n", TaskMetadataFileName)
+		delete(metadata, "")
+	}
+
+	if len(metadata) == 0 {
+		return
+	}
+
+	step.delegate.MetadataSet(metadata)
+}
+
+func readMetadataFile(container worker.Container, metadataPath string) ([]byte, bool, error) {
+	out, err := container.StreamOut(garden.StreamOutSpec{
+		Path: metadataPath,
+	})
+	if err != nil {
+		// the file not existing is indistinguishable from other failures
+		return nil, false, nil
+	}
+
+	defer out.Close()
+
+	tarReader := tar.NewReader(out)
+
+	header, err := tarReader.Next()
+	if err == io.EOF {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	if header.Size > maxTaskMetadataSize {
+		return nil, false, fmt.Errorf("metadata file exceeds %d bytes", maxTaskMetadataSize)
+	}
+
+	contents, err := ioutil.ReadAll(tarReader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return contents, true, nil
+}
@@ -8,12 +8,16 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -21,6 +25,10 @@ const taskProcessID = "task"
 const taskProcessPropertyName = "concourse:task-process"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
+// WorkerCapacityRetryInterval is how often a task waiting for a worker with
+// room for its container limits retries placing its container.
+const WorkerCapacityRetryInterval = 10 * time.Second
+
 // MissingInputsError is returned when any of the task's required inputs are
 // missing.
 type MissingInputsError struct {
@@ -44,23 +52,34 @@ make sure there's a corresponding 'get' step, or a task that produces it as an o
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
 // worker.ArtifactRepository and outputs will be added to the worker.ArtifactRepository.
 type TaskStep struct {
-	logger            lager.Logger
-	metadata          dbng.ContainerMetadata
-	tags              atc.Tags
-	teamID            int
-	buildID           int
-	planID            atc.PlanID
-	delegate          TaskDelegate
-	privileged        Privileged
-	configSource      TaskConfigSource
-	workerPool        worker.Client
-	artifactsRoot     string
-	resourceTypes     atc.VersionedResourceTypes
-	inputMapping      map[string]string
-	outputMapping     map[string]string
-	imageArtifactName string
-	clock             clock.Clock
-	repo              *worker.ArtifactRepository
+	logger                lager.Logger
+	metadata              dbng.ContainerMetadata
+	tags                  atc.Tags
+	teamID                int
+	buildID               int
+	planID                atc.PlanID
+	delegate              TaskDelegate
+	privileged            Privileged
+	configSource          TaskConfigSource
+	workerPool            worker.Client
+	resourceFactory       resource.ResourceFactory
+	artifactsRoot         string
+	resourceTypes         atc.VersionedResourceTypes
+	inputMapping          map[string]string
+	outputMapping         map[string]string
+	imageArtifactName     string
+	cacheConfig           TaskCacheConfig
+	taskCacheFactory      dbng.TaskCacheFactory
+	artifactFactory       dbng.BuildArtifactFactory
+	artifacts             []string
+	storedArtifactFactory dbng.StoredArtifactFactory
+	artifactStore         blobstore.Store
+	testReports           *atc.TestReportsConfig
+	testResultFactory     dbng.TestResultFactory
+	priority              int
+	clock                 clock.Clock
+	repo                  *worker.ArtifactRepository
+	trace                 tracing.SpanContext
 
 	process garden.Process
 
@@ -78,30 +97,50 @@ func newTaskStep(
 	privileged Privileged,
 	configSource TaskConfigSource,
 	workerPool worker.Client,
+	resourceFactory resource.ResourceFactory,
 	artifactsRoot string,
 	resourceTypes atc.VersionedResourceTypes,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	imageArtifactName string,
+	cacheConfig TaskCacheConfig,
+	taskCacheFactory dbng.TaskCacheFactory,
+	artifactFactory dbng.BuildArtifactFactory,
+	artifacts []string,
+	storedArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	testReports *atc.TestReportsConfig,
+	testResultFactory dbng.TestResultFactory,
+	priority int,
 	clock clock.Clock,
 ) TaskStep {
 	return TaskStep{
-		logger:            logger,
-		metadata:          metadata,
-		tags:              tags,
-		teamID:            teamID,
-		buildID:           buildID,
-		planID:            planID,
-		delegate:          delegate,
-		privileged:        privileged,
-		configSource:      configSource,
-		workerPool:        workerPool,
-		artifactsRoot:     artifactsRoot,
-		resourceTypes:     resourceTypes,
-		inputMapping:      inputMapping,
-		outputMapping:     outputMapping,
-		imageArtifactName: imageArtifactName,
-		clock:             clock,
+		logger:                logger,
+		metadata:              metadata,
+		tags:                  tags,
+		teamID:                teamID,
+		buildID:               buildID,
+		planID:                planID,
+		delegate:              delegate,
+		privileged:            privileged,
+		configSource:          configSource,
+		workerPool:            workerPool,
+		resourceFactory:       resourceFactory,
+		artifactsRoot:         artifactsRoot,
+		resourceTypes:         resourceTypes,
+		inputMapping:          inputMapping,
+		outputMapping:         outputMapping,
+		imageArtifactName:     imageArtifactName,
+		cacheConfig:           cacheConfig,
+		taskCacheFactory:      taskCacheFactory,
+		artifactFactory:       artifactFactory,
+		artifacts:             artifacts,
+		storedArtifactFactory: storedArtifactFactory,
+		artifactStore:         artifactStore,
+		testReports:           testReports,
+		testResultFactory:     testResultFactory,
+		priority:              priority,
+		clock:                 clock,
 	}
 }
 
@@ -132,6 +171,18 @@ func (step TaskStep) Using(prev Step, repo *worker.ArtifactRepository) Step {
 // are registered with the worker.ArtifactRepository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// If the step has SkipIfUnchanged configured and a previous successful run
+// had an identical config, image and inputs, the script is not run, and that
+// run's output volumes are registered instead.
+//
+// Outputs marked to be published are recorded against the build once the
+// script exits successfully, so that downstream jobs can fetch them.
+//
+// Outputs named as artifacts of the step are archived to the artifact store
+// once the script exits, whether or not it succeeded. Likewise, any JUnit
+// reports configured for the step are parsed and recorded against the build,
+// as is any metadata the script wrote to TaskMetadataFileName.
 func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 	processIO := garden.ProcessIO{
 		Stdout: step.delegate.Stdout(),
@@ -155,16 +206,26 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	step.delegate.Initializing(config)
 
-	container, err := step.workerPool.FindOrCreateBuildContainer(
-		step.logger,
-		signals,
-		step.delegate,
-		step.buildID,
-		step.planID,
-		step.metadata,
-		containerSpec,
-		step.resourceTypes,
-	)
+	var imageVersion *worker.ResourceCacheIdentifier
+
+	if step.cacheConfig.SkipIfUnchanged {
+		if step.imageArtifactName == "" && config.ImageResource != nil {
+			// the image's version is part of the cache key, so it's resolved
+			// before the container is created and then fetched for it
+			imageVersion, err = step.resolveImageVersion(signals, config)
+			if err != nil {
+				return err
+			}
+
+			containerSpec.ImageSpec.ImageResourceVersion = imageVersion.ResourceVersion
+		}
+
+		if step.runFromCache(config, imageVersion) {
+			return nil
+		}
+	}
+
+	container, err := step.findOrCreateContainer(signals, step.delegate, containerSpec)
 	if err != nil {
 		return err
 	}
@@ -252,12 +313,96 @@ func (step *TaskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		step.storeArtifacts(step.logger.Session("store-artifacts"), config, container)
+		step.recordTestResults(step.logger.Session("record-test-results"), config, container)
+		step.recordMetadata(step.logger.Session("record-metadata"), container)
+
+		if processStatus == 0 {
+			outputHandles := step.outputVolumeHandles(config, container)
+
+			if step.cacheConfig.SkipIfUnchanged {
+				if key, ok := step.cacheKey(config, imageVersion); ok {
+					step.saveCachedOutputs(step.logger.Session("save-task-cache"), key, outputHandles)
+				}
+			}
+
+			step.publishOutputs(step.logger.Session("publish-outputs"), config, outputHandles)
+		}
+
 		step.delegate.Finished(ExitStatus(processStatus))
 
 		return nil
 	}
 }
 
+// runFromCache checks for a previous run with an identical cache key and, if
+// one is found, uses its outputs. It returns true if the task need not run.
+func (step *TaskStep) runFromCache(config atc.TaskConfig, imageVersion *worker.ResourceCacheIdentifier) bool {
+	logger := step.logger.Session("task-cache")
+
+	key, ok := step.cacheKey(config, imageVersion)
+	if !ok {
+		logger.Debug("inputs-not-identifiable")
+		return false
+	}
+
+	cache, volumes, found := step.findCachedOutputs(logger, config, key)
+	if !found {
+		logger.Debug("miss", lager.Data{"key": key})
+		return false
+	}
+
+	logger.Info("hit", lager.Data{"key": key, "build-id": cache.BuildID})
+
+	step.useCachedOutputs(config, cache, volumes)
+
+	return true
+}
+
+// findOrCreateContainer places the task's container, waiting for as long as
+// no worker has room for its limits rather than overcommitting one.
+func (step *TaskStep) findOrCreateContainer(
+	signals <-chan os.Signal,
+	imageFetchingDelegate worker.ImageFetchingDelegate,
+	containerSpec worker.ContainerSpec,
+) (worker.Container, error) {
+	waiting := false
+
+	for {
+		container, err := step.workerPool.FindOrCreateBuildContainer(
+			step.logger,
+			signals,
+			imageFetchingDelegate,
+			step.buildID,
+			step.planID,
+			step.metadata,
+			containerSpec,
+			step.resourceTypes,
+		)
+		if _, ok := err.(worker.NoWorkerCapacityError); !ok {
+			if waiting {
+				step.delegate.WaitingForWorkerCapacity(false)
+			}
+
+			return container, err
+		}
+
+		if !waiting {
+			step.logger.Info("waiting-for-worker-capacity")
+			fmt.Fprintf(step.delegate.Stderr(), "waiting for worker capacity: %s\n", err)
+			step.delegate.WaitingForWorkerCapacity(true)
+			waiting = true
+		}
+
+		select {
+		case <-signals:
+			step.delegate.WaitingForWorkerCapacity(false)
+			return nil, ErrInterrupted
+		case <-step.clock.After(WorkerCapacityRetryInterval):
+		}
+	}
+}
+
 func (step *TaskStep) containerSpec(config atc.TaskConfig) (worker.ContainerSpec, error) {
 	imageSpec := worker.ImageSpec{
 		Privileged: bool(step.privileged),
@@ -283,11 +428,17 @@ func (step *TaskStep) containerSpec(config atc.TaskConfig) (worker.ContainerSpec
 		User:      config.Run.User,
 		Dir:       step.artifactsRoot,
 		Env:       step.envForParams(config.Params),
+		Priority:  step.priority,
+		Trace:     step.trace,
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
 	}
 
+	if config.ContainerLimits != nil {
+		containerSpec.Limits = *config.ContainerLimits
+	}
+
 	var missingInputs []string
 	for _, input := range config.Inputs {
 		inputName := input.Name
@@ -343,6 +494,80 @@ func (step *TaskStep) registerSource(config atc.TaskConfig, container worker.Con
 	}
 }
 
+// outputVolumeHandles maps each of the task's outputs to the handle of the
+// volume mounted at its path.
+func (step *TaskStep) outputVolumeHandles(config atc.TaskConfig, container worker.Container) map[string]string {
+	volumeMounts := container.VolumeMounts()
+
+	outputHandles := map[string]string{}
+	for _, output := range config.Outputs {
+		outputPath := artifactsPath(output, step.artifactsRoot)
+
+		for _, mount := range volumeMounts {
+			if mount.MountPath == outputPath {
+				outputHandles[output.Name] = mount.Volume.Handle()
+			}
+		}
+	}
+
+	return outputHandles
+}
+
+// outputVolume finds the volume mounted for the named output of the task.
+func (step *TaskStep) outputVolume(config atc.TaskConfig, container worker.Container, name string) (worker.Volume, bool) {
+	for _, output := range config.Outputs {
+		if output.Name != name {
+			continue
+		}
+
+		outputPath := artifactsPath(output, step.artifactsRoot)
+
+		for _, mount := range container.VolumeMounts() {
+			if mount.MountPath == outputPath {
+				return mount.Volume, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// publishOutputs records the volumes of outputs marked to be published as
+// artifacts of the build, under their mapped names. Outputs of one-off builds
+// are never published, as there is no job to fetch them from.
+func (step *TaskStep) publishOutputs(logger lager.Logger, config atc.TaskConfig, outputHandles map[string]string) {
+	if step.metadata.JobID == 0 {
+		return
+	}
+
+	for _, output := range config.Outputs {
+		if !output.Publish {
+			continue
+		}
+
+		handle, found := outputHandles[output.Name]
+		if !found {
+			continue
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.outputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		_, err := step.artifactFactory.CreateBuildArtifact(step.buildID, outputName, handle)
+		if err != nil {
+			logger.Error("failed-to-publish-output", err, lager.Data{"output": outputName})
+		}
+	}
+}
+
+// SetTraceContext traces the creation of the step's container beneath the
+// given span.
+func (step *TaskStep) SetTraceContext(trace tracing.SpanContext) {
+	step.trace = trace
+}
+
 // Result indicates Success as true if the script's exit status was 0.
 //
 // It also indicates ExitStatus as the exit status of the script.
@@ -422,6 +647,12 @@ func (src *volumeSource) VolumeOn(w worker.Worker) (worker.Volume, bool, error)
 	return w.LookupVolume(src.logger, src.volume.Handle())
 }
 
+// CacheIdentifier identifies the source by its volume's handle, as task
+// output volumes are never modified once the task has exited.
+func (src *volumeSource) CacheIdentifier() (string, bool) {
+	return "volume:" + src.volume.Handle(), true
+}
+
 type taskInputSource struct {
 	name          worker.ArtifactName
 	config        atc.TaskInputConfig
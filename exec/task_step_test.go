@@ -2,6 +2,8 @@ package exec_test
 
 import (
 	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -14,6 +16,7 @@ import (
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/exec"
@@ -21,6 +24,7 @@ import (
 	"github.com/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/workerfakes"
+	"github.com/concourse/baggageclaim"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -29,8 +33,14 @@ import (
 
 var _ = Describe("GardenFactory", func() {
 	var (
-		fakeWorkerClient           *workerfakes.FakeClient
-		fakeDBResourceCacheFactory *dbngfakes.FakeResourceCacheFactory
+		fakeWorkerClient            *workerfakes.FakeClient
+		fakeResourceFactory         *resourcefakes.FakeResourceFactory
+		fakeDBResourceCacheFactory  *dbngfakes.FakeResourceCacheFactory
+		fakeDBTaskCacheFactory      *dbngfakes.FakeTaskCacheFactory
+		fakeDBArtifactFactory       *dbngfakes.FakeBuildArtifactFactory
+		fakeDBStoredArtifactFactory *dbngfakes.FakeStoredArtifactFactory
+		fakeArtifactStore           *blobstorefakes.FakeStore
+		fakeDBTestResultFactory     *dbngfakes.FakeTestResultFactory
 
 		factory Factory
 
@@ -45,10 +55,15 @@ var _ = Describe("GardenFactory", func() {
 
 	BeforeEach(func() {
 		fakeWorkerClient = new(workerfakes.FakeClient)
-		fakeResourceFactory := new(resourcefakes.FakeResourceFactory)
+		fakeResourceFactory = new(resourcefakes.FakeResourceFactory)
 		fakeResourceFetcher := new(resourcefakes.FakeFetcher)
 		fakeDBResourceCacheFactory = new(dbngfakes.FakeResourceCacheFactory)
-		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory)
+		fakeDBTaskCacheFactory = new(dbngfakes.FakeTaskCacheFactory)
+		fakeDBArtifactFactory = new(dbngfakes.FakeBuildArtifactFactory)
+		fakeDBStoredArtifactFactory = new(dbngfakes.FakeStoredArtifactFactory)
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
+		fakeDBTestResultFactory = new(dbngfakes.FakeTestResultFactory)
+		factory = NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeDBResourceCacheFactory, fakeDBTaskCacheFactory, fakeDBArtifactFactory, fakeDBStoredArtifactFactory, fakeArtifactStore, fakeDBTestResultFactory)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -64,12 +79,17 @@ var _ = Describe("GardenFactory", func() {
 			resourceTypes atc.VersionedResourceTypes
 			inputMapping  map[string]string
 			outputMapping map[string]string
+			cacheConfig   TaskCacheConfig
+			artifacts     []string
+			testReports   *atc.TestReportsConfig
+			priority      int
 
 			inStep *execfakes.FakeStep
 			repo   *worker.ArtifactRepository
 
 			step    Step
 			process ifrit.Process
+			invoke  func(ifrit.Runner) ifrit.Process
 		)
 
 		BeforeEach(func() {
@@ -85,6 +105,8 @@ var _ = Describe("GardenFactory", func() {
 			inStep = new(execfakes.FakeStep)
 			repo = worker.NewArtifactRepository()
 
+			invoke = ifrit.Invoke
+
 			resourceTypes = atc.VersionedResourceTypes{
 				{
 					ResourceType: atc.ResourceType{
@@ -98,6 +120,10 @@ var _ = Describe("GardenFactory", func() {
 
 			inputMapping = nil
 			outputMapping = nil
+			cacheConfig = TaskCacheConfig{}
+			artifacts = nil
+			testReports = nil
+			priority = 0
 			imageArtifactName = ""
 			fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
 
@@ -123,10 +149,14 @@ var _ = Describe("GardenFactory", func() {
 				inputMapping,
 				outputMapping,
 				imageArtifactName,
+				cacheConfig,
+				artifacts,
+				testReports,
+				priority,
 				fakeClock,
 			).Using(inStep, repo)
 
-			process = ifrit.Invoke(step)
+			process = invoke(step)
 		})
 
 		Context("when getting the config works", func() {
@@ -158,6 +188,7 @@ var _ = Describe("GardenFactory", func() {
 				BeforeEach(func() {
 					fakeContainer = new(workerfakes.FakeContainer)
 					fakeContainer.HandleReturns("some-handle")
+					fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
 					fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
 				})
 
@@ -1432,6 +1463,842 @@ var _ = Describe("GardenFactory", func() {
 			})
 		})
 
+		Context("when the task publishes an output", func() {
+			var fakeContainer *workerfakes.FakeContainer
+			var fakeProcess *gardenfakes.FakeProcess
+
+			BeforeEach(func() {
+				workerMetadata.JobID = 456
+				outputMapping = map[string]string{"some-output": "some-mapped-output"}
+
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output", Publish: true},
+						{Name: "some-other-output"},
+					},
+				}, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.PropertyReturns("", errors.New("nope"))
+				fakeContainer.AttachReturns(nil, errors.New("nope"))
+				fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+
+				fakeVolume := new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-output-handle")
+				fakeOtherVolume := new(workerfakes.FakeVolume)
+				fakeOtherVolume.HandleReturns("some-other-output-handle")
+				fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+					{
+						Volume:    fakeVolume,
+						MountPath: "/tmp/build/a1f5c0c1/some-output/",
+					},
+					{
+						Volume:    fakeOtherVolume,
+						MountPath: "/tmp/build/a1f5c0c1/some-other-output/",
+					},
+				})
+
+				fakeProcess = new(gardenfakes.FakeProcess)
+				fakeContainer.RunReturns(fakeProcess, nil)
+				fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+			})
+
+			Context("when the process exits 0", func() {
+				BeforeEach(func() {
+					fakeProcess.WaitReturns(0, nil)
+				})
+
+				It("publishes only that output under its mapped name", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeDBArtifactFactory.CreateBuildArtifactCallCount()).To(Equal(1))
+
+					buildID, name, handle := fakeDBArtifactFactory.CreateBuildArtifactArgsForCall(0)
+					Expect(buildID).To(Equal(1234))
+					Expect(name).To(Equal("some-mapped-output"))
+					Expect(handle).To(Equal("some-output-handle"))
+				})
+
+				Context("when publishing fails", func() {
+					BeforeEach(func() {
+						fakeDBArtifactFactory.CreateBuildArtifactReturns(nil, errors.New("nope"))
+					})
+
+					It("still succeeds", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						var success Success
+						Expect(step.Result(&success)).To(BeTrue())
+						Expect(bool(success)).To(BeTrue())
+					})
+				})
+
+				Context("when the build is a one-off", func() {
+					BeforeEach(func() {
+						workerMetadata.JobID = 0
+					})
+
+					It("does not publish anything", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeDBArtifactFactory.CreateBuildArtifactCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the process exits nonzero", func() {
+				BeforeEach(func() {
+					fakeProcess.WaitReturns(1, nil)
+				})
+
+				It("does not publish anything", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeDBArtifactFactory.CreateBuildArtifactCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the task names outputs as artifacts", func() {
+			var fakeVolume *workerfakes.FakeVolume
+			var fakeProcess *gardenfakes.FakeProcess
+			var stored []byte
+
+			BeforeEach(func() {
+				artifacts = []string{"some-reports", "some-bogus-output"}
+
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-reports"},
+						{Name: "some-other-output"},
+					},
+				}, nil)
+
+				fakeContainer := new(workerfakes.FakeContainer)
+				fakeContainer.PropertyReturns("", errors.New("nope"))
+				fakeContainer.AttachReturns(nil, errors.New("nope"))
+				fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.StreamOutReturns(ioutil.NopCloser(bytes.NewBufferString("some-tar-contents")), nil)
+				fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+					{
+						Volume:    fakeVolume,
+						MountPath: "/tmp/build/a1f5c0c1/some-reports/",
+					},
+					{
+						Volume:    new(workerfakes.FakeVolume),
+						MountPath: "/tmp/build/a1f5c0c1/some-other-output/",
+					},
+				})
+
+				fakeProcess = new(gardenfakes.FakeProcess)
+				fakeProcess.WaitReturns(1, nil)
+				fakeContainer.RunReturns(fakeProcess, nil)
+				fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+
+				stored = nil
+				fakeArtifactStore.PutStub = func(key string, blob io.Reader) (int64, error) {
+					var err error
+					stored, err = ioutil.ReadAll(blob)
+					return int64(len(stored)), err
+				}
+			})
+
+			It("stores the compressed output even though the task failed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+				Expect(fakeVolume.StreamOutArgsForCall(0)).To(Equal("."))
+
+				Expect(fakeArtifactStore.PutCallCount()).To(Equal(1))
+				key, _ := fakeArtifactStore.PutArgsForCall(0)
+				Expect(key).To(Equal("builds/1234/some-reports.tgz"))
+
+				Expect(fakeDBStoredArtifactFactory.CreateStoredArtifactCallCount()).To(Equal(1))
+				buildID, name, blobKey, size := fakeDBStoredArtifactFactory.CreateStoredArtifactArgsForCall(0)
+				Expect(buildID).To(Equal(1234))
+				Expect(name).To(Equal("some-reports"))
+				Expect(blobKey).To(Equal("builds/1234/some-reports.tgz"))
+				Expect(size).To(Equal(int64(len(stored))))
+
+				gzReader, err := gzip.NewReader(bytes.NewBuffer(stored))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(gzReader)).To(Equal([]byte("some-tar-contents")))
+			})
+
+			Context("when storing the artifact fails", func() {
+				BeforeEach(func() {
+					fakeProcess.WaitReturns(0, nil)
+					fakeArtifactStore.PutStub = nil
+					fakeArtifactStore.PutReturns(0, errors.New("nope"))
+				})
+
+				It("does not record it, but still succeeds", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeDBStoredArtifactFactory.CreateStoredArtifactCallCount()).To(BeZero())
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the task declares test reports", func() {
+			var fakeVolume *workerfakes.FakeVolume
+			var fakeProcess *gardenfakes.FakeProcess
+
+			BeforeEach(func() {
+				testReports = &atc.TestReportsConfig{
+					Output: "some-reports",
+					Glob:   "junit/*.xml",
+				}
+
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-reports"},
+					},
+				}, nil)
+
+				reportsTar := new(bytes.Buffer)
+				tarWriter := tar.NewWriter(reportsTar)
+				writeReport := func(name string, contents string) {
+					err := tarWriter.WriteHeader(&tar.Header{
+						Name:     name,
+						Mode:     0644,
+						Size:     int64(len(contents)),
+						Typeflag: tar.TypeReg,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = tarWriter.Write([]byte(contents))
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				writeReport("./unit.xml", `<testsuite name="unit" time="1.5">
+  <testcase classname="some.Class" name="passes" time="0.5"/>
+  <testcase classname="some.Class" name="fails" time="1"><failure message="nope"/></testcase>
+</testsuite>`)
+				writeReport("./garbage.xml", "not xml")
+				writeReport("./nested/integration.xml", `<testsuite name="integration"/>`)
+				writeReport("./huge.xml", `<testsuite name="huge">`+strings.Repeat(" ", MaxTestReportSize)+`</testsuite>`)
+				Expect(tarWriter.Close()).To(Succeed())
+
+				fakeContainer := new(workerfakes.FakeContainer)
+				fakeContainer.PropertyReturns("", errors.New("nope"))
+				fakeContainer.AttachReturns(nil, errors.New("nope"))
+				fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.StreamOutReturns(ioutil.NopCloser(reportsTar), nil)
+				fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+					{
+						Volume:    fakeVolume,
+						MountPath: "/tmp/build/a1f5c0c1/some-reports/",
+					},
+				})
+
+				fakeProcess = new(gardenfakes.FakeProcess)
+				fakeProcess.WaitReturns(1, nil)
+				fakeContainer.RunReturns(fakeProcess, nil)
+				fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+			})
+
+			It("records the suites of the matching reports even though the task failed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+				Expect(fakeVolume.StreamOutArgsForCall(0)).To(Equal("junit"))
+
+				Expect(fakeDBTestResultFactory.SaveTestSuitesCallCount()).To(Equal(1))
+				buildID, planID, suites := fakeDBTestResultFactory.SaveTestSuitesArgsForCall(0)
+				Expect(buildID).To(Equal(1234))
+				Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+				Expect(suites).To(Equal([]dbng.TestSuite{
+					{
+						Name:     "unit",
+						Duration: 1500 * time.Millisecond,
+						Cases: []dbng.TestCase{
+							{
+								ClassName: "some.Class",
+								Name:      "passes",
+								Status:    dbng.TestStatusPassed,
+								Duration:  500 * time.Millisecond,
+							},
+							{
+								ClassName: "some.Class",
+								Name:      "fails",
+								Status:    dbng.TestStatusFailed,
+								Duration:  time.Second,
+								Message:   "nope",
+							},
+						},
+					},
+				}))
+			})
+
+			Context("when the glob is not within a directory", func() {
+				BeforeEach(func() {
+					testReports.Glob = "*.xml"
+				})
+
+				It("streams out the whole output", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+					Expect(fakeVolume.StreamOutArgsForCall(0)).To(Equal("."))
+				})
+			})
+
+			Context("when the directory of the glob does not exist", func() {
+				BeforeEach(func() {
+					fakeProcess.WaitReturns(0, nil)
+					fakeVolume.StreamOutReturns(nil, baggageclaim.ErrFileNotFound)
+				})
+
+				It("records no results and succeeds", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(fakeDBTestResultFactory.SaveTestSuitesCallCount()).To(BeZero())
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeTrue())
+				})
+			})
+
+			Context("when saving the results fails", func() {
+				BeforeEach(func() {
+					fakeProcess.WaitReturns(0, nil)
+					fakeDBTestResultFactory.SaveTestSuitesReturns(errors.New("nope"))
+				})
+
+				It("still succeeds", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the task writes a build metadata file", func() {
+			var fakeContainer *workerfakes.FakeContainer
+			var metadataContents string
+
+			BeforeEach(func() {
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+				}, nil)
+
+				metadataContents = `{"version":"1.2.3","deploy_url":"https://example.com"}`
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.PropertyReturns("", errors.New("nope"))
+				fakeContainer.AttachReturns(nil, errors.New("nope"))
+				fakeContainer.StreamOutStub = func(garden.StreamOutSpec) (io.ReadCloser, error) {
+					metadataTar := new(bytes.Buffer)
+
+					tarWriter := tar.NewWriter(metadataTar)
+					err := tarWriter.WriteHeader(&tar.Header{
+						Name:     ".build-metadata.json",
+						Mode:     0644,
+						Size:     int64(len(metadataContents)),
+						Typeflag: tar.TypeReg,
+					})
+					if err != nil {
+						return nil, err
+					}
+
+					_, err = tarWriter.Write([]byte(metadataContents))
+					if err != nil {
+						return nil, err
+					}
+
+					err = tarWriter.Close()
+					if err != nil {
+						return nil, err
+					}
+
+					return ioutil.NopCloser(metadataTar), nil
+				}
+
+				fakeProcess := new(gardenfakes.FakeProcess)
+				fakeProcess.WaitReturns(1, nil)
+				fakeContainer.RunReturns(fakeProcess, nil)
+				fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+			})
+
+			It("reads the file from the root of the working directory", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(fakeContainer.StreamOutCallCount()).To(Equal(1))
+				spec := fakeContainer.StreamOutArgsForCall(0)
+				Expect(spec.Path).To(Equal("/tmp/build/a1f5c0c1/.build-metadata.json"))
+			})
+
+			It("reports the metadata to the delegate even though the task failed", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(taskDelegate.MetadataSetCallCount()).To(Equal(1))
+				Expect(taskDelegate.MetadataSetArgsForCall(0)).To(Equal(map[string]string{
+					"version":    "1.2.3",
+					"deploy_url": "https://example.com",
+				}))
+			})
+
+			Context("when the file is not a JSON object of strings", func() {
+				BeforeEach(func() {
+					metadataContents = `{"coverage":80}`
+				})
+
+				It("reports it on stderr and does not set any metadata", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(stderrBuf).To(gbytes.Say("ignoring invalid .build-metadata.json"))
+					Expect(taskDelegate.MetadataSetCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the file does not exist", func() {
+				BeforeEach(func() {
+					fakeContainer.StreamOutStub = nil
+					fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+				})
+
+				It("does not set any metadata", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(taskDelegate.MetadataSetCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the task declares container limits", func() {
+			var fakeContainer *workerfakes.FakeContainer
+			var noCapacityErr error
+
+			BeforeEach(func() {
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					ContainerLimits: &atc.ContainerLimits{
+						CPU:    2000,
+						Memory: 1024,
+					},
+				}, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.PropertyReturns("", errors.New("nope"))
+				fakeContainer.AttachReturns(nil, errors.New("nope"))
+				fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+
+				fakeProcess := new(gardenfakes.FakeProcess)
+				fakeProcess.WaitReturns(0, nil)
+				fakeContainer.RunReturns(fakeProcess, nil)
+				fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+
+				noCapacityErr = worker.NoWorkerCapacityError{
+					Limits: atc.ContainerLimits{CPU: 2000, Memory: 1024},
+				}
+
+				priority = 10
+			})
+
+			It("places the container with the limits", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				_, _, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateBuildContainerArgsForCall(0)
+				Expect(spec.Limits).To(Equal(atc.ContainerLimits{CPU: 2000, Memory: 1024}))
+			})
+
+			It("places the container with the build's priority", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				_, _, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateBuildContainerArgsForCall(0)
+				Expect(spec.Priority).To(Equal(10))
+			})
+
+			It("does not report waiting for worker capacity", func() {
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(taskDelegate.WaitingForWorkerCapacityCallCount()).To(BeZero())
+			})
+
+			Context("when no worker has capacity for them at first", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.FindOrCreateBuildContainerReturnsOnCall(0, nil, noCapacityErr)
+					fakeWorkerClient.FindOrCreateBuildContainerReturnsOnCall(1, nil, noCapacityErr)
+
+					// the step isn't ready until it has a container
+					invoke = ifrit.Background
+				})
+
+				It("waits for capacity, retrying periodically", func() {
+					Eventually(taskDelegate.WaitingForWorkerCapacityCallCount).Should(Equal(1))
+					Expect(taskDelegate.WaitingForWorkerCapacityArgsForCall(0)).To(BeTrue())
+					Expect(stderrBuf).To(gbytes.Say("waiting for worker capacity"))
+
+					fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+					fakeClock.WaitForWatcherAndIncrement(WorkerCapacityRetryInterval)
+
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(Equal(3))
+					Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+					Expect(taskDelegate.WaitingForWorkerCapacityCallCount()).To(Equal(2))
+					Expect(taskDelegate.WaitingForWorkerCapacityArgsForCall(1)).To(BeFalse())
+				})
+			})
+
+			Context("when interrupted while waiting for capacity", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.FindOrCreateBuildContainerReturns(nil, noCapacityErr)
+
+					invoke = ifrit.Background
+				})
+
+				It("stops waiting and returns ErrInterrupted", func() {
+					Eventually(taskDelegate.WaitingForWorkerCapacityCallCount).Should(Equal(1))
+
+					process.Signal(os.Interrupt)
+
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+
+					Expect(taskDelegate.WaitingForWorkerCapacityCallCount()).To(Equal(2))
+					Expect(taskDelegate.WaitingForWorkerCapacityArgsForCall(1)).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when skip_if_unchanged is enabled", func() {
+			BeforeEach(func() {
+				cacheConfig = TaskCacheConfig{
+					SkipIfUnchanged: true,
+					Key:             "some-cache-key",
+				}
+
+				configSource.FetchConfigReturns(atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output"},
+					},
+				}, nil)
+			})
+
+			Context("when a previous run with identical inputs is cached", func() {
+				var fakeCachedVolume *workerfakes.FakeVolume
+
+				BeforeEach(func() {
+					fakeDBWorker := new(dbngfakes.FakeWorker)
+					fakeDBWorker.NameReturns("some-worker")
+
+					fakeCreatedVolume := new(dbngfakes.FakeCreatedVolume)
+					fakeCreatedVolume.HandleReturns("some-cached-handle")
+					fakeCreatedVolume.WorkerReturns(fakeDBWorker)
+
+					fakeDBTaskCacheFactory.FindTaskCacheReturns(&dbng.TaskCache{
+						BuildID: 42,
+						Key:     "some-key",
+						Volumes: map[string]dbng.CreatedVolume{
+							"some-output": fakeCreatedVolume,
+						},
+					}, true, nil)
+
+					fakeCachedVolume = new(workerfakes.FakeVolume)
+					fakeCachedVolume.HandleReturns("some-cached-handle")
+
+					fakeWorker := new(workerfakes.FakeWorker)
+					fakeWorker.NameReturns("some-worker")
+					fakeWorker.LookupVolumeReturns(fakeCachedVolume, true, nil)
+					fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+				})
+
+				It("does not create a container", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(BeZero())
+				})
+
+				It("looks up the cache for the team", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeDBTaskCacheFactory.FindTaskCacheCallCount()).To(Equal(1))
+
+					actualTeamID, key := fakeDBTaskCacheFactory.FindTaskCacheArgsForCall(0)
+					Expect(actualTeamID).To(Equal(123))
+					Expect(key).ToNot(BeEmpty())
+				})
+
+				It("registers the cached volumes as its outputs", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					_, found := repo.SourceFor("some-output")
+					Expect(found).To(BeTrue())
+				})
+
+				It("reports the cache hit and finishes successfully", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+
+					Expect(taskDelegate.CacheHitCallCount()).To(Equal(1))
+					_, buildID := taskDelegate.CacheHitArgsForCall(0)
+					Expect(buildID).To(Equal(42))
+
+					Expect(taskDelegate.FinishedCallCount()).To(Equal(1))
+					Expect(taskDelegate.FinishedArgsForCall(0)).To(Equal(ExitStatus(0)))
+
+					var success Success
+					Expect(step.Result(&success)).To(BeTrue())
+					Expect(bool(success)).To(BeTrue())
+				})
+
+				Context("when a cached volume is no longer on its worker", func() {
+					BeforeEach(func() {
+						fakeContainer := new(workerfakes.FakeContainer)
+						fakeContainer.PropertyReturns("", errors.New("nope"))
+						fakeContainer.AttachReturns(nil, errors.New("nope"))
+						fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+						fakeProcess := new(gardenfakes.FakeProcess)
+						fakeProcess.WaitReturns(0, nil)
+						fakeContainer.RunReturns(fakeProcess, nil)
+						fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+
+						fakeWorker := new(workerfakes.FakeWorker)
+						fakeWorker.NameReturns("some-worker")
+						fakeWorker.LookupVolumeReturns(nil, false, nil)
+						fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+					})
+
+					It("runs the task", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+						Expect(taskDelegate.CacheHitCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when no previous run is cached", func() {
+				var fakeContainer *workerfakes.FakeContainer
+				var fakeProcess *gardenfakes.FakeProcess
+
+				BeforeEach(func() {
+					fakeDBTaskCacheFactory.FindTaskCacheReturns(nil, false, nil)
+
+					fakeContainer = new(workerfakes.FakeContainer)
+					fakeContainer.PropertyReturns("", errors.New("nope"))
+					fakeContainer.AttachReturns(nil, errors.New("nope"))
+					fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+
+					fakeVolume := new(workerfakes.FakeVolume)
+					fakeVolume.HandleReturns("some-output-handle")
+					fakeContainer.VolumeMountsReturns([]worker.VolumeMount{
+						{
+							Volume:    fakeVolume,
+							MountPath: "/tmp/build/a1f5c0c1/some-output/",
+						},
+					})
+
+					fakeProcess = new(gardenfakes.FakeProcess)
+					fakeContainer.RunReturns(fakeProcess, nil)
+					fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+				})
+
+				It("runs the task", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeContainer.RunCallCount()).To(Equal(1))
+				})
+
+				Context("when the process exits 0", func() {
+					BeforeEach(func() {
+						fakeProcess.WaitReturns(0, nil)
+					})
+
+					It("saves its outputs to the cache", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeDBTaskCacheFactory.CreateTaskCacheCallCount()).To(Equal(1))
+
+						actualTeamID, _, key, outputHandles := fakeDBTaskCacheFactory.CreateTaskCacheArgsForCall(0)
+						Expect(actualTeamID).To(Equal(123))
+
+						_, lookedUpKey := fakeDBTaskCacheFactory.FindTaskCacheArgsForCall(0)
+						Expect(key).To(Equal(lookedUpKey))
+						Expect(outputHandles).To(Equal(map[string]string{
+							"some-output": "some-output-handle",
+						}))
+					})
+				})
+
+				Context("when the process exits nonzero", func() {
+					BeforeEach(func() {
+						fakeProcess.WaitReturns(1, nil)
+					})
+
+					It("does not save its outputs to the cache", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeDBTaskCacheFactory.CreateTaskCacheCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when an input cannot be identified", func() {
+				BeforeEach(func() {
+					configSource.FetchConfigReturns(atc.TaskConfig{
+						Platform:  "some-platform",
+						RootfsURI: "some-image",
+						Run: atc.TaskRunConfig{
+							Path: "ls",
+						},
+						Inputs: []atc.TaskInputConfig{
+							{Name: "some-input"},
+						},
+					}, nil)
+
+					repo.RegisterSource("some-input", new(workerfakes.FakeArtifactSource))
+
+					fakeContainer := new(workerfakes.FakeContainer)
+					fakeContainer.PropertyReturns("", errors.New("nope"))
+					fakeContainer.AttachReturns(nil, errors.New("nope"))
+					fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+					fakeProcess := new(gardenfakes.FakeProcess)
+					fakeContainer.RunReturns(fakeProcess, nil)
+					fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+				})
+
+				It("does not consult the cache", func() {
+					Eventually(process.Wait()).Should(Receive(BeNil()))
+					Expect(fakeDBTaskCacheFactory.FindTaskCacheCallCount()).To(BeZero())
+					Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the task's image is an image_resource", func() {
+				var fakeCheckResource *resourcefakes.FakeResource
+
+				BeforeEach(func() {
+					configSource.FetchConfigReturns(atc.TaskConfig{
+						Platform: "some-platform",
+						ImageResource: &atc.ImageResource{
+							Type:   "docker",
+							Source: atc.Source{"some": "source"},
+						},
+						Run: atc.TaskRunConfig{
+							Path: "ls",
+						},
+						Outputs: []atc.TaskOutputConfig{
+							{Name: "some-output"},
+						},
+					}, nil)
+
+					fakeCheckResource = new(resourcefakes.FakeResource)
+					fakeCheckResource.CheckReturns([]atc.Version{{"v": "1"}}, nil)
+					fakeResourceFactory.NewCheckResourceReturns(fakeCheckResource, nil)
+
+					fakeContainer := new(workerfakes.FakeContainer)
+					fakeContainer.PropertyReturns("", errors.New("nope"))
+					fakeContainer.AttachReturns(nil, errors.New("nope"))
+					fakeContainer.StreamOutReturns(nil, errors.New("file not found"))
+					fakeContainer.RunReturns(new(gardenfakes.FakeProcess), nil)
+					fakeWorkerClient.FindOrCreateBuildContainerReturns(fakeContainer, nil)
+				})
+
+				It("checks for the latest version of the image resource", func() {
+					Eventually(process.Wait()).Should(Receive())
+					Expect(fakeResourceFactory.NewCheckResourceCallCount()).To(Equal(1))
+
+					_, _, _, resourceType, _, metadata, spec, actualResourceTypes, _ := fakeResourceFactory.NewCheckResourceArgsForCall(0)
+					Expect(resourceType).To(Equal("docker"))
+					Expect(metadata).To(Equal(dbng.ContainerMetadata{Type: dbng.ContainerTypeCheck}))
+					Expect(spec.ImageSpec.ResourceType).To(Equal("docker"))
+					Expect(spec.Tags).To(Equal([]string{"step", "tags"}))
+					Expect(spec.TeamID).To(Equal(123))
+					Expect(actualResourceTypes).To(Equal(resourceTypes))
+
+					Expect(fakeCheckResource.CheckCallCount()).To(Equal(1))
+					source, version := fakeCheckResource.CheckArgsForCall(0)
+					Expect(source).To(Equal(atc.Source{"some": "source"}))
+					Expect(version).To(BeNil())
+				})
+
+				Context("when a previous run with the same image version is cached", func() {
+					BeforeEach(func() {
+						fakeCreatedVolume := new(dbngfakes.FakeCreatedVolume)
+						fakeCreatedVolume.HandleReturns("some-cached-handle")
+						fakeDBWorker := new(dbngfakes.FakeWorker)
+						fakeDBWorker.NameReturns("some-worker")
+						fakeCreatedVolume.WorkerReturns(fakeDBWorker)
+
+						fakeDBTaskCacheFactory.FindTaskCacheReturns(&dbng.TaskCache{
+							BuildID: 42,
+							Volumes: map[string]dbng.CreatedVolume{
+								"some-output": fakeCreatedVolume,
+							},
+						}, true, nil)
+
+						fakeWorker := new(workerfakes.FakeWorker)
+						fakeWorker.NameReturns("some-worker")
+						fakeWorker.LookupVolumeReturns(new(workerfakes.FakeVolume), true, nil)
+						fakeWorkerClient.RunningWorkersReturns([]worker.Worker{fakeWorker}, nil)
+					})
+
+					It("does not create a container", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(BeZero())
+						Expect(taskDelegate.CacheHitCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when no previous run is cached", func() {
+					BeforeEach(func() {
+						fakeDBTaskCacheFactory.FindTaskCacheReturns(nil, false, nil)
+					})
+
+					It("creates the container with the checked version of the image", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+
+						_, _, _, _, _, _, spec, _ := fakeWorkerClient.FindOrCreateBuildContainerArgsForCall(0)
+						Expect(spec.ImageSpec.ImageResourceVersion).To(Equal(atc.Version{"v": "1"}))
+					})
+				})
+
+				Context("when checking the image resource fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeCheckResource.CheckReturns(nil, disaster)
+					})
+
+					It("exits with the error and does not create a container", func() {
+						Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+						Expect(fakeWorkerClient.FindOrCreateBuildContainerCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
 		Context("when getting the config fails", func() {
 			disaster := errors.New("nope")
 
@@ -0,0 +1,150 @@
+package exec
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/junit"
+	"github.com/concourse/atc/worker"
+	"github.com/concourse/baggageclaim"
+)
+
+// MaxTestReportSize is the size beyond which a test report is skipped rather
+// than parsed.
+const MaxTestReportSize = 10 * 1024 * 1024
+
+// recordTestResults parses the JUnit XML reports matching the step's test
+// reports glob within the configured output, and records their suites
+// against the build regardless of whether the task succeeded.
+//
+// Reports which fail to parse are skipped, and failing to record the results
+// does not fail the step.
+func (step *TaskStep) recordTestResults(logger lager.Logger, config atc.TaskConfig, container worker.Container) {
+	if step.testReports == nil {
+		return
+	}
+
+	volume, found := step.outputVolume(config, container, step.testReports.Output)
+	if !found {
+		logger.Info("test-reports-output-not-found", lager.Data{"output": step.testReports.Output})
+		return
+	}
+
+	suites, err := step.parseTestReports(logger, volume)
+	if err != nil {
+		logger.Error("failed-to-read-test-reports", err)
+		return
+	}
+
+	if len(suites) == 0 {
+		logger.Info("no-test-reports-found", lager.Data{"glob": step.testReports.Glob})
+		return
+	}
+
+	err = step.testResultFactory.SaveTestSuites(step.buildID, step.planID, suites)
+	if err != nil {
+		logger.Error("failed-to-save-test-results", err)
+	}
+}
+
+func (step *TaskStep) parseTestReports(logger lager.Logger, volume worker.Volume) ([]dbng.TestSuite, error) {
+	reportsDir := globDir(step.testReports.Glob)
+
+	tarStream, err := volume.StreamOut(reportsDir)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer tarStream.Close()
+
+	suites := []dbng.TestSuite{}
+
+	tarReader := tar.NewReader(tarStream)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			continue
+		}
+
+		reportPath := path.Join(reportsDir, header.Name)
+
+		matched, err := path.Match(step.testReports.Glob, reportPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		if header.Size > MaxTestReportSize {
+			logger.Info("test-report-too-large", lager.Data{"path": reportPath, "size": header.Size})
+			continue
+		}
+
+		parsed, err := junit.Parse(tarReader)
+		if err != nil {
+			logger.Info("failed-to-parse-test-report", lager.Data{"path": reportPath, "error": err.Error()})
+			continue
+		}
+
+		for _, suite := range parsed {
+			suites = append(suites, testSuite(suite))
+		}
+	}
+
+	return suites, nil
+}
+
+// globDir returns the directory containing everything the glob can match,
+// i.e. its leading path segments which contain no pattern characters, so that
+// only that directory need be streamed out of the volume.
+func globDir(glob string) string {
+	fixed := glob
+	if i := strings.IndexAny(glob, `*?[\`); i != -1 {
+		fixed = glob[:i]
+	}
+
+	slash := strings.LastIndex(fixed, "/")
+	if slash == -1 {
+		return "."
+	}
+
+	return path.Clean(fixed[:slash])
+}
+
+func testSuite(suite junit.Suite) dbng.TestSuite {
+	cases := []dbng.TestCase{}
+	for _, c := range suite.Cases {
+		cases = append(cases, dbng.TestCase{
+			ClassName: c.ClassName,
+			Name:      c.Name,
+			Status:    dbng.TestStatus(c.Status),
+			Duration:  c.Duration,
+			Message:   c.Message,
+		})
+	}
+
+	return dbng.TestSuite{
+		Name:     suite.Name,
+		Duration: suite.Duration,
+		Cases:    cases,
+	}
+}
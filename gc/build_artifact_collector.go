@@ -0,0 +1,59 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+type buildArtifactCollector struct {
+	logger          lager.Logger
+	pipelineFactory dbng.PipelineFactory
+	artifactFactory dbng.BuildArtifactFactory
+}
+
+func NewBuildArtifactCollector(
+	logger lager.Logger,
+	pipelineFactory dbng.PipelineFactory,
+	artifactFactory dbng.BuildArtifactFactory,
+) Collector {
+	return &buildArtifactCollector{
+		logger:          logger.Session("build-artifact-collector"),
+		pipelineFactory: pipelineFactory,
+		artifactFactory: artifactFactory,
+	}
+}
+
+// Run expires the artifacts of builds that fall outside of each job's
+// build_logs_to_retain. Jobs without retention configured only keep the
+// artifacts of their latest succeeded build, as that's the only one that will
+// ever be fetched.
+func (bac *buildArtifactCollector) Run() error {
+	pipelines, err := bac.pipelineFactory.AllPipelines()
+	if err != nil {
+		bac.logger.Error("could-not-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			bac.logger.Error("could-not-get-jobs", err)
+			return err
+		}
+
+		for _, job := range jobs {
+			buildsToRetain := job.Config().BuildLogsToRetain
+			if buildsToRetain == 0 {
+				buildsToRetain = 1
+			}
+
+			err := bac.artifactFactory.ExpireJobArtifacts(job.ID(), buildsToRetain)
+			if err != nil {
+				bac.logger.Error("could-not-expire-job-artifacts", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
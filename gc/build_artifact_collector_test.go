@@ -0,0 +1,96 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArtifactCollector", func() {
+	var (
+		collector gc.Collector
+
+		fakePipelineFactory  *dbngfakes.FakePipelineFactory
+		fakeArtifactFactory  *dbngfakes.FakeBuildArtifactFactory
+		fakePipeline         *dbngfakes.FakePipeline
+		fakeJob              *dbngfakes.FakeJob
+		fakeJobWithRetention *dbngfakes.FakeJob
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbngfakes.FakePipelineFactory)
+		fakeArtifactFactory = new(dbngfakes.FakeBuildArtifactFactory)
+
+		fakeJob = new(dbngfakes.FakeJob)
+		fakeJob.IDReturns(1)
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job"})
+
+		fakeJobWithRetention = new(dbngfakes.FakeJob)
+		fakeJobWithRetention.IDReturns(2)
+		fakeJobWithRetention.ConfigReturns(atc.JobConfig{
+			Name:              "some-other-job",
+			BuildLogsToRetain: 10,
+		})
+
+		fakePipeline = new(dbngfakes.FakePipeline)
+		fakePipeline.JobsReturns([]dbng.Job{fakeJob, fakeJobWithRetention}, nil)
+
+		fakePipelineFactory.AllPipelinesReturns([]dbng.Pipeline{fakePipeline}, nil)
+
+		collector = gc.NewBuildArtifactCollector(
+			lagertest.NewTestLogger("test"),
+			fakePipelineFactory,
+			fakeArtifactFactory,
+		)
+	})
+
+	JustBeforeEach(func() {
+		err = collector.Run()
+	})
+
+	It("expires artifacts according to each job's retention", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeArtifactFactory.ExpireJobArtifactsCallCount()).To(Equal(2))
+
+		jobID, buildsToRetain := fakeArtifactFactory.ExpireJobArtifactsArgsForCall(0)
+		Expect(jobID).To(Equal(1))
+		Expect(buildsToRetain).To(Equal(1))
+
+		jobID, buildsToRetain = fakeArtifactFactory.ExpireJobArtifactsArgsForCall(1)
+		Expect(jobID).To(Equal(2))
+		Expect(buildsToRetain).To(Equal(10))
+	})
+
+	Context("when getting the pipelines fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+
+	Context("when expiring artifacts fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeArtifactFactory.ExpireJobArtifactsReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package gc
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/blobstore"
 	"github.com/concourse/atc/dbng"
 )
 
@@ -10,20 +11,29 @@ type BuildReaper interface {
 }
 
 type buildReaper struct {
-	logger          lager.Logger
-	pipelineFactory dbng.PipelineFactory
-	batchSize       int
+	logger                lager.Logger
+	pipelineFactory       dbng.PipelineFactory
+	storedArtifactFactory dbng.StoredArtifactFactory
+	artifactStore         blobstore.Store
+	batchSize             int
 }
 
+// NewBuildReaper constructs a BuildReaper which removes the events and stored
+// artifacts of builds beyond their job's build_logs_to_retain. The artifact
+// store may be nil if artifacts are not being kept.
 func NewBuildReaper(
 	logger lager.Logger,
 	pipelineFactory dbng.PipelineFactory,
+	storedArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
 	batchSize int,
 ) BuildReaper {
 	return &buildReaper{
-		logger:          logger,
-		pipelineFactory: pipelineFactory,
-		batchSize:       batchSize,
+		logger:                logger,
+		pipelineFactory:       pipelineFactory,
+		storedArtifactFactory: storedArtifactFactory,
+		artifactStore:         artifactStore,
+		batchSize:             batchSize,
 	}
 }
 
@@ -128,6 +138,12 @@ func (br *buildReaper) Run() error {
 				return err
 			}
 
+			err = br.deleteStoredArtifacts(buildIDsToDelete)
+			if err != nil {
+				br.logger.Error("could-not-delete-stored-artifacts", err)
+				return err
+			}
+
 			err = job.UpdateFirstLoggedBuildID(buildIDsToDelete[len(buildIDsToDelete)-1] + 1)
 			if err != nil {
 				br.logger.Error("could-not-update-first-logged-build-id", err)
@@ -138,3 +154,12 @@ func (br *buildReaper) Run() error {
 
 	return nil
 }
+
+func (br *buildReaper) deleteStoredArtifacts(buildIDs []int) error {
+	artifacts, err := br.storedArtifactFactory.FindStoredArtifacts(buildIDs)
+	if err != nil {
+		return err
+	}
+
+	return removeStoredArtifacts(br.storedArtifactFactory, br.artifactStore, artifacts)
+}
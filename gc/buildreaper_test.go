@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/gc"
@@ -16,13 +17,17 @@ import (
 
 var _ = Describe("BuildReaper", func() {
 	var (
-		buildReaper         BuildReaper
-		fakePipelineFactory *dbngfakes.FakePipelineFactory
-		batchSize           int
+		buildReaper               BuildReaper
+		fakePipelineFactory       *dbngfakes.FakePipelineFactory
+		fakeStoredArtifactFactory *dbngfakes.FakeStoredArtifactFactory
+		fakeArtifactStore         *blobstorefakes.FakeStore
+		batchSize                 int
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbngfakes.FakePipelineFactory)
+		fakeStoredArtifactFactory = new(dbngfakes.FakeStoredArtifactFactory)
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
 		batchSize = 5
 	})
 
@@ -31,6 +36,8 @@ var _ = Describe("BuildReaper", func() {
 		buildReaper = NewBuildReaper(
 			buildReaperLogger,
 			fakePipelineFactory,
+			fakeStoredArtifactFactory,
+			fakeArtifactStore,
 			batchSize,
 		)
 	})
@@ -110,6 +117,48 @@ var _ = Describe("BuildReaper", func() {
 						actualNewFirstLoggedBuildID := fakeJob.UpdateFirstLoggedBuildIDArgsForCall(0)
 						Expect(actualNewFirstLoggedBuildID).To(Equal(11))
 					})
+
+					Context("when the reaped builds stored artifacts", func() {
+						BeforeEach(func() {
+							fakeStoredArtifactFactory.FindStoredArtifactsReturns([]dbng.StoredArtifact{
+								{ID: 1, BuildID: 6, BlobKey: "builds/6/some-reports.tgz"},
+								{ID: 2, BuildID: 8, BlobKey: "builds/8/some-reports.tgz"},
+							}, nil)
+						})
+
+						It("deletes their blobs and records", func() {
+							err := buildReaper.Run()
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(fakeStoredArtifactFactory.FindStoredArtifactsCallCount()).To(Equal(1))
+							Expect(fakeStoredArtifactFactory.FindStoredArtifactsArgsForCall(0)).To(ConsistOf(6, 7, 8, 9, 10))
+
+							Expect(fakeArtifactStore.DeleteCallCount()).To(Equal(2))
+							Expect(fakeArtifactStore.DeleteArgsForCall(0)).To(Equal("builds/6/some-reports.tgz"))
+							Expect(fakeArtifactStore.DeleteArgsForCall(1)).To(Equal("builds/8/some-reports.tgz"))
+
+							Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsCallCount()).To(Equal(1))
+							Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsArgsForCall(0)).To(Equal([]int{1, 2}))
+						})
+
+						Context("when deleting a blob fails", func() {
+							var disaster error
+
+							BeforeEach(func() {
+								disaster = errors.New("major malfunction")
+
+								fakeArtifactStore.DeleteReturns(disaster)
+							})
+
+							It("returns the error without forgetting the artifacts", func() {
+								err := buildReaper.Run()
+								Expect(err).To(Equal(disaster))
+
+								Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsCallCount()).To(BeZero())
+								Expect(fakeJob.UpdateFirstLoggedBuildIDCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 
 				Context("when deleting build events fails", func() {
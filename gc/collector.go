@@ -1,6 +1,11 @@
 package gc
 
-import "code.cloudfoundry.org/lager"
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
 
 //go:generate counterfeiter . Collector
 
@@ -16,6 +21,10 @@ type aggregateCollector struct {
 	resourceConfigUseCollector Collector
 	resourceConfigCollector    Collector
 	resourceCacheCollector     Collector
+	taskCacheCollector         Collector
+	buildArtifactCollector     Collector
+	storedArtifactCollector    Collector
+	volumeEvictionCollector    Collector
 	volumeCollector            Collector
 	containerCollector         Collector
 }
@@ -28,6 +37,10 @@ func NewCollector(
 	resourceConfigUses Collector,
 	resourceConfigs Collector,
 	resourceCaches Collector,
+	taskCaches Collector,
+	buildArtifacts Collector,
+	storedArtifacts Collector,
+	volumeEvictions Collector,
 	volumes Collector,
 	containers Collector,
 ) Collector {
@@ -39,53 +52,42 @@ func NewCollector(
 		resourceConfigUseCollector: resourceConfigUses,
 		resourceConfigCollector:    resourceConfigs,
 		resourceCacheCollector:     resourceCaches,
+		taskCacheCollector:         taskCaches,
+		buildArtifactCollector:     buildArtifacts,
+		storedArtifactCollector:    storedArtifacts,
+		volumeEvictionCollector:    volumeEvictions,
 		volumeCollector:            volumes,
 		containerCollector:         containers,
 	}
 }
 
 func (c *aggregateCollector) Run() error {
-	var err error
-
-	err = c.buildCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-build-collector", err)
-	}
-
-	err = c.workerCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-worker-collector", err)
-	}
-
-	err = c.resourceCacheUseCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-resource-cache-use-collector", err)
-	}
+	c.run("build-collector", c.buildCollector)
+	c.run("worker-collector", c.workerCollector)
+	c.run("resource-cache-use-collector", c.resourceCacheUseCollector)
+	c.run("resource-config-use-collector", c.resourceConfigUseCollector)
+	c.run("resource-config-collector", c.resourceConfigCollector)
+	c.run("resource-cache-collector", c.resourceCacheCollector)
+	c.run("task-cache-collector", c.taskCacheCollector)
+	c.run("build-artifact-collector", c.buildArtifactCollector)
+	c.run("stored-artifact-collector", c.storedArtifactCollector)
+	c.run("volume-eviction-collector", c.volumeEvictionCollector)
+	c.run("container-collector", c.containerCollector)
+	c.run("volume-collector", c.volumeCollector)
 
-	err = c.resourceConfigUseCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-resource-config-use-collector", err)
-	}
-
-	err = c.resourceConfigCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-resource-config-collector", err)
-	}
-
-	err = c.resourceCacheCollector.Run()
-	if err != nil {
-		c.logger.Error("failed-to-run-resource-cache-collector", err)
-	}
+	return nil
+}
 
-	err = c.containerCollector.Run()
-	if err != nil {
-		c.logger.Error("container-collector", err)
-	}
+func (c *aggregateCollector) run(name string, collector Collector) {
+	start := time.Now()
 
-	err = c.volumeCollector.Run()
+	err := collector.Run()
 	if err != nil {
-		c.logger.Error("volume-collector", err)
+		c.logger.Error("failed-to-run-"+name, err)
 	}
 
-	return nil
+	metric.GCCollectorDuration{
+		Collector: name,
+		Duration:  time.Since(start),
+	}.Emit(c.logger)
 }
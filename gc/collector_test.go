@@ -7,6 +7,7 @@ import (
 
 	. "github.com/concourse/atc/gc"
 	"github.com/concourse/atc/gc/gcfakes"
+	"github.com/concourse/atc/metric"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -22,6 +23,10 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeResourceConfigUseCollector *gcfakes.FakeCollector
 		fakeResourceConfigCollector    *gcfakes.FakeCollector
 		fakeResourceCacheCollector     *gcfakes.FakeCollector
+		fakeTaskCacheCollector         *gcfakes.FakeCollector
+		fakeBuildArtifactCollector     *gcfakes.FakeCollector
+		fakeStoredArtifactCollector    *gcfakes.FakeCollector
+		fakeVolumeEvictionCollector    *gcfakes.FakeCollector
 		fakeVolumeCollector            *gcfakes.FakeCollector
 		fakeContainerCollector         *gcfakes.FakeCollector
 
@@ -37,6 +42,10 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeResourceConfigUseCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCollector = new(gcfakes.FakeCollector)
 		fakeResourceCacheCollector = new(gcfakes.FakeCollector)
+		fakeTaskCacheCollector = new(gcfakes.FakeCollector)
+		fakeBuildArtifactCollector = new(gcfakes.FakeCollector)
+		fakeStoredArtifactCollector = new(gcfakes.FakeCollector)
+		fakeVolumeEvictionCollector = new(gcfakes.FakeCollector)
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 
@@ -48,6 +57,10 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeResourceConfigUseCollector,
 			fakeResourceConfigCollector,
 			fakeResourceCacheCollector,
+			fakeTaskCacheCollector,
+			fakeBuildArtifactCollector,
+			fakeStoredArtifactCollector,
+			fakeVolumeEvictionCollector,
 			fakeVolumeCollector,
 			fakeContainerCollector,
 		)
@@ -64,6 +77,16 @@ var _ = Describe("Aggregate Collector", func() {
 			Expect(fakeBuildCollector.RunCallCount()).To(Equal(1))
 		})
 
+		It("emits how long each collector took", func() {
+			collectorOf := func(event metric.Event) string {
+				return event.Attributes["collector"]
+			}
+
+			Eventually(metricEmitter.Events("gc collector duration (ms)")).Should(ContainElement(WithTransform(collectorOf, Equal("build-collector"))))
+			Eventually(metricEmitter.Events("gc collector duration (ms)")).Should(ContainElement(WithTransform(collectorOf, Equal("container-collector"))))
+			Eventually(metricEmitter.Events("gc collector duration (ms)")).Should(ContainElement(WithTransform(collectorOf, Equal("volume-collector"))))
+		})
+
 		Context("when the build collector errors", func() {
 			BeforeEach(func() {
 				fakeBuildCollector.RunReturns(disaster)
@@ -79,10 +102,61 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeResourceConfigUseCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceCacheCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeTaskCacheCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeBuildArtifactCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeStoredArtifactCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+			})
+
+		})
+
+		Context("when the task cache collector errors", func() {
+			BeforeEach(func() {
+				fakeTaskCacheCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeBuildArtifactCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeEvictionCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the build artifact collector errors", func() {
+			BeforeEach(func() {
+				fakeBuildArtifactCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeVolumeEvictionCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 			})
+		})
 
+		Context("when the volume eviction collector errors", func() {
+			BeforeEach(func() {
+				fakeVolumeEvictionCollector.RunReturns(disaster)
+			})
+
+			It("does not return an error", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("runs the rest of collectors", func() {
+				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
+			})
 		})
 
 		Context("when the build collector succeeds", func() {
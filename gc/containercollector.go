@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/garden/client/connection"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
 )
 
 const HijackedContainerTimeout = 5 * time.Minute
@@ -145,15 +146,22 @@ func (c *containerCollector) Run() error {
 		}
 	}
 
+	deleted := 0
 	for _, destroyingContainer := range destroyingContainers {
 		cLog := logger.Session("destroy-container", lager.Data{
 			"container": destroyingContainer.Handle(),
 			"worker":    destroyingContainer.WorkerName(),
 		})
 
-		c.tryToDestroyContainer(cLog, destroyingContainer, workersByName)
+		if c.tryToDestroyContainer(cLog, destroyingContainer, workersByName) {
+			deleted++
+		}
 	}
 
+	metric.ContainersDeleted{
+		Count: deleted,
+	}.Emit(logger)
+
 	return nil
 }
 
@@ -205,24 +213,24 @@ func (c *containerCollector) markHijackedContainerAsDestroying(
 	return nil
 }
 
-func (c *containerCollector) tryToDestroyContainer(logger lager.Logger, container dbng.DestroyingContainer, workersByName map[string]dbng.Worker) {
+func (c *containerCollector) tryToDestroyContainer(logger lager.Logger, container dbng.DestroyingContainer, workersByName map[string]dbng.Worker) bool {
 	logger.Debug("start")
 	defer logger.Debug("done")
 
 	w, found := workersByName[container.WorkerName()]
 	if !found {
 		logger.Info("worker-not-found")
-		return
+		return false
 	}
 	if w.State() == dbng.WorkerStateStalled || w.State() == dbng.WorkerStateLanded {
 		logger.Debug("worker-is-not-available", lager.Data{"state": string(w.State())})
-		return
+		return false
 	}
 
 	gclient, err := c.gardenClientFactory(w, logger)
 	if err != nil {
 		logger.Error("failed-to-get-garden-client-for-worker", err)
-		return
+		return false
 	}
 
 	if container.IsDiscontinued() {
@@ -234,11 +242,11 @@ func (c *containerCollector) tryToDestroyContainer(logger lager.Logger, containe
 				logger.Debug("container-no-longer-present-in-garden")
 			} else {
 				logger.Error("failed-to-lookup-container-in-garden", err)
-				return
+				return false
 			}
 		} else {
 			logger.Debug("still-present-in-garden")
-			return
+			return false
 		}
 	} else {
 		err = gclient.Destroy(container.Handle())
@@ -247,7 +255,7 @@ func (c *containerCollector) tryToDestroyContainer(logger lager.Logger, containe
 				logger.Debug("container-no-longer-present-in-garden")
 			} else {
 				logger.Error("failed-to-destroy-garden-container", err)
-				return
+				return false
 			}
 		}
 
@@ -257,13 +265,15 @@ func (c *containerCollector) tryToDestroyContainer(logger lager.Logger, containe
 	ok, err := container.Destroy()
 	if err != nil {
 		logger.Error("failed-to-destroy-database-container", err)
-		return
+		return false
 	}
 
 	if !ok {
 		logger.Info("could-not-destroy-database-container")
-		return
+		return false
 	}
 
 	logger.Debug("destroyed-in-db")
+
+	return true
 }
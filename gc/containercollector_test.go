@@ -7,6 +7,7 @@ import (
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/gc/gcfakes"
+	"github.com/concourse/atc/metric"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -200,6 +201,15 @@ var _ = Describe("ContainerCollector", func() {
 			Expect(fakeGardenClient.DestroyArgsForCall(2)).To(Equal("some-handle-1"))
 		})
 
+		It("emits the number of containers it deleted", func() {
+			Eventually(metricEmitter.Events("containers deleted")).Should(ContainElement(metric.Event{
+				Name:       "containers deleted",
+				Value:      3,
+				State:      metric.EventStateOK,
+				Attributes: map[string]string{},
+			}))
+		})
+
 		Context("when there are destroying containers that are discontinued", func() {
 			BeforeEach(func() {
 				destroyingContainer.IsDiscontinuedReturns(true)
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/lock"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric/metrictest"
 	"github.com/concourse/atc/postgresrunner"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -44,6 +45,8 @@ var (
 
 	usedResource dbng.Resource
 	logger       *lagertest.TestLogger
+
+	metricEmitter *metrictest.Emitter
 )
 
 var _ = BeforeSuite(func() {
@@ -54,6 +57,9 @@ var _ = BeforeSuite(func() {
 	dbProcess = ifrit.Invoke(postgresRunner)
 
 	postgresRunner.CreateTestDB()
+
+	metricEmitter, err = metrictest.Initialize(lagertest.NewTestLogger("metrics"))
+	Expect(err).NotTo(HaveOccurred())
 })
 
 var _ = BeforeEach(func() {
@@ -0,0 +1,116 @@
+package gc
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/blobstore"
+	"github.com/concourse/atc/dbng"
+)
+
+type storedArtifactCollector struct {
+	logger                lager.Logger
+	pipelineFactory       dbng.PipelineFactory
+	storedArtifactFactory dbng.StoredArtifactFactory
+	artifactStore         blobstore.Store
+	maxAge                time.Duration
+}
+
+// NewStoredArtifactCollector constructs a Collector which removes the stored
+// artifacts that the build reaper never will: those of one-off builds and of
+// jobs without build_logs_to_retain, once they are older than maxAge. A
+// maxAge of 0 keeps them indefinitely. The artifact store may be nil if
+// artifacts are not being kept.
+func NewStoredArtifactCollector(
+	logger lager.Logger,
+	pipelineFactory dbng.PipelineFactory,
+	storedArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	maxAge time.Duration,
+) Collector {
+	return &storedArtifactCollector{
+		logger:                logger,
+		pipelineFactory:       pipelineFactory,
+		storedArtifactFactory: storedArtifactFactory,
+		artifactStore:         artifactStore,
+		maxAge:                maxAge,
+	}
+}
+
+func (sac *storedArtifactCollector) Run() error {
+	if sac.maxAge == 0 {
+		return nil
+	}
+
+	artifacts, err := sac.storedArtifactFactory.FindExpiredOneOffStoredArtifacts(sac.maxAge)
+	if err != nil {
+		sac.logger.Error("could-not-find-one-off-stored-artifacts", err)
+		return err
+	}
+
+	err = removeStoredArtifacts(sac.storedArtifactFactory, sac.artifactStore, artifacts)
+	if err != nil {
+		sac.logger.Error("could-not-remove-one-off-stored-artifacts", err)
+		return err
+	}
+
+	pipelines, err := sac.pipelineFactory.AllPipelines()
+	if err != nil {
+		sac.logger.Error("could-not-get-pipelines", err)
+		return err
+	}
+
+	for _, pipeline := range pipelines {
+		jobs, err := pipeline.Jobs()
+		if err != nil {
+			sac.logger.Error("could-not-get-jobs", err)
+			return err
+		}
+
+		for _, job := range jobs {
+			if job.Config().BuildLogsToRetain != 0 {
+				continue
+			}
+
+			artifacts, err := sac.storedArtifactFactory.FindExpiredJobStoredArtifacts(job.ID(), sac.maxAge)
+			if err != nil {
+				sac.logger.Error("could-not-find-job-stored-artifacts", err)
+				return err
+			}
+
+			err = removeStoredArtifacts(sac.storedArtifactFactory, sac.artifactStore, artifacts)
+			if err != nil {
+				sac.logger.Error("could-not-remove-job-stored-artifacts", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeStoredArtifacts deletes the artifacts' blobs and then their records,
+// so that a failure leaves the records to be retried.
+func removeStoredArtifacts(
+	storedArtifactFactory dbng.StoredArtifactFactory,
+	artifactStore blobstore.Store,
+	artifacts []dbng.StoredArtifact,
+) error {
+	if len(artifacts) == 0 {
+		return nil
+	}
+
+	artifactIDs := []int{}
+	for _, artifact := range artifacts {
+		if artifactStore != nil {
+			err := artifactStore.Delete(artifact.BlobKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		artifactIDs = append(artifactIDs, artifact.ID)
+	}
+
+	return storedArtifactFactory.DeleteStoredArtifacts(artifactIDs)
+}
@@ -0,0 +1,138 @@
+package gc_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/blobstore/blobstorefakes"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoredArtifactCollector", func() {
+	var (
+		collector gc.Collector
+
+		fakePipelineFactory       *dbngfakes.FakePipelineFactory
+		fakeStoredArtifactFactory *dbngfakes.FakeStoredArtifactFactory
+		fakeArtifactStore         *blobstorefakes.FakeStore
+		fakePipeline              *dbngfakes.FakePipeline
+		fakeJob                   *dbngfakes.FakeJob
+		fakeJobWithRetention      *dbngfakes.FakeJob
+
+		maxAge time.Duration
+
+		err error
+	)
+
+	BeforeEach(func() {
+		fakePipelineFactory = new(dbngfakes.FakePipelineFactory)
+		fakeStoredArtifactFactory = new(dbngfakes.FakeStoredArtifactFactory)
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
+
+		fakeJob = new(dbngfakes.FakeJob)
+		fakeJob.IDReturns(1)
+		fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job"})
+
+		fakeJobWithRetention = new(dbngfakes.FakeJob)
+		fakeJobWithRetention.IDReturns(2)
+		fakeJobWithRetention.ConfigReturns(atc.JobConfig{
+			Name:              "some-other-job",
+			BuildLogsToRetain: 10,
+		})
+
+		fakePipeline = new(dbngfakes.FakePipeline)
+		fakePipeline.JobsReturns([]dbng.Job{fakeJob, fakeJobWithRetention}, nil)
+
+		fakePipelineFactory.AllPipelinesReturns([]dbng.Pipeline{fakePipeline}, nil)
+
+		fakeStoredArtifactFactory.FindExpiredOneOffStoredArtifactsReturns([]dbng.StoredArtifact{
+			{ID: 1, BlobKey: "one-off-key"},
+		}, nil)
+
+		fakeStoredArtifactFactory.FindExpiredJobStoredArtifactsReturns([]dbng.StoredArtifact{
+			{ID: 2, BlobKey: "job-key"},
+		}, nil)
+
+		maxAge = time.Hour
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewStoredArtifactCollector(
+			lagertest.NewTestLogger("test"),
+			fakePipelineFactory,
+			fakeStoredArtifactFactory,
+			fakeArtifactStore,
+			maxAge,
+		)
+
+		err = collector.Run()
+	})
+
+	It("removes the expired artifacts of one-off builds", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeStoredArtifactFactory.FindExpiredOneOffStoredArtifactsCallCount()).To(Equal(1))
+		Expect(fakeStoredArtifactFactory.FindExpiredOneOffStoredArtifactsArgsForCall(0)).To(Equal(time.Hour))
+
+		Expect(fakeArtifactStore.DeleteArgsForCall(0)).To(Equal("one-off-key"))
+		Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsArgsForCall(0)).To(Equal([]int{1}))
+	})
+
+	It("removes the expired artifacts of jobs without build_logs_to_retain", func() {
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeStoredArtifactFactory.FindExpiredJobStoredArtifactsCallCount()).To(Equal(1))
+		jobID, jobMaxAge := fakeStoredArtifactFactory.FindExpiredJobStoredArtifactsArgsForCall(0)
+		Expect(jobID).To(Equal(1))
+		Expect(jobMaxAge).To(Equal(time.Hour))
+
+		Expect(fakeArtifactStore.DeleteCallCount()).To(Equal(2))
+		Expect(fakeArtifactStore.DeleteArgsForCall(1)).To(Equal("job-key"))
+		Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsArgsForCall(1)).To(Equal([]int{2}))
+	})
+
+	Context("when the max age is 0", func() {
+		BeforeEach(func() {
+			maxAge = 0
+		})
+
+		It("keeps every artifact", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStoredArtifactFactory.FindExpiredOneOffStoredArtifactsCallCount()).To(BeZero())
+			Expect(fakeStoredArtifactFactory.FindExpiredJobStoredArtifactsCallCount()).To(BeZero())
+			Expect(fakeArtifactStore.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when deleting a blob fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeArtifactStore.DeleteReturns(disaster)
+		})
+
+		It("returns the error without forgetting the artifacts", func() {
+			Expect(err).To(Equal(disaster))
+			Expect(fakeStoredArtifactFactory.DeleteStoredArtifactsCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when getting the pipelines fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakePipelineFactory.AllPipelinesReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(err).To(Equal(disaster))
+		})
+	})
+})
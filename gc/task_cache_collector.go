@@ -0,0 +1,25 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+type taskCacheCollector struct {
+	logger       lager.Logger
+	cacheFactory dbng.TaskCacheFactory
+}
+
+func NewTaskCacheCollector(
+	logger lager.Logger,
+	cacheFactory dbng.TaskCacheFactory,
+) Collector {
+	return &taskCacheCollector{
+		logger:       logger.Session("task-cache-collector"),
+		cacheFactory: cacheFactory,
+	}
+}
+
+func (tcc *taskCacheCollector) Run() error {
+	return tcc.cacheFactory.CleanUpSupersededCaches()
+}
@@ -0,0 +1,40 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskCacheCollector", func() {
+	var (
+		collector            gc.Collector
+		fakeTaskCacheFactory *dbngfakes.FakeTaskCacheFactory
+	)
+
+	BeforeEach(func() {
+		logger := lagertest.NewTestLogger("task-cache-collector")
+		fakeTaskCacheFactory = new(dbngfakes.FakeTaskCacheFactory)
+
+		collector = gc.NewTaskCacheCollector(logger, fakeTaskCacheFactory)
+	})
+
+	Describe("Run", func() {
+		It("cleans up superseded caches", func() {
+			Expect(collector.Run()).To(Succeed())
+			Expect(fakeTaskCacheFactory.CleanUpSupersededCachesCallCount()).To(Equal(1))
+		})
+
+		It("returns an error if cleaning up fails", func() {
+			disaster := errors.New("nope")
+			fakeTaskCacheFactory.CleanUpSupersededCachesReturns(disaster)
+
+			Expect(collector.Run()).To(Equal(disaster))
+		})
+	})
+})
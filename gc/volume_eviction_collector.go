@@ -0,0 +1,75 @@
+package gc
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/dbng"
+)
+
+// VolumeEvictionBatchSize is the most cache volumes evicted from a worker in
+// a single run. Volume sizes are not known, so eviction carries on run by run
+// until the worker reports its disk usage below the threshold.
+const VolumeEvictionBatchSize = 10
+
+type volumeEvictionCollector struct {
+	logger        lager.Logger
+	workerFactory dbng.WorkerFactory
+	volumeFactory dbng.VolumeFactory
+	threshold     int
+}
+
+// NewVolumeEvictionCollector constructs a Collector which evicts the least
+// recently used resource cache and task cache volumes from workers whose disk
+// usage is at or above the given threshold, as a percentage. A threshold of 0
+// disables eviction.
+func NewVolumeEvictionCollector(
+	logger lager.Logger,
+	workerFactory dbng.WorkerFactory,
+	volumeFactory dbng.VolumeFactory,
+	threshold int,
+) Collector {
+	return &volumeEvictionCollector{
+		logger:        logger,
+		workerFactory: workerFactory,
+		volumeFactory: volumeFactory,
+		threshold:     threshold,
+	}
+}
+
+func (vec *volumeEvictionCollector) Run() error {
+	if vec.threshold == 0 {
+		return nil
+	}
+
+	logger := vec.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	workers, err := vec.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	for _, worker := range workers {
+		disk := worker.Disk()
+		if disk == nil || disk.UsedPercent() < vec.threshold {
+			continue
+		}
+
+		wLog := logger.WithData(lager.Data{
+			"worker":       worker.Name(),
+			"used-percent": disk.UsedPercent(),
+		})
+
+		evicted, err := vec.volumeFactory.EvictCacheVolumes(worker.Name(), VolumeEvictionBatchSize)
+		if err != nil {
+			wLog.Error("failed-to-evict-cache-volumes", err)
+			continue
+		}
+
+		wLog.Info("evicted-cache-volumes", lager.Data{"count": evicted})
+	}
+
+	return nil
+}
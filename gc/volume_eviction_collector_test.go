@@ -0,0 +1,107 @@
+package gc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VolumeEvictionCollector", func() {
+	var (
+		collector         gc.Collector
+		fakeWorkerFactory *dbngfakes.FakeWorkerFactory
+		fakeVolumeFactory *dbngfakes.FakeVolumeFactory
+		threshold         int
+
+		fullWorker       *dbngfakes.FakeWorker
+		roomyWorker      *dbngfakes.FakeWorker
+		unknownWorker    *dbngfakes.FakeWorker
+		borderlineWorker *dbngfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbngfakes.FakeWorkerFactory)
+		fakeVolumeFactory = new(dbngfakes.FakeVolumeFactory)
+		threshold = 80
+
+		fullWorker = new(dbngfakes.FakeWorker)
+		fullWorker.NameReturns("full-worker")
+		fullWorker.DiskReturns(&atc.WorkerDisk{Used: 85, Total: 100})
+
+		roomyWorker = new(dbngfakes.FakeWorker)
+		roomyWorker.NameReturns("roomy-worker")
+		roomyWorker.DiskReturns(&atc.WorkerDisk{Used: 10, Total: 100})
+
+		unknownWorker = new(dbngfakes.FakeWorker)
+		unknownWorker.NameReturns("unknown-worker")
+
+		borderlineWorker = new(dbngfakes.FakeWorker)
+		borderlineWorker.NameReturns("borderline-worker")
+		borderlineWorker.DiskReturns(&atc.WorkerDisk{Used: 80, Total: 100})
+
+		fakeWorkerFactory.WorkersReturns([]dbng.Worker{
+			fullWorker,
+			roomyWorker,
+			unknownWorker,
+			borderlineWorker,
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewVolumeEvictionCollector(
+			lagertest.NewTestLogger("volume-eviction-collector"),
+			fakeWorkerFactory,
+			fakeVolumeFactory,
+			threshold,
+		)
+	})
+
+	Describe("Run", func() {
+		It("evicts cache volumes from workers at or above the threshold", func() {
+			Expect(collector.Run()).To(Succeed())
+
+			Expect(fakeVolumeFactory.EvictCacheVolumesCallCount()).To(Equal(2))
+
+			workerName, limit := fakeVolumeFactory.EvictCacheVolumesArgsForCall(0)
+			Expect(workerName).To(Equal("full-worker"))
+			Expect(limit).To(Equal(gc.VolumeEvictionBatchSize))
+
+			workerName, limit = fakeVolumeFactory.EvictCacheVolumesArgsForCall(1)
+			Expect(workerName).To(Equal("borderline-worker"))
+			Expect(limit).To(Equal(gc.VolumeEvictionBatchSize))
+		})
+
+		It("carries on with other workers if evicting from one fails", func() {
+			fakeVolumeFactory.EvictCacheVolumesReturnsOnCall(0, 0, errors.New("nope"))
+
+			Expect(collector.Run()).To(Succeed())
+			Expect(fakeVolumeFactory.EvictCacheVolumesCallCount()).To(Equal(2))
+		})
+
+		It("returns an error if the workers cannot be listed", func() {
+			disaster := errors.New("nope")
+			fakeWorkerFactory.WorkersReturns(nil, disaster)
+
+			Expect(collector.Run()).To(Equal(disaster))
+		})
+
+		Context("when the threshold is 0", func() {
+			BeforeEach(func() {
+				threshold = 0
+			})
+
+			It("does not evict anything", func() {
+				Expect(collector.Run()).To(Succeed())
+				Expect(fakeWorkerFactory.WorkersCallCount()).To(BeZero())
+				Expect(fakeVolumeFactory.EvictCacheVolumesCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim"
 	bclient "github.com/concourse/baggageclaim/client"
 )
@@ -125,6 +126,7 @@ func (vc *volumeCollector) Run() error {
 		destroyingVolumes = append(destroyingVolumes, destroyingVolume)
 	}
 
+	deleted := 0
 	for _, destroyingVolume := range destroyingVolumes {
 		vLog := logger.Session("destroy", lager.Data{
 			"handle": destroyingVolume.Handle(),
@@ -145,10 +147,16 @@ func (vc *volumeCollector) Run() error {
 		}
 
 		if vc.destroyRealVolume(vLog.Session("in-worker"), volume, found) {
-			vc.destroyDBVolume(vLog.Session("in-db"), destroyingVolume)
+			if vc.destroyDBVolume(vLog.Session("in-db"), destroyingVolume) {
+				deleted++
+			}
 		}
 	}
 
+	metric.VolumesDeleted{
+		Count: deleted,
+	}.Emit(logger)
+
 	return nil
 }
 
@@ -170,19 +178,21 @@ func (vc *volumeCollector) destroyRealVolume(logger lager.Logger, volume baggage
 	return true
 }
 
-func (vc *volumeCollector) destroyDBVolume(logger lager.Logger, dbVolume dbng.DestroyingVolume) {
+func (vc *volumeCollector) destroyDBVolume(logger lager.Logger, dbVolume dbng.DestroyingVolume) bool {
 	logger.Debug("destroying")
 
 	destroyed, err := dbVolume.Destroy()
 	if err != nil {
 		logger.Error("failed-to-destroy", err)
-		return
+		return false
 	}
 
 	if !destroyed {
 		logger.Info("could-not-destroy")
-		return
+		return false
 	}
 
 	logger.Debug("destroyed")
+
+	return true
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/gc"
 	"github.com/concourse/atc/gc/gcfakes"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -122,6 +123,18 @@ var _ = Describe("VolumeCollector", func() {
 			Expect(fakeBCVolume.DestroyCallCount()).To(Equal(2))
 		})
 
+		It("emits the number of volumes it deleted", func() {
+			err := volumeCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(metricEmitter.Events("volumes deleted")).Should(ContainElement(metric.Event{
+				Name:       "volumes deleted",
+				Value:      2,
+				State:      metric.EventStateOK,
+				Attributes: map[string]string{},
+			}))
+		})
+
 		Context("when destroying the volume in db fails because volume has children", func() {
 			BeforeEach(func() {
 				_, err := createdVolume.CreateChildForContainer(creatingContainer2, "some-path-1")
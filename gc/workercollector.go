@@ -1,22 +1,36 @@
 package gc
 
 import (
+	"time"
+
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
 )
 
 type workerCollector struct {
 	logger          lager.Logger
 	workerLifecycle dbng.WorkerLifecycle
+	landingDeadline time.Duration
+	requeueBuilds   bool
 }
 
+// NewWorkerCollector constructs a Collector which moves workers through their
+// lifecycle. Builds still running on a worker which has been landing for
+// longer than the landing deadline are aborted, and re-queued if
+// requeueBuilds is true, so that it can land. A deadline of 0 means landing
+// workers wait for their builds indefinitely.
 func NewWorkerCollector(
 	logger lager.Logger,
 	workerLifecycle dbng.WorkerLifecycle,
+	landingDeadline time.Duration,
+	requeueBuilds bool,
 ) Collector {
 	return &workerCollector{
 		logger:          logger,
 		workerLifecycle: workerLifecycle,
+		landingDeadline: landingDeadline,
+		requeueBuilds:   requeueBuilds,
 	}
 }
 
@@ -46,6 +60,22 @@ func (wc *workerCollector) Run() error {
 		logger.Debug("retired", lager.Data{"count": len(affected), "workers": affected})
 	}
 
+	if wc.landingDeadline != 0 {
+		aborted, err := wc.workerLifecycle.AbortBuildsOnOverdueLandingWorkers(wc.landingDeadline, wc.requeueBuilds)
+		if err != nil {
+			logger.Error("failed-to-abort-builds-on-overdue-landing-workers", err)
+			return err
+		}
+
+		if len(aborted) > 0 {
+			logger.Info("aborted-builds-on-overdue-landing-workers", lager.Data{"builds": aborted, "requeued": wc.requeueBuilds})
+
+			metric.BuildsAbortedForLanding{
+				Builds: len(aborted),
+			}.Emit(logger)
+		}
+	}
+
 	affected, err = wc.workerLifecycle.LandFinishedLandingWorkers()
 	if err != nil {
 		logger.Error("failed-to-land-finished-landing-workers", err)
@@ -5,6 +5,7 @@ import (
 	"github.com/concourse/atc/gc"
 
 	"errors"
+	"time"
 
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/onsi/ginkgo"
@@ -24,6 +25,8 @@ var _ = Describe("WorkerCollector", func() {
 		workerCollector = gc.NewWorkerCollector(
 			logger,
 			fakeWorkerLifecycle,
+			0,
+			false,
 		)
 
 		fakeWorkerLifecycle.StallUnresponsiveWorkersReturns(nil, nil)
@@ -76,5 +79,43 @@ var _ = Describe("WorkerCollector", func() {
 			err := workerCollector.Run()
 			Expect(err).To(MatchError(returnedErr))
 		})
+
+		It("does not abort builds on landing workers", func() {
+			err := workerCollector.Run()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeWorkerLifecycle.AbortBuildsOnOverdueLandingWorkersCallCount()).To(BeZero())
+		})
+
+		Context("when there is a landing deadline", func() {
+			BeforeEach(func() {
+				workerCollector = gc.NewWorkerCollector(
+					lagertest.NewTestLogger("worker-collector"),
+					fakeWorkerLifecycle,
+					10*time.Minute,
+					true,
+				)
+			})
+
+			It("aborts builds on workers which have been landing for longer than it", func() {
+				err := workerCollector.Run()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeWorkerLifecycle.AbortBuildsOnOverdueLandingWorkersCallCount()).To(Equal(1))
+				deadline, requeue := fakeWorkerLifecycle.AbortBuildsOnOverdueLandingWorkersArgsForCall(0)
+				Expect(deadline).To(Equal(10 * time.Minute))
+				Expect(requeue).To(BeTrue())
+
+				Expect(fakeWorkerLifecycle.LandFinishedLandingWorkersCallCount()).To(Equal(1))
+			})
+
+			It("returns an error if aborting the builds fails", func() {
+				returnedErr := errors.New("some-error")
+				fakeWorkerLifecycle.AbortBuildsOnOverdueLandingWorkersReturns(nil, returnedErr)
+
+				err := workerCollector.Run()
+				Expect(err).To(MatchError(returnedErr))
+			})
+		})
 	})
 })
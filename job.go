@@ -25,6 +25,7 @@ type JobInput struct {
 	Version  *VersionConfig `json:"version,omitempty"`
 	Params   Params         `json:"params,omitempty"`
 	Tags     Tags           `json:"tags,omitempty"`
+	Artifact bool           `json:"artifact,omitempty"`
 }
 
 type JobOutput struct {
@@ -10,6 +10,7 @@ type JobConfig struct {
 	SerialGroups         []string `yaml:"serial_groups,omitempty" json:"serial_groups,omitempty" mapstructure:"serial_groups"`
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
+	Priority             int      `yaml:"priority,omitempty" json:"priority,omitempty" mapstructure:"priority"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
@@ -117,7 +118,16 @@ func (config JobConfig) Inputs() []JobInput {
 	var inputs []JobInput
 
 	for _, plan := range config.Plans() {
-		if plan.Get != "" {
+		if plan.Get != "" && plan.Artifact {
+			inputs = append(inputs, JobInput{
+				Name:     plan.Get,
+				Resource: ArtifactResourceName(plan.Get),
+				Passed:   plan.Passed,
+				Trigger:  plan.Trigger,
+				Tags:     plan.Tags,
+				Artifact: true,
+			})
+		} else if plan.Get != "" {
 			get := plan.Get
 
 			resource := get
@@ -157,6 +157,38 @@ var _ = Describe("JobConfig", func() {
 				})
 			})
 
+			Context("with a get of an artifact", func() {
+				BeforeEach(func() {
+					jobConfig.Plan = atc.PlanSequence{
+						{
+							Get: "some-get-plan",
+						},
+						{
+							Get:      "some-binary",
+							Artifact: true,
+							Passed:   []string{"a"},
+							Trigger:  true,
+						},
+					}
+				})
+
+				It("uses the artifact's resource for its input", func() {
+					Expect(inputs).To(Equal([]atc.JobInput{
+						{
+							Name:     "some-get-plan",
+							Resource: "some-get-plan",
+						},
+						{
+							Name:     "some-binary",
+							Resource: "artifact:some-binary",
+							Passed:   []string{"a"},
+							Trigger:  true,
+							Artifact: true,
+						},
+					}))
+				})
+			})
+
 			Context("when a plan has a version on a get", func() {
 				BeforeEach(func() {
 					jobConfig.Plan = atc.PlanSequence{
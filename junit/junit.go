@@ -0,0 +1,159 @@
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Status string
+
+const (
+	StatusPassed  Status = "passed"
+	StatusFailed  Status = "failed"
+	StatusErrored Status = "errored"
+	StatusSkipped Status = "skipped"
+)
+
+// maxMessageLength bounds the failure output kept for each test case.
+const maxMessageLength = 4096
+
+type Suite struct {
+	Name     string
+	Duration time.Duration
+	Cases    []Case
+}
+
+type Case struct {
+	ClassName string
+	Name      string
+	Status    Status
+	Duration  time.Duration
+	Message   string
+}
+
+type xmlSuite struct {
+	XMLName xml.Name
+	Name    string     `xml:"name,attr"`
+	Time    string     `xml:"time,attr"`
+	Suites  []xmlSuite `xml:"testsuite"`
+	Cases   []xmlCase  `xml:"testcase"`
+}
+
+type xmlCase struct {
+	ClassName string     `xml:"classname,attr"`
+	Name      string     `xml:"name,attr"`
+	Time      string     `xml:"time,attr"`
+	Failure   *xmlResult `xml:"failure"`
+	Error     *xmlResult `xml:"error"`
+	Skipped   *xmlResult `xml:"skipped"`
+}
+
+type xmlResult struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// Parse reads a JUnit XML report, whose root is either a <testsuites> or a
+// single <testsuite> element. Nested suites are flattened.
+func Parse(r io.Reader) ([]Suite, error) {
+	var root xmlSuite
+	err := xml.NewDecoder(r).Decode(&root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root.XMLName.Local {
+	case "testsuites":
+		suites := []Suite{}
+		for _, suite := range root.Suites {
+			suites = append(suites, flatten(suite)...)
+		}
+		return suites, nil
+	case "testsuite":
+		return flatten(root), nil
+	default:
+		return nil, fmt.Errorf("unexpected root element: %s", root.XMLName.Local)
+	}
+}
+
+func flatten(suite xmlSuite) []Suite {
+	suites := []Suite{}
+
+	if len(suite.Cases) > 0 || len(suite.Suites) == 0 {
+		parsed := Suite{
+			Name:     suite.Name,
+			Duration: parseDuration(suite.Time),
+			Cases:    []Case{},
+		}
+
+		for _, c := range suite.Cases {
+			parsed.Cases = append(parsed.Cases, parseCase(c))
+		}
+
+		suites = append(suites, parsed)
+	}
+
+	for _, child := range suite.Suites {
+		suites = append(suites, flatten(child)...)
+	}
+
+	return suites
+}
+
+func parseCase(c xmlCase) Case {
+	parsed := Case{
+		ClassName: c.ClassName,
+		Name:      c.Name,
+		Status:    StatusPassed,
+		Duration:  parseDuration(c.Time),
+	}
+
+	switch {
+	case c.Error != nil:
+		parsed.Status = StatusErrored
+		parsed.Message = c.Error.message()
+	case c.Failure != nil:
+		parsed.Status = StatusFailed
+		parsed.Message = c.Failure.message()
+	case c.Skipped != nil:
+		parsed.Status = StatusSkipped
+		parsed.Message = c.Skipped.message()
+	}
+
+	return parsed
+}
+
+func (result xmlResult) message() string {
+	message := strings.TrimSpace(result.Body)
+	if message == "" {
+		message = result.Message
+	}
+
+	if len(message) > maxMessageLength {
+		// back up to the start of a rune so as not to split it
+		end := maxMessageLength
+		for end > 0 && !utf8.RuneStart(message[end]) {
+			end--
+		}
+
+		message = message[:end]
+	}
+
+	return message
+}
+
+// parseDuration parses a duration given in (possibly fractional) seconds,
+// returning zero if it is missing or malformed.
+func parseDuration(seconds string) time.Duration {
+	value, err := strconv.ParseFloat(strings.Replace(seconds, ",", "", -1), 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(value * float64(time.Second))
+}
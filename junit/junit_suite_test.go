@@ -0,0 +1,13 @@
+package junit_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestJunit(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "JUnit Suite")
+}
@@ -0,0 +1,141 @@
+package junit_test
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/concourse/atc/junit"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parse", func() {
+	var (
+		report string
+
+		suites   []junit.Suite
+		parseErr error
+	)
+
+	JustBeforeEach(func() {
+		suites, parseErr = junit.Parse(strings.NewReader(report))
+	})
+
+	Context("with a single test suite", func() {
+		BeforeEach(func() {
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="some-suite" tests="4" time="1.5">
+	<testcase classname="some.Class" name="passes" time="0.25"></testcase>
+	<testcase classname="some.Class" name="fails" time="1,000.5">
+		<failure message="expected true">some stack trace</failure>
+	</testcase>
+	<testcase classname="some.Class" name="errors" time="bogus">
+		<error message="boom"></error>
+	</testcase>
+	<testcase classname="some.Class" name="is skipped">
+		<skipped/>
+	</testcase>
+</testsuite>`
+		})
+
+		It("parses the suite and its cases", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(suites).To(Equal([]junit.Suite{
+				{
+					Name:     "some-suite",
+					Duration: 1500 * time.Millisecond,
+					Cases: []junit.Case{
+						{
+							ClassName: "some.Class",
+							Name:      "passes",
+							Status:    junit.StatusPassed,
+							Duration:  250 * time.Millisecond,
+						},
+						{
+							ClassName: "some.Class",
+							Name:      "fails",
+							Status:    junit.StatusFailed,
+							Duration:  1000500 * time.Millisecond,
+							Message:   "some stack trace",
+						},
+						{
+							ClassName: "some.Class",
+							Name:      "errors",
+							Status:    junit.StatusErrored,
+							Message:   "boom",
+						},
+						{
+							ClassName: "some.Class",
+							Name:      "is skipped",
+							Status:    junit.StatusSkipped,
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	Context("with nested test suites", func() {
+		BeforeEach(func() {
+			report = `<testsuites>
+	<testsuite name="outer">
+		<testsuite name="inner">
+			<testcase name="some-test"/>
+		</testsuite>
+	</testsuite>
+	<testsuite name="other">
+		<testcase name="other-test"/>
+	</testsuite>
+</testsuites>`
+		})
+
+		It("flattens them", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(suites).To(HaveLen(2))
+			Expect(suites[0].Name).To(Equal("inner"))
+			Expect(suites[0].Cases).To(HaveLen(1))
+			Expect(suites[1].Name).To(Equal("other"))
+			Expect(suites[1].Cases).To(HaveLen(1))
+		})
+	})
+
+	Context("with a failure message longer than is kept", func() {
+		BeforeEach(func() {
+			// 'é' is two bytes, so the limit falls in the middle of one
+			report = `<testsuite name="some-suite">
+	<testcase classname="some.Class" name="fails">
+		<failure>a` + strings.Repeat("é", 4096) + `</failure>
+	</testcase>
+</testsuite>`
+		})
+
+		It("truncates it without splitting a character", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+
+			message := suites[0].Cases[0].Message
+			Expect(utf8.ValidString(message)).To(BeTrue())
+			Expect(message).To(Equal("a" + strings.Repeat("é", 2047)))
+		})
+	})
+
+	Context("with a document that is not a JUnit report", func() {
+		BeforeEach(func() {
+			report = `<html></html>`
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+
+	Context("with malformed XML", func() {
+		BeforeEach(func() {
+			report = `<testsuite`
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
@@ -2,9 +2,12 @@ package metric
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	flags "github.com/jessevdk/go-flags"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
 
 	"code.cloudfoundry.org/lager"
 )
@@ -24,14 +27,28 @@ const EventStateOK EventState = "ok"
 const EventStateWarning EventState = "warning"
 const EventStateCritical EventState = "critical"
 
+//go:generate counterfeiter . Emitter
+
 type Emitter interface {
-	Emit(lager.Logger, Event)
+	Emit(lager.Logger, Event) error
+}
+
+// RunnableEmitter is an emitter with a process of its own, such as a server
+// for its metrics to be scraped from or a loop sending batched metrics. The
+// process is run alongside the ATC's other members, so that it stops the ATC
+// if it fails.
+type RunnableEmitter interface {
+	Emitter
+
+	Runner(lager.Logger) ifrit.Runner
 }
 
+//go:generate counterfeiter . EmitterFactory
+
 type EmitterFactory interface {
 	Description() string
 	IsConfigured() bool
-	NewEmitter() Emitter
+	NewEmitter() (Emitter, error)
 }
 
 var emitterFactories []EmitterFactory
@@ -49,7 +66,12 @@ func WireEmitters(group *flags.Group) {
 	}
 }
 
-var emitter Emitter
+// EmitterBufferSize is how many events are buffered for each emitter before
+// further events are dropped.
+const EmitterBufferSize = 1000
+
+var emitterQueues []*EmitterQueue
+var emitterRunners []grouper.Member
 var eventHost string
 var eventAttributes map[string]string
 
@@ -58,28 +80,65 @@ type eventEmission struct {
 	logger lager.Logger
 }
 
-var emissions = make(chan eventEmission, 1000)
-
-func Initialize(logger lager.Logger, host string, attributes map[string]string) {
+// Initialize constructs an emitter for every configured factory; each event
+// is emitted to all of them, each from its own buffer.
+func Initialize(logger lager.Logger, host string, attributes map[string]string) error {
 	for _, factory := range emitterFactories {
-		if factory.IsConfigured() {
-			emitter = factory.NewEmitter()
+		if !factory.IsConfigured() {
+			continue
 		}
+
+		emitter, err := factory.NewEmitter()
+		if err != nil {
+			logger.Error("failed-to-construct-emitter", err, lager.Data{"emitter": factory.Description()})
+			return fmt.Errorf("failed to configure %s metrics: %s", factory.Description(), err)
+		}
+
+		if runnable, ok := emitter.(RunnableEmitter); ok {
+			emitterRunners = append(emitterRunners, grouper.Member{
+				Name:   "metrics-" + factory.Description(),
+				Runner: runnable.Runner(logger.Session("emitter", lager.Data{"emitter": factory.Description()})),
+			})
+		}
+
+		emitterQueues = append(emitterQueues, NewEmitterQueue(factory.Description(), emitter, EmitterBufferSize))
 	}
 
-	if emitter == nil {
-		return
+	if len(emitterQueues) == 0 {
+		return nil
 	}
 
-	emitter = emitter
 	eventHost = host
 	eventAttributes = attributes
 
-	go emitLoop()
+	for _, queue := range emitterQueues {
+		go queue.Run()
+	}
+
+	return nil
+}
+
+// Runners returns the processes of the emitters constructed by Initialize
+// which have one.
+func Runners() []grouper.Member {
+	return emitterRunners
+}
+
+// AttributeNames returns the names of the attributes given to Initialize,
+// which are added to every event.
+func AttributeNames() []string {
+	names := make([]string, 0, len(eventAttributes))
+	for name := range eventAttributes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
 }
 
 func emit(logger lager.Logger, event Event) {
-	if emitter == nil {
+	if len(emitterQueues) == 0 {
 		return
 	}
 
@@ -99,15 +158,7 @@ func emit(logger lager.Logger, event Event) {
 
 	event.Attributes = mergedAttributes
 
-	select {
-	case emissions <- eventEmission{logger: logger, event: event}:
-	default:
-		logger.Error("queue-full", nil)
-	}
-}
-
-func emitLoop() {
-	for emission := range emissions {
-		emitter.Emit(emission.logger.Session("emit"), emission.event)
+	for _, queue := range emitterQueues {
+		queue.Enqueue(logger, event)
 	}
 }
@@ -0,0 +1,32 @@
+package emitter
+
+// highCardinalityAttributes are attributes which are unique to every event,
+// and so would give every event its own series.
+var highCardinalityAttributes = map[string]bool{
+	"build_id":   true,
+	"build_name": true,
+	"path":       true,
+}
+
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
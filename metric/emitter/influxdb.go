@@ -30,27 +30,30 @@ func init() {
 func (config *InfluxDBConfig) Description() string { return "InfluxDB" }
 func (config *InfluxDBConfig) IsConfigured() bool  { return config.URL != "" }
 
-func (config *InfluxDBConfig) NewEmitter() metric.Emitter {
-	client, _ := influxclient.NewHTTPClient(influxclient.HTTPConfig{
+func (config *InfluxDBConfig) NewEmitter() (metric.Emitter, error) {
+	client, err := influxclient.NewHTTPClient(influxclient.HTTPConfig{
 		Addr:               config.URL,
 		Username:           config.Username,
 		Password:           config.Password,
 		InsecureSkipVerify: config.InsecureSkipVerify,
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &InfluxDBEmitter{
 		client:   client,
 		database: config.Database,
-	}
+	}, nil
 }
 
-func (emitter *InfluxDBEmitter) Emit(logger lager.Logger, event metric.Event) {
+func (emitter *InfluxDBEmitter) Emit(logger lager.Logger, event metric.Event) error {
 	bp, err := influxclient.NewBatchPoints(influxclient.BatchPointsConfig{
 		Database: emitter.database,
 	})
 	if err != nil {
 		logger.Error("failed-to-construct-batch-points", err)
-		return
+		return err
 	}
 
 	tags := map[string]string{
@@ -72,14 +75,10 @@ func (emitter *InfluxDBEmitter) Emit(logger lager.Logger, event metric.Event) {
 	)
 	if err != nil {
 		logger.Error("failed-to-construct-point", err)
-		return
+		return err
 	}
 
 	bp.AddPoint(point)
 
-	err = emitter.client.Write(bp)
-	if err != nil {
-		logger.Error("failed-to-send-points", err)
-		return
-	}
+	return emitter.client.Write(bp)
 }
@@ -20,11 +20,11 @@ func init() {
 func (config *LagerConfig) Description() string { return "Lager" }
 func (config *LagerConfig) IsConfigured() bool  { return config.Enabled }
 
-func (config *LagerConfig) NewEmitter() metric.Emitter {
-	return &LagerEmitter{}
+func (config *LagerConfig) NewEmitter() (metric.Emitter, error) {
+	return &LagerEmitter{}, nil
 }
 
-func (emitter *LagerEmitter) Emit(logger lager.Logger, event metric.Event) {
+func (emitter *LagerEmitter) Emit(logger lager.Logger, event metric.Event) error {
 	data := lager.Data{
 		"name":  event.Name,
 		"value": event.Value,
@@ -37,4 +37,6 @@ func (emitter *LagerEmitter) Emit(logger lager.Logger, event metric.Event) {
 	}
 
 	logger.Debug("event", data)
+
+	return nil
 }
@@ -0,0 +1,272 @@
+package emitter
+
+import (
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/http_server"
+)
+
+type PrometheusEmitter struct {
+	registry *prometheus.Registry
+	bind     string
+	clock    clock.Clock
+
+	lock       sync.Mutex
+	collectors map[string]*prometheusCollector
+}
+
+type PrometheusConfig struct {
+	BindIP   string `long:"prometheus-bind-ip"   description:"IP to listen on to expose Prometheus metrics."`
+	BindPort string `long:"prometheus-bind-port" description:"Port to listen on to expose Prometheus metrics."`
+}
+
+type prometheusMetric struct {
+	name string
+	help string
+}
+
+// prometheusMetrics gives the name and help of each event emitted by the
+// metric package; their kinds and labels come from metric.Definitions. Events
+// not listed here are named after the event, and exposed as gauges labelled
+// only with the configured metric attributes.
+var prometheusMetrics = map[string]prometheusMetric{
+	"scheduling: full duration (ms)":             {"concourse_scheduling_full_duration_seconds", "Time taken to schedule an entire pipeline."},
+	"scheduling: loading versions duration (ms)": {"concourse_scheduling_loading_versions_duration_seconds", "Time taken to load version information from the database."},
+	"scheduling: job duration (ms)":              {"concourse_scheduling_job_duration_seconds", "Time taken to schedule a single job."},
+	"worker containers":                          {"concourse_workers_containers", "Number of containers per worker."},
+	"worker volumes":                             {"concourse_workers_volumes", "Number of volumes per worker."},
+	"worker state transition":                    {"concourse_workers_state_transitions_total", "Number of times workers entered each state."},
+	"worker circuit breaker state":               {"concourse_workers_circuit_breaker_transitions_total", "Number of times worker circuit breakers entered each state."},
+	"builds aborted for landing":                 {"concourse_builds_aborted_for_landing_total", "Number of builds aborted so that a worker could land."},
+	"build started":                              {"concourse_builds_started_total", "Number of builds started."},
+	"build finished":                             {"concourse_builds_duration_seconds", "Duration of finished builds."},
+	"resource check duration (ms)":               {"concourse_resources_checks_duration_seconds", "Duration of resource checks."},
+	"pending builds":                             {"concourse_builds_pending", "Number of pending builds per pipeline."},
+	"gc collector duration (ms)":                 {"concourse_gc_collector_duration_seconds", "Time taken by each garbage collector run."},
+	"containers deleted":                         {"concourse_gc_containers_deleted_total", "Number of containers deleted by garbage collection."},
+	"volumes deleted":                            {"concourse_gc_volumes_deleted_total", "Number of volumes deleted by garbage collection."},
+	"team quota usage":                           {"concourse_teams_quota_usage", "Running builds, containers and volumes used by each team."},
+	"team quota limit":                           {"concourse_teams_quota_limit", "Limit of each team's quotas, 0 meaning unlimited."},
+	"http response time":                         {"concourse_http_responses_duration_seconds", "Response time of API and web requests."},
+	"database queries":                           {"concourse_db_queries_total", "Number of queries made to the database."},
+	"database connections":                       {"concourse_db_connections", "Number of open connections to the database."},
+	"emitter dropped events":                     {"concourse_metrics_emitter_dropped_events_total", "Number of events dropped because an emitter's buffer was full."},
+	"emitter errors":                             {"concourse_metrics_emitter_errors_total", "Number of events an emitter failed to emit."},
+	"emitter latency (ms)":                       {"concourse_metrics_emitter_latency_milliseconds", "Average time an emitter took to emit each event."},
+}
+
+var prometheusInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]+`)
+
+func init() {
+	metric.RegisterEmitter(&PrometheusConfig{})
+}
+
+func (config *PrometheusConfig) Description() string { return "Prometheus" }
+func (config *PrometheusConfig) IsConfigured() bool {
+	return config.BindIP != "" && config.BindPort != ""
+}
+
+func (config *PrometheusConfig) bind() string {
+	return net.JoinHostPort(config.BindIP, config.BindPort)
+}
+
+// prometheusWorkerSeriesExpiry is how long a worker's gauges are kept after
+// they were last set, so that workers which are gone stop being reported.
+const prometheusWorkerSeriesExpiry = 5 * time.Minute
+
+func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
+	return NewPrometheusEmitter(config.bind(), clock.NewClock()), nil
+}
+
+func NewPrometheusEmitter(bind string, clock clock.Clock) *PrometheusEmitter {
+	return &PrometheusEmitter{
+		registry:   prometheus.NewRegistry(),
+		bind:       bind,
+		clock:      clock,
+		collectors: map[string]*prometheusCollector{},
+	}
+}
+
+// Runner serves the metrics for Prometheus to scrape.
+func (emitter *PrometheusEmitter) Runner(lager.Logger) ifrit.Runner {
+	return http_server.New(
+		emitter.bind,
+		promhttp.HandlerFor(prometheus.GathererFunc(emitter.gather), promhttp.HandlerOpts{}),
+	)
+}
+
+func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event) error {
+	value, ok := numericValue(event.Value)
+	if !ok {
+		logger.Info("unsupported-value", lager.Data{"name": event.Name, "value": event.Value})
+		return nil
+	}
+
+	emitter.lock.Lock()
+	defer emitter.lock.Unlock()
+
+	collector, err := emitter.collectorFor(event)
+	if err != nil {
+		logger.Error("failed-to-register-collector", err, lager.Data{"name": event.Name})
+		return err
+	}
+
+	collector.record(event, value, emitter.clock.Now())
+
+	return nil
+}
+
+func (emitter *PrometheusEmitter) gather() ([]*dto.MetricFamily, error) {
+	emitter.lock.Lock()
+	expiredBefore := emitter.clock.Now().Add(-prometheusWorkerSeriesExpiry)
+	for _, collector := range emitter.collectors {
+		collector.expire(expiredBefore)
+	}
+	emitter.lock.Unlock()
+
+	return emitter.registry.Gather()
+}
+
+func (emitter *PrometheusEmitter) collectorFor(event metric.Event) (*prometheusCollector, error) {
+	collector, found := emitter.collectors[event.Name]
+	if found {
+		return collector, nil
+	}
+
+	config, found := prometheusMetrics[event.Name]
+	if !found {
+		config = prometheusMetric{
+			name: "concourse_" + prometheusName(event.Name),
+			help: event.Name,
+		}
+	}
+
+	definition := metric.Definitions[event.Name]
+
+	labels := append([]string{}, definition.Labels...)
+	for _, name := range metric.AttributeNames() {
+		if !containsString(labels, name) {
+			labels = append(labels, name)
+		}
+	}
+
+	collector = &prometheusCollector{
+		kind:   definition.Kind,
+		labels: labels,
+	}
+
+	var vec prometheus.Collector
+	switch definition.Kind {
+	case metric.CountKind, metric.DeltaKind:
+		collector.counter = prometheus.NewCounterVec(prometheus.CounterOpts{Name: config.name, Help: config.help}, prometheusNames(labels))
+		vec = collector.counter
+	case metric.DurationKind:
+		collector.histogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: config.name, Help: config.help}, prometheusNames(labels))
+		vec = collector.histogram
+	default:
+		collector.gauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: config.name, Help: config.help}, prometheusNames(labels))
+		vec = collector.gauge
+
+		if containsString(labels, "worker") {
+			collector.lastSet = map[string]prometheusSeries{}
+		}
+	}
+
+	err := emitter.registry.Register(vec)
+	if err != nil {
+		return nil, err
+	}
+
+	emitter.collectors[event.Name] = collector
+
+	return collector, nil
+}
+
+type prometheusCollector struct {
+	kind   metric.Kind
+	labels []string
+
+	counter   *prometheus.CounterVec
+	gauge     *prometheus.GaugeVec
+	histogram *prometheus.HistogramVec
+
+	// lastSet is tracked for per-worker gauges, keyed by their label values
+	lastSet map[string]prometheusSeries
+}
+
+type prometheusSeries struct {
+	labelValues []string
+	time        time.Time
+}
+
+// record labels the value with the collector's labels; attributes which
+// aren't labels are dropped, and missing ones are left empty.
+func (collector *prometheusCollector) record(event metric.Event, value float64, now time.Time) {
+	labelValues := make([]string, len(collector.labels))
+	for i, label := range collector.labels {
+		labelValues[i] = event.Attributes[label]
+	}
+
+	switch collector.kind {
+	case metric.CountKind:
+		collector.counter.WithLabelValues(labelValues...).Inc()
+	case metric.DeltaKind:
+		if value > 0 {
+			collector.counter.WithLabelValues(labelValues...).Add(value)
+		}
+	case metric.DurationKind:
+		collector.histogram.WithLabelValues(labelValues...).Observe(value / 1000)
+	default:
+		collector.gauge.WithLabelValues(labelValues...).Set(value)
+
+		if collector.lastSet != nil {
+			collector.lastSet[strings.Join(labelValues, "\x00")] = prometheusSeries{
+				labelValues: labelValues,
+				time:        now,
+			}
+		}
+	}
+}
+
+func (collector *prometheusCollector) expire(before time.Time) {
+	for key, series := range collector.lastSet {
+		if series.time.Before(before) {
+			collector.gauge.DeleteLabelValues(series.labelValues...)
+			delete(collector.lastSet, key)
+		}
+	}
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+func prometheusName(name string) string {
+	return strings.Trim(strings.ToLower(prometheusInvalidChars.ReplaceAllString(name, "_")), "_")
+}
+
+func prometheusNames(names []string) []string {
+	sanitized := make([]string, len(names))
+	for i, name := range names {
+		sanitized[i] = prometheusName(name)
+	}
+
+	return sanitized
+}
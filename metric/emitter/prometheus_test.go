@@ -0,0 +1,147 @@
+package emitter_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/emitter"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrometheusEmitter", func() {
+	var (
+		bind      string
+		fakeClock *fakeclock.FakeClock
+		logger    *lagertest.TestLogger
+
+		prometheus *emitter.PrometheusEmitter
+		process    ifrit.Process
+	)
+
+	BeforeEach(func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		bind = listener.Addr().String()
+		listener.Close()
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		logger = lagertest.NewTestLogger("test")
+
+		prometheus = emitter.NewPrometheusEmitter(bind, fakeClock)
+		process = ifrit.Invoke(prometheus.Runner(logger))
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		<-process.Wait()
+	})
+
+	emit := func(name string, value interface{}, attributes map[string]string) {
+		err := prometheus.Emit(logger, metric.Event{
+			Name:       name,
+			Value:      value,
+			Attributes: attributes,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	scrape := func() string {
+		response, err := http.Get("http://" + bind + "/metrics")
+		Expect(err).NotTo(HaveOccurred())
+
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(body)
+	}
+
+	It("exposes gauges labelled with their declared attributes", func() {
+		emit("worker containers", 3, map[string]string{"worker": "some-worker"})
+		emit("worker containers", 5, map[string]string{"worker": "other-worker"})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("# TYPE concourse_workers_containers gauge\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_workers_containers{worker="some-worker"} 3` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_workers_containers{worker="other-worker"} 5` + "\n"))
+	})
+
+	It("labels events with the same labels whatever attributes the first event had", func() {
+		emit("build started", 1, map[string]string{"team": "main"})
+		emit("build started", 1, map[string]string{
+			"pipeline":   "some-pipeline",
+			"job":        "some-job",
+			"team":       "main",
+			"build_name": "42",
+			"build_id":   "1234",
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("# TYPE concourse_builds_started_total counter\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_started_total{job="",pipeline="",team="main"} 1` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_started_total{job="some-job",pipeline="some-pipeline",team="main"} 1` + "\n"))
+		Expect(metrics).NotTo(ContainSubstring("build_id"))
+	})
+
+	It("observes durations in seconds", func() {
+		emit("build finished", 1500, map[string]string{
+			"pipeline":     "some-pipeline",
+			"job":          "some-job",
+			"team":         "main",
+			"build_status": "succeeded",
+		})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("# TYPE concourse_builds_duration_seconds histogram\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_sum{build_status="succeeded",job="some-job",pipeline="some-pipeline",team="main"} 1.5` + "\n"))
+		Expect(metrics).To(ContainSubstring(`concourse_builds_duration_seconds_count{build_status="succeeded",job="some-job",pipeline="some-pipeline",team="main"} 1` + "\n"))
+	})
+
+	It("adds deltas to counters", func() {
+		emit("containers deleted", 2, nil)
+		emit("containers deleted", 3, nil)
+
+		Expect(scrape()).To(ContainSubstring("concourse_gc_containers_deleted_total 5\n"))
+	})
+
+	It("exposes undeclared events as unlabelled gauges", func() {
+		emit("goroutines", 12, map[string]string{"some": "attribute"})
+
+		metrics := scrape()
+		Expect(metrics).To(ContainSubstring("# TYPE concourse_goroutines gauge\n"))
+		Expect(metrics).To(ContainSubstring("concourse_goroutines 12\n"))
+	})
+
+	It("stops reporting the gauges of workers which are no longer emitted", func() {
+		emit("worker volumes", 3, map[string]string{"worker": "some-worker"})
+		emit("worker volumes", 5, map[string]string{"worker": "other-worker"})
+
+		fakeClock.Increment(4 * time.Minute)
+		emit("worker volumes", 6, map[string]string{"worker": "other-worker"})
+
+		fakeClock.Increment(2 * time.Minute)
+
+		metrics := scrape()
+		Expect(metrics).NotTo(ContainSubstring("some-worker"))
+		Expect(metrics).To(ContainSubstring(`concourse_workers_volumes{worker="other-worker"} 6` + "\n"))
+	})
+
+	Context("when the address is already in use", func() {
+		It("exits with an error", func() {
+			failing := ifrit.Background(emitter.NewPrometheusEmitter(bind, fakeClock).Runner(logger))
+			Eventually(failing.Wait()).Should(Receive(HaveOccurred()))
+		})
+	})
+})
@@ -33,22 +33,22 @@ func init() {
 func (config *RiemannConfig) Description() string { return "Riemann" }
 func (config *RiemannConfig) IsConfigured() bool  { return config.Host != "" }
 
-func (config *RiemannConfig) NewEmitter() metric.Emitter {
+func (config *RiemannConfig) NewEmitter() (metric.Emitter, error) {
 	return &RiemannEmitter{
 		client:    goryman.NewGorymanClient(net.JoinHostPort(config.Host, fmt.Sprintf("%d", config.Port))),
 		connected: false,
 
 		servicePrefix: config.ServicePrefix,
 		tags:          config.Tags,
-	}
+	}, nil
 }
 
-func (emitter *RiemannEmitter) Emit(logger lager.Logger, event metric.Event) {
+func (emitter *RiemannEmitter) Emit(logger lager.Logger, event metric.Event) error {
 	if !emitter.connected {
 		err := emitter.client.Connect()
 		if err != nil {
 			logger.Error("connection-failed", err)
-			return
+			return err
 		}
 
 		emitter.connected = true
@@ -66,12 +66,14 @@ func (emitter *RiemannEmitter) Emit(logger lager.Logger, event metric.Event) {
 		Tags: emitter.tags,
 	})
 	if err != nil {
-		logger.Error("failed-to-emit", err)
-
 		if err := emitter.client.Close(); err != nil {
 			logger.Error("failed-to-close", err)
 		}
 
 		emitter.connected = false
+
+		return err
 	}
+
+	return nil
 }
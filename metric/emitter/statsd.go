@@ -0,0 +1,218 @@
+package emitter
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+	"github.com/tedsuo/ifrit"
+)
+
+type StatsdEmitter struct {
+	conn net.Conn
+
+	prefix        string
+	dogStatsdTags bool
+	sampleRate    float64
+	maxPacketSize int
+	flushInterval time.Duration
+
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+type StatsdConfig struct {
+	Host string `long:"statsd-host"                description:"StatsD server address to emit metrics to."`
+	Port uint16 `long:"statsd-port"  default:"8125" description:"Port of the StatsD server to emit metrics to."`
+
+	Prefix string `long:"statsd-prefix" default:"concourse." description:"Prefix for the names of emitted metrics."`
+
+	DogStatsdTags bool `long:"statsd-dogstatsd-tags" description:"Attach event attributes to metrics as DogStatsD tags."`
+
+	SampleRate float64 `long:"statsd-sample-rate" default:"1" description:"Fraction of counter and timer events to emit, between 0 and 1."`
+
+	MaxPacketSize int           `long:"statsd-max-packet-size" default:"1432" description:"Maximum size in bytes of the UDP packets metrics are batched into."`
+	FlushInterval time.Duration `long:"statsd-flush-interval"  default:"1s"   description:"Interval on which to send batched metrics."`
+}
+
+var statsdInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.]+`)
+
+func init() {
+	metric.RegisterEmitter(&StatsdConfig{})
+}
+
+func (config *StatsdConfig) Description() string { return "StatsD" }
+func (config *StatsdConfig) IsConfigured() bool  { return config.Host != "" }
+
+func (config *StatsdConfig) NewEmitter() (metric.Emitter, error) {
+	if config.SampleRate <= 0 || config.SampleRate > 1 {
+		return nil, fmt.Errorf("sample rate must be greater than 0 and at most 1, got %g", config.SampleRate)
+	}
+
+	conn, err := net.Dial("udp", net.JoinHostPort(config.Host, fmt.Sprintf("%d", config.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	emitter := &StatsdEmitter{
+		conn: conn,
+
+		prefix:        config.Prefix,
+		dogStatsdTags: config.DogStatsdTags,
+		sampleRate:    config.SampleRate,
+		maxPacketSize: config.MaxPacketSize,
+		flushInterval: config.FlushInterval,
+	}
+
+	return emitter, nil
+}
+
+func (emitter *StatsdEmitter) Emit(logger lager.Logger, event metric.Event) error {
+	value, ok := numericValue(event.Value)
+	if !ok {
+		logger.Info("unsupported-value", lager.Data{"name": event.Name, "value": event.Value})
+		return nil
+	}
+
+	kind := metric.KindOf(event.Name)
+
+	sampled := kind != metric.GaugeKind && emitter.sampleRate < 1
+	if sampled && rand.Float64() >= emitter.sampleRate {
+		return nil
+	}
+
+	line := emitter.prefix + statsdName(event.Name) + ":"
+
+	switch kind {
+	case metric.CountKind:
+		line += "1|c"
+	case metric.DeltaKind:
+		line += strconv.FormatFloat(value, 'f', -1, 64) + "|c"
+	case metric.DurationKind:
+		line += strconv.FormatFloat(value, 'f', -1, 64) + "|ms"
+	default:
+		line += strconv.FormatFloat(value, 'f', -1, 64) + "|g"
+	}
+
+	if sampled {
+		line += "|@" + strconv.FormatFloat(emitter.sampleRate, 'f', -1, 64)
+	}
+
+	if emitter.dogStatsdTags {
+		line += dogStatsdTags(event.Attributes)
+	}
+
+	return emitter.write(line)
+}
+
+// Flush sends any batched metrics.
+func (emitter *StatsdEmitter) Flush() error {
+	emitter.lock.Lock()
+	defer emitter.lock.Unlock()
+
+	return emitter.flush()
+}
+
+// write batches the line, first sending the batch if the line would not fit
+// in the same packet.
+func (emitter *StatsdEmitter) write(line string) error {
+	emitter.lock.Lock()
+	defer emitter.lock.Unlock()
+
+	if emitter.buffer.Len() > 0 && emitter.buffer.Len()+1+len(line) > emitter.maxPacketSize {
+		err := emitter.flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if emitter.buffer.Len() > 0 {
+		emitter.buffer.WriteByte('\n')
+	}
+
+	emitter.buffer.WriteString(line)
+
+	return nil
+}
+
+func (emitter *StatsdEmitter) flush() error {
+	if emitter.buffer.Len() == 0 {
+		return nil
+	}
+
+	defer emitter.buffer.Reset()
+
+	_, err := emitter.conn.Write(emitter.buffer.Bytes())
+	return err
+}
+
+// Runner sends the batched metrics on the configured interval, and once more
+// when signalled to stop.
+func (emitter *StatsdEmitter) Runner(logger lager.Logger) ifrit.Runner {
+	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		close(ready)
+
+		var ticks <-chan time.Time
+		if emitter.flushInterval > 0 {
+			ticker := time.NewTicker(emitter.flushInterval)
+			defer ticker.Stop()
+
+			ticks = ticker.C
+		}
+
+		for {
+			select {
+			case <-ticks:
+				emitter.logFlush(logger)
+			case <-signals:
+				emitter.logFlush(logger)
+				return nil
+			}
+		}
+	})
+}
+
+func (emitter *StatsdEmitter) logFlush(logger lager.Logger) {
+	err := emitter.Flush()
+	if err != nil {
+		logger.Error("failed-to-flush", err)
+	}
+}
+
+func dogStatsdTags(attributes map[string]string) string {
+	tags := []string{}
+	for k, v := range attributes {
+		if highCardinalityAttributes[k] {
+			continue
+		}
+
+		tags = append(tags, statsdTag(k)+":"+statsdTag(v))
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	sort.Strings(tags)
+
+	return "|#" + strings.Join(tags, ",")
+}
+
+func statsdName(name string) string {
+	return strings.Trim(strings.ToLower(statsdInvalidChars.ReplaceAllString(name, "_")), "_")
+}
+
+// statsdTag replaces the characters which delimit tags.
+func statsdTag(tag string) string {
+	return strings.NewReplacer(",", "_", "|", "_", ":", "_", "#", "_").Replace(tag)
+}
@@ -0,0 +1,204 @@
+package emitter_test
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/emitter"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsdEmitter", func() {
+	var (
+		listener net.PacketConn
+		config   *emitter.StatsdConfig
+		logger   *lagertest.TestLogger
+
+		statsd *emitter.StatsdEmitter
+	)
+
+	BeforeEach(func() {
+		var err error
+		listener, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		port, err := strconv.Atoi(strings.Split(listener.LocalAddr().String(), ":")[1])
+		Expect(err).NotTo(HaveOccurred())
+
+		config = &emitter.StatsdConfig{
+			Host:          "127.0.0.1",
+			Port:          uint16(port),
+			Prefix:        "concourse.",
+			SampleRate:    1,
+			MaxPacketSize: 1432,
+		}
+
+		logger = lagertest.NewTestLogger("test")
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	JustBeforeEach(func() {
+		e, err := config.NewEmitter()
+		Expect(err).NotTo(HaveOccurred())
+
+		statsd = e.(*emitter.StatsdEmitter)
+	})
+
+	readPacket := func() string {
+		buf := make([]byte, 65536)
+
+		err := listener.SetReadDeadline(time.Now().Add(time.Second))
+		Expect(err).NotTo(HaveOccurred())
+
+		n, _, err := listener.ReadFrom(buf)
+		Expect(err).NotTo(HaveOccurred())
+
+		return string(buf[:n])
+	}
+
+	It("is configured by its host", func() {
+		Expect(config.IsConfigured()).To(BeTrue())
+		Expect((&emitter.StatsdConfig{}).IsConfigured()).To(BeFalse())
+	})
+
+	It("sends each kind of event with its statsd type, batched into one packet", func() {
+		Expect(statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 12})).To(Succeed())
+		Expect(statsd.Emit(logger, metric.Event{Name: "build started", Value: 42})).To(Succeed())
+		Expect(statsd.Emit(logger, metric.Event{Name: "database queries", Value: 7})).To(Succeed())
+		Expect(statsd.Emit(logger, metric.Event{Name: "http response time", Value: 1.5})).To(Succeed())
+		Expect(statsd.Flush()).To(Succeed())
+
+		Expect(readPacket()).To(Equal(strings.Join([]string{
+			"concourse.worker_containers:12|g",
+			"concourse.build_started:1|c",
+			"concourse.database_queries:7|c",
+			"concourse.http_response_time:1.5|ms",
+		}, "\n")))
+	})
+
+	It("does not send anything until flushed", func() {
+		Expect(statsd.Emit(logger, metric.Event{Name: "goroutines", Value: 3})).To(Succeed())
+
+		err := listener.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, _, err = listener.ReadFrom(make([]byte, 1024))
+		Expect(err).To(HaveOccurred())
+	})
+
+	Context("when the batch would exceed the packet size", func() {
+		BeforeEach(func() {
+			config.MaxPacketSize = 40
+		})
+
+		It("sends the batch before adding to it", func() {
+			Expect(statsd.Emit(logger, metric.Event{Name: "worker containers", Value: 12})).To(Succeed())
+			Expect(statsd.Emit(logger, metric.Event{Name: "worker volumes", Value: 34})).To(Succeed())
+
+			Expect(readPacket()).To(Equal("concourse.worker_containers:12|g"))
+
+			Expect(statsd.Flush()).To(Succeed())
+			Expect(readPacket()).To(Equal("concourse.worker_volumes:34|g"))
+		})
+	})
+
+	Context("with DogStatsD tags", func() {
+		BeforeEach(func() {
+			config.DogStatsdTags = true
+		})
+
+		It("attaches the attributes as tags, leaving out per-build ones", func() {
+			Expect(statsd.Emit(logger, metric.Event{
+				Name:  "build finished",
+				Value: 1000,
+				Attributes: map[string]string{
+					"pipeline":     "some-pipeline",
+					"build_status": "succeeded",
+					"build_id":     "42",
+					"environment":  "prod",
+				},
+			})).To(Succeed())
+			Expect(statsd.Flush()).To(Succeed())
+
+			Expect(readPacket()).To(Equal("concourse.build_finished:1000|ms|#build_status:succeeded,environment:prod,pipeline:some-pipeline"))
+		})
+	})
+
+	Context("with a sample rate", func() {
+		BeforeEach(func() {
+			config.SampleRate = 0.999999
+		})
+
+		It("marks sampled counters and timers with the rate", func() {
+			Expect(statsd.Emit(logger, metric.Event{Name: "build started", Value: 1})).To(Succeed())
+			Expect(statsd.Flush()).To(Succeed())
+
+			Expect(readPacket()).To(Equal("concourse.build_started:1|c|@0.999999"))
+		})
+
+		It("does not sample gauges", func() {
+			Expect(statsd.Emit(logger, metric.Event{Name: "goroutines", Value: 3})).To(Succeed())
+			Expect(statsd.Flush()).To(Succeed())
+
+			Expect(readPacket()).To(Equal("concourse.goroutines:3|g"))
+		})
+	})
+
+	Context("when run", func() {
+		var process ifrit.Process
+
+		BeforeEach(func() {
+			config.FlushInterval = 10 * time.Millisecond
+		})
+
+		JustBeforeEach(func() {
+			process = ifrit.Invoke(statsd.Runner(logger))
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		It("sends the batched metrics on the flush interval", func() {
+			Expect(statsd.Emit(logger, metric.Event{Name: "goroutines", Value: 3})).To(Succeed())
+
+			Expect(readPacket()).To(Equal("concourse.goroutines:3|g"))
+		})
+
+		Context("when the flush interval is 0", func() {
+			BeforeEach(func() {
+				config.FlushInterval = 0
+			})
+
+			It("sends the batched metrics when signalled to stop", func() {
+				Expect(statsd.Emit(logger, metric.Event{Name: "goroutines", Value: 3})).To(Succeed())
+
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+
+				Expect(readPacket()).To(Equal("concourse.goroutines:3|g"))
+			})
+		})
+	})
+
+	Context("with an invalid sample rate", func() {
+		It("fails to construct the emitter", func() {
+			config.SampleRate = 0
+
+			_, err := config.NewEmitter()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package metric
+
+import (
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// EmitterQueue buffers the events for a single emitter, so that an emitter
+// which is slow or failing only loses its own events.
+type EmitterQueue struct {
+	Name string
+
+	// Dropped counts the events discarded because the buffer was full.
+	Dropped Meter
+
+	// Errors counts the events the emitter failed to emit.
+	Errors Meter
+
+	emitter   Emitter
+	emissions chan eventEmission
+
+	latencyTotal int64
+	latencyCount int64
+}
+
+func NewEmitterQueue(name string, emitter Emitter, size int) *EmitterQueue {
+	return &EmitterQueue{
+		Name:      name,
+		emitter:   emitter,
+		emissions: make(chan eventEmission, size),
+	}
+}
+
+// Enqueue buffers the event, dropping it if the buffer is full.
+func (queue *EmitterQueue) Enqueue(logger lager.Logger, event Event) {
+	select {
+	case queue.emissions <- eventEmission{logger: logger, event: event}:
+	default:
+		queue.Dropped.Inc()
+		logger.Error("queue-full", nil, lager.Data{"emitter": queue.Name})
+	}
+}
+
+// Run emits the buffered events until Close is called.
+func (queue *EmitterQueue) Run() {
+	for emission := range queue.emissions {
+		logger := emission.logger.Session("emit", lager.Data{"emitter": queue.Name})
+
+		start := time.Now()
+		err := queue.emitter.Emit(logger, emission.event)
+		atomic.AddInt64(&queue.latencyTotal, int64(time.Since(start)))
+		atomic.AddInt64(&queue.latencyCount, 1)
+
+		if err != nil {
+			queue.Errors.Inc()
+			logger.Error("failed-to-emit", err)
+		}
+	}
+}
+
+func (queue *EmitterQueue) Close() {
+	close(queue.emissions)
+}
+
+// AverageLatency returns how long the emitter took on average to emit each
+// event since it was last called.
+func (queue *EmitterQueue) AverageLatency() time.Duration {
+	total := atomic.SwapInt64(&queue.latencyTotal, 0)
+	count := atomic.SwapInt64(&queue.latencyCount, 0)
+
+	if count == 0 {
+		return 0
+	}
+
+	return time.Duration(total / count)
+}
@@ -0,0 +1,90 @@
+package metric_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/metricfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EmitterQueue", func() {
+	var (
+		logger      *lagertest.TestLogger
+		fakeEmitter *metricfakes.FakeEmitter
+		queue       *EmitterQueue
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakeEmitter = new(metricfakes.FakeEmitter)
+		queue = NewEmitterQueue("some-emitter", fakeEmitter, 2)
+	})
+
+	It("emits the queued events in order", func() {
+		queue.Enqueue(logger, Event{Name: "first"})
+		queue.Enqueue(logger, Event{Name: "second"})
+		queue.Close()
+
+		queue.Run()
+
+		Expect(fakeEmitter.EmitCallCount()).To(Equal(2))
+
+		_, event := fakeEmitter.EmitArgsForCall(0)
+		Expect(event.Name).To(Equal("first"))
+
+		_, event = fakeEmitter.EmitArgsForCall(1)
+		Expect(event.Name).To(Equal("second"))
+	})
+
+	It("counts the events dropped once the buffer is full", func() {
+		queue.Enqueue(logger, Event{Name: "first"})
+		queue.Enqueue(logger, Event{Name: "second"})
+		queue.Enqueue(logger, Event{Name: "third"})
+		queue.Close()
+
+		queue.Run()
+
+		Expect(fakeEmitter.EmitCallCount()).To(Equal(2))
+		Expect(queue.Dropped.Delta()).To(Equal(1))
+	})
+
+	It("counts the events the emitter fails to emit", func() {
+		fakeEmitter.EmitStub = func(_ lager.Logger, event Event) error {
+			if event.Name == "bad" {
+				return errors.New("nope")
+			}
+
+			return nil
+		}
+
+		queue.Enqueue(logger, Event{Name: "bad"})
+		queue.Enqueue(logger, Event{Name: "good"})
+		queue.Close()
+
+		queue.Run()
+
+		Expect(fakeEmitter.EmitCallCount()).To(Equal(2))
+		Expect(queue.Errors.Delta()).To(Equal(1))
+	})
+
+	Describe("AverageLatency", func() {
+		It("is zero when nothing has been emitted", func() {
+			Expect(queue.AverageLatency()).To(BeZero())
+		})
+
+		It("resets once read", func() {
+			queue.Enqueue(logger, Event{Name: "first"})
+			queue.Close()
+
+			queue.Run()
+
+			Expect(queue.AverageLatency()).To(BeNumerically(">=", 0))
+			Expect(queue.AverageLatency()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,61 @@
+package metric
+
+// Kind is how an event's value should be interpreted.
+type Kind int
+
+const (
+	// the event's value is the current value
+	GaugeKind Kind = iota
+
+	// each event counts once, whatever its value
+	CountKind
+
+	// the event's value is added to the running total
+	DeltaKind
+
+	// the event's value is a duration, in milliseconds
+	DurationKind
+)
+
+// Definition describes the events of one name, for emitters which need to
+// know more than each event says about itself.
+type Definition struct {
+	Kind Kind
+
+	// Labels are the attributes which distinguish the event's series.
+	Labels []string
+}
+
+// Definitions gives the kind and labels of each event emitted by this
+// package, by name.
+var Definitions = map[string]Definition{
+	"scheduling: full duration (ms)":             {DurationKind, []string{"pipeline"}},
+	"scheduling: loading versions duration (ms)": {DurationKind, []string{"pipeline"}},
+	"scheduling: job duration (ms)":              {DurationKind, []string{"pipeline", "job"}},
+	"worker containers":                          {GaugeKind, []string{"worker"}},
+	"worker volumes":                             {GaugeKind, []string{"worker"}},
+	"worker state transition":                    {CountKind, []string{"worker", "state"}},
+	"worker circuit breaker state":               {CountKind, []string{"worker", "state"}},
+	"builds aborted for landing":                 {DeltaKind, nil},
+	"build started":                              {CountKind, []string{"pipeline", "job", "team"}},
+	"build finished":                             {DurationKind, []string{"pipeline", "job", "team", "build_status"}},
+	"resource check duration (ms)":               {DurationKind, []string{"pipeline", "team", "resource_type", "outcome"}},
+	"pending builds":                             {GaugeKind, []string{"pipeline", "team"}},
+	"gc collector duration (ms)":                 {DurationKind, []string{"collector"}},
+	"containers deleted":                         {DeltaKind, nil},
+	"volumes deleted":                            {DeltaKind, nil},
+	"team quota usage":                           {GaugeKind, []string{"team", "quota"}},
+	"team quota limit":                           {GaugeKind, []string{"team", "quota"}},
+	"http response time":                         {DurationKind, []string{"route", "method"}},
+	"database queries":                           {DeltaKind, nil},
+	"database connections":                       {GaugeKind, nil},
+	"emitter dropped events":                     {DeltaKind, []string{"emitter"}},
+	"emitter errors":                             {DeltaKind, []string{"emitter"}},
+	"emitter latency (ms)":                       {GaugeKind, []string{"emitter"}},
+}
+
+// KindOf returns the kind of the named event; events this package doesn't
+// define are gauges.
+func KindOf(name string) Kind {
+	return Definitions[name].Kind
+}
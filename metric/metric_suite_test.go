@@ -1,6 +1,8 @@
 package metric_test
 
 import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric/metrictest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -11,3 +13,11 @@ func TestMetric(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Metric Suite")
 }
+
+var metricEmitter *metrictest.Emitter
+
+var _ = BeforeSuite(func() {
+	var err error
+	metricEmitter, err = metrictest.Initialize(lagertest.NewTestLogger("metrics"))
+	Expect(err).NotTo(HaveOccurred())
+})
@@ -138,9 +138,87 @@ func (event WorkerVolumes) Emit(logger lager.Logger) {
 	)
 }
 
+type WorkerStateTransition struct {
+	WorkerName string
+	State      dbng.WorkerState
+}
+
+func (event WorkerStateTransition) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("worker-state-transition"),
+		Event{
+			Name:  "worker state transition",
+			Value: 1,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+				"state":  string(event.State),
+			},
+		},
+	)
+}
+
+type workerStateObserver struct {
+	logger lager.Logger
+}
+
+// NewWorkerStateObserver emits a WorkerStateTransition for each worker the
+// lifecycle moves to a new state.
+func NewWorkerStateObserver(logger lager.Logger) dbng.WorkerStateObserver {
+	return workerStateObserver{logger: logger}
+}
+
+func (observer workerStateObserver) WorkerStateChanged(workerName string, state dbng.WorkerState) {
+	WorkerStateTransition{
+		WorkerName: workerName,
+		State:      state,
+	}.Emit(observer.logger)
+}
+
+type WorkerCircuitBreakerState struct {
+	WorkerName string
+	State      string
+}
+
+func (event WorkerCircuitBreakerState) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.State != "closed" {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("worker-circuit-breaker-state"),
+		Event{
+			Name:  "worker circuit breaker state",
+			Value: 1,
+			State: state,
+			Attributes: map[string]string{
+				"worker": event.WorkerName,
+				"state":  event.State,
+			},
+		},
+	)
+}
+
+type BuildsAbortedForLanding struct {
+	Builds int
+}
+
+func (event BuildsAbortedForLanding) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("builds-aborted-for-landing"),
+		Event{
+			Name:  "builds aborted for landing",
+			Value: event.Builds,
+			State: EventStateWarning,
+		},
+	)
+}
+
 type BuildStarted struct {
 	PipelineName string
 	JobName      string
+	TeamName     string
 	BuildName    string
 	BuildID      int
 }
@@ -155,6 +233,7 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 			Attributes: map[string]string{
 				"pipeline":   event.PipelineName,
 				"job":        event.JobName,
+				"team":       event.TeamName,
 				"build_name": event.BuildName,
 				"build_id":   strconv.Itoa(event.BuildID),
 			},
@@ -165,6 +244,7 @@ func (event BuildStarted) Emit(logger lager.Logger) {
 type BuildFinished struct {
 	PipelineName  string
 	JobName       string
+	TeamName      string
 	BuildName     string
 	BuildID       int
 	BuildStatus   dbng.BuildStatus
@@ -181,6 +261,7 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 			Attributes: map[string]string{
 				"pipeline":     event.PipelineName,
 				"job":          event.JobName,
+				"team":         event.TeamName,
 				"build_name":   event.BuildName,
 				"build_id":     strconv.Itoa(event.BuildID),
 				"build_status": string(event.BuildStatus),
@@ -189,6 +270,207 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type ResourceCheck struct {
+	PipelineName string
+	TeamName     string
+	ResourceType string
+	Outcome      string
+	Duration     time.Duration
+}
+
+const (
+	ResourceCheckSucceeded = "succeeded"
+	ResourceCheckFailed    = "failed"
+	ResourceCheckErrored   = "errored"
+)
+
+func (event ResourceCheck) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Outcome != ResourceCheckSucceeded {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("resource-check"),
+		Event{
+			Name:  "resource check duration (ms)",
+			Value: ms(event.Duration),
+			State: state,
+			Attributes: map[string]string{
+				"pipeline":      event.PipelineName,
+				"team":          event.TeamName,
+				"resource_type": event.ResourceType,
+				"outcome":       event.Outcome,
+			},
+		},
+	)
+}
+
+type PendingBuilds struct {
+	PipelineName string
+	TeamName     string
+	Count        int
+}
+
+func (event PendingBuilds) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("pending-builds"),
+		Event{
+			Name:  "pending builds",
+			Value: event.Count,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"team":     event.TeamName,
+			},
+		},
+	)
+}
+
+type GCCollectorDuration struct {
+	Collector string
+	Duration  time.Duration
+}
+
+func (event GCCollectorDuration) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("gc-collector-duration"),
+		Event{
+			Name:  "gc collector duration (ms)",
+			Value: ms(event.Duration),
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"collector": event.Collector,
+			},
+		},
+	)
+}
+
+type ContainersDeleted struct {
+	Count int
+}
+
+func (event ContainersDeleted) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("containers-deleted"),
+		Event{
+			Name:  "containers deleted",
+			Value: event.Count,
+			State: EventStateOK,
+		},
+	)
+}
+
+type VolumesDeleted struct {
+	Count int
+}
+
+func (event VolumesDeleted) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("volumes-deleted"),
+		Event{
+			Name:  "volumes deleted",
+			Value: event.Count,
+			State: EventStateOK,
+		},
+	)
+}
+
+type TeamQuotaUsage struct {
+	TeamName string
+	Quota    string
+	Used     int
+	Limit    int
+}
+
+func (event TeamQuotaUsage) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if event.Limit != 0 && event.Used >= event.Limit {
+		state = EventStateWarning
+	}
+
+	emit(
+		logger.Session("team-quota-usage"),
+		Event{
+			Name:  "team quota usage",
+			Value: event.Used,
+			State: state,
+			Attributes: map[string]string{
+				"team":  event.TeamName,
+				"quota": event.Quota,
+			},
+		},
+	)
+
+	// the limit is a series of its own, rather than an attribute of the
+	// usage, so that changing it doesn't start a new series of usage
+	emit(
+		logger.Session("team-quota-limit"),
+		Event{
+			Name:  "team quota limit",
+			Value: event.Limit,
+			State: EventStateOK,
+			Attributes: map[string]string{
+				"team":  event.TeamName,
+				"quota": event.Quota,
+			},
+		},
+	)
+}
+
+type EmitterStats struct {
+	Emitter string
+	Dropped int
+	Errors  int
+	Latency time.Duration
+}
+
+func (event EmitterStats) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"emitter": event.Emitter,
+	}
+
+	droppedState := EventStateOK
+	if event.Dropped > 0 {
+		droppedState = EventStateWarning
+	}
+
+	emit(
+		logger.Session("emitter-dropped-events"),
+		Event{
+			Name:       "emitter dropped events",
+			Value:      event.Dropped,
+			State:      droppedState,
+			Attributes: attributes,
+		},
+	)
+
+	errorsState := EventStateOK
+	if event.Errors > 0 {
+		errorsState = EventStateWarning
+	}
+
+	emit(
+		logger.Session("emitter-errors"),
+		Event{
+			Name:       "emitter errors",
+			Value:      event.Errors,
+			State:      errorsState,
+			Attributes: attributes,
+		},
+	)
+
+	emit(
+		logger.Session("emitter-latency"),
+		Event{
+			Name:       "emitter latency (ms)",
+			Value:      ms(event.Latency),
+			State:      EventStateOK,
+			Attributes: attributes,
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
@@ -0,0 +1,56 @@
+// Package metrictest records the events emitted through the metric package,
+// for suites which assert on them.
+package metrictest
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/metric/metricfakes"
+)
+
+// Emitter records every event emitted once it is initialized.
+type Emitter struct {
+	fake *metricfakes.FakeEmitter
+}
+
+// Initialize registers an Emitter and initializes the metric package with it.
+// The metric package can only be initialized once, so suites call it from
+// their BeforeSuite.
+func Initialize(logger lager.Logger) (*Emitter, error) {
+	fake := new(metricfakes.FakeEmitter)
+
+	emitterFactory := new(metricfakes.FakeEmitterFactory)
+	emitterFactory.IsConfiguredReturns(true)
+	emitterFactory.NewEmitterReturns(fake, nil)
+
+	metric.RegisterEmitter(emitterFactory)
+
+	err := metric.Initialize(logger, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Emitter{fake: fake}, nil
+}
+
+// Events returns a function for Eventually which returns the events emitted
+// so far with the given name, without their host and time.
+func (emitter *Emitter) Events(name string) func() []metric.Event {
+	return func() []metric.Event {
+		events := []metric.Event{}
+		for i := 0; i < emitter.fake.EmitCallCount(); i++ {
+			_, event := emitter.fake.EmitArgsForCall(i)
+			if event.Name != name {
+				continue
+			}
+
+			event.Host = ""
+			event.Time = time.Time{}
+			events = append(events, event)
+		}
+
+		return events
+	}
+}
@@ -73,5 +73,14 @@ func PeriodicallyEmit(logger lager.Logger, interval time.Duration) {
 				State: EventStateOK,
 			},
 		)
+
+		for _, queue := range emitterQueues {
+			EmitterStats{
+				Emitter: queue.Name,
+				Dropped: queue.Dropped.Delta(),
+				Errors:  queue.Errors.Delta(),
+				Latency: queue.AverageLatency(),
+			}.Emit(tLog)
+		}
 	}
 }
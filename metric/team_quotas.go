@@ -0,0 +1,77 @@
+package metric
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+type teamQuotaUsageEmitter struct {
+	logger      lager.Logger
+	teamFactory dbng.TeamFactory
+}
+
+// NewTeamQuotaUsageEmitter constructs a task which emits the usage of each
+// team's quotas. Counting a team's builds, containers and volumes is too
+// costly to do whenever they change, so the usage is emitted periodically
+// instead.
+func NewTeamQuotaUsageEmitter(logger lager.Logger, teamFactory dbng.TeamFactory) *teamQuotaUsageEmitter {
+	return &teamQuotaUsageEmitter{
+		logger:      logger,
+		teamFactory: teamFactory,
+	}
+}
+
+func (e *teamQuotaUsageEmitter) Run() error {
+	logger := e.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	teams, err := e.teamFactory.GetTeams()
+	if err != nil {
+		logger.Error("failed-to-get-teams", err)
+		return err
+	}
+
+	for _, team := range teams {
+		quotas, err := team.FindQuotas()
+		if err != nil {
+			logger.Error("failed-to-find-quotas", err, lager.Data{"team": team.Name()})
+			continue
+		}
+
+		if quotas == (atc.TeamQuotas{}) {
+			continue
+		}
+
+		usage, err := team.QuotaUsage()
+		if err != nil {
+			logger.Error("failed-to-count-quota-usage", err, lager.Data{"team": team.Name()})
+			continue
+		}
+
+		TeamQuotaUsage{
+			TeamName: team.Name(),
+			Quota:    "running builds",
+			Used:     usage.RunningBuilds,
+			Limit:    quotas.MaxRunningBuilds,
+		}.Emit(logger)
+
+		TeamQuotaUsage{
+			TeamName: team.Name(),
+			Quota:    "containers",
+			Used:     usage.Containers,
+			Limit:    quotas.MaxContainers,
+		}.Emit(logger)
+
+		TeamQuotaUsage{
+			TeamName: team.Name(),
+			Quota:    "volumes",
+			Used:     usage.Volumes,
+			Limit:    quotas.MaxVolumes,
+		}.Emit(logger)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package metric_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/metric"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TeamQuotaUsageEmitter", func() {
+	var (
+		fakeTeamFactory *dbngfakes.FakeTeamFactory
+		quotaTeam       *dbngfakes.FakeTeam
+		unlimitedTeam   *dbngfakes.FakeTeam
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeTeamFactory = new(dbngfakes.FakeTeamFactory)
+
+		quotaTeam = new(dbngfakes.FakeTeam)
+		quotaTeam.NameReturns("quota-team")
+		quotaTeam.FindQuotasReturns(atc.TeamQuotas{MaxRunningBuilds: 2, MaxContainers: 10}, nil)
+		quotaTeam.QuotaUsageReturns(atc.TeamQuotaUsage{RunningBuilds: 2, Containers: 3, Volumes: 4}, nil)
+
+		unlimitedTeam = new(dbngfakes.FakeTeam)
+		unlimitedTeam.NameReturns("unlimited-team")
+
+		fakeTeamFactory.GetTeamsReturns([]dbng.Team{unlimitedTeam, quotaTeam}, nil)
+	})
+
+	JustBeforeEach(func() {
+		runErr = metric.NewTeamQuotaUsageEmitter(lagertest.NewTestLogger("test"), fakeTeamFactory).Run()
+	})
+
+	It("emits the usage of each quota of the teams with quotas", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		usage := metricEmitter.Events("team quota usage")
+
+		Eventually(usage).Should(ContainElement(metric.Event{
+			Name:       "team quota usage",
+			Value:      2,
+			State:      metric.EventStateWarning,
+			Attributes: map[string]string{"team": "quota-team", "quota": "running builds"},
+		}))
+
+		Eventually(usage).Should(ContainElement(metric.Event{
+			Name:       "team quota usage",
+			Value:      3,
+			State:      metric.EventStateOK,
+			Attributes: map[string]string{"team": "quota-team", "quota": "containers"},
+		}))
+
+		Eventually(usage).Should(ContainElement(metric.Event{
+			Name:       "team quota usage",
+			Value:      4,
+			State:      metric.EventStateOK,
+			Attributes: map[string]string{"team": "quota-team", "quota": "volumes"},
+		}))
+	})
+
+	It("emits the limit of each quota as its own series", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		limits := metricEmitter.Events("team quota limit")
+
+		Eventually(limits).Should(ContainElement(metric.Event{
+			Name:       "team quota limit",
+			Value:      2,
+			State:      metric.EventStateOK,
+			Attributes: map[string]string{"team": "quota-team", "quota": "running builds"},
+		}))
+
+		Eventually(limits).Should(ContainElement(metric.Event{
+			Name:       "team quota limit",
+			Value:      10,
+			State:      metric.EventStateOK,
+			Attributes: map[string]string{"team": "quota-team", "quota": "containers"},
+		}))
+	})
+
+	It("does not count the usage of teams without quotas", func() {
+		Expect(unlimitedTeam.QuotaUsageCallCount()).To(BeZero())
+	})
+
+	Context("when the teams cannot be listed", func() {
+		BeforeEach(func() {
+			fakeTeamFactory.GetTeamsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// NotificationSubscription asks for a JSON payload to be POSTed to a URL
+// whenever a build of the team, or of one of its pipelines, changes status.
+type NotificationSubscription struct {
+	ID       int    `json:"id,omitempty"`
+	Pipeline string `json:"pipeline,omitempty"`
+	URL      string `json:"url"`
+
+	// Secret is used to sign each payload with HMAC-SHA256. It is never
+	// returned by the API.
+	Secret string `json:"secret,omitempty"`
+
+	// Jobs and Statuses restrict which builds are notified. Empty means all.
+	Jobs     []string      `json:"jobs,omitempty"`
+	Statuses []BuildStatus `json:"statuses,omitempty"`
+}
+
+var notifiableStatuses = []BuildStatus{
+	StatusStarted,
+	StatusSucceeded,
+	StatusFailed,
+	StatusErrored,
+	StatusAborted,
+}
+
+func (subscription NotificationSubscription) Validate() error {
+	endpoint, err := url.Parse(subscription.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	if len(subscription.Jobs) > 0 && subscription.Pipeline == "" {
+		return errors.New("jobs can only be filtered for a pipeline")
+	}
+
+	for _, status := range subscription.Statuses {
+		if !isNotifiable(status) {
+			return fmt.Errorf("unknown status '%s'", status)
+		}
+	}
+
+	return nil
+}
+
+// Matches returns whether a build of the given job changing to the given
+// status passes the subscription's filters.
+func (subscription NotificationSubscription) Matches(jobName string, status BuildStatus) bool {
+	if len(subscription.Jobs) > 0 && !containsString(subscription.Jobs, jobName) {
+		return false
+	}
+
+	if len(subscription.Statuses) > 0 {
+		for _, s := range subscription.Statuses {
+			if s == status {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func isNotifiable(status BuildStatus) bool {
+	for _, s := range notifiableStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(strings []string, str string) bool {
+	for _, s := range strings {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BuildNotification is the payload POSTed to a subscription.
+type BuildNotification struct {
+	Team      string      `json:"team"`
+	Pipeline  string      `json:"pipeline,omitempty"`
+	Job       string      `json:"job,omitempty"`
+	BuildID   int         `json:"build_id"`
+	BuildName string      `json:"build_name"`
+	Status    BuildStatus `json:"status"`
+	Time      int64       `json:"time"`
+	URL       string      `json:"url,omitempty"`
+}
+
+// NotificationDelivery is one attempt to deliver a notification.
+type NotificationDelivery struct {
+	NotificationID int         `json:"notification_id"`
+	BuildID        int         `json:"build_id"`
+	Status         BuildStatus `json:"status"`
+	Attempt        int         `json:"attempt"`
+	StatusCode     int         `json:"status_code,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	Duration       float64     `json:"duration"`
+	AttemptedAt    int64       `json:"attempted_at"`
+}
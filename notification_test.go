@@ -0,0 +1,62 @@
+package atc_test
+
+import (
+	"github.com/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NotificationSubscription", func() {
+	Describe("Validate", func() {
+		var subscription atc.NotificationSubscription
+
+		BeforeEach(func() {
+			subscription = atc.NotificationSubscription{
+				Pipeline: "some-pipeline",
+				URL:      "https://example.com/hook",
+				Jobs:     []string{"some-job"},
+				Statuses: []atc.BuildStatus{atc.StatusStarted, atc.StatusFailed},
+			}
+		})
+
+		It("accepts a valid subscription", func() {
+			Expect(subscription.Validate()).To(Succeed())
+		})
+
+		It("requires an absolute http URL", func() {
+			subscription.URL = "/hook"
+			Expect(subscription.Validate()).To(MatchError("url must be an absolute http or https URL"))
+
+			subscription.URL = "ftp://example.com/hook"
+			Expect(subscription.Validate()).To(MatchError("url must be an absolute http or https URL"))
+		})
+
+		It("only filters jobs for a pipeline", func() {
+			subscription.Pipeline = ""
+			Expect(subscription.Validate()).To(MatchError("jobs can only be filtered for a pipeline"))
+		})
+
+		It("rejects statuses builds do not transition to", func() {
+			subscription.Statuses = []atc.BuildStatus{atc.StatusPending}
+			Expect(subscription.Validate()).To(MatchError("unknown status 'pending'"))
+		})
+	})
+
+	Describe("Matches", func() {
+		It("matches everything without filters", func() {
+			Expect(atc.NotificationSubscription{}.Matches("", atc.StatusAborted)).To(BeTrue())
+		})
+
+		It("matches only the filtered jobs and statuses", func() {
+			subscription := atc.NotificationSubscription{
+				Jobs:     []string{"some-job"},
+				Statuses: []atc.BuildStatus{atc.StatusFailed},
+			}
+
+			Expect(subscription.Matches("some-job", atc.StatusFailed)).To(BeTrue())
+			Expect(subscription.Matches("some-job", atc.StatusSucceeded)).To(BeFalse())
+			Expect(subscription.Matches("other-job", atc.StatusFailed)).To(BeFalse())
+		})
+	})
+})
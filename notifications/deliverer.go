@@ -0,0 +1,265 @@
+package notifications
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/web"
+	"github.com/tedsuo/rata"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the payload, keyed with the
+	// subscription's secret, as "sha256=<hex>".
+	SignatureHeader = "X-Concourse-Signature"
+
+	// DeliveryHeader carries the ID of the notification, which stays the same
+	// across retries so that receivers can ignore duplicates.
+	DeliveryHeader = "X-Concourse-Delivery"
+
+	EventHeader = "X-Concourse-Event"
+	BuildEvent  = "build-status"
+)
+
+const (
+	// DeliveryBatchSize is how many due notifications are delivered per run.
+	DeliveryBatchSize = 100
+
+	// InitialBackoff is how long after the first failed attempt a
+	// notification is retried, doubling with each further failure up to
+	// MaxBackoff.
+	InitialBackoff = 30 * time.Second
+	MaxBackoff     = time.Hour
+
+	// Retention is how long delivered and abandoned notifications are kept in
+	// the delivery log.
+	Retention = 7 * 24 * time.Hour
+)
+
+type deliverer struct {
+	logger              lager.Logger
+	notificationFactory dbng.NotificationFactory
+	client              *http.Client
+	clock               clock.Clock
+	externalURL         string
+	maxAttempts         int
+}
+
+// NewDeliverer constructs a task which POSTs the due notifications in the
+// outbox to their subscriptions, retrying failed deliveries with exponential
+// backoff until maxAttempts have been made.
+func NewDeliverer(
+	logger lager.Logger,
+	notificationFactory dbng.NotificationFactory,
+	client *http.Client,
+	clock clock.Clock,
+	externalURL string,
+	maxAttempts int,
+) *deliverer {
+	return &deliverer{
+		logger:              logger,
+		notificationFactory: notificationFactory,
+		client:              client,
+		clock:               clock,
+		externalURL:         externalURL,
+		maxAttempts:         maxAttempts,
+	}
+}
+
+func (d *deliverer) Run() error {
+	logger := d.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	notifications, err := d.notificationFactory.DueNotifications(DeliveryBatchSize)
+	if err != nil {
+		logger.Error("failed-to-get-due-notifications", err)
+		return err
+	}
+
+	// notifications to the same subscription are delivered in order, so that
+	// e.g. a build's success does not arrive before its start
+	subscriptionIDs := []int{}
+	bySubscription := map[int][]dbng.Notification{}
+	for _, notification := range notifications {
+		if _, found := bySubscription[notification.SubscriptionID]; !found {
+			subscriptionIDs = append(subscriptionIDs, notification.SubscriptionID)
+		}
+
+		bySubscription[notification.SubscriptionID] = append(bySubscription[notification.SubscriptionID], notification)
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, subscriptionID := range subscriptionIDs {
+		wg.Add(1)
+		go func(notifications []dbng.Notification) {
+			defer wg.Done()
+
+			for _, notification := range notifications {
+				if !d.deliver(logger.Session("deliver", lager.Data{"notification": notification.ID}), notification) {
+					// leave the rest until this one gets through
+					return
+				}
+			}
+		}(bySubscription[subscriptionID])
+	}
+
+	wg.Wait()
+
+	err = d.notificationFactory.PruneNotifications(d.clock.Now().Add(-Retention))
+	if err != nil {
+		logger.Error("failed-to-prune-notifications", err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *deliverer) deliver(logger lager.Logger, notification dbng.Notification) bool {
+	attempt := d.post(logger, notification)
+
+	var retryAt *time.Time
+	if !attempt.Delivered {
+		attempts := notification.Attempts + 1
+
+		if attempts < d.maxAttempts {
+			next := d.clock.Now().Add(backoff(attempts))
+			retryAt = &next
+		}
+
+		logger.Info("failed-to-deliver", lager.Data{
+			"error":    attempt.Error,
+			"attempts": attempts,
+			"retrying": retryAt != nil,
+		})
+	}
+
+	err := d.notificationFactory.RecordAttempt(notification.ID, attempt, retryAt)
+	if err != nil {
+		logger.Error("failed-to-record-attempt", err)
+		return false
+	}
+
+	return attempt.Delivered
+}
+
+// post attempts to deliver the notification. The attempt's error is shown to
+// the team in the delivery log, so it is kept generic and the detail is only
+// logged; otherwise it would tell them about whatever the ATC can reach.
+func (d *deliverer) post(logger lager.Logger, notification dbng.Notification) dbng.NotificationAttempt {
+	payload := notification.Payload
+	payload.URL = d.buildURL(payload)
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed-to-marshal-payload", err)
+		return dbng.NotificationAttempt{Error: "failed to build request"}
+	}
+
+	request, err := http.NewRequest("POST", notification.URL, bytes.NewReader(body))
+	if err != nil {
+		logger.Error("failed-to-build-request", err)
+		return dbng.NotificationAttempt{Error: "failed to build request"}
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, BuildEvent)
+	request.Header.Set(DeliveryHeader, strconv.Itoa(notification.ID))
+
+	if notification.Secret != "" {
+		request.Header.Set(SignatureHeader, Sign(notification.Secret, body))
+	}
+
+	start := d.clock.Now()
+	response, err := d.client.Do(request)
+	attempt := dbng.NotificationAttempt{Duration: d.clock.Since(start)}
+	if err != nil {
+		logger.Info("failed-to-reach-endpoint", lager.Data{"error": err.Error()})
+		attempt.Error = requestError(err)
+		return attempt
+	}
+
+	response.Body.Close()
+
+	attempt.StatusCode = response.StatusCode
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("endpoint responded with %s", response.Status)
+		return attempt
+	}
+
+	attempt.Delivered = true
+
+	return attempt
+}
+
+func requestError(err error) string {
+	if urlErr, ok := err.(*url.Error); ok {
+		if urlErr.Err == ErrForbiddenAddress {
+			return ErrForbiddenAddress.Error()
+		}
+
+		if urlErr.Timeout() {
+			return "timed out waiting for endpoint"
+		}
+	}
+
+	return "failed to reach endpoint"
+}
+
+func (d *deliverer) buildURL(notification atc.BuildNotification) string {
+	var path string
+	var err error
+	if notification.Job == "" && notification.Pipeline == "" {
+		path, err = web.Routes.CreatePathForRoute(web.GetJoblessBuild, rata.Params{
+			"build_id":  strconv.Itoa(notification.BuildID),
+			"team_name": notification.Team,
+		})
+	} else {
+		path, err = web.Routes.CreatePathForRoute(web.GetBuild, rata.Params{
+			"job":           notification.Job,
+			"build":         notification.BuildName,
+			"pipeline_name": notification.Pipeline,
+			"team_name":     notification.Team,
+		})
+	}
+	if err != nil {
+		return ""
+	}
+
+	return d.externalURL + path
+}
+
+// Sign returns the signature of a payload as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func backoff(attempts int) time.Duration {
+	delay := InitialBackoff
+	for i := 1; i < attempts && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > MaxBackoff {
+		delay = MaxBackoff
+	}
+
+	return delay
+}
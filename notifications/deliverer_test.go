@@ -0,0 +1,269 @@
+package notifications_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/lockrunner"
+	"github.com/concourse/atc/notifications"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Deliverer", func() {
+	var (
+		fakeNotificationFactory *dbngfakes.FakeNotificationFactory
+		fakeClock               *fakeclock.FakeClock
+		server                  *httptest.Server
+		client                  *http.Client
+
+		lock     sync.Mutex
+		status   int
+		requests []*http.Request
+		bodies   [][]byte
+
+		deliverer lockrunner.Task
+		runErr    error
+	)
+
+	received := func() [][]byte {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return append([][]byte{}, bodies...)
+	}
+
+	BeforeEach(func() {
+		fakeNotificationFactory = new(dbngfakes.FakeNotificationFactory)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		status = http.StatusOK
+		requests = nil
+		bodies = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+
+			lock.Lock()
+			requests = append(requests, r)
+			bodies = append(bodies, body)
+			lock.Unlock()
+
+			w.WriteHeader(status)
+		}))
+
+		client = http.DefaultClient
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		deliverer = notifications.NewDeliverer(
+			lagertest.NewTestLogger("test"),
+			fakeNotificationFactory,
+			client,
+			fakeClock,
+			"https://ci.example.com",
+			3,
+		)
+
+		runErr = deliverer.Run()
+	})
+
+	Context("when there are due notifications", func() {
+		var due []dbng.Notification
+
+		BeforeEach(func() {
+			due = []dbng.Notification{
+				{
+					ID:             42,
+					SubscriptionID: 1,
+					URL:            server.URL + "/hook",
+					Secret:         "some-secret",
+					Payload: atc.BuildNotification{
+						Team:      "some-team",
+						Pipeline:  "some-pipeline",
+						Job:       "some-job",
+						BuildID:   128,
+						BuildName: "7",
+						Status:    atc.StatusFailed,
+						Time:      123,
+					},
+				},
+			}
+
+			fakeNotificationFactory.DueNotificationsReturns(due, nil)
+		})
+
+		It("posts the payload with a link to the build", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+
+			Expect(received()).To(HaveLen(1))
+			Expect(requests[0].Method).To(Equal("POST"))
+			Expect(requests[0].URL.Path).To(Equal("/hook"))
+			Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"))
+			Expect(requests[0].Header.Get("X-Concourse-Event")).To(Equal("build-status"))
+			Expect(requests[0].Header.Get("X-Concourse-Delivery")).To(Equal("42"))
+
+			Expect(bodies[0]).To(MatchJSON(`{
+				"team": "some-team",
+				"pipeline": "some-pipeline",
+				"job": "some-job",
+				"build_id": 128,
+				"build_name": "7",
+				"status": "failed",
+				"time": 123,
+				"url": "https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/7"
+			}`))
+		})
+
+		It("signs the payload with the subscription's secret", func() {
+			mac := hmac.New(sha256.New, []byte("some-secret"))
+			mac.Write(bodies[0])
+
+			Expect(requests[0].Header.Get("X-Concourse-Signature")).To(Equal("sha256=" + hex.EncodeToString(mac.Sum(nil))))
+		})
+
+		It("records the delivery", func() {
+			Expect(fakeNotificationFactory.RecordAttemptCallCount()).To(Equal(1))
+
+			id, attempt, retryAt := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+			Expect(id).To(Equal(42))
+			Expect(attempt.Delivered).To(BeTrue())
+			Expect(attempt.StatusCode).To(Equal(http.StatusOK))
+			Expect(attempt.Error).To(BeEmpty())
+			Expect(retryAt).To(BeNil())
+		})
+
+		It("prunes old notifications", func() {
+			Expect(fakeNotificationFactory.PruneNotificationsCallCount()).To(Equal(1))
+			Expect(fakeNotificationFactory.PruneNotificationsArgsForCall(0)).To(Equal(fakeClock.Now().Add(-notifications.Retention)))
+		})
+
+		Context("when the endpoint fails", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("retries with backoff", func() {
+				Expect(fakeNotificationFactory.RecordAttemptCallCount()).To(Equal(1))
+
+				_, attempt, retryAt := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+				Expect(attempt.Delivered).To(BeFalse())
+				Expect(attempt.StatusCode).To(Equal(http.StatusInternalServerError))
+				Expect(attempt.Error).To(Equal("endpoint responded with 500 Internal Server Error"))
+				Expect(retryAt).NotTo(BeNil())
+				Expect(*retryAt).To(Equal(fakeClock.Now().Add(notifications.InitialBackoff)))
+			})
+
+			Context("when it has already been attempted", func() {
+				BeforeEach(func() {
+					due[0].Attempts = 1
+				})
+
+				It("backs off for longer", func() {
+					_, _, retryAt := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+					Expect(*retryAt).To(Equal(fakeClock.Now().Add(2 * notifications.InitialBackoff)))
+				})
+			})
+
+			Context("when it has run out of attempts", func() {
+				BeforeEach(func() {
+					due[0].Attempts = 2
+				})
+
+				It("gives up", func() {
+					_, _, retryAt := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+					Expect(retryAt).To(BeNil())
+				})
+			})
+		})
+
+		Context("when the endpoint can not be reached", func() {
+			BeforeEach(func() {
+				server.Close()
+			})
+
+			It("records a generic error", func() {
+				_, attempt, retryAt := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+				Expect(attempt.Delivered).To(BeFalse())
+				Expect(attempt.StatusCode).To(BeZero())
+				Expect(attempt.Error).To(Equal("failed to reach endpoint"))
+				Expect(retryAt).NotTo(BeNil())
+			})
+		})
+
+		Context("when the endpoint resolves to a forbidden address", func() {
+			BeforeEach(func() {
+				client = notifications.NewClient(time.Second, false)
+			})
+
+			It("does not connect to it", func() {
+				Expect(received()).To(BeEmpty())
+
+				_, attempt, _ := fakeNotificationFactory.RecordAttemptArgsForCall(0)
+				Expect(attempt.Delivered).To(BeFalse())
+				Expect(attempt.Error).To(Equal("endpoint address is not allowed"))
+			})
+
+			Context("when private networks are allowed", func() {
+				BeforeEach(func() {
+					client = notifications.NewClient(time.Second, true)
+				})
+
+				It("delivers it", func() {
+					Expect(received()).To(HaveLen(1))
+				})
+			})
+		})
+	})
+
+	Context("when several notifications are due for a subscription", func() {
+		BeforeEach(func() {
+			status = http.StatusServiceUnavailable
+
+			fakeNotificationFactory.DueNotificationsReturns([]dbng.Notification{
+				{ID: 1, SubscriptionID: 1, URL: server.URL, Payload: atc.BuildNotification{BuildID: 1, Status: atc.StatusStarted}},
+				{ID: 2, SubscriptionID: 1, URL: server.URL, Payload: atc.BuildNotification{BuildID: 1, Status: atc.StatusSucceeded}},
+			}, nil)
+		})
+
+		It("stops at the first that can not be delivered, to keep them in order", func() {
+			Expect(received()).To(HaveLen(1))
+
+			var payload atc.BuildNotification
+			Expect(json.Unmarshal(received()[0], &payload)).To(Succeed())
+			Expect(payload.Status).To(Equal(atc.StatusStarted))
+			Expect(payload.URL).To(Equal("https://ci.example.com/builds/1"))
+
+			Expect(requests[0].Header.Get("X-Concourse-Signature")).To(BeEmpty())
+		})
+	})
+
+	Context("when getting the due notifications fails", func() {
+		BeforeEach(func() {
+			fakeNotificationFactory.DueNotificationsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
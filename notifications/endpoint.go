@@ -0,0 +1,161 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for endpoints which resolve to a loopback,
+// private or link-local address, unless private networks are allowed. This
+// stops subscriptions from being used to reach services which are only
+// reachable from the ATC.
+var ErrForbiddenAddress = errors.New("endpoint address is not allowed")
+
+var forbiddenNetworks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+//go:generate counterfeiter . EndpointValidator
+
+type EndpointValidator interface {
+	Validate(endpoint string) error
+}
+
+type endpointValidator struct {
+	allowPrivateNetworks bool
+}
+
+// NewEndpointValidator constructs an EndpointValidator which checks that a
+// subscription's URL does not resolve to a forbidden address, unless
+// allowPrivateNetworks is true.
+func NewEndpointValidator(allowPrivateNetworks bool) EndpointValidator {
+	return endpointValidator{
+		allowPrivateNetworks: allowPrivateNetworks,
+	}
+}
+
+func (validator endpointValidator) Validate(endpoint string) error {
+	if validator.allowPrivateNetworks {
+		return nil
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	_, err = resolveAllowed(context.Background(), parsed.Hostname())
+	return err
+}
+
+// NewClient constructs the client deliveries are made with. Unless
+// allowPrivateNetworks is true, it refuses to connect to forbidden addresses,
+// which is checked for each connection so that neither redirects nor a
+// change in DNS get around it.
+func NewClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	dial := dialer.DialContext
+	if !allowPrivateNetworks {
+		dial = func(ctx context.Context, network string, addr string) (net.Conn, error) {
+			host, port, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			ips, err := resolveAllowed(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+
+			return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// a proxy would make the connection on our behalf, unchecked
+			Proxy:               nil,
+			DialContext:         dial,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
+
+// resolveAllowed returns the addresses of the host, or ErrForbiddenAddress if
+// any of them is forbidden.
+func resolveAllowed(ctx context.Context, host string) ([]net.IP, error) {
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, ErrForbiddenAddress
+	}
+
+	for _, ip := range ips {
+		if isForbidden(ip) {
+			return nil, ErrForbiddenAddress
+		}
+	}
+
+	return ips, nil
+}
+
+func isForbidden(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return true
+	}
+
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
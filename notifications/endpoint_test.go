@@ -0,0 +1,45 @@
+package notifications_test
+
+import (
+	"github.com/concourse/atc/notifications"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EndpointValidator", func() {
+	var validator notifications.EndpointValidator
+
+	BeforeEach(func() {
+		validator = notifications.NewEndpointValidator(false)
+	})
+
+	It("rejects loopback, private and link-local addresses", func() {
+		for _, endpoint := range []string{
+			"http://127.0.0.1/hook",
+			"http://localhost:8080/hook",
+			"https://10.0.0.5/hook",
+			"https://192.168.1.1/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+			"http://[fd00::1]/hook",
+		} {
+			Expect(validator.Validate(endpoint)).To(Equal(notifications.ErrForbiddenAddress), endpoint)
+		}
+	})
+
+	It("allows public addresses", func() {
+		Expect(validator.Validate("https://93.184.216.34/hook")).To(Succeed())
+	})
+
+	Context("when private networks are allowed", func() {
+		BeforeEach(func() {
+			validator = notifications.NewEndpointValidator(true)
+		})
+
+		It("allows them", func() {
+			Expect(validator.Validate("http://127.0.0.1/hook")).To(Succeed())
+			Expect(validator.Validate("http://169.254.169.254/latest/meta-data")).To(Succeed())
+		})
+	})
+})
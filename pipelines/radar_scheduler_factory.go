@@ -27,17 +27,23 @@ type radarSchedulerFactory struct {
 	resourceFactory resource.ResourceFactory
 	interval        time.Duration
 	engine          engine.Engine
+	teamFactory     dbng.TeamFactory
+	admissionQueue  dbng.AdmissionQueue
 }
 
 func NewRadarSchedulerFactory(
 	resourceFactory resource.ResourceFactory,
 	interval time.Duration,
 	engine engine.Engine,
+	teamFactory dbng.TeamFactory,
+	admissionQueue dbng.AdmissionQueue,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		resourceFactory: resourceFactory,
 		interval:        interval,
 		engine:          engine,
+		teamFactory:     teamFactory,
+		admissionQueue:  admissionQueue,
 	}
 }
 
@@ -57,12 +63,14 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline dbng.Pipeline, externa
 		pipeline,
 		inputconfig.NewTransformer(pipeline),
 	)
+	team := rsf.teamFactory.GetByID(pipeline.TeamID())
 	return &scheduler.Scheduler{
 		Pipeline:    pipeline,
 		InputMapper: inputMapper,
 		BuildStarter: scheduler.NewBuildStarter(
 			pipeline,
-			maxinflight.NewUpdater(pipeline),
+			rsf.admissionQueue,
+			maxinflight.NewUpdater(pipeline, team, clock.NewClock()),
 			factory.NewBuildFactory(
 				pipeline.ID(),
 				atc.NewPlanFactory(time.Now().Unix()),
@@ -1,5 +1,7 @@
 package atc
 
+import "strconv"
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
@@ -14,6 +16,7 @@ type Plan struct {
 	OnFailure    *OnFailurePlan    `json:"on_failure,omitempty"`
 	Try          *TryPlan          `json:"try,omitempty"`
 	DependentGet *DependentGetPlan `json:"dependent_get,omitempty"`
+	GetArtifact  *GetArtifactPlan  `json:"get_artifact,omitempty"`
 	Timeout      *TimeoutPlan      `json:"timeout,omitempty"`
 	Retry        *RetryPlan        `json:"retry,omitempty"`
 }
@@ -44,6 +47,38 @@ func (plan DependentGetPlan) GetPlan() GetPlan {
 	}
 }
 
+// ArtifactResourceType is the type of the versions recorded for the outputs
+// a job's builds publish, which lets them pass between jobs like resources.
+const ArtifactResourceType = "artifact"
+
+// ArtifactResourceName returns the name of the resource the versions of a
+// published output are recorded under. It is prefixed so that it can not
+// collide with the pipeline's own resources.
+func ArtifactResourceName(name string) string {
+	return "artifact:" + name
+}
+
+// ArtifactVersion returns the version recorded for an output published by the
+// given build.
+func ArtifactVersion(buildID int) Version {
+	return Version{"build": strconv.Itoa(buildID)}
+}
+
+type GetArtifactPlan struct {
+	Name    string `json:"name"`
+	Job     string `json:"job"`
+	BuildID int    `json:"build_id"`
+}
+
+func (plan GetArtifactPlan) GetPlan() GetPlan {
+	return GetPlan{
+		Type:     ArtifactResourceType,
+		Name:     plan.Name,
+		Resource: ArtifactResourceName(plan.Name),
+		Version:  ArtifactVersion(plan.BuildID),
+	}
+}
+
 type OnFailurePlan struct {
 	Step Plan `json:"step"`
 	Next Plan `json:"on_failure"`
@@ -109,6 +144,12 @@ type TaskPlan struct {
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
 
+	SkipIfUnchanged bool   `json:"skip_if_unchanged,omitempty"`
+	CacheKey        string `json:"cache_key,omitempty"`
+
+	Artifacts   []string           `json:"artifacts,omitempty"`
+	TestReports *TestReportsConfig `json:"test_reports,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -45,6 +45,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Try = &t
 	case DependentGetPlan:
 		plan.DependentGet = &t
+	case GetArtifactPlan:
+		plan.GetArtifact = &t
 	case TimeoutPlan:
 		plan.Timeout = &t
 	case RetryPlan:
@@ -16,6 +16,7 @@ func (plan Plan) Public() *json.RawMessage {
 		OnFailure    *json.RawMessage `json:"on_failure,omitempty"`
 		Try          *json.RawMessage `json:"try,omitempty"`
 		DependentGet *json.RawMessage `json:"dependent_get,omitempty"`
+		GetArtifact  *json.RawMessage `json:"get_artifact,omitempty"`
 		Timeout      *json.RawMessage `json:"timeout,omitempty"`
 		Retry        *json.RawMessage `json:"retry,omitempty"`
 	}
@@ -62,6 +63,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.DependentGet = plan.DependentGet.Public()
 	}
 
+	if plan.GetArtifact != nil {
+		public.GetArtifact = plan.GetArtifact.Public()
+	}
+
 	if plan.Timeout != nil {
 		public.Timeout = plan.Timeout.Public()
 	}
@@ -105,6 +110,16 @@ func (plan DependentGetPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan GetArtifactPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+		Job  string `json:"job"`
+	}{
+		Name: plan.Name,
+		Job:  plan.Job,
+	})
+}
+
 func (plan EnsurePlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
@@ -3,6 +3,9 @@ package radar_test
 import (
 	"testing"
 
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/metric/metrictest"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -11,3 +14,11 @@ func TestRadar(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Radar Suite")
 }
+
+var metricEmitter *metrictest.Emitter
+
+var _ = BeforeSuite(func() {
+	var err error
+	metricEmitter, err = metrictest.Initialize(lagertest.NewTestLogger("metrics"))
+	Expect(err).NotTo(HaveOccurred())
+})
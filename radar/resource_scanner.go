@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -58,6 +59,13 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 		return 0, ResourceNotFoundError{Name: resourceName}
 	}
 
+	if savedResource.CheckEvery() == atc.CheckEveryNever {
+		// versions are saved through the API; keep polling in case the
+		// resource is reconfigured
+		logger.Debug("never-checked")
+		return scanner.defaultInterval, nil
+	}
+
 	interval, err := scanner.checkInterval(savedResource.CheckEvery())
 	if err != nil {
 		setErr := scanner.dbPipeline.SetResourceCheckError(savedResource, err)
@@ -185,6 +193,19 @@ func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceNam
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
+	savedResource, found, err := scanner.dbPipeline.Resource(resourceName)
+	if err != nil {
+		logger.Error("failed-to-get-resource", err)
+		return err
+	}
+
+	if found && savedResource.CheckEvery() == atc.CheckEveryNever {
+		// versions are saved through the API, so there is nothing to check
+		// before a build; the resource can still be checked explicitly
+		logger.Debug("never-checked")
+		return nil
+	}
+
 	vr, _, err := scanner.dbPipeline.GetLatestVersionedResource(resourceName)
 	if err != nil {
 		logger.Error("failed-to-get-current-version", err)
@@ -269,8 +290,18 @@ func (scanner *resourceScanner) scan(
 		"from": fromVersion,
 	})
 
+	checkStart := scanner.clock.Now()
+
 	newVersions, err := res.Check(savedResource.Source(), fromVersion)
 
+	metric.ResourceCheck{
+		PipelineName: savedResource.PipelineName(),
+		TeamName:     scanner.dbPipeline.TeamName(),
+		ResourceType: savedResource.Type(),
+		Outcome:      checkOutcome(err),
+		Duration:     scanner.clock.Now().Sub(checkStart),
+	}.Emit(logger)
+
 	setErr := scanner.dbPipeline.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
 		logger.Error("failed-to-set-check-error", err)
@@ -309,6 +340,18 @@ func (scanner *resourceScanner) scan(
 	return nil
 }
 
+func checkOutcome(err error) string {
+	if err == nil {
+		return metric.ResourceCheckSucceeded
+	}
+
+	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
+		return metric.ResourceCheckFailed
+	}
+
+	return metric.ResourceCheckErrored
+}
+
 func swallowErrResourceScriptFailed(err error) error {
 	if _, ok := err.(resource.ErrResourceScriptFailed); ok {
 		return nil
@@ -318,7 +361,7 @@ func swallowErrResourceScriptFailed(err error) error {
 
 func (scanner *resourceScanner) checkInterval(checkEvery string) (time.Duration, error) {
 	interval := scanner.defaultInterval
-	if checkEvery != "" {
+	if checkEvery != "" && checkEvery != atc.CheckEveryNever {
 		configuredInterval, err := time.ParseDuration(checkEvery)
 		if err != nil {
 			return 0, err
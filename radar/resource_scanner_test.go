@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/worker"
 
 	. "github.com/concourse/atc/radar"
@@ -22,6 +23,14 @@ import (
 )
 
 var _ = Describe("ResourceScanner", func() {
+	attributesOf := func(event metric.Event) map[string]string {
+		return event.Attributes
+	}
+
+	stateOf := func(event metric.Event) metric.EventState {
+		return event.State
+	}
+
 	var (
 		epoch time.Time
 
@@ -69,6 +78,7 @@ var _ = Describe("ResourceScanner", func() {
 		}
 
 		fakeDBPipeline.ReloadReturns(true, nil)
+		fakeDBPipeline.TeamNameReturns("some-team")
 
 		fakeResourceType = new(dbngfakes.FakeResourceType)
 		fakeResourceType.IDReturns(1)
@@ -204,6 +214,23 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is never checked", func() {
+				BeforeEach(func() {
+					fakeDBResource.CheckEveryReturns(atc.CheckEveryNever)
+					fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
+				})
+
+				It("does not check", func() {
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				It("returns the default interval", func() {
+					Expect(runErr).NotTo(HaveOccurred())
+					Expect(actualInterval).To(Equal(interval))
+				})
+			})
+
 			It("grabs a periodic resource checking lock before checking, breaks lock after done", func() {
 				Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(Equal(1))
 
@@ -300,6 +327,15 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			It("emits the outcome of the check", func() {
+				Eventually(metricEmitter.Events("resource check duration (ms)")).Should(ContainElement(WithTransform(attributesOf, Equal(map[string]string{
+					"pipeline":      "some-pipeline",
+					"team":          "some-team",
+					"resource_type": "git",
+					"outcome":       "succeeded",
+				}))))
+			})
+
 			Context("when checking fails internally", func() {
 				disaster := errors.New("nope")
 
@@ -311,6 +347,13 @@ var _ = Describe("ResourceScanner", func() {
 					Expect(runErr).To(HaveOccurred())
 					Expect(runErr).To(Equal(disaster))
 				})
+
+				It("emits that the check errored", func() {
+					Eventually(metricEmitter.Events("resource check duration (ms)")).Should(ContainElement(SatisfyAll(
+						WithTransform(attributesOf, HaveKeyWithValue("outcome", "errored")),
+						WithTransform(stateOf, Equal(metric.EventStateWarning)),
+					)))
+				})
 			})
 
 			Context("when checking fails with ErrResourceScriptFailed", func() {
@@ -323,6 +366,12 @@ var _ = Describe("ResourceScanner", func() {
 				It("returns no error", func() {
 					Expect(runErr).NotTo(HaveOccurred())
 				})
+
+				It("emits that the check failed", func() {
+					Eventually(metricEmitter.Events("resource check duration (ms)")).Should(ContainElement(
+						WithTransform(attributesOf, HaveKeyWithValue("outcome", "failed")),
+					))
+				})
 			})
 
 			Context("when the pipeline is paused", func() {
@@ -511,6 +560,29 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is never checked", func() {
+				BeforeEach(func() {
+					fakeDBResource.CheckEveryReturns(atc.CheckEveryNever)
+					fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
+				})
+
+				It("does not check it", func() {
+					Expect(scanErr).NotTo(HaveOccurred())
+					Expect(fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckCallCount()).To(BeZero())
+					Expect(fakeResource.CheckCallCount()).To(BeZero())
+				})
+
+				Context("when its check would fail", func() {
+					BeforeEach(func() {
+						fakeResource.CheckReturns(nil, errors.New("no check script"))
+					})
+
+					It("succeeds", func() {
+						Expect(scanErr).NotTo(HaveOccurred())
+					})
+				})
+			})
+
 			Context("when the lock is not immediately available", func() {
 				BeforeEach(func() {
 					results := make(chan bool, 4)
@@ -752,6 +824,22 @@ var _ = Describe("ResourceScanner", func() {
 				})
 			})
 
+			Context("when the resource is never checked", func() {
+				BeforeEach(func() {
+					fakeDBResource.CheckEveryReturns(atc.CheckEveryNever)
+					fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
+				})
+
+				It("still checks when asked to", func() {
+					Expect(scanErr).NotTo(HaveOccurred())
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+
+					_, _, leaseInterval, immediate := fakeDBPipeline.AcquireResourceCheckingLockWithIntervalCheckArgsForCall(0)
+					Expect(leaseInterval).To(Equal(interval))
+					Expect(immediate).To(BeTrue())
+				})
+			})
+
 			Context("when checking fails with ErrResourceScriptFailed", func() {
 				scriptFail := resource.ErrResourceScriptFailed{}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/atc/worker"
 )
 
@@ -23,6 +24,10 @@ type ResourceType string
 
 type Session struct {
 	Metadata dbng.ContainerMetadata
+
+	// Trace is the span context of the step the session's containers are
+	// created for.
+	Trace tracing.SpanContext
 }
 
 //go:generate counterfeiter . Cache
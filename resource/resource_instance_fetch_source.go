@@ -160,6 +160,7 @@ func (s *resourceInstanceFetchSource) createContainerForVolume(volume worker.Vol
 		Tags:   s.tags,
 		TeamID: s.teamID,
 		Env:    s.metadata.Env(),
+		Trace:  s.session.Trace,
 
 		ResourceCache: &worker.VolumeMount{
 			Volume:    volume,
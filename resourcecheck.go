@@ -8,3 +8,16 @@ type CheckResponseBody struct {
 	ExitStatus int    `json:"exit_status"`
 	Stderr     string `json:"stderr"`
 }
+
+// CheckEveryNever disables periodic checking of a resource whose versions are
+// saved through the API instead.
+const CheckEveryNever = "never"
+
+type SaveVersionsRequestBody struct {
+	Versions []VersionWithMetadata `json:"versions"`
+}
+
+type VersionWithMetadata struct {
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata,omitempty"`
+}
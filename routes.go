@@ -14,6 +14,9 @@ const (
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
 	GetBuildPreparation = "GetBuildPreparation"
+	ListBuildArtifacts  = "ListBuildArtifacts"
+	GetBuildArtifact    = "GetBuildArtifact"
+	GetBuildTests       = "GetBuildTests"
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
@@ -27,6 +30,8 @@ const (
 	JobBadge       = "JobBadge"
 	MainJobBadge   = "MainJobBadge"
 
+	GetJobTestHistory = "GetJobTestHistory"
+
 	ListResources        = "ListResources"
 	GetResource          = "GetResource"
 	PauseResource        = "PauseResource"
@@ -35,6 +40,7 @@ const (
 	CheckResourceWebHook = "CheckResourceWebHook"
 
 	ListResourceVersions          = "ListResourceVersions"
+	SaveResourceVersions          = "SaveResourceVersions"
 	EnableResourceVersion         = "EnableResourceVersion"
 	DisableResourceVersion        = "DisableResourceVersion"
 	ListBuildsWithVersionAsInput  = "ListBuildsWithVersionAsInput"
@@ -62,6 +68,7 @@ const (
 	HeartbeatWorker = "HeartbeatWorker"
 	ListWorkers     = "ListWorkers"
 	DeleteWorker    = "DeleteWorker"
+	GetWorkerHealth = "GetWorkerHealth"
 
 	SetLogLevel = "SetLogLevel"
 	GetLogLevel = "GetLogLevel"
@@ -82,6 +89,16 @@ const (
 	ListTeams   = "ListTeams"
 	SetTeam     = "SetTeam"
 	DestroyTeam = "DestroyTeam"
+
+	GetTeamQuotas = "GetTeamQuotas"
+
+	ListNotificationSubscriptions  = "ListNotificationSubscriptions"
+	CreateNotificationSubscription = "CreateNotificationSubscription"
+	DeleteNotificationSubscription = "DeleteNotificationSubscription"
+	ListNotificationDeliveries     = "ListNotificationDeliveries"
+
+	ListAuditEvents     = "ListAuditEvents"
+	ListTeamAuditEvents = "ListTeamAuditEvents"
 )
 
 var Routes = rata.Routes([]rata.Route{
@@ -96,6 +113,9 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
+	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/artifacts/:artifact_name", Method: "GET", Name: GetBuildArtifact},
+	{Path: "/api/v1/builds/:build_id/tests", Method: "GET", Name: GetBuildTests},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
@@ -107,6 +127,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: MainJobBadge},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tests", Method: "GET", Name: GetJobTestHistory},
 
 	{Path: "/api/v1/pipelines", Method: "GET", Name: ListAllPipelines},
 	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
@@ -128,6 +149,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "POST", Name: SaveResourceVersions},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/input_to", Method: "GET", Name: ListBuildsWithVersionAsInput},
@@ -144,6 +166,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/workers/:worker_name/prune", Method: "PUT", Name: PruneWorker},
 	{Path: "/api/v1/workers/:worker_name/heartbeat", Method: "PUT", Name: HeartbeatWorker},
 	{Path: "/api/v1/workers/:worker_name", Method: "DELETE", Name: DeleteWorker},
+	{Path: "/api/v1/workers/:worker_name/health", Method: "GET", Name: GetWorkerHealth},
 
 	{Path: "/api/v1/log-level", Method: "GET", Name: GetLogLevel},
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
@@ -164,4 +187,13 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
 	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SetTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
+	{Path: "/api/v1/teams/:team_name/quotas", Method: "GET", Name: GetTeamQuotas},
+
+	{Path: "/api/v1/teams/:team_name/notifications", Method: "GET", Name: ListNotificationSubscriptions},
+	{Path: "/api/v1/teams/:team_name/notifications", Method: "POST", Name: CreateNotificationSubscription},
+	{Path: "/api/v1/teams/:team_name/notifications/:subscription_id", Method: "DELETE", Name: DeleteNotificationSubscription},
+	{Path: "/api/v1/teams/:team_name/notifications/:subscription_id/deliveries", Method: "GET", Name: ListNotificationDeliveries},
+
+	{Path: "/api/v1/audit", Method: "GET", Name: ListAuditEvents},
+	{Path: "/api/v1/teams/:team_name/audit", Method: "GET", Name: ListTeamAuditEvents},
 })
@@ -29,6 +29,7 @@ type BuildFactory interface {
 
 func NewBuildStarter(
 	pipeline dbng.Pipeline,
+	admissionQueue dbng.AdmissionQueue,
 	maxInFlightUpdater maxinflight.Updater,
 	factory BuildFactory,
 	scanner Scanner,
@@ -37,6 +38,7 @@ func NewBuildStarter(
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
+		admissionQueue:     admissionQueue,
 		maxInFlightUpdater: maxInFlightUpdater,
 		factory:            factory,
 		scanner:            scanner,
@@ -47,6 +49,7 @@ func NewBuildStarter(
 
 type buildStarter struct {
 	pipeline           dbng.Pipeline
+	admissionQueue     dbng.AdmissionQueue
 	maxInFlightUpdater maxinflight.Updater
 	factory            BuildFactory
 	execEngine         engine.Engine
@@ -95,62 +98,61 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	if nextPendingBuild.IsManuallyTriggered() {
-		jobBuildInputs := job.Config().Inputs()
-		for _, input := range jobBuildInputs {
-			scanLog := logger.Session("scan", lager.Data{
-				"input":    input.Name,
-				"resource": input.Resource,
-			})
-
-			err := s.scanner.Scan(scanLog, input.Resource)
-			if err != nil {
-				return false, err
-			}
-		}
+	// a build requeued in place of one aborted on a landing worker already has
+	// the inputs of the build it replaces
+	buildInputs, _, err := nextPendingBuild.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-build-inputs", err)
+		return false, err
+	}
 
-		versions, err := s.pipeline.LoadVersionsDB()
+	if len(buildInputs) == 0 {
+		var found bool
+		buildInputs, found, err = s.nextBuildInputs(logger, nextPendingBuild, job)
 		if err != nil {
-			logger.Error("failed-to-load-versions-db", err)
 			return false, err
 		}
-
-		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
-		if err != nil {
-			return false, err
+		if !found {
+			return false, nil
 		}
 	}
 
-	buildInputs, found, err := job.GetNextBuildInputs()
+	pipelinePaused, err := s.pipeline.CheckPaused()
 	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
+		logger.Error("failed-to-check-if-pipeline-is-paused", err)
 		return false, err
 	}
-	if !found {
+	if pipelinePaused {
 		return false, nil
 	}
 
-	pipelinePaused, err := s.pipeline.CheckPaused()
+	if job.Paused() {
+		return false, nil
+	}
+
+	admitted, err := s.admissionQueue.Admit(nextPendingBuild)
 	if err != nil {
-		logger.Error("failed-to-check-if-pipeline-is-paused", err)
+		logger.Error("failed-to-admit-build", err)
 		return false, err
 	}
-	if pipelinePaused {
+	if !admitted {
+		logger.Debug("build-queued-for-admission")
 		return false, nil
 	}
 
-	if job.Paused() {
-		return false, nil
+	teamSerialGroups, err := s.maxInFlightUpdater.TeamSerialGroups(logger, job)
+	if err != nil {
+		return false, err
 	}
 
-	updated, err := nextPendingBuild.Schedule()
+	updated, err := s.admissionQueue.Schedule(nextPendingBuild, teamSerialGroups)
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
 	}
 
 	if !updated {
-		logger.Debug("build-already-scheduled")
+		logger.Debug("build-not-scheduled")
 		return false, nil
 	}
 
@@ -191,3 +193,48 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+func (s *buildStarter) nextBuildInputs(
+	logger lager.Logger,
+	nextPendingBuild dbng.Build,
+	job dbng.Job,
+) ([]dbng.BuildInput, bool, error) {
+	if nextPendingBuild.IsManuallyTriggered() {
+		jobBuildInputs := job.Config().Inputs()
+		for _, input := range jobBuildInputs {
+			if input.Artifact {
+				// artifacts are published by builds, there is nothing to check
+				continue
+			}
+
+			scanLog := logger.Session("scan", lager.Data{
+				"input":    input.Name,
+				"resource": input.Resource,
+			})
+
+			err := s.scanner.Scan(scanLog, input.Resource)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+
+		versions, err := s.pipeline.LoadVersionsDB()
+		if err != nil {
+			logger.Error("failed-to-load-versions-db", err)
+			return nil, false, err
+		}
+
+		_, err = s.inputMapper.SaveNextInputMapping(logger, versions, job)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	buildInputs, found, err := job.GetNextBuildInputs()
+	if err != nil {
+		logger.Error("failed-to-get-next-build-inputs", err)
+		return nil, false, err
+	}
+
+	return buildInputs, found, nil
+}
@@ -23,6 +23,7 @@ import (
 var _ = Describe("I'm a BuildStarter", func() {
 	var (
 		fakePipeline     *dbngfakes.FakePipeline
+		fakeQueue        *dbngfakes.FakeAdmissionQueue
 		fakeUpdater      *maxinflightfakes.FakeUpdater
 		fakeFactory      *schedulerfakes.FakeBuildFactory
 		fakeEngine       *enginefakes.FakeEngine
@@ -38,6 +39,9 @@ var _ = Describe("I'm a BuildStarter", func() {
 
 	BeforeEach(func() {
 		fakePipeline = new(dbngfakes.FakePipeline)
+		fakeQueue = new(dbngfakes.FakeAdmissionQueue)
+		fakeQueue.AdmitReturns(true, nil)
+		fakeQueue.ScheduleReturns(true, nil)
 		fakeUpdater = new(maxinflightfakes.FakeUpdater)
 		fakeFactory = new(schedulerfakes.FakeBuildFactory)
 		fakeEngine = new(enginefakes.FakeEngine)
@@ -45,7 +49,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 		fakeBuildStarter = new(schedulerfakes.FakeBuildStarter)
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeQueue, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
 
 		disaster = errors.New("bad thing")
 	})
@@ -118,6 +122,21 @@ var _ = Describe("I'm a BuildStarter", func() {
 					Expect(fakeScanner.ScanCallCount()).To(Equal(2))
 				})
 
+				Context("when the job gets an artifact", func() {
+					BeforeEach(func() {
+						job.ConfigReturns(atc.JobConfig{Plan: atc.PlanSequence{
+							{Get: "input-1"},
+							{Get: "some-binary", Artifact: true, Passed: []string{"upstream-job"}},
+						}})
+					})
+
+					It("does not check the artifact", func() {
+						Expect(fakeScanner.ScanCallCount()).To(Equal(1))
+						_, resource := fakeScanner.ScanArgsForCall(0)
+						Expect(resource).To(Equal("input-1"))
+					})
+				})
+
 				Context("when resource checking fails", func() {
 					BeforeEach(func() {
 						fakeScanner.ScanReturns(disaster)
@@ -269,7 +288,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 				})
 
 				It("doesn't try to mark the build as scheduled", func() {
-					Expect(createdBuild.ScheduleCallCount()).To(BeZero())
+					Expect(fakeQueue.ScheduleCallCount()).To(BeZero())
 				})
 			}
 
@@ -311,19 +330,16 @@ var _ = Describe("I'm a BuildStarter", func() {
 					BeforeEach(func() {
 						pendingBuild1 = new(dbngfakes.FakeBuild)
 						pendingBuild1.IDReturns(99)
-						pendingBuild1.ScheduleReturns(true, nil)
 						pendingBuild2 = new(dbngfakes.FakeBuild)
 						pendingBuild2.IDReturns(999)
-						pendingBuild2.ScheduleReturns(true, nil)
 						pendingBuild3 = new(dbngfakes.FakeBuild)
 						pendingBuild3.IDReturns(555)
-						pendingBuild3.ScheduleReturns(true, nil)
 						pendingBuilds = []dbng.Build{pendingBuild1, pendingBuild2, pendingBuild3}
 					})
 
 					Context("when marking the build as scheduled fails", func() {
 						BeforeEach(func() {
-							pendingBuild1.ScheduleReturns(false, disaster)
+							fakeQueue.ScheduleReturns(false, disaster)
 						})
 
 						It("returns the error", func() {
@@ -331,13 +347,81 @@ var _ = Describe("I'm a BuildStarter", func() {
 						})
 
 						It("marked the right build as scheduled", func() {
-							Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							Expect(fakeQueue.ScheduleCallCount()).To(Equal(1))
+							scheduledBuild, _ := fakeQueue.ScheduleArgsForCall(0)
+							Expect(scheduledBuild).To(Equal(pendingBuild1))
+						})
+					})
+
+					Context("when the build was requeued with the inputs of an aborted build", func() {
+						BeforeEach(func() {
+							pendingBuild1.ResourcesReturns([]dbng.BuildInput{{Name: "requeued-input"}}, nil, nil)
+							fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+						})
+
+						It("uses them rather than the job's next build inputs", func() {
+							Expect(tryStartErr).NotTo(HaveOccurred())
+							Expect(pendingBuild1.UseInputsCallCount()).To(Equal(1))
+							Expect(pendingBuild1.UseInputsArgsForCall(0)).To(Equal([]dbng.BuildInput{{Name: "requeued-input"}}))
+						})
+					})
+
+					Context("when looking up the build's inputs fails", func() {
+						BeforeEach(func() {
+							pendingBuild1.ResourcesReturns(nil, nil, disaster)
+						})
+
+						It("returns the error", func() {
+							Expect(tryStartErr).To(Equal(disaster))
+							Expect(fakeQueue.ScheduleCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the job is in a serial group declared by the team", func() {
+						BeforeEach(func() {
+							fakeUpdater.TeamSerialGroupsReturns(atc.SerialGroupConfigs{
+								{Name: "some-serial-group", Size: 2},
+							}, nil)
+							fakeEngine.CreateBuildReturns(new(enginefakes.FakeBuild), nil)
+						})
+
+						It("schedules the builds within the team's serial groups", func() {
+							Expect(fakeQueue.ScheduleCallCount()).To(Equal(3))
+							for i, pendingBuild := range pendingBuilds {
+								scheduledBuild, serialGroups := fakeQueue.ScheduleArgsForCall(i)
+								Expect(scheduledBuild).To(Equal(pendingBuild))
+								Expect(serialGroups).To(Equal(atc.SerialGroupConfigs{
+									{Name: "some-serial-group", Size: 2},
+								}))
+							}
+						})
+
+						Context("when the team's serial groups are full", func() {
+							BeforeEach(func() {
+								fakeQueue.ScheduleReturns(false, nil)
+							})
+
+							It("doesn't use inputs for the build", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+								Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when finding the team's serial groups fails", func() {
+							BeforeEach(func() {
+								fakeUpdater.TeamSerialGroupsReturns(nil, disaster)
+							})
+
+							It("returns the error", func() {
+								Expect(tryStartErr).To(Equal(disaster))
+								Expect(fakeQueue.ScheduleCallCount()).To(BeZero())
+							})
 						})
 					})
 
 					Context("when someone else already scheduled the build", func() {
 						BeforeEach(func() {
-							pendingBuild1.ScheduleReturns(false, nil)
+							fakeQueue.ScheduleReturns(false, nil)
 						})
 
 						It("doesn't return an error", func() {
@@ -351,7 +435,7 @@ var _ = Describe("I'm a BuildStarter", func() {
 
 					Context("when marking the build as scheduled succeeds", func() {
 						BeforeEach(func() {
-							pendingBuild1.ScheduleReturns(true, nil)
+							fakeQueue.ScheduleReturns(true, nil)
 						})
 
 						Context("when using inputs for build fails", func() {
@@ -570,6 +654,29 @@ var _ = Describe("I'm a BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when admitting the build fails", func() {
+						BeforeEach(func() {
+							fakeQueue.AdmitReturns(false, disaster)
+						})
+
+						itReturnsTheError()
+						itUpdatedMaxInFlightForTheFirstBuild()
+					})
+
+					Context("when the build is not admitted", func() {
+						BeforeEach(func() {
+							fakeQueue.AdmitReturns(false, nil)
+						})
+
+						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
+						itUpdatedMaxInFlightForTheFirstBuild()
+
+						It("asks the admission queue to admit the build", func() {
+							Expect(fakeQueue.AdmitCallCount()).To(Equal(1))
+							Expect(fakeQueue.AdmitArgsForCall(0)).To(Equal(pendingBuild1))
+						})
+					})
 				})
 			})
 		})
@@ -2,6 +2,7 @@ package factory
 
 import (
 	"errors"
+	"strconv"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
@@ -192,6 +193,26 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Next: factory.planFactory.NewPlan(dependentGetPlan),
 		})
 
+	case planConfig.Get != "" && planConfig.Artifact:
+		var jobName string
+		if len(planConfig.Passed) > 0 {
+			jobName = planConfig.Passed[0]
+		}
+
+		var buildID int
+		for _, input := range inputs {
+			if input.Name == planConfig.Get {
+				buildID, _ = strconv.Atoi(input.Version["build"])
+				break
+			}
+		}
+
+		plan = factory.planFactory.NewPlan(atc.GetArtifactPlan{
+			Name:    planConfig.Get,
+			Job:     jobName,
+			BuildID: buildID,
+		})
+
 	case planConfig.Get != "":
 		resourceName := planConfig.Resource
 		if resourceName == "" {
@@ -235,6 +256,10 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			SkipIfUnchanged:   planConfig.SkipIfUnchanged,
+			CacheKey:          planConfig.CacheKey,
+			Artifacts:         planConfig.Artifacts,
+			TestReports:       planConfig.TestReports,
 
 			VersionedResourceTypes: resourceTypes,
 		})
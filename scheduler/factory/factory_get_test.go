@@ -2,6 +2,7 @@ package factory_test
 
 import (
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/scheduler/factory"
 	"github.com/concourse/atc/testhelpers"
 	. "github.com/onsi/ginkgo"
@@ -73,6 +74,41 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a get of an artifact", func() {
+		BeforeEach(func() {
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-binary",
+						Artifact: true,
+						Passed:   []string{"some-upstream-job"},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, []dbng.BuildInput{
+				{
+					Name: "some-binary",
+					VersionedResource: dbng.VersionedResource{
+						Resource: "artifact:some-binary",
+						Type:     "artifact",
+						Version:  dbng.ResourceVersion{"build": "128"},
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.GetArtifactPlan{
+				Name:    "some-binary",
+				Job:     "some-upstream-job",
+				BuildID: 128,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
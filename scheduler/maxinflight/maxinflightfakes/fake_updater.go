@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/scheduler/maxinflight"
 )
@@ -25,6 +26,20 @@ type FakeUpdater struct {
 		result1 bool
 		result2 error
 	}
+	TeamSerialGroupsStub        func(logger lager.Logger, job dbng.Job) (atc.SerialGroupConfigs, error)
+	teamSerialGroupsMutex       sync.RWMutex
+	teamSerialGroupsArgsForCall []struct {
+		logger lager.Logger
+		job    dbng.Job
+	}
+	teamSerialGroupsReturns struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}
+	teamSerialGroupsReturnsOnCall map[int]struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -82,11 +97,65 @@ func (fake *FakeUpdater) UpdateMaxInFlightReachedReturnsOnCall(i int, result1 bo
 	}{result1, result2}
 }
 
+func (fake *FakeUpdater) TeamSerialGroups(logger lager.Logger, job dbng.Job) (atc.SerialGroupConfigs, error) {
+	fake.teamSerialGroupsMutex.Lock()
+	ret, specificReturn := fake.teamSerialGroupsReturnsOnCall[len(fake.teamSerialGroupsArgsForCall)]
+	fake.teamSerialGroupsArgsForCall = append(fake.teamSerialGroupsArgsForCall, struct {
+		logger lager.Logger
+		job    dbng.Job
+	}{logger, job})
+	fake.recordInvocation("TeamSerialGroups", []interface{}{logger, job})
+	fake.teamSerialGroupsMutex.Unlock()
+	if fake.TeamSerialGroupsStub != nil {
+		return fake.TeamSerialGroupsStub(logger, job)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.teamSerialGroupsReturns.result1, fake.teamSerialGroupsReturns.result2
+}
+
+func (fake *FakeUpdater) TeamSerialGroupsCallCount() int {
+	fake.teamSerialGroupsMutex.RLock()
+	defer fake.teamSerialGroupsMutex.RUnlock()
+	return len(fake.teamSerialGroupsArgsForCall)
+}
+
+func (fake *FakeUpdater) TeamSerialGroupsArgsForCall(i int) (lager.Logger, dbng.Job) {
+	fake.teamSerialGroupsMutex.RLock()
+	defer fake.teamSerialGroupsMutex.RUnlock()
+	return fake.teamSerialGroupsArgsForCall[i].logger, fake.teamSerialGroupsArgsForCall[i].job
+}
+
+func (fake *FakeUpdater) TeamSerialGroupsReturns(result1 atc.SerialGroupConfigs, result2 error) {
+	fake.TeamSerialGroupsStub = nil
+	fake.teamSerialGroupsReturns = struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUpdater) TeamSerialGroupsReturnsOnCall(i int, result1 atc.SerialGroupConfigs, result2 error) {
+	fake.TeamSerialGroupsStub = nil
+	if fake.teamSerialGroupsReturnsOnCall == nil {
+		fake.teamSerialGroupsReturnsOnCall = make(map[int]struct {
+			result1 atc.SerialGroupConfigs
+			result2 error
+		})
+	}
+	fake.teamSerialGroupsReturnsOnCall[i] = struct {
+		result1 atc.SerialGroupConfigs
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUpdater) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.updateMaxInFlightReachedMutex.RLock()
 	defer fake.updateMaxInFlightReachedMutex.RUnlock()
+	fake.teamSerialGroupsMutex.RLock()
+	defer fake.teamSerialGroupsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
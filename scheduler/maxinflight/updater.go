@@ -1,7 +1,12 @@
 package maxinflight
 
 import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
 )
 
@@ -9,14 +14,38 @@ import (
 
 type Updater interface {
 	UpdateMaxInFlightReached(logger lager.Logger, job dbng.Job, buildID int) (bool, error)
+
+	// TeamSerialGroups returns the job's serial groups which span the
+	// pipelines of its team, which the build must be scheduled within.
+	TeamSerialGroups(logger lager.Logger, job dbng.Job) (atc.SerialGroupConfigs, error)
 }
 
-func NewUpdater(pipeline dbng.Pipeline) Updater {
-	return &updater{pipeline: pipeline}
+// teamSerialGroupsTTL is how long the team's serial groups are used for
+// before being found again, so that they are not looked up for every pending
+// build on every tick.
+const teamSerialGroupsTTL = 10 * time.Second
+
+func NewUpdater(pipeline dbng.Pipeline, team dbng.Team, clock clock.Clock) Updater {
+	return &updater{
+		pipeline: pipeline,
+		team:     team,
+		clock:    clock,
+	}
 }
 
 type updater struct {
 	pipeline dbng.Pipeline
+	team     dbng.Team
+	clock    clock.Clock
+
+	lock sync.Mutex
+
+	pipelineSerialGroups       atc.SerialGroupConfigs
+	pipelineSerialGroupsLoaded bool
+	pipelineConfigVersion      dbng.ConfigVersion
+
+	teamSerialGroups          atc.SerialGroupConfigs
+	teamSerialGroupsExpiresAt time.Time
 }
 
 func (u *updater) UpdateMaxInFlightReached(logger lager.Logger, job dbng.Job, buildID int) (bool, error) {
@@ -43,6 +72,18 @@ func (u *updater) isMaxInFlightReached(logger lager.Logger, job dbng.Job, buildI
 		return false, nil
 	}
 
+	if len(job.Config().SerialGroups) > 0 {
+		sizedGroups, err := u.sizedSerialGroups(job.Config().SerialGroups)
+		if err != nil {
+			logger.Error("failed-to-find-serial-group-sizes", err)
+			return false, err
+		}
+
+		if len(sizedGroups) > 0 {
+			return u.isSerialGroupFull(logger, job, buildID, sizedGroups)
+		}
+	}
+
 	builds, err := job.GetRunningBuildsBySerialGroup(job.Config().GetSerialGroups())
 	if err != nil {
 		logger.Error("failed-to-get-running-builds-by-serial-group", err)
@@ -53,6 +94,143 @@ func (u *updater) isMaxInFlightReached(logger lager.Logger, job dbng.Job, buildI
 		return true, nil
 	}
 
+	return u.isBehindInLine(logger, job, buildID)
+}
+
+type sizedSerialGroup struct {
+	name     string
+	size     int
+	teamWide bool
+}
+
+func (u *updater) TeamSerialGroups(logger lager.Logger, job dbng.Job) (atc.SerialGroupConfigs, error) {
+	if len(job.Config().SerialGroups) == 0 {
+		return nil, nil
+	}
+
+	sizedGroups, err := u.sizedSerialGroups(job.Config().SerialGroups)
+	if err != nil {
+		logger.Error("failed-to-find-serial-group-sizes", err)
+		return nil, err
+	}
+
+	teamGroups := atc.SerialGroupConfigs{}
+	for _, group := range sizedGroups {
+		if group.teamWide {
+			teamGroups = append(teamGroups, atc.SerialGroupConfig{Name: group.name, Size: group.size})
+		}
+	}
+
+	if len(teamGroups) == 0 {
+		return nil, nil
+	}
+
+	return teamGroups, nil
+}
+
+// sizedSerialGroups returns the given serial groups along with their sizes,
+// as declared by the pipeline or otherwise by its team. If none of them are
+// declared, it returns none, and they each allow one build at a time within
+// the pipeline.
+func (u *updater) sizedSerialGroups(serialGroups []string) ([]sizedSerialGroup, error) {
+	pipelineSerialGroups, teamSerialGroups, err := u.declaredSerialGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	declared := false
+	sizedGroups := []sizedSerialGroup{}
+	for _, name := range serialGroups {
+		sizedGroup := sizedSerialGroup{name: name, size: 1}
+
+		if group, found := pipelineSerialGroups.Lookup(name); found {
+			sizedGroup.size = group.Size
+			declared = true
+		} else if group, found := teamSerialGroups.Lookup(name); found {
+			sizedGroup.size = group.Size
+			sizedGroup.teamWide = true
+			declared = true
+		}
+
+		sizedGroups = append(sizedGroups, sizedGroup)
+	}
+
+	if !declared {
+		return nil, nil
+	}
+
+	return sizedGroups, nil
+}
+
+// declaredSerialGroups returns the serial groups declared by the pipeline and
+// its team. The pipeline's are only loaded again once its config has changed,
+// and the team's once they are teamSerialGroupsTTL old.
+func (u *updater) declaredSerialGroups() (atc.SerialGroupConfigs, atc.SerialGroupConfigs, error) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if !u.pipelineSerialGroupsLoaded || u.pipeline.ConfigVersion() != u.pipelineConfigVersion {
+		config, _, version, err := u.pipeline.Config()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		u.pipelineSerialGroups = config.SerialGroups
+		u.pipelineConfigVersion = version
+		u.pipelineSerialGroupsLoaded = true
+	}
+
+	now := u.clock.Now()
+	if !now.Before(u.teamSerialGroupsExpiresAt) {
+		teamSerialGroups, err := u.team.FindSerialGroups()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		u.teamSerialGroups = teamSerialGroups
+		u.teamSerialGroupsExpiresAt = now.Add(teamSerialGroupsTTL)
+	}
+
+	return u.pipelineSerialGroups, u.teamSerialGroups, nil
+}
+
+// isSerialGroupFull returns whether any of the serial groups is already
+// running as many builds as its size allows, or otherwise whether there is
+// another build ahead of ours in line.
+func (u *updater) isSerialGroupFull(logger lager.Logger, job dbng.Job, buildID int, sizedGroups []sizedSerialGroup) (bool, error) {
+	for _, group := range sizedGroups {
+		running, err := job.CountRunningBuildsInSerialGroup(group.name, group.teamWide)
+		if err != nil {
+			logger.Error("failed-to-count-running-builds-in-serial-group", err)
+			return false, err
+		}
+
+		if running >= group.size {
+			return true, nil
+		}
+	}
+
+	for _, group := range sizedGroups {
+		if !group.teamWide {
+			continue
+		}
+
+		behind, err := u.isBehindInTeamLine(logger, job, buildID, group.name)
+		if err != nil {
+			return false, err
+		}
+
+		if behind {
+			return true, nil
+		}
+	}
+
+	return u.isBehindInLine(logger, job, buildID)
+}
+
+// isBehindInLine returns whether the build is not the next pending build in
+// the job's serial groups.
+func (u *updater) isBehindInLine(logger lager.Logger, job dbng.Job, buildID int) (bool, error) {
 	nextMostPendingBuild, found, err := job.GetNextPendingBuildBySerialGroup(job.Config().GetSerialGroups())
 	if err != nil {
 		logger.Error("failed-to-get-next-pending-build-by-serial-group", err)
@@ -66,3 +244,20 @@ func (u *updater) isMaxInFlightReached(logger lager.Logger, job dbng.Job, buildI
 
 	return nextMostPendingBuild.ID() != buildID, nil
 }
+
+// isBehindInTeamLine returns whether the build is not the next pending build
+// in the serial group across all of the team's pipelines.
+func (u *updater) isBehindInTeamLine(logger lager.Logger, job dbng.Job, buildID int, serialGroup string) (bool, error) {
+	nextMostPendingBuild, found, err := job.GetNextPendingBuildInTeamSerialGroup(serialGroup)
+	if err != nil {
+		logger.Error("failed-to-get-next-pending-build-in-team-serial-group", err)
+		return false, err
+	}
+
+	if !found {
+		logger.Info("pending-build-disappeared-from-team-serial-group", lager.Data{"serial-group": serialGroup})
+		return true, nil
+	}
+
+	return nextMostPendingBuild.ID() != buildID, nil
+}
@@ -2,7 +2,9 @@ package maxinflight_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
@@ -16,15 +18,19 @@ import (
 var _ = Describe("Updater", func() {
 	var (
 		fakePipeline *dbngfakes.FakePipeline
+		fakeTeam     *dbngfakes.FakeTeam
 		fakeJob      *dbngfakes.FakeJob
+		fakeClock    *fakeclock.FakeClock
 		updater      maxinflight.Updater
 		disaster     error
 	)
 
 	BeforeEach(func() {
 		fakePipeline = new(dbngfakes.FakePipeline)
+		fakeTeam = new(dbngfakes.FakeTeam)
 		fakeJob = new(dbngfakes.FakeJob)
-		updater = maxinflight.NewUpdater(fakePipeline)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		updater = maxinflight.NewUpdater(fakePipeline, fakeTeam, fakeClock)
 		disaster = errors.New("bad thing")
 	})
 
@@ -242,6 +248,233 @@ var _ = Describe("Updater", func() {
 
 				itReturnsFalseIfOurBuildIsNext()
 			})
+
+			Context("when the pipeline declares the size of a serial group", func() {
+				BeforeEach(func() {
+					fakePipeline.ConfigReturns(atc.Config{
+						SerialGroups: atc.SerialGroupConfigs{
+							{Name: "serial-group-1", Size: 3},
+						},
+					}, "", 0, nil)
+				})
+
+				It("counts the running builds in each group within the pipeline", func() {
+					Expect(fakeJob.CountRunningBuildsInSerialGroupCallCount()).To(Equal(2))
+
+					serialGroup, teamWide := fakeJob.CountRunningBuildsInSerialGroupArgsForCall(0)
+					Expect(serialGroup).To(Equal("serial-group-1"))
+					Expect(teamWide).To(BeFalse())
+
+					serialGroup, teamWide = fakeJob.CountRunningBuildsInSerialGroupArgsForCall(1)
+					Expect(serialGroup).To(Equal("serial-group-2"))
+					Expect(teamWide).To(BeFalse())
+				})
+
+				Context("when counting the running builds fails", func() {
+					BeforeEach(func() {
+						fakeJob.CountRunningBuildsInSerialGroupReturns(0, disaster)
+					})
+
+					itReturnsTheError()
+				})
+
+				Context("when fewer builds than its size are running in the group", func() {
+					BeforeEach(func() {
+						fakeJob.CountRunningBuildsInSerialGroupReturnsOnCall(0, 2, nil)
+						fakeJob.CountRunningBuildsInSerialGroupReturnsOnCall(1, 0, nil)
+					})
+
+					itReturnsFalseIfOurBuildIsNext()
+				})
+
+				Context("when as many builds as its size are running in the group", func() {
+					BeforeEach(func() {
+						fakeJob.CountRunningBuildsInSerialGroupReturnsOnCall(0, 3, nil)
+					})
+
+					itReturnsTrueAndNoError()
+
+					It("doesn't look up the next pending build", func() {
+						Expect(fakeJob.GetNextPendingBuildBySerialGroupCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when an undeclared group is already running a build", func() {
+					BeforeEach(func() {
+						fakeJob.CountRunningBuildsInSerialGroupReturnsOnCall(0, 0, nil)
+						fakeJob.CountRunningBuildsInSerialGroupReturnsOnCall(1, 1, nil)
+					})
+
+					itReturnsTrueAndNoError()
+				})
+			})
+
+			Context("when the team declares the size of a serial group", func() {
+				BeforeEach(func() {
+					fakeTeam.FindSerialGroupsReturns(atc.SerialGroupConfigs{
+						{Name: "serial-group-2", Size: 2},
+					}, nil)
+				})
+
+				It("counts the running builds in that group across the team", func() {
+					Expect(fakeJob.CountRunningBuildsInSerialGroupCallCount()).To(Equal(2))
+
+					serialGroup, teamWide := fakeJob.CountRunningBuildsInSerialGroupArgsForCall(1)
+					Expect(serialGroup).To(Equal("serial-group-2"))
+					Expect(teamWide).To(BeTrue())
+				})
+
+				Context("when fewer builds than its size are running in the group", func() {
+					var ourBuild *dbngfakes.FakeBuild
+
+					BeforeEach(func() {
+						ourBuild = new(dbngfakes.FakeBuild)
+						ourBuild.IDReturns(57)
+						fakeJob.GetNextPendingBuildBySerialGroupReturns(ourBuild, true, nil)
+					})
+
+					Context("when the build is first in line across the team", func() {
+						BeforeEach(func() {
+							fakeJob.GetNextPendingBuildInTeamSerialGroupReturns(ourBuild, true, nil)
+						})
+
+						itReturnsFalseAndNoError()
+
+						It("looks up the next pending build in the team's group", func() {
+							Expect(fakeJob.GetNextPendingBuildInTeamSerialGroupCallCount()).To(Equal(1))
+							Expect(fakeJob.GetNextPendingBuildInTeamSerialGroupArgsForCall(0)).To(Equal("serial-group-2"))
+						})
+					})
+
+					Context("when a build of another pipeline is ahead in line across the team", func() {
+						BeforeEach(func() {
+							otherBuild := new(dbngfakes.FakeBuild)
+							otherBuild.IDReturns(101)
+							fakeJob.GetNextPendingBuildInTeamSerialGroupReturns(otherBuild, true, nil)
+						})
+
+						itReturnsTrueAndNoError()
+					})
+
+					Context("when looking up the next pending build across the team fails", func() {
+						BeforeEach(func() {
+							fakeJob.GetNextPendingBuildInTeamSerialGroupReturns(nil, false, disaster)
+						})
+
+						itReturnsTheError()
+					})
+				})
+
+				Context("when the pipeline also declares it", func() {
+					BeforeEach(func() {
+						fakePipeline.ConfigReturns(atc.Config{
+							SerialGroups: atc.SerialGroupConfigs{
+								{Name: "serial-group-2", Size: 3},
+							},
+						}, "", 0, nil)
+					})
+
+					It("counts the running builds in that group within the pipeline", func() {
+						_, teamWide := fakeJob.CountRunningBuildsInSerialGroupArgsForCall(1)
+						Expect(teamWide).To(BeFalse())
+					})
+				})
+
+				Context("when finding the team's serial groups fails", func() {
+					BeforeEach(func() {
+						fakeTeam.FindSerialGroupsReturns(nil, disaster)
+					})
+
+					itReturnsTheError()
+				})
+			})
+		})
+
+		Context("when it is called again", func() {
+			BeforeEach(func() {
+				rawMaxInFlight = 0
+				serialGroups = []string{"serial-group-1"}
+				fakePipeline.ConfigVersionReturns(1)
+				fakePipeline.ConfigReturns(atc.Config{
+					SerialGroups: atc.SerialGroupConfigs{
+						{Name: "serial-group-1", Size: 3},
+					},
+				}, "", 1, nil)
+			})
+
+			call := func() {
+				_, err := updater.UpdateMaxInFlightReached(lagertest.NewTestLogger("test"), fakeJob, 57)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			It("doesn't load the pipeline's config or the team's serial groups again", func() {
+				call()
+
+				Expect(fakePipeline.ConfigCallCount()).To(Equal(1))
+				Expect(fakeTeam.FindSerialGroupsCallCount()).To(Equal(1))
+			})
+
+			Context("when the pipeline's config has changed", func() {
+				It("loads it again", func() {
+					fakePipeline.ConfigVersionReturns(2)
+					call()
+
+					Expect(fakePipeline.ConfigCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the team's serial groups were found a while ago", func() {
+				It("finds them again", func() {
+					fakeClock.Increment(10 * time.Second)
+					call()
+
+					Expect(fakeTeam.FindSerialGroupsCallCount()).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("TeamSerialGroups", func() {
+		var teamSerialGroups atc.SerialGroupConfigs
+		var findErr error
+
+		BeforeEach(func() {
+			fakeJob.ConfigReturns(atc.JobConfig{
+				Name:         "some-job",
+				SerialGroups: []string{"serial-group-1", "serial-group-2", "serial-group-3"},
+			})
+
+			fakePipeline.ConfigReturns(atc.Config{
+				SerialGroups: atc.SerialGroupConfigs{
+					{Name: "serial-group-1", Size: 3},
+				},
+			}, "", 0, nil)
+
+			fakeTeam.FindSerialGroupsReturns(atc.SerialGroupConfigs{
+				{Name: "serial-group-1", Size: 5},
+				{Name: "serial-group-2", Size: 2},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			teamSerialGroups, findErr = updater.TeamSerialGroups(lagertest.NewTestLogger("test"), fakeJob)
+		})
+
+		It("returns the job's groups declared only by the team", func() {
+			Expect(findErr).NotTo(HaveOccurred())
+			Expect(teamSerialGroups).To(Equal(atc.SerialGroupConfigs{
+				{Name: "serial-group-2", Size: 2},
+			}))
+		})
+
+		Context("when finding the team's serial groups fails", func() {
+			BeforeEach(func() {
+				fakeTeam.FindSerialGroupsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(findErr).To(Equal(disaster))
+			})
 		})
 	})
 })
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/metric"
+	"github.com/concourse/atc/tracing"
 )
 
 //go:generate counterfeiter . BuildScheduler
@@ -17,6 +18,7 @@ import (
 type BuildScheduler interface {
 	Schedule(
 		logger lager.Logger,
+		trace tracing.SpanContext,
 		versions *algorithm.VersionsDB,
 		jobs []dbng.Job,
 		resources dbng.Resources,
@@ -28,6 +30,7 @@ type BuildScheduler interface {
 		job dbng.Job,
 		resources dbng.Resources,
 		resourceTypes atc.VersionedResourceTypes,
+		priority int,
 	) (dbng.Build, Waiter, error)
 
 	SaveNextInputMapping(logger lager.Logger, job dbng.Job) error
@@ -60,15 +63,32 @@ func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	defer runner.Logger.Info("done")
 
+	var newVersions <-chan struct{}
+
+	notifier, err := runner.Pipeline.SchedulingNotifier()
+	if err != nil {
+		runner.Logger.Error("failed-to-listen-for-new-versions", err)
+	} else {
+		defer notifier.Close()
+		newVersions = notifier.Notify()
+	}
+
+	interval := runner.Interval
+
 dance:
 	for {
-		err := runner.tick(runner.Logger.Session("tick"))
+		err := runner.tick(runner.Logger.Session("tick"), interval)
 		if err != nil {
 			return err
 		}
 
 		select {
 		case <-time.After(runner.Interval):
+			interval = runner.Interval
+		case <-newVersions:
+			// schedule right away rather than waiting for the interval since
+			// the last tick
+			interval = 0
 		case <-signals:
 			break dance
 		}
@@ -77,12 +97,12 @@ dance:
 	return nil
 }
 
-func (runner *Runner) tick(logger lager.Logger) error {
+func (runner *Runner) tick(logger lager.Logger, interval time.Duration) error {
 	if runner.Noop {
 		return nil
 	}
 
-	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, runner.Interval)
+	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, interval)
 	if err != nil {
 		logger.Error("failed-to-acquire-scheduling-lock", err)
 		return nil
@@ -94,6 +114,12 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 	defer schedulingLock.Release()
 
+	span := tracing.StartSpan("scheduler-tick", tracing.SpanContext{}, map[string]string{
+		"team":     runner.Pipeline.TeamName(),
+		"pipeline": runner.Pipeline.Name(),
+	})
+	defer span.End()
+
 	start := time.Now()
 
 	defer func() {
@@ -106,6 +132,7 @@ func (runner *Runner) tick(logger lager.Logger) error {
 	versions, err := runner.Pipeline.LoadVersionsDB()
 	if err != nil {
 		logger.Error("failed-to-load-versions-db", err)
+		span.RecordError(err)
 		return err
 	}
 
@@ -146,12 +173,15 @@ func (runner *Runner) tick(logger lager.Logger) error {
 
 	schedulingTimes, err := runner.Scheduler.Schedule(
 		sLog,
+		span.Context(),
 		versions,
 		jobs,
 		resources,
 		resourceTypes.Deserialize(),
 	)
 
+	span.RecordError(err)
+
 	for jobName, duration := range schedulingTimes {
 		metric.SchedulingJobDuration{
 			PipelineName: runner.Pipeline.Name(),
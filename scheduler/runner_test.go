@@ -27,6 +27,9 @@ var _ = Describe("Runner", func() {
 
 		lock *lockfakes.FakeLock
 
+		fakeNotifier *dbngfakes.FakeNotifier
+		newVersions  chan struct{}
+
 		initialConfig atc.Config
 
 		someVersions *algorithm.VersionsDB
@@ -146,6 +149,11 @@ var _ = Describe("Runner", func() {
 
 		lock = new(lockfakes.FakeLock)
 		fakePipeline.AcquireSchedulingLockReturns(lock, true, nil)
+
+		newVersions = make(chan struct{}, 1)
+		fakeNotifier = new(dbngfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(newVersions)
+		fakePipeline.SchedulingNotifierReturns(fakeNotifier, nil)
 	})
 
 	JustBeforeEach(func() {
@@ -169,6 +177,41 @@ var _ = Describe("Runner", func() {
 		Expect(duration).To(Equal(100 * time.Millisecond))
 	})
 
+	It("leaves fetching the pending builds to the scheduler", func() {
+		Eventually(scheduler.ScheduleCallCount).Should(BeNumerically(">=", 1))
+		Expect(fakePipeline.GetAllPendingBuildsCallCount()).To(BeZero())
+	})
+
+	Context("when new versions are saved", func() {
+		JustBeforeEach(func() {
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(Equal(1))
+			newVersions <- struct{}{}
+		})
+
+		It("schedules without waiting for the interval", func() {
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(BeNumerically(">=", 2))
+
+			_, duration := fakePipeline.AcquireSchedulingLockArgsForCall(1)
+			Expect(duration).To(BeZero())
+		})
+	})
+
+	It("stops listening for new versions when interrupted", func() {
+		ginkgomon.Interrupt(process)
+
+		Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+	})
+
+	Context("when listening for new versions fails", func() {
+		BeforeEach(func() {
+			fakePipeline.SchedulingNotifierReturns(nil, errors.New("nope"))
+		})
+
+		It("keeps scheduling on the interval", func() {
+			Eventually(scheduler.ScheduleCallCount).Should(BeNumerically(">=", 2))
+		})
+	})
+
 	Context("when it can't get the lock", func() {
 		BeforeEach(func() {
 			fakePipeline.AcquireSchedulingLockReturns(nil, false, nil)
@@ -196,7 +239,7 @@ var _ = Describe("Runner", func() {
 	It("schedules pending builds", func() {
 		Eventually(scheduler.ScheduleCallCount).Should(Equal(2))
 
-		_, versions, jobs, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
+		_, _, versions, jobs, resources, resourceTypes := scheduler.ScheduleArgsForCall(0)
 		Expect(versions).To(Equal(someVersions))
 		Expect(jobs).To(Equal([]dbng.Job{fakeJob1, fakeJob2}))
 		Expect(resources).To(Equal(dbng.Resources{fakeResource1, fakeResource2}))
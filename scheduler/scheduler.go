@@ -8,7 +8,9 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/metric"
 	"github.com/concourse/atc/scheduler/inputmapper"
+	"github.com/concourse/atc/tracing"
 )
 
 type Scheduler struct {
@@ -26,6 +28,7 @@ type Scanner interface {
 
 func (s *Scheduler) Schedule(
 	logger lager.Logger,
+	trace tracing.SpanContext,
 	versions *algorithm.VersionsDB,
 	jobs []dbng.Job,
 	resources dbng.Resources,
@@ -35,7 +38,7 @@ func (s *Scheduler) Schedule(
 
 	for _, job := range jobs {
 		jStart := time.Now()
-		err := s.ensurePendingBuildExists(logger, versions, job)
+		err := s.ensurePendingBuildExists(logger, trace, versions, job)
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
 		if err != nil {
@@ -49,6 +52,17 @@ func (s *Scheduler) Schedule(
 		return jobSchedulingTime, err
 	}
 
+	pendingCount := 0
+	for _, builds := range nextPendingBuilds {
+		pendingCount += len(builds)
+	}
+
+	metric.PendingBuilds{
+		PipelineName: s.Pipeline.Name(),
+		TeamName:     s.Pipeline.TeamName(),
+		Count:        pendingCount,
+	}.Emit(logger)
+
 	for _, job := range jobs {
 		jStart := time.Now()
 		nextPendingBuildsForJob, ok := nextPendingBuilds[job.Name()]
@@ -69,10 +83,18 @@ func (s *Scheduler) Schedule(
 
 func (s *Scheduler) ensurePendingBuildExists(
 	logger lager.Logger,
+	trace tracing.SpanContext,
 	versions *algorithm.VersionsDB,
 	job dbng.Job,
 ) error {
+	span := tracing.StartSpan("input-mapping", trace, map[string]string{
+		"job": job.Name(),
+	})
+
 	inputMapping, err := s.InputMapper.SaveNextInputMapping(logger, versions, job)
+	span.RecordError(err)
+	span.End()
+
 	if err != nil {
 		return err
 	}
@@ -104,10 +126,11 @@ func (s *Scheduler) TriggerImmediately(
 	job dbng.Job,
 	resources dbng.Resources,
 	resourceTypes atc.VersionedResourceTypes,
+	priority int,
 ) (dbng.Build, Waiter, error) {
 	logger = logger.Session("trigger-immediately", lager.Data{"job_name": job.Name()})
 
-	build, err := job.CreateBuild()
+	build, err := job.CreateBuildWithPriority(priority)
 	if err != nil {
 		logger.Error("failed-to-create-job-build", err)
 		return nil, nil, err
@@ -8,9 +8,11 @@ import (
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/metric"
 	. "github.com/concourse/atc/scheduler"
 	"github.com/concourse/atc/scheduler/inputmapper/inputmapperfakes"
 	"github.com/concourse/atc/scheduler/schedulerfakes"
+	"github.com/concourse/atc/tracing"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -58,6 +60,9 @@ var _ = Describe("Scheduler", func() {
 		)
 
 		BeforeEach(func() {
+			fakePipeline.NameReturns("some-pending-pipeline")
+			fakePipeline.TeamNameReturns("some-pending-team")
+
 			nextPendingBuilds = []dbng.Build{new(dbngfakes.FakeBuild)}
 			nextPendingBuildsJob1 = []dbng.Build{new(dbngfakes.FakeBuild), new(dbngfakes.FakeBuild)}
 			nextPendingBuildsJob2 = []dbng.Build{new(dbngfakes.FakeBuild)}
@@ -84,6 +89,7 @@ var _ = Describe("Scheduler", func() {
 			var waiter Waiter
 			_, scheduleErr = scheduler.Schedule(
 				lagertest.NewTestLogger("test"),
+				tracing.SpanContext{},
 				versionsDB,
 				fakeJobs,
 				dbng.Resources{fakeResource},
@@ -162,6 +168,18 @@ var _ = Describe("Scheduler", func() {
 					It("didn't create a pending build", func() {
 						Expect(fakePipeline.EnsurePendingBuildExistsCallCount()).To(BeZero())
 					})
+
+					It("emits the number of pending builds in the pipeline", func() {
+						Eventually(metricEmitter.Events("pending builds")).Should(ContainElement(metric.Event{
+							Name:  "pending builds",
+							Value: 4,
+							State: metric.EventStateOK,
+							Attributes: map[string]string{
+								"pipeline": "some-pending-pipeline",
+								"team":     "some-pending-team",
+							},
+						}))
+					})
 				})
 			})
 		})
@@ -281,6 +299,7 @@ var _ = Describe("Scheduler", func() {
 						Version:      atc.Version{"some": "version"},
 					},
 				},
+				10,
 			)
 			if waiter != nil {
 				waiter.Wait()
@@ -289,7 +308,7 @@ var _ = Describe("Scheduler", func() {
 
 		Context("when creating the build fails", func() {
 			BeforeEach(func() {
-				fakeJob.CreateBuildReturns(nil, disaster)
+				fakeJob.CreateBuildWithPriorityReturns(nil, disaster)
 			})
 
 			It("returns the error", func() {
@@ -303,11 +322,12 @@ var _ = Describe("Scheduler", func() {
 			BeforeEach(func() {
 				createdBuild = new(dbngfakes.FakeBuild)
 				createdBuild.IsManuallyTriggeredReturns(true)
-				fakeJob.CreateBuildReturns(createdBuild, nil)
+				fakeJob.CreateBuildWithPriorityReturns(createdBuild, nil)
 			})
 
 			It("tried to create a build for the right job", func() {
-				Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+				Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(1))
+				Expect(fakeJob.CreateBuildWithPriorityArgsForCall(0)).To(Equal(10))
 			})
 
 			Context("when get pending builds for job fails", func() {
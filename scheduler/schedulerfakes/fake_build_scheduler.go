@@ -10,13 +10,15 @@ import (
 	"github.com/concourse/atc/db/algorithm"
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/scheduler"
+	"github.com/concourse/atc/tracing"
 )
 
 type FakeBuildScheduler struct {
-	ScheduleStub        func(logger lager.Logger, versions *algorithm.VersionsDB, jobs []dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error)
+	ScheduleStub        func(logger lager.Logger, trace tracing.SpanContext, versions *algorithm.VersionsDB, jobs []dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error)
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
 		logger        lager.Logger
+		trace         tracing.SpanContext
 		versions      *algorithm.VersionsDB
 		jobs          []dbng.Job
 		resources     dbng.Resources
@@ -30,13 +32,26 @@ type FakeBuildScheduler struct {
 		result1 map[string]time.Duration
 		result2 error
 	}
-	TriggerImmediatelyStub        func(logger lager.Logger, job dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (dbng.Build, scheduler.Waiter, error)
+	SaveNextInputMappingStub        func(logger lager.Logger, job dbng.Job) error
+	saveNextInputMappingMutex       sync.RWMutex
+	saveNextInputMappingArgsForCall []struct {
+		logger lager.Logger
+		job    dbng.Job
+	}
+	saveNextInputMappingReturns struct {
+		result1 error
+	}
+	saveNextInputMappingReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TriggerImmediatelyStub        func(logger lager.Logger, job dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes, priority int) (dbng.Build, scheduler.Waiter, error)
 	triggerImmediatelyMutex       sync.RWMutex
 	triggerImmediatelyArgsForCall []struct {
 		logger        lager.Logger
 		job           dbng.Job
 		resources     dbng.Resources
 		resourceTypes atc.VersionedResourceTypes
+		priority      int
 	}
 	triggerImmediatelyReturns struct {
 		result1 dbng.Build
@@ -48,23 +63,11 @@ type FakeBuildScheduler struct {
 		result2 scheduler.Waiter
 		result3 error
 	}
-	SaveNextInputMappingStub        func(logger lager.Logger, job dbng.Job) error
-	saveNextInputMappingMutex       sync.RWMutex
-	saveNextInputMappingArgsForCall []struct {
-		logger lager.Logger
-		job    dbng.Job
-	}
-	saveNextInputMappingReturns struct {
-		result1 error
-	}
-	saveNextInputMappingReturnsOnCall map[int]struct {
-		result1 error
-	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorithm.VersionsDB, jobs []dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error) {
+func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, trace tracing.SpanContext, versions *algorithm.VersionsDB, jobs []dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (map[string]time.Duration, error) {
 	var jobsCopy []dbng.Job
 	if jobs != nil {
 		jobsCopy = make([]dbng.Job, len(jobs))
@@ -74,15 +77,16 @@ func (fake *FakeBuildScheduler) Schedule(logger lager.Logger, versions *algorith
 	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
 	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
 		logger        lager.Logger
+		trace         tracing.SpanContext
 		versions      *algorithm.VersionsDB
 		jobs          []dbng.Job
 		resources     dbng.Resources
 		resourceTypes atc.VersionedResourceTypes
-	}{logger, versions, jobsCopy, resources, resourceTypes})
-	fake.recordInvocation("Schedule", []interface{}{logger, versions, jobsCopy, resources, resourceTypes})
+	}{logger, trace, versions, jobsCopy, resources, resourceTypes})
+	fake.recordInvocation("Schedule", []interface{}{logger, trace, versions, jobsCopy, resources, resourceTypes})
 	fake.scheduleMutex.Unlock()
 	if fake.ScheduleStub != nil {
-		return fake.ScheduleStub(logger, versions, jobs, resources, resourceTypes)
+		return fake.ScheduleStub(logger, trace, versions, jobs, resources, resourceTypes)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -96,10 +100,10 @@ func (fake *FakeBuildScheduler) ScheduleCallCount() int {
 	return len(fake.scheduleArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (lager.Logger, *algorithm.VersionsDB, []dbng.Job, dbng.Resources, atc.VersionedResourceTypes) {
+func (fake *FakeBuildScheduler) ScheduleArgsForCall(i int) (lager.Logger, tracing.SpanContext, *algorithm.VersionsDB, []dbng.Job, dbng.Resources, atc.VersionedResourceTypes) {
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
-	return fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobs, fake.scheduleArgsForCall[i].resources, fake.scheduleArgsForCall[i].resourceTypes
+	return fake.scheduleArgsForCall[i].logger, fake.scheduleArgsForCall[i].trace, fake.scheduleArgsForCall[i].versions, fake.scheduleArgsForCall[i].jobs, fake.scheduleArgsForCall[i].resources, fake.scheduleArgsForCall[i].resourceTypes
 }
 
 func (fake *FakeBuildScheduler) ScheduleReturns(result1 map[string]time.Duration, result2 error) {
@@ -124,7 +128,56 @@ func (fake *FakeBuildScheduler) ScheduleReturnsOnCall(i int, result1 map[string]
 	}{result1, result2}
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes) (dbng.Build, scheduler.Waiter, error) {
+func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job dbng.Job) error {
+	fake.saveNextInputMappingMutex.Lock()
+	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
+	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
+		logger lager.Logger
+		job    dbng.Job
+	}{logger, job})
+	fake.recordInvocation("SaveNextInputMapping", []interface{}{logger, job})
+	fake.saveNextInputMappingMutex.Unlock()
+	if fake.SaveNextInputMappingStub != nil {
+		return fake.SaveNextInputMappingStub(logger, job)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.saveNextInputMappingReturns.result1
+}
+
+func (fake *FakeBuildScheduler) SaveNextInputMappingCallCount() int {
+	fake.saveNextInputMappingMutex.RLock()
+	defer fake.saveNextInputMappingMutex.RUnlock()
+	return len(fake.saveNextInputMappingArgsForCall)
+}
+
+func (fake *FakeBuildScheduler) SaveNextInputMappingArgsForCall(i int) (lager.Logger, dbng.Job) {
+	fake.saveNextInputMappingMutex.RLock()
+	defer fake.saveNextInputMappingMutex.RUnlock()
+	return fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].job
+}
+
+func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
+	fake.SaveNextInputMappingStub = nil
+	fake.saveNextInputMappingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
+	fake.SaveNextInputMappingStub = nil
+	if fake.saveNextInputMappingReturnsOnCall == nil {
+		fake.saveNextInputMappingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveNextInputMappingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job dbng.Job, resources dbng.Resources, resourceTypes atc.VersionedResourceTypes, priority int) (dbng.Build, scheduler.Waiter, error) {
 	fake.triggerImmediatelyMutex.Lock()
 	ret, specificReturn := fake.triggerImmediatelyReturnsOnCall[len(fake.triggerImmediatelyArgsForCall)]
 	fake.triggerImmediatelyArgsForCall = append(fake.triggerImmediatelyArgsForCall, struct {
@@ -132,11 +185,12 @@ func (fake *FakeBuildScheduler) TriggerImmediately(logger lager.Logger, job dbng
 		job           dbng.Job
 		resources     dbng.Resources
 		resourceTypes atc.VersionedResourceTypes
-	}{logger, job, resources, resourceTypes})
-	fake.recordInvocation("TriggerImmediately", []interface{}{logger, job, resources, resourceTypes})
+		priority      int
+	}{logger, job, resources, resourceTypes, priority})
+	fake.recordInvocation("TriggerImmediately", []interface{}{logger, job, resources, resourceTypes, priority})
 	fake.triggerImmediatelyMutex.Unlock()
 	if fake.TriggerImmediatelyStub != nil {
-		return fake.TriggerImmediatelyStub(logger, job, resources, resourceTypes)
+		return fake.TriggerImmediatelyStub(logger, job, resources, resourceTypes, priority)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -150,10 +204,10 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyCallCount() int {
 	return len(fake.triggerImmediatelyArgsForCall)
 }
 
-func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, dbng.Job, dbng.Resources, atc.VersionedResourceTypes) {
+func (fake *FakeBuildScheduler) TriggerImmediatelyArgsForCall(i int) (lager.Logger, dbng.Job, dbng.Resources, atc.VersionedResourceTypes, int) {
 	fake.triggerImmediatelyMutex.RLock()
 	defer fake.triggerImmediatelyMutex.RUnlock()
-	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].job, fake.triggerImmediatelyArgsForCall[i].resources, fake.triggerImmediatelyArgsForCall[i].resourceTypes
+	return fake.triggerImmediatelyArgsForCall[i].logger, fake.triggerImmediatelyArgsForCall[i].job, fake.triggerImmediatelyArgsForCall[i].resources, fake.triggerImmediatelyArgsForCall[i].resourceTypes, fake.triggerImmediatelyArgsForCall[i].priority
 }
 
 func (fake *FakeBuildScheduler) TriggerImmediatelyReturns(result1 dbng.Build, result2 scheduler.Waiter, result3 error) {
@@ -181,64 +235,15 @@ func (fake *FakeBuildScheduler) TriggerImmediatelyReturnsOnCall(i int, result1 d
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildScheduler) SaveNextInputMapping(logger lager.Logger, job dbng.Job) error {
-	fake.saveNextInputMappingMutex.Lock()
-	ret, specificReturn := fake.saveNextInputMappingReturnsOnCall[len(fake.saveNextInputMappingArgsForCall)]
-	fake.saveNextInputMappingArgsForCall = append(fake.saveNextInputMappingArgsForCall, struct {
-		logger lager.Logger
-		job    dbng.Job
-	}{logger, job})
-	fake.recordInvocation("SaveNextInputMapping", []interface{}{logger, job})
-	fake.saveNextInputMappingMutex.Unlock()
-	if fake.SaveNextInputMappingStub != nil {
-		return fake.SaveNextInputMappingStub(logger, job)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fake.saveNextInputMappingReturns.result1
-}
-
-func (fake *FakeBuildScheduler) SaveNextInputMappingCallCount() int {
-	fake.saveNextInputMappingMutex.RLock()
-	defer fake.saveNextInputMappingMutex.RUnlock()
-	return len(fake.saveNextInputMappingArgsForCall)
-}
-
-func (fake *FakeBuildScheduler) SaveNextInputMappingArgsForCall(i int) (lager.Logger, dbng.Job) {
-	fake.saveNextInputMappingMutex.RLock()
-	defer fake.saveNextInputMappingMutex.RUnlock()
-	return fake.saveNextInputMappingArgsForCall[i].logger, fake.saveNextInputMappingArgsForCall[i].job
-}
-
-func (fake *FakeBuildScheduler) SaveNextInputMappingReturns(result1 error) {
-	fake.SaveNextInputMappingStub = nil
-	fake.saveNextInputMappingReturns = struct {
-		result1 error
-	}{result1}
-}
-
-func (fake *FakeBuildScheduler) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
-	fake.SaveNextInputMappingStub = nil
-	if fake.saveNextInputMappingReturnsOnCall == nil {
-		fake.saveNextInputMappingReturnsOnCall = make(map[int]struct {
-			result1 error
-		})
-	}
-	fake.saveNextInputMappingReturnsOnCall[i] = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeBuildScheduler) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
-	fake.triggerImmediatelyMutex.RLock()
-	defer fake.triggerImmediatelyMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.triggerImmediatelyMutex.RLock()
+	defer fake.triggerImmediatelyMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
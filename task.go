@@ -31,6 +31,18 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) outputs provided by the task.
 	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty" mapstructure:"outputs"`
+
+	// Optional CPU and memory to reserve for the task's container. The task is
+	// only placed on a worker with enough unallocated capacity.
+	ContainerLimits *ContainerLimits `json:"container_limits,omitempty" yaml:"container_limits,omitempty" mapstructure:"container_limits"`
+}
+
+type ContainerLimits struct {
+	// CPU, in millicores.
+	CPU int64 `json:"cpu,omitempty" yaml:"cpu,omitempty" mapstructure:"cpu"`
+
+	// Memory, in bytes.
+	Memory int64 `json:"memory,omitempty" yaml:"memory,omitempty" mapstructure:"memory"`
 }
 
 type ImageResource struct {
@@ -110,6 +122,10 @@ func (config TaskConfig) Merge(other TaskConfig) TaskConfig {
 		config.Run = other.Run
 	}
 
+	if other.ContainerLimits != nil {
+		config.ContainerLimits = other.ContainerLimits
+	}
+
 	return config
 }
 
@@ -126,6 +142,16 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputsAndOutputs()...)
 
+	if config.ContainerLimits != nil {
+		if config.ContainerLimits.CPU < 0 {
+			messages = append(messages, "  container_limits.cpu must not be negative")
+		}
+
+		if config.ContainerLimits.Memory < 0 {
+			messages = append(messages, "  container_limits.memory must not be negative")
+		}
+	}
+
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
 	}
@@ -351,6 +377,10 @@ func (input TaskInputConfig) resolvePath() string {
 type TaskOutputConfig struct {
 	Name string `json:"name" yaml:"name"`
 	Path string `json:"path,omitempty" yaml:"path"`
+
+	// Whether to keep the output once the task succeeds so that downstream
+	// jobs can fetch it with an artifact get step.
+	Publish bool `json:"publish,omitempty" yaml:"publish,omitempty"`
 }
 
 func (output TaskOutputConfig) resolvePath() string {
@@ -244,6 +244,18 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when container limits are negative", func() {
+			BeforeEach(func() {
+				invalidConfig.ContainerLimits = &ContainerLimits{CPU: -1, Memory: -1}
+			})
+
+			It("returns an error", func() {
+				err := invalidConfig.Validate()
+				Expect(err).To(MatchError(ContainSubstring("  container_limits.cpu must not be negative")))
+				Expect(err).To(MatchError(ContainSubstring("  container_limits.memory must not be negative")))
+			})
+		})
+
 		Describe("input overlapping checks", func() {
 			Context("when two inputs have the same name", func() {
 				BeforeEach(func() {
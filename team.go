@@ -1,6 +1,9 @@
 package atc
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+)
 
 type Team struct {
 	ID   int    `json:"id,omitempty"`
@@ -9,6 +12,45 @@ type Team struct {
 	BasicAuth *BasicAuth `json:"basic_auth,omitempty"`
 
 	Auth map[string]*json.RawMessage `json:"auth,omitempty"`
+
+	ContainerPlacementStrategy string `json:"container_placement_strategy,omitempty"`
+
+	Quotas *TeamQuotas `json:"quotas,omitempty"`
+
+	// AdmissionWeight is the team's share of the running builds relative to
+	// other teams' when the number of concurrent builds is limited. Teams
+	// default to a weight of 1.
+	AdmissionWeight int `json:"admission_weight,omitempty"`
+
+	// SerialGroups declared on a team span all of its pipelines, unless a
+	// pipeline declares a serial group of the same name itself.
+	SerialGroups SerialGroupConfigs `json:"serial_groups,omitempty"`
+}
+
+// TeamQuotas limits how much a team may use at once. Zero means no limit.
+type TeamQuotas struct {
+	MaxRunningBuilds int `json:"max_running_builds,omitempty"`
+	MaxContainers    int `json:"max_containers,omitempty"`
+	MaxVolumes       int `json:"max_volumes,omitempty"`
+}
+
+func (quotas TeamQuotas) Validate() error {
+	if quotas.MaxRunningBuilds < 0 || quotas.MaxContainers < 0 || quotas.MaxVolumes < 0 {
+		return errors.New("quotas must not be negative")
+	}
+
+	return nil
+}
+
+type TeamQuotaUsage struct {
+	RunningBuilds int `json:"running_builds"`
+	Containers    int `json:"containers"`
+	Volumes       int `json:"volumes"`
+}
+
+type TeamQuotaStatus struct {
+	Quotas TeamQuotas     `json:"quotas"`
+	Usage  TeamQuotaUsage `json:"usage"`
 }
 
 type BasicAuth struct {
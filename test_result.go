@@ -0,0 +1,30 @@
+package atc
+
+type TestSuite struct {
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases"`
+}
+
+type TestCase struct {
+	ClassName string  `json:"class_name"`
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Duration  float64 `json:"duration"`
+	Message   string  `json:"message,omitempty"`
+}
+
+type TestHistory struct {
+	Suite     string        `json:"suite"`
+	ClassName string        `json:"class_name"`
+	Name      string        `json:"name"`
+	Flakiness float64       `json:"flakiness"`
+	Runs      []TestCaseRun `json:"runs"`
+}
+
+type TestCaseRun struct {
+	BuildID   int     `json:"build_id"`
+	BuildName string  `json:"build_name"`
+	Status    string  `json:"status"`
+	Duration  float64 `json:"duration"`
+}
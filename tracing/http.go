@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// TraceparentHeader carries the caller's span context in requests.
+const TraceparentHeader = "traceparent"
+
+type TracingHandler struct {
+	Route   string
+	Handler http.Handler
+}
+
+// WrapHandler records a span for each request, as a child of the caller's
+// span if the request carries one.
+func WrapHandler(route string, handler http.Handler) http.Handler {
+	return TracingHandler{
+		Route:   route,
+		Handler: handler,
+	}
+}
+
+func (handler TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parent, _ := ParseTraceparent(r.Header.Get(TraceparentHeader))
+
+	span := StartServerSpan(handler.Route, parent, map[string]string{
+		"http.method": r.Method,
+		"http.route":  handler.Route,
+		"http.target": r.URL.Path,
+	})
+	defer span.End()
+
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	handler.Handler.ServeHTTP(recorder, r)
+
+	span.SetAttribute("http.status_code", strconv.Itoa(recorder.status))
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (recorder *statusRecorder) WriteHeader(status int) {
+	recorder.status = status
+	recorder.ResponseWriter.WriteHeader(status)
+}
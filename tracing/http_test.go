@@ -0,0 +1,76 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracingHandler", func() {
+	var (
+		stubbedCollector *collector
+		handler          http.Handler
+	)
+
+	BeforeEach(func() {
+		stubbedCollector = newCollector()
+
+		err := tracing.Initialize(lagertest.NewTestLogger("test"), tracing.Config{
+			OTLPEndpoint: stubbedCollector.server.URL,
+			BatchSize:    10,
+		}, "")
+		Expect(err).NotTo(HaveOccurred())
+
+		handler = tracing.WrapHandler("GetBuild", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	})
+
+	AfterEach(func() {
+		stubbedCollector.server.Close()
+		Expect(tracing.Initialize(lagertest.NewTestLogger("test"), tracing.Config{}, "")).To(Succeed())
+	})
+
+	It("records a span for the request beneath the caller's span", func() {
+		parent := tracing.NewSpanContext()
+
+		request, err := http.NewRequest("GET", "/api/v1/builds/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("traceparent", parent.Traceparent())
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusTeapot))
+
+		Expect(tracing.Flush()).To(Succeed())
+
+		spans := stubbedCollector.exported()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name).To(Equal("GetBuild"))
+		Expect(spans[0].Kind).To(Equal(int(tracing.SpanKindServer)))
+		Expect(spans[0].TraceID).To(Equal(parent.TraceID))
+		Expect(spans[0].ParentSpanID).To(Equal(parent.SpanID))
+		Expect(spans[0].attribute("http.method")).To(Equal("GET"))
+		Expect(spans[0].attribute("http.target")).To(Equal("/api/v1/builds/1"))
+		Expect(spans[0].attribute("http.status_code")).To(Equal("418"))
+	})
+
+	It("starts a new trace when the request carries no span", func() {
+		request, err := http.NewRequest("GET", "/api/v1/builds/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+
+		Expect(tracing.Flush()).To(Succeed())
+
+		spans := stubbedCollector.exported()
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].TraceID).NotTo(BeEmpty())
+		Expect(spans[0].ParentSpanID).To(BeEmpty())
+	})
+})
@@ -0,0 +1,263 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// MaxQueuedBatches is how many batches of spans are queued while the
+// collector is unreachable before further spans are dropped.
+const MaxQueuedBatches = 4
+
+const otlpScopeName = "github.com/concourse/atc/tracing"
+
+// OTLPExporter batches ended spans and exports them as JSON over OTLP/HTTP.
+type OTLPExporter struct {
+	logger    lager.Logger
+	tracesURL string
+	resource  otlpResource
+	batchSize int
+	client    *http.Client
+
+	lock     sync.Mutex
+	spans    []*Span
+	flushing bool
+}
+
+func NewOTLPExporter(logger lager.Logger, endpoint *url.URL, serviceName string, host string, batchSize int) *OTLPExporter {
+	tracesURL := *endpoint
+	tracesURL.Path = tracesURL.Path + "/v1/traces"
+
+	resource := otlpResource{
+		Attributes: []otlpAttribute{
+			{Key: "service.name", Value: otlpValue{StringValue: serviceName}},
+		},
+	}
+
+	if host != "" {
+		resource.Attributes = append(resource.Attributes, otlpAttribute{Key: "host.name", Value: otlpValue{StringValue: host}})
+	}
+
+	return &OTLPExporter{
+		logger:    logger,
+		tracesURL: tracesURL.String(),
+		resource:  resource,
+		batchSize: batchSize,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Export batches the span, exporting the batch in the background once it is
+// full.
+func (exporter *OTLPExporter) Export(span *Span) {
+	exporter.lock.Lock()
+
+	if len(exporter.spans) >= exporter.maxQueued() {
+		exporter.lock.Unlock()
+		exporter.logger.Error("queue-full", nil, lager.Data{"span": span.name})
+		return
+	}
+
+	exporter.spans = append(exporter.spans, span)
+
+	flush := len(exporter.spans) >= exporter.batchSize && !exporter.flushing
+	if flush {
+		exporter.flushing = true
+	}
+
+	exporter.lock.Unlock()
+
+	if flush {
+		go exporter.flushInBackground()
+	}
+}
+
+// Flush exports the batched spans. If the collector can't be reached or
+// rejects them, they are kept to be retried by the next flush.
+func (exporter *OTLPExporter) Flush() error {
+	exporter.lock.Lock()
+	spans := exporter.spans
+	exporter.spans = nil
+	exporter.lock.Unlock()
+
+	if len(spans) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(exporter.traces(spans))
+	if err != nil {
+		return err
+	}
+
+	err = exporter.post(payload)
+	if err != nil {
+		exporter.requeue(spans)
+		return err
+	}
+
+	return nil
+}
+
+func (exporter *OTLPExporter) post(payload []byte) error {
+	response, err := exporter.client.Post(exporter.tracesURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with %s", response.Status)
+	}
+
+	return nil
+}
+
+// requeue puts spans which failed to export back ahead of any batched since,
+// dropping the oldest if that would exceed the queue.
+func (exporter *OTLPExporter) requeue(spans []*Span) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+
+	queued := append(spans, exporter.spans...)
+
+	dropped := len(queued) - exporter.maxQueued()
+	if dropped > 0 {
+		exporter.logger.Error("queue-full", nil, lager.Data{"dropped": dropped})
+		queued = queued[dropped:]
+	}
+
+	exporter.spans = queued
+}
+
+func (exporter *OTLPExporter) maxQueued() int {
+	return exporter.batchSize * MaxQueuedBatches
+}
+
+func (exporter *OTLPExporter) flushInBackground() {
+	exporter.flushAndLog()
+
+	exporter.lock.Lock()
+	exporter.flushing = false
+	exporter.lock.Unlock()
+}
+
+func (exporter *OTLPExporter) flushAndLog() {
+	err := exporter.Flush()
+	if err != nil {
+		exporter.logger.Error("failed-to-export-spans", err)
+	}
+}
+
+func (exporter *OTLPExporter) flushPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		exporter.flushAndLog()
+	}
+}
+
+func (exporter *OTLPExporter) traces(spans []*Span) otlpTraces {
+	otlpSpans := make([]otlpSpan, len(spans))
+	for i, span := range spans {
+		otlpSpans[i] = span.otlp()
+	}
+
+	return otlpTraces{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: exporter.resource,
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: otlpScopeName},
+						Spans: otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (span *Span) otlp() otlpSpan {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	converted := otlpSpan{
+		TraceID:           span.context.TraceID,
+		SpanID:            span.context.SpanID,
+		ParentSpanID:      span.parentID,
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+	}
+
+	for k, v := range span.attributes {
+		converted.Attributes = append(converted.Attributes, otlpAttribute{Key: k, Value: otlpValue{StringValue: v}})
+	}
+
+	if span.err != nil {
+		converted.Status = otlpStatus{Code: otlpStatusError, Message: span.err.Error()}
+	}
+
+	return converted
+}
+
+const otlpStatusError = 2
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              SpanKind        `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,299 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	} `json:"attributes"`
+	Status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+func (span exportedSpan) attribute(key string) string {
+	for _, attribute := range span.Attributes {
+		if attribute.Key == key {
+			return attribute.Value.StringValue
+		}
+	}
+
+	return ""
+}
+
+// collector stands in for an OTLP/HTTP collector.
+type collector struct {
+	server *httptest.Server
+	status int
+
+	lock     sync.Mutex
+	paths    []string
+	services []string
+	spans    []exportedSpan
+}
+
+func newCollector() *collector {
+	c := &collector{status: http.StatusOK}
+	c.server = httptest.NewServer(http.HandlerFunc(c.serve))
+	return c
+}
+
+func (c *collector) serve(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []exportedSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	Expect(err).NotTo(HaveOccurred())
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.paths = append(c.paths, r.URL.Path)
+
+	for _, resourceSpans := range payload.ResourceSpans {
+		for _, attribute := range resourceSpans.Resource.Attributes {
+			if attribute.Key == "service.name" {
+				c.services = append(c.services, attribute.Value.StringValue)
+			}
+		}
+
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			c.spans = append(c.spans, scopeSpans.Spans...)
+		}
+	}
+
+	w.WriteHeader(c.status)
+}
+
+func (c *collector) exported() []exportedSpan {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return append([]exportedSpan{}, c.spans...)
+}
+
+var _ = Describe("Tracing", func() {
+	var (
+		logger           *lagertest.TestLogger
+		stubbedCollector *collector
+		config           tracing.Config
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		stubbedCollector = newCollector()
+
+		config = tracing.Config{
+			OTLPEndpoint: stubbedCollector.server.URL,
+			ServiceName:  "some-service",
+			BatchSize:    10,
+		}
+	})
+
+	AfterEach(func() {
+		stubbedCollector.server.Close()
+		Expect(tracing.Initialize(logger, tracing.Config{}, "")).To(Succeed())
+	})
+
+	Context("when not configured", func() {
+		BeforeEach(func() {
+			Expect(tracing.Initialize(logger, tracing.Config{}, "")).To(Succeed())
+		})
+
+		It("passes the parent's context through without recording", func() {
+			parent := tracing.NewSpanContext()
+
+			span := tracing.StartSpan("some-span", parent, nil)
+			Expect(span.Context()).To(Equal(parent))
+			span.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+			Expect(stubbedCollector.exported()).To(BeEmpty())
+		})
+	})
+
+	Context("when configured", func() {
+		BeforeEach(func() {
+			Expect(tracing.Initialize(logger, config, "some-host")).To(Succeed())
+		})
+
+		It("exports ended spans to the collector", func() {
+			span := tracing.StartSpan("some-span", tracing.SpanContext{}, map[string]string{"some": "attribute"})
+			span.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+
+			Expect(stubbedCollector.paths).To(Equal([]string{"/v1/traces"}))
+			Expect(stubbedCollector.services).To(Equal([]string{"some-service"}))
+
+			spans := stubbedCollector.exported()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("some-span"))
+			Expect(spans[0].TraceID).To(Equal(span.Context().TraceID))
+			Expect(spans[0].SpanID).To(Equal(span.Context().SpanID))
+			Expect(spans[0].ParentSpanID).To(BeEmpty())
+			Expect(spans[0].attribute("some")).To(Equal("attribute"))
+		})
+
+		It("starts child spans in their parent's trace", func() {
+			parent := tracing.StartSpan("parent", tracing.SpanContext{}, nil)
+			child := tracing.StartSpan("child", parent.Context(), nil)
+			child.End()
+			parent.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+
+			spans := stubbedCollector.exported()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("child"))
+			Expect(spans[0].TraceID).To(Equal(parent.Context().TraceID))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.Context().SpanID))
+		})
+
+		It("marks spans which recorded an error as failed", func() {
+			span := tracing.StartSpan("some-span", tracing.SpanContext{}, nil)
+			span.RecordError(errors.New("nope"))
+			span.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+
+			spans := stubbedCollector.exported()
+			Expect(spans[0].Status.Code).To(Equal(2))
+			Expect(spans[0].Status.Message).To(Equal("nope"))
+		})
+
+		It("only exports a span once", func() {
+			span := tracing.StartSpan("some-span", tracing.SpanContext{}, nil)
+			span.End()
+			span.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+			Expect(stubbedCollector.exported()).To(HaveLen(1))
+		})
+
+		It("resumes spans in the trace of a persisted context, each as a span of its own", func() {
+			context := tracing.NewSpanContext()
+
+			tracing.ResumeSpan("build", context, nil).End()
+			tracing.ResumeSpan("build", context, nil).End()
+
+			Expect(tracing.Flush()).To(Succeed())
+
+			spans := stubbedCollector.exported()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].TraceID).To(Equal(context.TraceID))
+			Expect(spans[0].ParentSpanID).To(BeEmpty())
+			Expect(spans[1].TraceID).To(Equal(context.TraceID))
+			Expect(spans[1].SpanID).NotTo(Equal(spans[0].SpanID))
+		})
+
+		It("resumes spans without a persisted context in a new trace", func() {
+			span := tracing.ResumeSpan("build", tracing.SpanContext{}, nil)
+			Expect(span.Context().IsValid()).To(BeTrue())
+
+			tracing.StartSpan("some-step", span.Context(), nil).End()
+			span.End()
+
+			Expect(tracing.Flush()).To(Succeed())
+
+			spans := stubbedCollector.exported()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].TraceID).To(Equal(spans[1].TraceID))
+			Expect(spans[0].ParentSpanID).To(Equal(spans[1].SpanID))
+		})
+
+		It("exports a batch in the background once it is full", func() {
+			for i := 0; i < config.BatchSize; i++ {
+				tracing.StartSpan("some-span", tracing.SpanContext{}, nil).End()
+			}
+
+			Eventually(stubbedCollector.exported).Should(HaveLen(config.BatchSize))
+		})
+
+		It("returns an error when the collector rejects the spans", func() {
+			stubbedCollector.status = http.StatusBadRequest
+
+			tracing.StartSpan("some-span", tracing.SpanContext{}, nil).End()
+
+			Expect(tracing.Flush()).To(MatchError("collector responded with 400 Bad Request"))
+		})
+
+		It("keeps spans the collector rejected and retries them on the next flush", func() {
+			stubbedCollector.status = http.StatusServiceUnavailable
+
+			tracing.StartSpan("some-span", tracing.SpanContext{}, nil).End()
+			Expect(tracing.Flush()).NotTo(Succeed())
+
+			stubbedCollector.lock.Lock()
+			stubbedCollector.status = http.StatusOK
+			stubbedCollector.spans = nil
+			stubbedCollector.lock.Unlock()
+
+			tracing.StartSpan("some-other-span", tracing.SpanContext{}, nil).End()
+			Expect(tracing.Flush()).To(Succeed())
+
+			spans := stubbedCollector.exported()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("some-span"))
+			Expect(spans[1].Name).To(Equal("some-other-span"))
+		})
+	})
+
+	It("rejects an invalid endpoint", func() {
+		config.OTLPEndpoint = "not-a-url"
+		Expect(tracing.Initialize(logger, config, "")).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("SpanContext", func() {
+	It("round-trips through a traceparent header", func() {
+		context := tracing.NewSpanContext()
+
+		parsed, ok := tracing.ParseTraceparent(context.Traceparent())
+		Expect(ok).To(BeTrue())
+		Expect(parsed).To(Equal(context))
+	})
+
+	It("does not parse malformed traceparents", func() {
+		_, ok := tracing.ParseTraceparent("00-nope-01")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("has no traceparent when empty", func() {
+		Expect(tracing.SpanContext{}.Traceparent()).To(BeEmpty())
+	})
+})
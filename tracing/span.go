@@ -0,0 +1,187 @@
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// SpanContext identifies a span and the trace it belongs to. The zero value
+// is no span; spans started with it as their parent begin a new trace.
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+var traceparentRegexp = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// ParseTraceparent parses a W3C Trace Context traceparent header value.
+func ParseTraceparent(traceparent string) (SpanContext, bool) {
+	matches := traceparentRegexp.FindStringSubmatch(traceparent)
+	if matches == nil {
+		return SpanContext{}, false
+	}
+
+	return SpanContext{TraceID: matches[1], SpanID: matches[2]}, true
+}
+
+// NewSpanContext generates the context of a span in a new trace.
+func NewSpanContext() SpanContext {
+	return SpanContext{TraceID: randomID(16), SpanID: randomID(8)}
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// Traceparent formats the context as a W3C Trace Context traceparent header
+// value, or returns an empty string if it is not valid.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+type SpanKind int
+
+// Span kinds, as numbered by OTLP.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+)
+
+// Span times an operation. Spans are only recorded while tracing is
+// configured; otherwise they carry their parent's context so that it is
+// still propagated.
+type Span struct {
+	name     string
+	kind     SpanKind
+	context  SpanContext
+	parentID string
+	start    time.Time
+
+	lock       sync.Mutex
+	end        time.Time
+	attributes map[string]string
+	err        error
+	recording  bool
+	ended      bool
+}
+
+// StartSpan starts a span as a child of the given parent.
+func StartSpan(name string, parent SpanContext, attributes map[string]string) *Span {
+	return startSpan(name, SpanKindInternal, parent, attributes)
+}
+
+// StartServerSpan starts a span for a request received by the ATC.
+func StartServerSpan(name string, parent SpanContext, attributes map[string]string) *Span {
+	return startSpan(name, SpanKindServer, parent, attributes)
+}
+
+// ResumeSpan starts a span in the trace of a context generated up front, e.g.
+// persisted before an operation which may be resumed by another ATC, so that
+// each ATC's part of the operation is a span of the same trace. The span has
+// no parent. If the context is not valid, e.g. because the operation began
+// before tracing was configured, the span starts a new trace.
+func ResumeSpan(name string, context SpanContext, attributes map[string]string) *Span {
+	if !Configured() {
+		return &Span{context: context}
+	}
+
+	resumed := NewSpanContext()
+	if context.IsValid() {
+		resumed.TraceID = context.TraceID
+	}
+
+	return newSpan(name, SpanKindInternal, resumed, "", attributes)
+}
+
+func startSpan(name string, kind SpanKind, parent SpanContext, attributes map[string]string) *Span {
+	if !Configured() {
+		return &Span{context: parent}
+	}
+
+	context := NewSpanContext()
+	if parent.IsValid() {
+		context.TraceID = parent.TraceID
+	}
+
+	return newSpan(name, kind, context, parent.SpanID, attributes)
+}
+
+func newSpan(name string, kind SpanKind, context SpanContext, parentID string, attributes map[string]string) *Span {
+	copied := map[string]string{}
+	for k, v := range attributes {
+		copied[k] = v
+	}
+
+	return &Span{
+		name:       name,
+		kind:       kind,
+		context:    context,
+		parentID:   parentID,
+		start:      time.Now(),
+		attributes: copied,
+		recording:  true,
+	}
+}
+
+// Context returns the span's context, for starting child spans.
+func (span *Span) Context() SpanContext {
+	return span.context
+}
+
+func (span *Span) SetAttribute(key string, value string) {
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	if span.recording {
+		span.attributes[key] = value
+	}
+}
+
+// RecordError marks the span as failed if err is not nil.
+func (span *Span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+
+	span.lock.Lock()
+	defer span.lock.Unlock()
+
+	span.err = err
+}
+
+// End ends the span and hands it to the exporter. Only the first call has any
+// effect.
+func (span *Span) End() {
+	span.lock.Lock()
+
+	if !span.recording || span.ended {
+		span.lock.Unlock()
+		return
+	}
+
+	span.ended = true
+	span.end = time.Now()
+
+	span.lock.Unlock()
+
+	export(span)
+}
+
+func randomID(size int) string {
+	id := make([]byte, size)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		panic("failed to generate span id: " + err.Error())
+	}
+
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type Config struct {
+	OTLPEndpoint string `long:"tracing-otlp-endpoint" description:"URL of an OTLP/HTTP collector to export spans to, e.g. http://127.0.0.1:4318. If not specified, tracing is disabled."`
+	ServiceName  string `long:"tracing-service-name" default:"concourse-atc" description:"Service name to attach to exported spans."`
+
+	BatchSize     int           `long:"tracing-batch-size"     default:"512" description:"Number of ended spans to batch before exporting them."`
+	FlushInterval time.Duration `long:"tracing-flush-interval" default:"5s"  description:"Interval on which to export batched spans."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.OTLPEndpoint != ""
+}
+
+var exporter *OTLPExporter
+
+// Initialize configures the exporter that spans are sent to once ended. If
+// tracing is not configured, spans are not recorded.
+func Initialize(logger lager.Logger, config Config, host string) error {
+	if !config.IsConfigured() {
+		exporter = nil
+		return nil
+	}
+
+	endpoint, err := url.Parse(config.OTLPEndpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return fmt.Errorf("invalid tracing endpoint: %s", config.OTLPEndpoint)
+	}
+
+	if config.BatchSize <= 0 {
+		return fmt.Errorf("tracing batch size must be greater than 0, got %d", config.BatchSize)
+	}
+
+	exporter = NewOTLPExporter(logger, endpoint, config.ServiceName, host, config.BatchSize)
+
+	if config.FlushInterval > 0 {
+		go exporter.flushPeriodically(config.FlushInterval)
+	}
+
+	return nil
+}
+
+func Configured() bool {
+	return exporter != nil
+}
+
+// Flush exports any batched spans.
+func Flush() error {
+	if exporter == nil {
+		return nil
+	}
+
+	return exporter.Flush()
+}
+
+func export(span *Span) {
+	if exporter == nil {
+		return
+	}
+
+	exporter.Export(span)
+}
@@ -3,6 +3,7 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 	"time"
@@ -50,6 +51,15 @@ func (c Config) Validate() ([]Warning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
+	serialGroupsErr := c.SerialGroups.Validate()
+	if serialGroupsErr != nil {
+		errorMessages = append(errorMessages, formatErr("serial groups", serialGroupsErr))
+	}
+
+	if c.AdmissionWeight < 0 {
+		errorMessages = append(errorMessages, formatErr("admission weight", errors.New("admission_weight must not be negative")))
+	}
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -112,6 +122,14 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.CheckEvery != CheckEveryNever && strings.EqualFold(resource.CheckEvery, CheckEveryNever) {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has a check_every of '%s', which must be written as '%s'", resource.CheckEvery, CheckEveryNever))
+		}
+
+		if resource.Webhook != nil {
+			errorMessages = append(errorMessages, validateWebhook(identifier, *resource.Webhook)...)
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -119,6 +137,30 @@ func validateResources(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+func validateWebhook(identifier string, webhook WebhookConfig) []string {
+	errorMessages := []string{}
+
+	known := false
+	for _, provider := range WebhookProviders {
+		if webhook.Provider == provider {
+			known = true
+		}
+	}
+
+	if !known {
+		errorMessages = append(errorMessages, fmt.Sprintf(
+			"%s has an unknown webhook provider '%s' (must be one of: %s)",
+			identifier, webhook.Provider, strings.Join(WebhookProviders, ", "),
+		))
+	}
+
+	if webhook.Secret == "" {
+		errorMessages = append(errorMessages, identifier+" has a webhook with no secret")
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c Config) error {
 	errorMessages := []string{}
 
@@ -319,11 +361,40 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.Get != "" && plan.Artifact:
+		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "version", "params", "privileged", "config", "file", "skip_if_unchanged", "cache_key", "artifacts", "test_reports"},
+			plan, identifier)...,
+		)
+
+		if len(plan.Passed) != 1 {
+			errorMessages = append(
+				errorMessages,
+				identifier+" gets an artifact but does not specify exactly one job in passed",
+			)
+		}
+
+		for _, job := range plan.Passed {
+			_, found := c.Jobs.Lookup(job)
+			if !found {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf(
+						"%s.passed references an unknown job ('%s')",
+						identifier,
+						job,
+					),
+				)
+			}
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "skip_if_unchanged", "cache_key", "artifacts", "test_reports"},
 			plan, identifier)...,
 		)
 
@@ -398,7 +469,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "artifact", "privileged", "config", "file", "skip_if_unchanged", "cache_key", "artifacts", "test_reports"},
 			plan, identifier)...,
 		)
 
@@ -445,8 +516,20 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			warnings = append(warnings, newDeprecationWarning(identifier+" specifies both `file` and `config` in a task step"))
 		}
 
+		if plan.CacheKey != "" && !plan.SkipIfUnchanged {
+			errorMessages = append(errorMessages, identifier+" specifies a cache_key but does not enable skip_if_unchanged")
+		}
+
+		if plan.TestReports != nil {
+			if plan.TestReports.Output == "" || plan.TestReports.Glob == "" {
+				errorMessages = append(errorMessages, identifier+".test_reports must specify both an output and a glob")
+			} else if _, err := path.Match(plan.TestReports.Glob, "report.xml"); err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".test_reports has an invalid glob ('%s')", plan.TestReports.Glob))
+			}
+		}
+
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "artifact"},
 			plan, identifier)...,
 		)
 
@@ -512,6 +595,18 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "artifact":
+			if plan.Artifact {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "version":
+			if plan.Version != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "params":
+			if plan.Params != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "privileged":
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -524,6 +619,22 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "skip_if_unchanged":
+			if plan.SkipIfUnchanged {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "cache_key":
+			if plan.CacheKey != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "artifacts":
+			if len(plan.Artifacts) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "test_reports":
+			if plan.TestReports != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
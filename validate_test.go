@@ -168,6 +168,57 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource's webhook has an unknown provider and no secret", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: "bogus",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an unknown webhook provider 'bogus' (must be one of: github, gitlab, bitbucket)"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a webhook with no secret"))
+			})
+		})
+
+		Context("when a resource's webhook is valid", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Provider: WebhookProviderGitHub,
+					Secret:   "some-secret",
+					Refs:     []string{"master"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
+		Context("when a resource's check_every is 'never' in the wrong case", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "Never"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has a check_every of 'Never', which must be written as 'never'"))
+			})
+		})
+
+		Context("when a resource is never checked", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = CheckEveryNever
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -390,6 +441,59 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid serial groups", func() {
+		Context("when a serial group has no name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = SerialGroupConfigs{{Size: 2}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] has no name"))
+			})
+		})
+
+		Context("when a serial group has a size less than 1", func() {
+			BeforeEach(func() {
+				config.SerialGroups = SerialGroupConfigs{{Name: "staging", Size: 0}}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups.staging must have a size of at least 1"))
+			})
+		})
+
+		Context("when two serial groups have the same name", func() {
+			BeforeEach(func() {
+				config.SerialGroups = SerialGroupConfigs{
+					{Name: "staging", Size: 2},
+					{Name: "staging", Size: 3},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid serial groups:"))
+				Expect(errorMessages[0]).To(ContainSubstring("serial_groups[0] and serial_groups[1] have the same name ('staging')"))
+			})
+		})
+	})
+
+	Describe("invalid admission weight", func() {
+		BeforeEach(func() {
+			config.AdmissionWeight = -1
+		})
+
+		It("returns an error", func() {
+			Expect(errorMessages).To(HaveLen(1))
+			Expect(errorMessages[0]).To(ContainSubstring("invalid admission weight:"))
+			Expect(errorMessages[0]).To(ContainSubstring("admission_weight must not be negative"))
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -582,6 +686,78 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a get plan fetches an artifact from a job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "some-binary",
+						Artifact: true,
+						Passed:   []string{"some-job"},
+						Trigger:  true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a get plan fetches an artifact without specifying a job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "some-binary",
+						Artifact: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-binary gets an artifact but does not specify exactly one job in passed"))
+				})
+			})
+
+			Context("when a get plan fetches an artifact from an unknown job", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "some-binary",
+						Artifact: true,
+						Passed:   []string{"bogus-job"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-binary.passed references an unknown job ('bogus-job')"))
+				})
+			})
+
+			Context("when a get plan fetches an artifact with resource-only fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:      "some-binary",
+						Artifact: true,
+						Passed:   []string{"some-job"},
+						Resource: "some-resource",
+						Params:   Params{"some": "param"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-binary has invalid fields specified (resource, params)"))
+				})
+			})
+
 			Context("when a task plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -642,6 +818,60 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has a cache key without skip_if_unchanged", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						CacheKey:       "v2",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol specifies a cache_key but does not enable skip_if_unchanged"))
+				})
+			})
+
+			Context("when a task plan has test reports without a glob", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						TestReports:    &TestReportsConfig{Output: "reports"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.test_reports must specify both an output and a glob"))
+				})
+			})
+
+			Context("when a task plan has test reports with an invalid glob", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "lol",
+						TaskConfigPath: "task.yml",
+						TestReports:    &TestReportsConfig{Output: "reports", Glob: "[*.xml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.lol.test_reports has an invalid glob ('[*.xml')"))
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -650,6 +880,7 @@ var _ = Describe("ValidateConfig", func() {
 						Trigger:        true,
 						Privileged:     true,
 						TaskConfigPath: "btaskyml",
+						Artifacts:      []string{"some-reports"},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -658,7 +889,7 @@ var _ = Describe("ValidateConfig", func() {
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
 					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (passed, trigger, privileged, file)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.lol has invalid fields specified (passed, trigger, privileged, file, artifacts)"))
 				})
 			})
 
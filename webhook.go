@@ -0,0 +1,30 @@
+package atc
+
+const (
+	// WebhookProviderGitHub verifies the HMAC-SHA256 signature in the
+	// X-Hub-Signature-256 header, or the HMAC-SHA1 in X-Hub-Signature.
+	WebhookProviderGitHub = "github"
+
+	// WebhookProviderGitLab compares the X-Gitlab-Token header to the secret.
+	WebhookProviderGitLab = "gitlab"
+
+	// WebhookProviderBitbucket verifies the HMAC-SHA256 signature in the
+	// X-Hub-Signature header.
+	WebhookProviderBitbucket = "bitbucket"
+)
+
+var WebhookProviders = []string{
+	WebhookProviderGitHub,
+	WebhookProviderGitLab,
+	WebhookProviderBitbucket,
+}
+
+type WebhookConfig struct {
+	Provider string `yaml:"provider" json:"provider" mapstructure:"provider"`
+	Secret   string `yaml:"secret" json:"secret" mapstructure:"secret"`
+
+	// Refs narrows checks to pushes to the given branches or tags, e.g.
+	// "master", "release/*" or "refs/tags/*". Payloads without a ref, such as
+	// pings, always trigger a check.
+	Refs []string `yaml:"refs,omitempty" json:"refs,omitempty" mapstructure:"refs"`
+}
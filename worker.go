@@ -17,6 +17,25 @@ type Worker struct {
 	ActiveContainers int `json:"active_containers"`
 	ActiveVolumes    int `json:"active_volumes"`
 
+	// Capacity is the total CPU and memory the worker can allocate to
+	// containers. Workers which do not report it are never considered full.
+	Capacity *WorkerResources `json:"capacity,omitempty"`
+
+	// Allocated is the CPU and memory currently allocated to the worker's
+	// containers.
+	Allocated *WorkerResources `json:"allocated,omitempty"`
+
+	// Disk is the space used by and available to the worker's volumes. Workers
+	// which do not report it are never considered to be under disk pressure.
+	Disk *WorkerDisk `json:"disk,omitempty"`
+
+	// Drain is what a landing or retiring worker is still waiting on.
+	Drain *WorkerDrain `json:"drain,omitempty"`
+
+	// HealthCheckFailures is the number of consecutive health checks of the
+	// worker's Garden and Baggageclaim servers which have failed.
+	HealthCheckFailures int `json:"health_check_failures,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -43,6 +62,52 @@ func (w Worker) Validate() error {
 	return nil
 }
 
+// WorkerResources is an amount of CPU, in millicores, and memory, in bytes.
+type WorkerResources struct {
+	CPU    int64 `json:"cpu"`
+	Memory int64 `json:"memory"`
+}
+
+// WorkerDisk is the used and total size, in bytes, of the disk backing a
+// worker's volumes.
+type WorkerDisk struct {
+	Used  int64 `json:"used"`
+	Total int64 `json:"total"`
+}
+
+// UsedPercent is the percentage of the disk that is in use, rounded down.
+func (disk WorkerDisk) UsedPercent() int {
+	if disk.Total <= 0 {
+		return 0
+	}
+
+	return int(disk.Used * 100 / disk.Total)
+}
+
+// WorkerDrain is the progress of a landing or retiring worker.
+type WorkerDrain struct {
+	RemainingContainers int `json:"remaining_containers"`
+	RemainingBuilds     int `json:"remaining_builds"`
+
+	// LandingStartedAt is when a landing worker started landing, in seconds
+	// since the epoch.
+	LandingStartedAt int64 `json:"landing_started_at,omitempty"`
+}
+
+// WorkerHealthCheck is the result of pinging a worker's Garden and
+// Baggageclaim servers. Latencies are in milliseconds.
+type WorkerHealthCheck struct {
+	CheckedAt           int64  `json:"checked_at"`
+	GardenLatency       int64  `json:"garden_latency"`
+	BaggageclaimLatency int64  `json:"baggageclaim_latency"`
+	Error               string `json:"error,omitempty"`
+}
+
+// Healthy is true if both servers responded.
+func (check WorkerHealthCheck) Healthy() bool {
+	return check.Error == ""
+}
+
 type WorkerResourceType struct {
 	Type       string `json:"type"`
 	Image      string `json:"image"`
@@ -0,0 +1,254 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+)
+
+// CapacityReservationTTL is how long capacity reserved for a container is
+// counted against a worker in addition to the allocation it reports. This
+// covers the time between placing the container and the worker's heartbeats
+// including it.
+const CapacityReservationTTL = time.Minute
+
+var ErrContainerLimitsExceedWorkerCapacity = errors.New("container limits exceed the capacity of every compatible worker")
+
+// NoWorkerCapacityError is returned when none of the workers compatible with a
+// container currently has enough unallocated capacity for its limits. The
+// container may be placed once capacity frees up.
+type NoWorkerCapacityError struct {
+	Spec   WorkerSpec
+	Limits atc.ContainerLimits
+}
+
+func (err NoWorkerCapacityError) Error() string {
+	return fmt.Sprintf(
+		"no workers satisfying: %s have %d millicores of CPU and %d bytes of memory available",
+		err.Spec.Description(),
+		err.Limits.CPU,
+		err.Limits.Memory,
+	)
+}
+
+// CapacityWaiterMaxDeferral is how long containers of a lower priority defer
+// to a container waiting for capacity, however often it retries. This stops a
+// container which is too large for the capacity that frees up from starving
+// everything below it.
+const CapacityWaiterMaxDeferral = 5 * time.Minute
+
+type capacityReservation struct {
+	waiter    string
+	limits    atc.ContainerLimits
+	expiresAt time.Time
+}
+
+// capacityWaiter is a container recently turned away for lack of capacity.
+type capacityWaiter struct {
+	priority int
+
+	// workers are the names of the workers it would fit on
+	workers map[string]bool
+
+	since     time.Time
+	expiresAt time.Time
+}
+
+// capacityReservations tracks the capacity recently reserved on each worker,
+// so that containers placed concurrently, or before the worker has next
+// heartbeated, do not overcommit it.
+//
+// It also tracks the containers recently turned away for lack of capacity, so
+// that capacity freeing up on the workers they would fit on goes to them
+// before containers of a lower priority.
+//
+// Both are held only in the memory of this ATC. Other ATCs placing containers
+// on the same workers do not see them, so with several ATCs a worker may be
+// overcommitted, and a waiting container passed over, until the worker next
+// heartbeats its allocation.
+type capacityReservations struct {
+	clock clock.Clock
+
+	lock         sync.Mutex
+	reservations map[string][]capacityReservation
+	waiting      map[string]capacityWaiter
+}
+
+func newCapacityReservations(clock clock.Clock) *capacityReservations {
+	return &capacityReservations{
+		clock:        clock,
+		reservations: map[string][]capacityReservation{},
+		waiting:      map[string]capacityWaiter{},
+	}
+}
+
+// Reserve chooses, using the given strategy, one of the workers with room for
+// the container's limits, and reserves them on it. The waiter identifies the
+// container across retries while it waits for capacity.
+func (r *capacityReservations) Reserve(
+	logger lager.Logger,
+	strategy ContainerPlacementStrategy,
+	workers []Worker,
+	spec ContainerSpec,
+	waiter string,
+) (Worker, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.clock.Now()
+
+	r.pruneWaiting(now)
+
+	fittingWorkers := map[string]bool{}
+	availableWorkers := []Worker{}
+	for _, w := range workers {
+		capacity := w.Capacity()
+		if capacity == nil {
+			fittingWorkers[w.Name()] = true
+			availableWorkers = append(availableWorkers, w)
+			continue
+		}
+
+		if !fits(spec.Limits, *capacity) {
+			continue
+		}
+
+		fittingWorkers[w.Name()] = true
+
+		if fits(spec.Limits, r.available(w, now)) {
+			availableWorkers = append(availableWorkers, w)
+		}
+	}
+
+	if len(fittingWorkers) == 0 {
+		return nil, ErrContainerLimitsExceedWorkerCapacity
+	}
+
+	unclaimedWorkers := []Worker{}
+	for _, w := range availableWorkers {
+		if !r.higherPriorityWaiting(w.Name(), spec.Priority, now) {
+			unclaimedWorkers = append(unclaimedWorkers, w)
+		}
+	}
+
+	if len(unclaimedWorkers) == 0 {
+		if len(availableWorkers) == 0 {
+			logger.Info("no-worker-capacity", lager.Data{"limits": spec.Limits})
+		} else {
+			logger.Info("deferring-to-higher-priority", lager.Data{"priority": spec.Priority})
+		}
+
+		since := now
+		if existing, found := r.waiting[waiter]; found {
+			since = existing.since
+		}
+
+		r.waiting[waiter] = capacityWaiter{
+			priority:  spec.Priority,
+			workers:   fittingWorkers,
+			since:     since,
+			expiresAt: now.Add(CapacityReservationTTL),
+		}
+
+		return nil, NoWorkerCapacityError{
+			Spec:   spec.WorkerSpec(),
+			Limits: spec.Limits,
+		}
+	}
+
+	chosen, err := strategy.Choose(logger, unclaimedWorkers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	r.reservations[chosen.Name()] = append(r.reservations[chosen.Name()], capacityReservation{
+		waiter:    waiter,
+		limits:    spec.Limits,
+		expiresAt: now.Add(CapacityReservationTTL),
+	})
+
+	delete(r.waiting, waiter)
+
+	return chosen, nil
+}
+
+// Release gives back the capacity reserved on the worker for the container,
+// for when it could not be placed there after all.
+func (r *capacityReservations) Release(workerName string, waiter string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	remaining := []capacityReservation{}
+	for _, reservation := range r.reservations[workerName] {
+		if reservation.waiter != waiter {
+			remaining = append(remaining, reservation)
+		}
+	}
+
+	if len(remaining) == 0 {
+		delete(r.reservations, workerName)
+	} else {
+		r.reservations[workerName] = remaining
+	}
+}
+
+// pruneWaiting forgets the containers which have stopped retrying.
+func (r *capacityReservations) pruneWaiting(now time.Time) {
+	for waiter, w := range r.waiting {
+		if !now.Before(w.expiresAt) {
+			delete(r.waiting, waiter)
+		}
+	}
+}
+
+// higherPriorityWaiting returns whether a container of a higher priority than
+// the given one, which would fit on the named worker, is waiting for capacity
+// and has not yet been deferred to for CapacityWaiterMaxDeferral.
+func (r *capacityReservations) higherPriorityWaiting(workerName string, priority int, now time.Time) bool {
+	for _, w := range r.waiting {
+		if w.priority > priority && w.workers[workerName] && now.Before(w.since.Add(CapacityWaiterMaxDeferral)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// available returns the worker's capacity less its reported allocation and
+// any unexpired reservations, pruning the expired ones.
+func (r *capacityReservations) available(w Worker, now time.Time) atc.WorkerResources {
+	available := *w.Capacity()
+
+	allocated := w.Allocated()
+	available.CPU -= allocated.CPU
+	available.Memory -= allocated.Memory
+
+	unexpired := []capacityReservation{}
+	for _, reservation := range r.reservations[w.Name()] {
+		if !now.Before(reservation.expiresAt) {
+			continue
+		}
+
+		unexpired = append(unexpired, reservation)
+
+		available.CPU -= reservation.limits.CPU
+		available.Memory -= reservation.limits.Memory
+	}
+
+	if len(unexpired) == 0 {
+		delete(r.reservations, w.Name())
+	} else {
+		r.reservations[w.Name()] = unexpired
+	}
+
+	return available
+}
+
+func fits(limits atc.ContainerLimits, resources atc.WorkerResources) bool {
+	return limits.CPU <= resources.CPU && limits.Memory <= resources.Memory
+}
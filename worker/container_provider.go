@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/tracing"
 	"github.com/concourse/baggageclaim"
 )
 
@@ -444,24 +445,22 @@ func (p *containerProvider) findOrCreateContainer(
 
 			defer lock.Release()
 
-			logger.Debug("fetching-image")
-
-			fetchedImage, err := image.FetchForContainer(logger, creatingContainer)
-			if err != nil {
-				logger.Error("failed-to-fetch-image-for-container", err)
-				return nil, err
-			}
-
-			logger.Debug("creating-container-in-garden")
+			createSpan := tracing.StartSpan("container-create", spec.Trace, map[string]string{
+				"worker": p.worker.Name(),
+				"handle": creatingContainer.Handle(),
+			})
 
-			gardenContainer, err = p.createGardenContainer(
+			gardenContainer, err = p.fetchImageAndCreateGardenContainer(
 				logger,
+				createSpan.Context(),
 				creatingContainer,
 				spec,
-				fetchedImage,
+				image,
 			)
+			createSpan.RecordError(err)
+			createSpan.End()
+
 			if err != nil {
-				logger.Error("failed-to-create-container-in-garden", err)
 				return nil, err
 			}
 
@@ -484,6 +483,43 @@ func (p *containerProvider) findOrCreateContainer(
 	}
 }
 
+func (p *containerProvider) fetchImageAndCreateGardenContainer(
+	logger lager.Logger,
+	trace tracing.SpanContext,
+	creatingContainer dbng.CreatingContainer,
+	spec ContainerSpec,
+	image Image,
+) (garden.Container, error) {
+	logger.Debug("fetching-image")
+
+	imageSpan := tracing.StartSpan("image-fetch", trace, nil)
+
+	fetchedImage, err := image.FetchForContainer(logger, creatingContainer)
+	imageSpan.RecordError(err)
+	imageSpan.End()
+
+	if err != nil {
+		logger.Error("failed-to-fetch-image-for-container", err)
+		return nil, err
+	}
+
+	logger.Debug("creating-container-in-garden")
+
+	gardenContainer, err := p.createGardenContainer(
+		logger,
+		trace,
+		creatingContainer,
+		spec,
+		fetchedImage,
+	)
+	if err != nil {
+		logger.Error("failed-to-create-container-in-garden", err)
+		return nil, err
+	}
+
+	return gardenContainer, nil
+}
+
 func (p *containerProvider) constructGardenWorkerContainer(
 	logger lager.Logger,
 	createdContainer dbng.CreatedContainer,
@@ -509,6 +545,7 @@ func (p *containerProvider) constructGardenWorkerContainer(
 
 func (p *containerProvider) createGardenContainer(
 	logger lager.Logger,
+	trace tracing.SpanContext,
 	creatingContainer dbng.CreatingContainer,
 	spec ContainerSpec,
 	fetchedImage FetchedImage,
@@ -593,7 +630,14 @@ func (p *containerProvider) createGardenContainer(
 				return nil, err
 			}
 
+			streamSpan := tracing.StartSpan("stream", trace, map[string]string{
+				"destination": inputSource.DestinationPath(),
+			})
+
 			err = inputSource.Source().StreamTo(inputVolume)
+			streamSpan.RecordError(err)
+			streamSpan.End()
+
 			if err != nil {
 				return nil, err
 			}
@@ -671,9 +715,23 @@ func (p *containerProvider) createGardenContainer(
 		BindMounts: bindMounts,
 		Env:        env,
 		Properties: gardenProperties,
+		Limits:     gardenLimits(spec.Limits),
 	})
 }
 
+// gardenLimits converts container limits to their Garden equivalent, where
+// 1024 CPU shares are taken to be one core.
+func gardenLimits(limits atc.ContainerLimits) garden.Limits {
+	return garden.Limits{
+		CPU: garden.CPULimits{
+			LimitInShares: uint64(limits.CPU * 1024 / 1000),
+		},
+		Memory: garden.MemoryLimits{
+			LimitInBytes: uint64(limits.Memory),
+		},
+	}
+}
+
 func (p *containerProvider) anyMountTo(path string, inputs []InputSource) bool {
 	for _, input := range inputs {
 		if input.DestinationPath() == path {
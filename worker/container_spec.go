@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/tracing"
 )
 
 type WorkerSpec struct {
@@ -37,6 +38,19 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional CPU and memory limits for the container. The container is only
+	// placed on a worker with enough unallocated capacity to satisfy them.
+	Limits atc.ContainerLimits
+
+	// Priority of the build the container is for. When worker capacity is
+	// short, containers with limits are placed in order of priority.
+	Priority int
+
+	// Optional span context of the step the container is for. Creating the
+	// container, fetching its image and streaming its inputs are traced
+	// beneath it.
+	Trace tracing.SpanContext
 }
 
 // OutputPaths is a mapping from output name to its path in the container.
@@ -49,6 +63,10 @@ type ImageSpec struct {
 	ImageArtifactSource ArtifactSource
 	ImageArtifactName   ArtifactName
 	Privileged          bool
+
+	// The version of ImageResource to fetch. If nil, the latest version is
+	// checked for.
+	ImageResourceVersion atc.Version
 }
 
 func (spec ContainerSpec) WorkerSpec() WorkerSpec {
@@ -30,6 +30,7 @@ var ErrDesiredWorkerNotRunning = errors.New("desired garden worker is not known
 type dbWorkerProvider struct {
 	lockDB                          LockDB
 	retryBackOffFactory             retryhttp.BackOffFactory
+	circuitBreakers                 *transport.CircuitBreakers
 	imageFactory                    ImageFactory
 	dbResourceCacheFactory          dbng.ResourceCacheFactory
 	dbResourceConfigFactory         dbng.ResourceConfigFactory
@@ -43,6 +44,7 @@ type dbWorkerProvider struct {
 func NewDBWorkerProvider(
 	lockDB LockDB,
 	retryBackOffFactory retryhttp.BackOffFactory,
+	circuitBreakers *transport.CircuitBreakers,
 	imageFactory ImageFactory,
 	dbResourceCacheFactory dbng.ResourceCacheFactory,
 	dbResourceConfigFactory dbng.ResourceConfigFactory,
@@ -55,6 +57,7 @@ func NewDBWorkerProvider(
 	return &dbWorkerProvider{
 		lockDB:                          lockDB,
 		retryBackOffFactory:             retryBackOffFactory,
+		circuitBreakers:                 circuitBreakers,
 		imageFactory:                    imageFactory,
 		dbResourceCacheFactory:          dbResourceCacheFactory,
 		dbResourceConfigFactory:         dbResourceConfigFactory,
@@ -182,6 +185,7 @@ func (provider *dbWorkerProvider) newGardenWorker(logger lager.Logger, tikTok cl
 		savedWorker.Name(),
 		savedWorker.GardenAddr(),
 		provider.retryBackOffFactory,
+		provider.circuitBreakers,
 	)
 
 	connection := NewRetryableConnection(gcf.BuildConnection())
@@ -190,10 +194,14 @@ func (provider *dbWorkerProvider) newGardenWorker(logger lager.Logger, tikTok cl
 		savedWorker.Name(),
 		savedWorker.BaggageclaimURL(),
 		provider.dbWorkerFactory,
-		&http.Transport{
-			DisableKeepAlives:     true,
-			ResponseHeaderTimeout: 10 * time.Minute,
-		},
+		transport.NewCircuitBreakerRoundTripper(
+			savedWorker.Name(),
+			provider.circuitBreakers,
+			&http.Transport{
+				DisableKeepAlives:     true,
+				ResponseHeaderTimeout: 10 * time.Minute,
+			},
+		),
 	))
 
 	volumeClient := NewVolumeClient(
@@ -228,6 +236,10 @@ func (provider *dbWorkerProvider) newGardenWorker(logger lager.Logger, tikTok cl
 		provider,
 		tikTok,
 		savedWorker.ActiveContainers(),
+		savedWorker.Capacity(),
+		savedWorker.Allocated(),
+		savedWorker.Disk(),
+		savedWorker.HealthCheckFailures(),
 		savedWorker.ResourceTypes(),
 		savedWorker.Platform(),
 		savedWorker.Tags(),
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
+	"code.cloudfoundry.org/clock"
 	gfakes "code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/garden/server"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -14,6 +16,7 @@ import (
 	"github.com/concourse/atc/dbng"
 	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/atc/worker/workerfakes"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/retryhttp/retryhttpfakes"
@@ -140,6 +143,7 @@ var _ = Describe("DBProvider", func() {
 		provider = NewDBWorkerProvider(
 			fakeLockDB,
 			fakeBackOffFactory,
+			transport.NewCircuitBreakers(logger, clock.NewClock(), 5, time.Minute),
 			fakeImageFactory,
 			fakeDBResourceCacheFactory,
 			fakeDBResourceConfigFactory,
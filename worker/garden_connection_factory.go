@@ -22,6 +22,7 @@ type gardenConnectionFactory struct {
 	workerName          string
 	workerHost          *string
 	retryBackOffFactory retryhttp.BackOffFactory
+	circuitBreakers     *transport.CircuitBreakers
 }
 
 func NewGardenConnectionFactory(
@@ -30,6 +31,7 @@ func NewGardenConnectionFactory(
 	workerName string,
 	workerHost *string,
 	retryBackOffFactory retryhttp.BackOffFactory,
+	circuitBreakers *transport.CircuitBreakers,
 ) GardenConnectionFactory {
 	return &gardenConnectionFactory{
 		db:                  db,
@@ -37,6 +39,7 @@ func NewGardenConnectionFactory(
 		workerName:          workerName,
 		workerHost:          workerHost,
 		retryBackOffFactory: retryBackOffFactory,
+		circuitBreakers:     circuitBreakers,
 	}
 }
 
@@ -49,7 +52,7 @@ func (gcf *gardenConnectionFactory) BuildConnection() gconn.Connection {
 		Transport: &retryhttp.RetryRoundTripper{
 			Logger:         gcf.logger.Session("retryable-http-client"),
 			BackOffFactory: gcf.retryBackOffFactory,
-			RoundTripper:   transport.NewGardenRoundTripper(gcf.workerName, gcf.workerHost, gcf.db, &http.Transport{DisableKeepAlives: true}),
+			RoundTripper:   transport.NewGardenRoundTripper(gcf.workerName, gcf.workerHost, gcf.db, transport.NewCircuitBreakerRoundTripper(gcf.workerName, gcf.circuitBreakers, &http.Transport{DisableKeepAlives: true})),
 			Retryer:        retryer,
 		},
 	}
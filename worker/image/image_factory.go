@@ -68,6 +68,7 @@ func (f *imageFactory) GetImage(
 				resourceUser,
 				resourceType.Type,
 				resourceType.Source,
+				nil,
 				worker.Tags(),
 				teamID,
 				resourceTypes.Without(imageSpec.ResourceType),
@@ -98,6 +99,7 @@ func (f *imageFactory) GetImage(
 			resourceUser,
 			imageSpec.ImageResource.Type,
 			imageSpec.ImageResource.Source,
+			imageSpec.ImageResourceVersion,
 			worker.Tags(),
 			teamID,
 			resourceTypes,
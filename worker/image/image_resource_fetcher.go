@@ -41,6 +41,7 @@ type ImageResourceFetcher interface {
 		resourceUser dbng.ResourceUser,
 		imageResourceType string,
 		imageResourceSource atc.Source,
+		imageResourceVersion atc.Version,
 		tags atc.Tags,
 		teamID int,
 		customTypes atc.VersionedResourceTypes,
@@ -97,16 +98,21 @@ func (i *imageResourceFetcher) Fetch(
 	resourceUser dbng.ResourceUser,
 	imageResourceType string,
 	imageResourceSource atc.Source,
+	imageResourceVersion atc.Version,
 	tags atc.Tags,
 	teamID int,
 	customTypes atc.VersionedResourceTypes,
 	imageFetchingDelegate worker.ImageFetchingDelegate,
 	privileged bool,
 ) (worker.Volume, io.ReadCloser, atc.Version, error) {
-	version, err := i.getLatestVersion(logger, signals, resourceUser, imageResourceType, imageResourceSource, tags, teamID, customTypes, imageFetchingDelegate)
-	if err != nil {
-		logger.Error("failed-to-get-latest-image-version", err)
-		return nil, nil, nil, err
+	version := imageResourceVersion
+	if version == nil {
+		var err error
+		version, err = i.getLatestVersion(logger, signals, resourceUser, imageResourceType, imageResourceSource, tags, teamID, customTypes, imageFetchingDelegate)
+		if err != nil {
+			logger.Error("failed-to-get-latest-image-version", err)
+			return nil, nil, nil, err
+		}
 	}
 
 	resourceInstance := resource.NewResourceInstance(
@@ -119,7 +125,7 @@ func (i *imageResourceFetcher) Fetch(
 		i.dbResourceCacheFactory,
 	)
 
-	err = imageFetchingDelegate.ImageVersionDetermined(
+	err := imageFetchingDelegate.ImageVersionDetermined(
 		resourceInstance.ResourceCacheIdentifier(),
 	)
 	if err != nil {
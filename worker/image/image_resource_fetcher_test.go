@@ -50,6 +50,7 @@ var _ = Describe("Image", func() {
 	var fakeClock *fakeclock.FakeClock
 	var customTypes atc.VersionedResourceTypes
 	var privileged bool
+	var imageResourceVersion atc.Version
 
 	var fetchedVolume worker.Volume
 	var fetchedMetadataReader io.ReadCloser
@@ -79,6 +80,7 @@ var _ = Describe("Image", func() {
 		fakeImageFetchingDelegate.StderrReturns(stderrBuf)
 		fakeWorker = new(workerfakes.FakeWorker)
 		teamID = 123
+		imageResourceVersion = nil
 
 		customTypes = atc.VersionedResourceTypes{
 			{
@@ -117,6 +119,7 @@ var _ = Describe("Image", func() {
 			dbng.ForBuild(42),
 			imageResource.Type,
 			imageResource.Source,
+			imageResourceVersion,
 			atc.Tags{"worker", "tags"},
 			teamID,
 			customTypes,
@@ -125,6 +128,30 @@ var _ = Describe("Image", func() {
 		)
 	})
 
+	Context("when the version of the image resource is given", func() {
+		BeforeEach(func() {
+			imageResourceVersion = atc.Version{"v": "2"}
+
+			fakeVersionedSource := new(resourcefakes.FakeVersionedSource)
+			fakeVersionedSource.StreamOutReturns(tarStreamWith("some-tar-contents"), nil)
+			fakeVersionedSource.VolumeReturns(new(workerfakes.FakeVolume))
+			fakeResourceFetcher.FetchReturns(fakeVersionedSource, nil)
+		})
+
+		It("does not check for the latest version", func() {
+			Expect(fakeResourceConfigFactory.AcquireResourceCheckingLockCallCount()).To(BeZero())
+			Expect(fakeResourceFactory.NewCheckResourceCallCount()).To(BeZero())
+		})
+
+		It("fetches the given version", func() {
+			Expect(fetchErr).NotTo(HaveOccurred())
+			Expect(fetchedVersion).To(Equal(atc.Version{"v": "2"}))
+
+			_, _, _, _, _, resourceInstance, _, _, _, _, _ := fakeResourceFetcher.FetchArgsForCall(0)
+			Expect(resourceInstance.ResourceCacheIdentifier().ResourceVersion).To(Equal(atc.Version{"v": "2"}))
+		})
+	})
+
 	Context("when acquiring resource checking lock succeeds", func() {
 		BeforeEach(func() {
 			fakeLock := new(lockfakes.FakeLock)
@@ -236,7 +236,8 @@ var _ = Describe("Image", func() {
 							Type:   "some-image-resource-type",
 							Source: atc.Source{"some": "source"},
 						},
-						Privileged: true,
+						ImageResourceVersion: atc.Version{"some": "version"},
+						Privileged:           true,
 					},
 					42,
 					nil,
@@ -247,6 +248,13 @@ var _ = Describe("Image", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
+			It("fetches the given version of the image resource", func() {
+				_, _, _, resourceType, resourceSource, resourceVersion, _, _, _, _, _ := fakeImageResourceFetcher.FetchArgsForCall(0)
+				Expect(resourceType).To(Equal("some-image-resource-type"))
+				Expect(resourceSource).To(Equal(atc.Source{"some": "source"}))
+				Expect(resourceVersion).To(Equal(atc.Version{"some": "version"}))
+			})
+
 			It("finds or creates cow volume", func() {
 				_, err := img.FetchForContainer(logger, fakeContainer)
 				Expect(err).NotTo(HaveOccurred())
@@ -320,11 +328,12 @@ var _ = Describe("Image", func() {
 			})
 
 			It("fetches unprivileged image without custom resource type", func() {
-				_, _, _, resourceType, resourceTypeSource, workerTags, teamID, resourceTypes, delegate, privileged := fakeImageResourceFetcher.FetchArgsForCall(0)
+				_, _, _, resourceType, resourceTypeSource, resourceTypeVersion, workerTags, teamID, resourceTypes, delegate, privileged := fakeImageResourceFetcher.FetchArgsForCall(0)
 				Expect(resourceType).To(Equal("some-base-resource-type"))
 				Expect(resourceTypeSource).To(Equal(atc.Source{
 					"some": "custom-resource-type-source",
 				}))
+				Expect(resourceTypeVersion).To(BeNil())
 				Expect(workerTags).To(Equal(atc.Tags{"worker", "tags"}))
 				Expect(teamID).To(Equal(42))
 				Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{
@@ -417,11 +426,12 @@ var _ = Describe("Image", func() {
 			})
 
 			It("fetches image without custom resource type", func() {
-				_, _, _, resourceType, resourceTypeSource, workerTags, teamID, resourceTypes, delegate, privileged := fakeImageResourceFetcher.FetchArgsForCall(0)
+				_, _, _, resourceType, resourceTypeSource, resourceTypeVersion, workerTags, teamID, resourceTypes, delegate, privileged := fakeImageResourceFetcher.FetchArgsForCall(0)
 				Expect(resourceType).To(Equal("some-base-image-resource-type"))
 				Expect(resourceTypeSource).To(Equal(atc.Source{
 					"some": "custom-image-resource-type-source",
 				}))
+				Expect(resourceTypeVersion).To(BeNil())
 				Expect(workerTags).To(Equal(atc.Tags{"worker", "tags"}))
 				Expect(teamID).To(Equal(42))
 				Expect(resourceTypes).To(Equal(atc.VersionedResourceTypes{
@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	VolumeLocalityPlacementStrategy         = "volume-locality"
+	FewestActiveContainersPlacementStrategy = "fewest-containers"
+	RandomPlacementStrategy                 = "random"
+)
+
+// ContainerPlacementStrategies lists the names accepted by
+// NewContainerPlacementStrategy.
+var ContainerPlacementStrategies = []string{
+	VolumeLocalityPlacementStrategy,
+	FewestActiveContainersPlacementStrategy,
+	RandomPlacementStrategy,
+}
+
+type UnknownContainerPlacementStrategyError struct {
+	Name string
+}
+
+func (err UnknownContainerPlacementStrategyError) Error() string {
+	return fmt.Sprintf("unknown container placement strategy '%s' (expected one of %v)", err.Name, ContainerPlacementStrategies)
+}
+
+// ValidateContainerPlacementStrategy returns an
+// UnknownContainerPlacementStrategyError if the name is not one of
+// ContainerPlacementStrategies.
+func ValidateContainerPlacementStrategy(name string) error {
+	for _, strategy := range ContainerPlacementStrategies {
+		if strategy == name {
+			return nil
+		}
+	}
+
+	return UnknownContainerPlacementStrategyError{Name: name}
+}
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// ContainerPlacementStrategy chooses which of the workers compatible with a
+// container spec the container should be placed on.
+type ContainerPlacementStrategy interface {
+	Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error)
+}
+
+// NewContainerPlacementStrategy constructs the named strategy, which chooses
+// between equally suitable workers using numbers from the given source. The
+// source must be safe for concurrent use, such as one from NewRandSource.
+func NewContainerPlacementStrategy(name string, source rand.Source) (ContainerPlacementStrategy, error) {
+	random := rand.New(source)
+
+	switch name {
+	case VolumeLocalityPlacementStrategy:
+		return volumeLocalityPlacementStrategy{random: random}, nil
+	case FewestActiveContainersPlacementStrategy:
+		return fewestActiveContainersPlacementStrategy{random: random}, nil
+	case RandomPlacementStrategy:
+		return randomPlacementStrategy{random: random}, nil
+	default:
+		return nil, UnknownContainerPlacementStrategyError{Name: name}
+	}
+}
+
+// NewRandSource returns a source of random numbers seeded with the given
+// value which, unlike rand.NewSource, is safe for concurrent use.
+func NewRandSource(seed int64) rand.Source {
+	return &lockedSource{source: rand.NewSource(seed)}
+}
+
+type lockedSource struct {
+	lock   sync.Mutex
+	source rand.Source
+}
+
+func (source *lockedSource) Int63() int64 {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	return source.source.Int63()
+}
+
+func (source *lockedSource) Seed(seed int64) {
+	source.lock.Lock()
+	defer source.lock.Unlock()
+
+	source.source.Seed(seed)
+}
+
+// volumeLocalityPlacementStrategy prefers the worker which already holds the
+// most of the container's inputs and image artifact, so that the fewest
+// volumes have to be streamed between workers.
+type volumeLocalityPlacementStrategy struct {
+	random *rand.Rand
+}
+
+func (strategy volumeLocalityPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	sources := []ArtifactSource{}
+	for _, input := range spec.Inputs {
+		sources = append(sources, input.Source())
+	}
+
+	if spec.ImageSpec.ImageArtifactSource != nil {
+		sources = append(sources, spec.ImageSpec.ImageArtifactSource)
+	}
+
+	var candidates []Worker
+	highestCount := -1
+
+	for _, w := range workers {
+		localCount := 0
+
+		for _, source := range sources {
+			_, found, err := source.VolumeOn(w)
+			if err != nil {
+				return nil, err
+			}
+
+			if found {
+				localCount++
+			}
+		}
+
+		if localCount > highestCount {
+			highestCount = localCount
+			candidates = []Worker{w}
+		} else if localCount == highestCount {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates[strategy.random.Intn(len(candidates))], nil
+}
+
+// fewestActiveContainersPlacementStrategy prefers the worker with the fewest
+// active containers, as of its last heartbeat.
+type fewestActiveContainersPlacementStrategy struct {
+	random *rand.Rand
+}
+
+func (strategy fewestActiveContainersPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	var candidates []Worker
+	var fewest int
+
+	for _, w := range workers {
+		active := w.ActiveContainers()
+
+		if len(candidates) == 0 || active < fewest {
+			fewest = active
+			candidates = []Worker{w}
+		} else if active == fewest {
+			candidates = append(candidates, w)
+		}
+	}
+
+	return candidates[strategy.random.Intn(len(candidates))], nil
+}
+
+type randomPlacementStrategy struct {
+	random *rand.Rand
+}
+
+func (strategy randomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	return workers[strategy.random.Intn(len(workers))], nil
+}
@@ -0,0 +1,185 @@
+package worker_test
+
+import (
+	"errors"
+	"math/rand"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		randSource rand.Source
+		strategy   ContainerPlacementStrategy
+		spec       ContainerSpec
+
+		workerA *workerfakes.FakeWorker
+		workerB *workerfakes.FakeWorker
+		workerC *workerfakes.FakeWorker
+		workers []Worker
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		randSource = NewRandSource(1)
+
+		workerA = new(workerfakes.FakeWorker)
+		workerB = new(workerfakes.FakeWorker)
+		workerC = new(workerfakes.FakeWorker)
+		workers = []Worker{workerA, workerB, workerC}
+
+		spec = ContainerSpec{}
+	})
+
+	chooseMany := func() map[Worker]int {
+		chosen := map[Worker]int{}
+		for i := 0; i < 100; i++ {
+			worker, err := strategy.Choose(logger, workers, spec)
+			Expect(err).NotTo(HaveOccurred())
+			chosen[worker]++
+		}
+		return chosen
+	}
+
+	Describe("NewContainerPlacementStrategy", func() {
+		It("constructs each of the known strategies", func() {
+			for _, name := range ContainerPlacementStrategies {
+				_, err := NewContainerPlacementStrategy(name, randSource)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		It("errors for an unknown strategy", func() {
+			_, err := NewContainerPlacementStrategy("bogus", randSource)
+			Expect(err).To(Equal(UnknownContainerPlacementStrategyError{Name: "bogus"}))
+		})
+	})
+
+	Describe("ValidateContainerPlacementStrategy", func() {
+		It("accepts each of the known strategies", func() {
+			for _, name := range ContainerPlacementStrategies {
+				Expect(ValidateContainerPlacementStrategy(name)).To(Succeed())
+			}
+		})
+
+		It("errors for an unknown strategy", func() {
+			err := ValidateContainerPlacementStrategy("bogus")
+			Expect(err).To(Equal(UnknownContainerPlacementStrategyError{Name: "bogus"}))
+		})
+	})
+
+	Describe("volume-locality", func() {
+		BeforeEach(func() {
+			var err error
+			strategy, err = NewContainerPlacementStrategy(VolumeLocalityPlacementStrategy, randSource)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when inputs and the image are on some workers", func() {
+			BeforeEach(func() {
+				input := new(workerfakes.FakeInputSource)
+				inputSource := new(workerfakes.FakeArtifactSource)
+				inputSource.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+					return new(workerfakes.FakeVolume), worker == workerA || worker == workerB, nil
+				}
+				input.SourceReturns(inputSource)
+
+				imageSource := new(workerfakes.FakeArtifactSource)
+				imageSource.VolumeOnStub = func(worker Worker) (Volume, bool, error) {
+					return new(workerfakes.FakeVolume), worker == workerB, nil
+				}
+
+				spec.Inputs = []InputSource{input}
+				spec.ImageSpec.ImageArtifactSource = imageSource
+			})
+
+			It("chooses the worker with the most of them", func() {
+				Expect(chooseMany()).To(Equal(map[Worker]int{workerB: 100}))
+			})
+		})
+
+		Context("when locating a volume fails", func() {
+			BeforeEach(func() {
+				input := new(workerfakes.FakeInputSource)
+				inputSource := new(workerfakes.FakeArtifactSource)
+				inputSource.VolumeOnReturns(nil, false, errors.New("disaster"))
+				input.SourceReturns(inputSource)
+
+				spec.Inputs = []InputSource{input}
+			})
+
+			It("returns the error", func() {
+				_, err := strategy.Choose(logger, workers, spec)
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+
+		Context("when no worker has any volumes", func() {
+			It("chooses randomly between them", func() {
+				chosen := chooseMany()
+				Expect(chosen[workerA]).NotTo(BeZero())
+				Expect(chosen[workerB]).NotTo(BeZero())
+				Expect(chosen[workerC]).NotTo(BeZero())
+			})
+		})
+	})
+
+	Describe("fewest-containers", func() {
+		BeforeEach(func() {
+			var err error
+			strategy, err = NewContainerPlacementStrategy(FewestActiveContainersPlacementStrategy, randSource)
+			Expect(err).NotTo(HaveOccurred())
+
+			workerA.ActiveContainersReturns(5)
+			workerB.ActiveContainersReturns(2)
+			workerC.ActiveContainersReturns(2)
+		})
+
+		It("chooses randomly between the workers with the fewest active containers", func() {
+			chosen := chooseMany()
+			Expect(chosen[workerA]).To(BeZero())
+			Expect(chosen[workerB]).NotTo(BeZero())
+			Expect(chosen[workerC]).NotTo(BeZero())
+		})
+	})
+
+	Describe("random", func() {
+		BeforeEach(func() {
+			var err error
+			strategy, err = NewContainerPlacementStrategy(RandomPlacementStrategy, randSource)
+			Expect(err).NotTo(HaveOccurred())
+
+			workerA.ActiveContainersReturns(100)
+		})
+
+		It("chooses randomly between all of the workers", func() {
+			chosen := chooseMany()
+			Expect(chosen[workerA]).NotTo(BeZero())
+			Expect(chosen[workerB]).NotTo(BeZero())
+			Expect(chosen[workerC]).NotTo(BeZero())
+		})
+
+		It("chooses the same workers given a source with the same seed", func() {
+			otherStrategy, err := NewContainerPlacementStrategy(RandomPlacementStrategy, NewRandSource(1))
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 10; i++ {
+				chosen, err := strategy.Choose(logger, workers, spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				otherChosen, err := otherStrategy.Choose(logger, workers, spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(otherChosen).To(BeIdenticalTo(chosen))
+			}
+		})
+	})
+})
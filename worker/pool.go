@@ -6,11 +6,12 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
-	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/worker/transport"
 )
 
 //go:generate counterfeiter . WorkerProvider
@@ -42,8 +43,10 @@ type WorkerProvider interface {
 }
 
 var (
-	ErrNoWorkers     = errors.New("no workers")
-	ErrMissingWorker = errors.New("worker for container is missing")
+	ErrNoWorkers                   = errors.New("no workers")
+	ErrMissingWorker               = errors.New("worker for container is missing")
+	ErrAllWorkersUnderDiskPressure = errors.New("all compatible workers are above the disk usage limit")
+	ErrAllWorkersUnhealthy         = errors.New("all compatible workers are failing their health checks")
 )
 
 type NoCompatibleWorkersError struct {
@@ -64,16 +67,62 @@ func (err NoCompatibleWorkersError) Error() string {
 	)
 }
 
+// TeamQuotaExceededError is returned when creating a container would take
+// its team beyond its quota of containers or volumes.
+type TeamQuotaExceededError struct {
+	Quota string
+	Limit int
+}
+
+func (err TeamQuotaExceededError) Error() string {
+	return fmt.Sprintf("team has reached its quota of %d %s", err.Limit, err.Quota)
+}
+
 type pool struct {
-	provider WorkerProvider
+	provider    WorkerProvider
+	teamFactory dbng.TeamFactory
 
-	rand *rand.Rand
+	defaultStrategy ContainerPlacementStrategy
+	randSource      rand.Source
+
+	reservations *capacityReservations
+
+	diskUsageLimit     int
+	unhealthyThreshold int
 }
 
-func NewPool(provider WorkerProvider) Client {
+// NewPool constructs a Client which places containers on the workers given by
+// the provider according to the placement strategy configured for the
+// container's team, falling back to the given default strategy. Teams'
+// strategies are constructed with the given source of random numbers.
+//
+// Build containers with limits are only placed on workers with enough
+// unallocated capacity for them; if there are none, a NoWorkerCapacityError
+// is returned.
+//
+// Workers reporting disk usage at or above the given limit, as a percentage,
+// are not given new containers. A limit of 0 disables this.
+//
+// Likewise, workers which have failed at least unhealthyThreshold
+// consecutive health checks are skipped until they pass one again. A
+// threshold of 0 disables this.
+func NewPool(
+	provider WorkerProvider,
+	teamFactory dbng.TeamFactory,
+	defaultStrategy ContainerPlacementStrategy,
+	randSource rand.Source,
+	clock clock.Clock,
+	diskUsageLimit int,
+	unhealthyThreshold int,
+) Client {
 	return &pool{
-		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		provider:           provider,
+		teamFactory:        teamFactory,
+		defaultStrategy:    defaultStrategy,
+		randSource:         randSource,
+		reservations:       newCapacityReservations(clock),
+		diskUsageLimit:     diskUsageLimit,
+		unhealthyThreshold: unhealthyThreshold,
 	}
 }
 
@@ -93,9 +142,28 @@ func (pool *pool) AllSatisfying(logger lager.Logger, spec WorkerSpec, resourceTy
 
 	compatibleTeamWorkers := []Worker{}
 	compatibleGeneralWorkers := []Worker{}
+	var excludedErr error
 	for _, worker := range workers {
 		satisfyingWorker, err := worker.Satisfying(logger, spec, resourceTypes)
 		if err == nil {
+			if pool.isUnderDiskPressure(worker) {
+				logger.Info("skipping-worker-under-disk-pressure", lager.Data{
+					"worker":       worker.Name(),
+					"used-percent": worker.Disk().UsedPercent(),
+				})
+				excludedErr = ErrAllWorkersUnderDiskPressure
+				continue
+			}
+
+			if pool.isUnhealthy(worker) {
+				logger.Info("skipping-unhealthy-worker", lager.Data{
+					"worker":                worker.Name(),
+					"health-check-failures": worker.HealthCheckFailures(),
+				})
+				excludedErr = ErrAllWorkersUnhealthy
+				continue
+			}
+
 			if worker.IsOwnedByTeam() {
 				compatibleTeamWorkers = append(compatibleTeamWorkers, satisfyingWorker)
 			} else {
@@ -112,6 +180,10 @@ func (pool *pool) AllSatisfying(logger lager.Logger, spec WorkerSpec, resourceTy
 		return compatibleGeneralWorkers, nil
 	}
 
+	if excludedErr != nil {
+		return nil, excludedErr
+	}
+
 	return nil, NoCompatibleWorkersError{
 		Spec:    spec,
 		Workers: workers,
@@ -123,8 +195,93 @@ func (pool *pool) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
-	return randomWorker, nil
+
+	strategy := pool.strategyFor(logger, spec.TeamID)
+
+	return strategy.Choose(logger, compatibleWorkers, ContainerSpec{
+		Platform: spec.Platform,
+		Tags:     spec.Tags,
+		TeamID:   spec.TeamID,
+	})
+}
+
+func (pool *pool) isUnderDiskPressure(worker Worker) bool {
+	if pool.diskUsageLimit == 0 {
+		return false
+	}
+
+	disk := worker.Disk()
+	if disk == nil {
+		return false
+	}
+
+	return disk.UsedPercent() >= pool.diskUsageLimit
+}
+
+func (pool *pool) isUnhealthy(worker Worker) bool {
+	if pool.unhealthyThreshold == 0 {
+		return false
+	}
+
+	return worker.HealthCheckFailures() >= pool.unhealthyThreshold
+}
+
+func (pool *pool) strategyFor(logger lager.Logger, teamID int) ContainerPlacementStrategy {
+	if teamID == 0 {
+		return pool.defaultStrategy
+	}
+
+	name, err := pool.teamFactory.GetByID(teamID).FindContainerPlacementStrategy()
+	if err != nil {
+		logger.Error("failed-to-find-team-container-placement-strategy", err)
+		return pool.defaultStrategy
+	}
+
+	if name == "" {
+		return pool.defaultStrategy
+	}
+
+	strategy, err := NewContainerPlacementStrategy(name, pool.randSource)
+	if err != nil {
+		logger.Error("unknown-team-container-placement-strategy", err)
+		return pool.defaultStrategy
+	}
+
+	return strategy
+}
+
+func (pool *pool) checkTeamQuotas(logger lager.Logger, teamID int) error {
+	if teamID == 0 {
+		return nil
+	}
+
+	team := pool.teamFactory.GetByID(teamID)
+
+	quotas, err := team.FindQuotas()
+	if err != nil {
+		logger.Error("failed-to-find-team-quotas", err)
+		return err
+	}
+
+	if quotas.MaxContainers == 0 && quotas.MaxVolumes == 0 {
+		return nil
+	}
+
+	usage, err := team.QuotaUsage()
+	if err != nil {
+		logger.Error("failed-to-find-team-quota-usage", err)
+		return err
+	}
+
+	if quotas.MaxContainers != 0 && usage.Containers >= quotas.MaxContainers {
+		return TeamQuotaExceededError{Quota: "containers", Limit: quotas.MaxContainers}
+	}
+
+	if quotas.MaxVolumes != 0 && usage.Volumes >= quotas.MaxVolumes {
+		return TeamQuotaExceededError{Quota: "volumes", Limit: quotas.MaxVolumes}
+	}
+
+	return nil
 }
 
 func (pool *pool) FindOrCreateBuildContainer(
@@ -148,37 +305,44 @@ func (pool *pool) FindOrCreateBuildContainer(
 	}
 
 	if !found {
+		err := pool.checkTeamQuotas(logger, spec.TeamID)
+		if err != nil {
+			return nil, err
+		}
+
 		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
 		if err != nil {
 			return nil, err
 		}
 
-		workersByCount := map[int][]Worker{}
-		var highestCount int
-		for _, w := range compatibleWorkers {
-			candidateInputCount := 0
+		strategy := pool.strategyFor(logger, spec.TeamID)
 
-			for _, inputSource := range spec.Inputs {
-				_, found, err := inputSource.Source().VolumeOn(w)
-				if err != nil {
-					return nil, err
-				}
+		hasLimits := spec.Limits != (atc.ContainerLimits{})
+		waiter := fmt.Sprintf("%d/%s", buildID, planID)
 
-				if found {
-					candidateInputCount++
-				}
+		return pool.placeContainer(logger, compatibleWorkers, func(workers []Worker) (Worker, error) {
+			if hasLimits {
+				return pool.reservations.Reserve(logger, strategy, workers, spec, waiter)
 			}
 
-			workersByCount[candidateInputCount] = append(workersByCount[candidateInputCount], w)
-
-			if candidateInputCount >= highestCount {
-				highestCount = candidateInputCount
+			return strategy.Choose(logger, workers, spec)
+		}, func(worker Worker) (Container, error) {
+			container, err := worker.FindOrCreateBuildContainer(
+				logger,
+				signals,
+				delegate,
+				buildID,
+				planID,
+				metadata,
+				spec,
+				resourceTypes,
+			)
+			if err != nil && hasLimits {
+				pool.reservations.Release(worker.Name(), waiter)
 			}
-		}
-
-		workers := workersByCount[highestCount]
 
-		worker = workers[pool.rand.Intn(len(workers))]
+			return container, err
+		})
 	}
 
 	return worker.FindOrCreateBuildContainer(
@@ -206,24 +370,31 @@ func (pool *pool) CreateResourceGetContainer(
 	source atc.Source,
 	params atc.Params,
 ) (Container, error) {
-	worker, err := pool.Satisfying(logger, spec.WorkerSpec(), resourceTypes)
+	err := pool.checkTeamQuotas(logger, spec.TeamID)
 	if err != nil {
 		return nil, err
 	}
 
-	return worker.CreateResourceGetContainer(
-		logger,
-		resourceUser,
-		cancel,
-		delegate,
-		metadata,
-		spec,
-		resourceTypes,
-		resourceType,
-		version,
-		source,
-		params,
-	)
+	compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.placeContainer(logger, compatibleWorkers, pool.chooser(logger, spec), func(worker Worker) (Container, error) {
+		return worker.CreateResourceGetContainer(
+			logger,
+			resourceUser,
+			cancel,
+			delegate,
+			metadata,
+			spec,
+			resourceTypes,
+			resourceType,
+			version,
+			source,
+			params,
+		)
+	})
 }
 
 func (pool *pool) FindOrCreateResourceCheckContainer(
@@ -250,10 +421,29 @@ func (pool *pool) FindOrCreateResourceCheckContainer(
 	}
 
 	if !found {
-		worker, err = pool.Satisfying(logger, spec.WorkerSpec(), resourceTypes)
+		err = pool.checkTeamQuotas(logger, spec.TeamID)
 		if err != nil {
 			return nil, err
 		}
+
+		compatibleWorkers, err := pool.AllSatisfying(logger, spec.WorkerSpec(), resourceTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		return pool.placeContainer(logger, compatibleWorkers, pool.chooser(logger, spec), func(worker Worker) (Container, error) {
+			return worker.FindOrCreateResourceCheckContainer(
+				logger,
+				resourceUser,
+				cancel,
+				delegate,
+				metadata,
+				spec,
+				resourceTypes,
+				resourceType,
+				source,
+			)
+		})
 	}
 
 	return worker.FindOrCreateResourceCheckContainer(
@@ -269,6 +459,55 @@ func (pool *pool) FindOrCreateResourceCheckContainer(
 	)
 }
 
+// placeContainer creates a container on the worker chosen from the given
+// workers, choosing again from the rest whenever the chosen worker's circuit
+// breaker is open.
+func (pool *pool) placeContainer(
+	logger lager.Logger,
+	workers []Worker,
+	choose func([]Worker) (Worker, error),
+	create func(Worker) (Container, error),
+) (Container, error) {
+	for {
+		worker, err := choose(workers)
+		if err != nil {
+			return nil, err
+		}
+
+		container, err := create(worker)
+		if !transport.IsCircuitOpen(err) {
+			return container, err
+		}
+
+		logger.Info("skipping-worker-with-open-circuit", lager.Data{"worker": worker.Name()})
+
+		remaining := []Worker{}
+		for _, w := range workers {
+			if w.Name() != worker.Name() {
+				remaining = append(remaining, w)
+			}
+		}
+
+		if len(remaining) == 0 {
+			return nil, err
+		}
+
+		workers = remaining
+	}
+}
+
+func (pool *pool) chooser(logger lager.Logger, spec ContainerSpec) func([]Worker) (Worker, error) {
+	strategy := pool.strategyFor(logger, spec.TeamID)
+
+	return func(workers []Worker) (Worker, error) {
+		return strategy.Choose(logger, workers, ContainerSpec{
+			Platform: spec.Platform,
+			Tags:     spec.Tags,
+			TeamID:   spec.TeamID,
+		})
+	}
+}
+
 func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	worker, found, err := pool.provider.FindWorkerForContainer(
 		logger.Session("find-worker"),
@@ -3,11 +3,15 @@ package worker_test
 import (
 	"errors"
 	"os"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
 	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/transport"
 	"github.com/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -16,8 +20,11 @@ import (
 
 var _ = Describe("Pool", func() {
 	var (
-		logger       *lagertest.TestLogger
-		fakeProvider *workerfakes.FakeWorkerProvider
+		logger          *lagertest.TestLogger
+		fakeProvider    *workerfakes.FakeWorkerProvider
+		fakeTeamFactory *dbngfakes.FakeTeamFactory
+		fakeTeam        *dbngfakes.FakeTeam
+		fakeClock       *fakeclock.FakeClock
 
 		pool Client
 	)
@@ -26,7 +33,18 @@ var _ = Describe("Pool", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		fakeTeam = new(dbngfakes.FakeTeam)
+		fakeTeamFactory = new(dbngfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		randSource := NewRandSource(1)
+
+		defaultStrategy, err := NewContainerPlacementStrategy(VolumeLocalityPlacementStrategy, randSource)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		pool = NewPool(fakeProvider, fakeTeamFactory, defaultStrategy, randSource, fakeClock, 90, 3)
 	})
 
 	Describe("Satisfying", func() {
@@ -239,6 +257,50 @@ var _ = Describe("Pool", func() {
 					}))
 				})
 			})
+
+			Context("when a worker is above the disk usage limit", func() {
+				BeforeEach(func() {
+					workerA.DiskReturns(&atc.WorkerDisk{Used: 95, Total: 100})
+					workerB.DiskReturns(&atc.WorkerDisk{Used: 50, Total: 100})
+				})
+
+				It("does not return it", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerB))
+				})
+
+				Context("when every satisfying worker is above the limit", func() {
+					BeforeEach(func() {
+						workerB.DiskReturns(&atc.WorkerDisk{Used: 90, Total: 100})
+					})
+
+					It("returns ErrAllWorkersUnderDiskPressure", func() {
+						Expect(satisfyingErr).To(Equal(ErrAllWorkersUnderDiskPressure))
+					})
+				})
+			})
+
+			Context("when a worker has failed too many health checks", func() {
+				BeforeEach(func() {
+					workerA.HealthCheckFailuresReturns(3)
+					workerB.HealthCheckFailuresReturns(1)
+				})
+
+				It("does not return it", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorkers).To(ConsistOf(workerB))
+				})
+
+				Context("when every satisfying worker is unhealthy", func() {
+					BeforeEach(func() {
+						workerB.HealthCheckFailuresReturns(4)
+					})
+
+					It("returns ErrAllWorkersUnhealthy", func() {
+						Expect(satisfyingErr).To(Equal(ErrAllWorkersUnhealthy))
+					})
+				})
+			})
 		})
 
 		Context("when team workers and general workers satisfy the spec", func() {
@@ -590,6 +652,351 @@ var _ = Describe("Pool", func() {
 				})
 			})
 
+			Context("when the team has quotas", func() {
+				BeforeEach(func() {
+					fakeTeam.FindQuotasReturns(atc.TeamQuotas{MaxContainers: 10, MaxVolumes: 30}, nil)
+
+					fakeProvider.RunningWorkersReturns([]Worker{
+						compatibleWorkerTwoCaches,
+					}, nil)
+				})
+
+				Context("when the team is within them", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{Containers: 9, Volumes: 29}, nil)
+					})
+
+					It("creates the container", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(compatibleWorkerTwoCaches.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the team has reached its container quota", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{Containers: 10}, nil)
+					})
+
+					It("returns TeamQuotaExceededError without creating the container", func() {
+						Expect(createErr).To(Equal(TeamQuotaExceededError{Quota: "containers", Limit: 10}))
+						Expect(compatibleWorkerTwoCaches.FindOrCreateBuildContainerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the team has reached its volume quota", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{Volumes: 30}, nil)
+					})
+
+					It("returns TeamQuotaExceededError", func() {
+						Expect(createErr).To(Equal(TeamQuotaExceededError{Quota: "volumes", Limit: 30}))
+					})
+				})
+
+				Context("when finding the usage fails", func() {
+					BeforeEach(func() {
+						fakeTeam.QuotaUsageReturns(atc.TeamQuotaUsage{}, errors.New("disaster"))
+					})
+
+					It("returns the error", func() {
+						Expect(createErr).To(MatchError("disaster"))
+					})
+				})
+			})
+
+			Context("when the container declares limits", func() {
+				BeforeEach(func() {
+					spec.Limits = atc.ContainerLimits{CPU: 2000, Memory: 1024}
+
+					compatibleWorkerTwoCaches.NameReturns("full-worker")
+					compatibleWorkerTwoCaches.CapacityReturns(&atc.WorkerResources{CPU: 4000, Memory: 4096})
+					compatibleWorkerTwoCaches.AllocatedReturns(atc.WorkerResources{CPU: 3000, Memory: 0})
+
+					compatibleWorkerOneCache1.NameReturns("roomy-worker")
+					compatibleWorkerOneCache1.CapacityReturns(&atc.WorkerResources{CPU: 4000, Memory: 4096})
+					compatibleWorkerOneCache1.AllocatedReturns(atc.WorkerResources{CPU: 0, Memory: 0})
+
+					fakeProvider.RunningWorkersReturns([]Worker{
+						compatibleWorkerTwoCaches,
+						compatibleWorkerOneCache1,
+					}, nil)
+				})
+
+				createAgain := func() error {
+					_, err := pool.FindOrCreateBuildContainer(
+						logger,
+						signals,
+						fakeImageFetchingDelegate,
+						42,
+						atc.PlanID("some-plan-id"),
+						metadata,
+						spec,
+						resourceTypes,
+					)
+					return err
+				}
+
+				It("only places it on a worker with room for them", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+					Expect(compatibleWorkerOneCache1.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+					Expect(compatibleWorkerTwoCaches.FindOrCreateBuildContainerCallCount()).To(BeZero())
+				})
+
+				It("reserves the capacity until the worker has had time to report it", func() {
+					Expect(createAgain()).To(Succeed())
+					Expect(compatibleWorkerOneCache1.FindOrCreateBuildContainerCallCount()).To(Equal(2))
+
+					Expect(createAgain()).To(Equal(NoWorkerCapacityError{
+						Spec:   spec.WorkerSpec(),
+						Limits: spec.Limits,
+					}))
+
+					fakeClock.Increment(CapacityReservationTTL)
+
+					Expect(createAgain()).To(Succeed())
+					Expect(compatibleWorkerOneCache1.FindOrCreateBuildContainerCallCount()).To(Equal(3))
+				})
+
+				Context("when creating the container on the chosen worker fails", func() {
+					BeforeEach(func() {
+						compatibleWorkerOneCache1.FindOrCreateBuildContainerReturnsOnCall(0, nil, errors.New("disaster"))
+					})
+
+					It("releases the capacity it reserved", func() {
+						Expect(createErr).To(MatchError("disaster"))
+
+						Expect(createAgain()).To(Succeed())
+						Expect(createAgain()).To(Succeed())
+					})
+				})
+
+				Context("when the chosen worker's circuit breaker is open", func() {
+					BeforeEach(func() {
+						compatibleWorkerOneCache1.FindOrCreateBuildContainerReturnsOnCall(0, nil, transport.WorkerCircuitOpenError{WorkerName: "roomy-worker"})
+					})
+
+					It("releases the capacity it reserved", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+						Expect(createAgain()).To(Succeed())
+						Expect(createAgain()).To(Succeed())
+					})
+				})
+
+				It("gives capacity that frees up to containers of a higher priority first", func() {
+					Expect(createAgain()).To(Succeed())
+
+					fakeClock.Increment(CapacityReservationTTL / 2)
+
+					urgentSpec := spec
+					urgentSpec.Priority = 10
+
+					_, err := pool.FindOrCreateBuildContainer(
+						logger,
+						signals,
+						fakeImageFetchingDelegate,
+						43,
+						atc.PlanID("some-other-plan-id"),
+						metadata,
+						urgentSpec,
+						resourceTypes,
+					)
+					Expect(err).To(Equal(NoWorkerCapacityError{
+						Spec:   spec.WorkerSpec(),
+						Limits: spec.Limits,
+					}))
+
+					fakeClock.Increment(CapacityReservationTTL / 2)
+
+					Expect(createAgain()).To(Equal(NoWorkerCapacityError{
+						Spec:   spec.WorkerSpec(),
+						Limits: spec.Limits,
+					}))
+
+					_, err = pool.FindOrCreateBuildContainer(
+						logger,
+						signals,
+						fakeImageFetchingDelegate,
+						43,
+						atc.PlanID("some-other-plan-id"),
+						metadata,
+						urgentSpec,
+						resourceTypes,
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(createAgain()).To(Succeed())
+				})
+
+				createFor := func(buildID int, priority int, limits atc.ContainerLimits) error {
+					otherSpec := spec
+					otherSpec.Priority = priority
+					otherSpec.Limits = limits
+
+					_, err := pool.FindOrCreateBuildContainer(
+						logger,
+						signals,
+						fakeImageFetchingDelegate,
+						buildID,
+						atc.PlanID("some-other-plan-id"),
+						metadata,
+						otherSpec,
+						resourceTypes,
+					)
+					return err
+				}
+
+				It("keeps deferring to each container of a higher priority until it has been placed", func() {
+					Expect(createAgain()).To(Succeed())
+
+					Expect(createFor(43, 10, spec.Limits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+					Expect(createFor(44, 10, spec.Limits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+					fakeClock.Increment(CapacityReservationTTL / 2)
+
+					Expect(createFor(43, 10, spec.Limits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+					Expect(createFor(44, 10, spec.Limits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+					fakeClock.Increment(CapacityReservationTTL / 2)
+
+					Expect(createFor(43, 10, spec.Limits)).To(Succeed())
+					Expect(createAgain()).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+					Expect(createFor(44, 10, spec.Limits)).To(Succeed())
+				})
+
+				Context("when a container of a higher priority only fits on some of the workers", func() {
+					BeforeEach(func() {
+						compatibleWorkerNoCaches1.NameReturns("big-worker")
+						compatibleWorkerNoCaches1.CapacityReturns(&atc.WorkerResources{CPU: 8000, Memory: 8192})
+						compatibleWorkerNoCaches1.AllocatedReturns(atc.WorkerResources{CPU: 4000, Memory: 0})
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							compatibleWorkerOneCache1,
+							compatibleWorkerNoCaches1,
+						}, nil)
+					})
+
+					It("only defers to it on the workers it fits on", func() {
+						bigLimits := atc.ContainerLimits{CPU: 6000, Memory: 1024}
+						Expect(createFor(43, 10, bigLimits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+						Expect(createAgain()).To(Succeed())
+						Expect(compatibleWorkerOneCache1.FindOrCreateBuildContainerCallCount()).To(Equal(2))
+						Expect(compatibleWorkerNoCaches1.FindOrCreateBuildContainerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when a container of a higher priority keeps waiting for capacity that never frees up", func() {
+					BeforeEach(func() {
+						compatibleWorkerNoCaches1.NameReturns("big-worker")
+						compatibleWorkerNoCaches1.CapacityReturns(&atc.WorkerResources{CPU: 8000, Memory: 8192})
+						compatibleWorkerNoCaches1.AllocatedReturns(atc.WorkerResources{CPU: 4000, Memory: 0})
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							compatibleWorkerNoCaches1,
+						}, nil)
+					})
+
+					It("stops deferring to it after CapacityWaiterMaxDeferral", func() {
+						bigLimits := atc.ContainerLimits{CPU: 6000, Memory: 1024}
+						Expect(createFor(43, 10, bigLimits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+						for elapsed := time.Duration(0); elapsed < CapacityWaiterMaxDeferral; elapsed += 10 * time.Second {
+							Expect(createFor(44, 0, spec.Limits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+
+							fakeClock.Increment(10 * time.Second)
+							Expect(createFor(43, 10, bigLimits)).To(BeAssignableToTypeOf(NoWorkerCapacityError{}))
+						}
+
+						Expect(createFor(44, 0, spec.Limits)).To(Succeed())
+					})
+				})
+
+				Context("when no worker has room for them", func() {
+					BeforeEach(func() {
+						fakeProvider.RunningWorkersReturns([]Worker{
+							compatibleWorkerTwoCaches,
+						}, nil)
+					})
+
+					It("returns NoWorkerCapacityError", func() {
+						Expect(createErr).To(Equal(NoWorkerCapacityError{
+							Spec:   spec.WorkerSpec(),
+							Limits: spec.Limits,
+						}))
+					})
+				})
+
+				Context("when a worker does not report its capacity", func() {
+					BeforeEach(func() {
+						compatibleWorkerNoCaches1.NameReturns("unmetered-worker")
+						compatibleWorkerNoCaches1.CapacityReturns(nil)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							compatibleWorkerTwoCaches,
+							compatibleWorkerNoCaches1,
+						}, nil)
+					})
+
+					It("considers it to have room", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(compatibleWorkerNoCaches1.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the limits exceed the capacity of every worker", func() {
+					BeforeEach(func() {
+						spec.Limits = atc.ContainerLimits{CPU: 8000, Memory: 1024}
+					})
+
+					It("returns ErrContainerLimitsExceedWorkerCapacity", func() {
+						Expect(createErr).To(Equal(ErrContainerLimitsExceedWorkerCapacity))
+					})
+				})
+			})
+
+			Context("when the team overrides the placement strategy", func() {
+				BeforeEach(func() {
+					fakeTeam.FindContainerPlacementStrategyReturns(FewestActiveContainersPlacementStrategy, nil)
+
+					compatibleWorkerTwoCaches.ActiveContainersReturns(10)
+					compatibleWorkerNoCaches1.ActiveContainersReturns(1)
+
+					fakeProvider.RunningWorkersReturns([]Worker{
+						incompatibleWorker,
+						compatibleWorkerTwoCaches,
+						compatibleWorkerNoCaches1,
+					}, nil)
+				})
+
+				It("looks up the strategy of the container's team", func() {
+					Expect(fakeTeamFactory.GetByIDCallCount()).NotTo(BeZero())
+					for i := 0; i < fakeTeamFactory.GetByIDCallCount(); i++ {
+						Expect(fakeTeamFactory.GetByIDArgsForCall(i)).To(Equal(4567))
+					}
+
+					Expect(fakeTeam.FindContainerPlacementStrategyCallCount()).To(Equal(1))
+				})
+
+				It("places the container using the team's strategy", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+					Expect(compatibleWorkerNoCaches1.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+					Expect(compatibleWorkerTwoCaches.FindOrCreateBuildContainerCallCount()).To(BeZero())
+				})
+
+				Context("when looking up the team's strategy fails", func() {
+					BeforeEach(func() {
+						fakeTeam.FindContainerPlacementStrategyReturns("", errors.New("disaster"))
+					})
+
+					It("falls back to the default strategy", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(compatibleWorkerTwoCaches.FindOrCreateBuildContainerCallCount()).To(Equal(1))
+					})
+				})
+			})
+
 			Context("with compatible workers available, with none having any local caches", func() {
 				BeforeEach(func() {
 					fakeProvider.RunningWorkersReturns([]Worker{
@@ -821,6 +1228,31 @@ var _ = Describe("Pool", func() {
 				})
 			})
 
+			Context("when a worker's circuit breaker is open", func() {
+				BeforeEach(func() {
+					workerA.NameReturns("worker-a")
+					workerB.NameReturns("worker-b")
+
+					workerA.FindOrCreateResourceCheckContainerReturns(nil, transport.WorkerCircuitOpenError{WorkerName: "worker-a"})
+				})
+
+				It("creates the container on another worker", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdContainer).To(Equal(fakeContainer))
+					Expect(workerB.FindOrCreateResourceCheckContainerCallCount()).To(Equal(1))
+				})
+
+				Context("when every worker's circuit breaker is open", func() {
+					BeforeEach(func() {
+						workerB.FindOrCreateResourceCheckContainerReturns(nil, transport.WorkerCircuitOpenError{WorkerName: "worker-b"})
+					})
+
+					It("returns the error", func() {
+						Expect(transport.IsCircuitOpen(createErr)).To(BeTrue())
+					})
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfyingReturns(nil, errors.New("nope"))
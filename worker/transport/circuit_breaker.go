@@ -0,0 +1,222 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc/metric"
+)
+
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+type circuit struct {
+	state         CircuitState
+	failures      int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+// CircuitBreakers tracks the requests made to each worker. After
+// failureThreshold consecutive failed requests a worker's circuit opens and
+// further requests fail immediately with a WorkerCircuitOpenError. Once the
+// cooldown has passed the circuit half-opens and lets a single request
+// through; if it succeeds the circuit closes again, otherwise it re-opens.
+//
+// A failureThreshold of 0 disables the breakers.
+type CircuitBreakers struct {
+	logger           lager.Logger
+	clock            clock.Clock
+	failureThreshold int
+	cooldown         time.Duration
+
+	lock     sync.Mutex
+	circuits map[string]*circuit
+}
+
+func NewCircuitBreakers(
+	logger lager.Logger,
+	clock clock.Clock,
+	failureThreshold int,
+	cooldown time.Duration,
+) *CircuitBreakers {
+	return &CircuitBreakers{
+		logger:           logger,
+		clock:            clock,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		circuits:         map[string]*circuit{},
+	}
+}
+
+// State returns the state of the worker's circuit.
+func (b *CircuitBreakers) State(workerName string) CircuitState {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	c, found := b.circuits[workerName]
+	if !found {
+		return CircuitClosed
+	}
+
+	return c.state
+}
+
+// Allow returns a WorkerCircuitOpenError if a request to the worker should
+// not be made. Otherwise the outcome of the request must be given to Done.
+func (b *CircuitBreakers) Allow(workerName string) error {
+	if b.failureThreshold == 0 {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	c := b.circuitFor(workerName)
+
+	switch c.state {
+	case CircuitOpen:
+		if b.clock.Now().Sub(c.openedAt) < b.cooldown {
+			return WorkerCircuitOpenError{WorkerName: workerName}
+		}
+
+		b.transition(workerName, c, CircuitHalfOpen)
+		c.trialInFlight = true
+		return nil
+
+	case CircuitHalfOpen:
+		if c.trialInFlight {
+			return WorkerCircuitOpenError{WorkerName: workerName}
+		}
+
+		c.trialInFlight = true
+		return nil
+	}
+
+	return nil
+}
+
+// Done records the outcome of a request allowed through by Allow.
+func (b *CircuitBreakers) Done(workerName string, err error) {
+	if b.failureThreshold == 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	c := b.circuitFor(workerName)
+	c.trialInFlight = false
+
+	if err == nil {
+		c.failures = 0
+		if c.state != CircuitClosed {
+			b.transition(workerName, c, CircuitClosed)
+		}
+		return
+	}
+
+	c.failures++
+
+	if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= b.failureThreshold) {
+		c.openedAt = b.clock.Now()
+		b.transition(workerName, c, CircuitOpen)
+	}
+}
+
+// Abandon records that a request allowed through by Allow was cancelled or
+// aborted by its caller, which counts neither for nor against the worker.
+func (b *CircuitBreakers) Abandon(workerName string) {
+	if b.failureThreshold == 0 {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.circuitFor(workerName).trialInFlight = false
+}
+
+func (b *CircuitBreakers) circuitFor(workerName string) *circuit {
+	c, found := b.circuits[workerName]
+	if !found {
+		c = &circuit{state: CircuitClosed}
+		b.circuits[workerName] = c
+	}
+
+	return c
+}
+
+func (b *CircuitBreakers) transition(workerName string, c *circuit, state CircuitState) {
+	c.state = state
+
+	b.logger.Info("circuit-breaker-state-changed", lager.Data{
+		"worker": workerName,
+		"state":  state,
+	})
+
+	metric.WorkerCircuitBreakerState{
+		WorkerName: workerName,
+		State:      string(state),
+	}.Emit(b.logger)
+}
+
+type circuitBreakerRoundTripper struct {
+	workerName        string
+	breakers          *CircuitBreakers
+	innerRoundTripper http.RoundTripper
+}
+
+// NewCircuitBreakerRoundTripper guards requests to the worker with its
+// circuit breaker. Only failures to get a response count against the worker,
+// and not those of requests cancelled by their caller.
+func NewCircuitBreakerRoundTripper(workerName string, breakers *CircuitBreakers, innerRoundTripper http.RoundTripper) http.RoundTripper {
+	return &circuitBreakerRoundTripper{
+		workerName:        workerName,
+		breakers:          breakers,
+		innerRoundTripper: innerRoundTripper,
+	}
+}
+
+func (c *circuitBreakerRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	err := c.breakers.Allow(c.workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.innerRoundTripper.RoundTrip(request)
+	if err != nil && requestAborted(request, err) {
+		c.breakers.Abandon(c.workerName)
+	} else {
+		c.breakers.Done(c.workerName, err)
+	}
+
+	return response, err
+}
+
+// requestAborted is true if the request failed because its caller gave up on
+// it, rather than because of the worker.
+func requestAborted(request *http.Request, err error) bool {
+	if err == context.Canceled || request.Context().Err() != nil {
+		return true
+	}
+
+	if request.Cancel != nil {
+		select {
+		case <-request.Cancel:
+			return true
+		default:
+		}
+	}
+
+	return false
+}
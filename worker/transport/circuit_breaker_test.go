@@ -0,0 +1,170 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp/retryhttpfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CircuitBreakerRoundTripper", func() {
+	var (
+		fakeClock        *fakeclock.FakeClock
+		fakeRoundTripper *retryhttpfakes.FakeRoundTripper
+		breakers         *transport.CircuitBreakers
+		roundTripper     http.RoundTripper
+		request          *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		fakeRoundTripper = new(retryhttpfakes.FakeRoundTripper)
+		breakers = transport.NewCircuitBreakers(lagertest.NewTestLogger("test"), fakeClock, 3, time.Minute)
+		roundTripper = transport.NewCircuitBreakerRoundTripper("some-worker", breakers, fakeRoundTripper)
+
+		requestURL, err := url.Parse("http://1.2.3.4/something")
+		Expect(err).NotTo(HaveOccurred())
+
+		request = &http.Request{URL: requestURL}
+
+		fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusTeapot}, nil)
+	})
+
+	failRequests := func(count int) {
+		fakeRoundTripper.RoundTripReturns(nil, errors.New("connection refused"))
+
+		for i := 0; i < count; i++ {
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).To(MatchError("connection refused"))
+		}
+	}
+
+	It("passes requests through while the worker responds", func() {
+		response, err := roundTripper.RoundTrip(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response).To(Equal(&http.Response{StatusCode: http.StatusTeapot}))
+		Expect(breakers.State("some-worker")).To(Equal(transport.CircuitClosed))
+	})
+
+	It("stays closed when failures are not consecutive", func() {
+		failRequests(2)
+
+		fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusOK}, nil)
+		_, err := roundTripper.RoundTrip(request)
+		Expect(err).NotTo(HaveOccurred())
+
+		failRequests(2)
+		Expect(breakers.State("some-worker")).To(Equal(transport.CircuitClosed))
+	})
+
+	Context("after consecutive failures", func() {
+		BeforeEach(func() {
+			failRequests(3)
+		})
+
+		It("opens", func() {
+			Expect(breakers.State("some-worker")).To(Equal(transport.CircuitOpen))
+		})
+
+		It("fails fast without making the request", func() {
+			_, err := roundTripper.RoundTrip(request)
+			Expect(err).To(Equal(transport.WorkerCircuitOpenError{WorkerName: "some-worker"}))
+			Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(3))
+		})
+
+		It("does not affect other workers", func() {
+			Expect(breakers.State("other-worker")).To(Equal(transport.CircuitClosed))
+		})
+
+		Context("once the cooldown has passed", func() {
+			BeforeEach(func() {
+				fakeClock.Increment(time.Minute)
+			})
+
+			It("lets a request through and closes if it succeeds", func() {
+				fakeRoundTripper.RoundTripReturns(&http.Response{StatusCode: http.StatusOK}, nil)
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(breakers.State("some-worker")).To(Equal(transport.CircuitClosed))
+			})
+
+			It("re-opens if the request fails", func() {
+				failRequests(1)
+				Expect(breakers.State("some-worker")).To(Equal(transport.CircuitOpen))
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(transport.IsCircuitOpen(err)).To(BeTrue())
+			})
+
+			It("only lets one request through while half-open", func() {
+				Expect(breakers.Allow("some-worker")).To(Succeed())
+				Expect(breakers.State("some-worker")).To(Equal(transport.CircuitHalfOpen))
+
+				_, err := roundTripper.RoundTrip(request)
+				Expect(transport.IsCircuitOpen(err)).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when requests are cancelled by their caller", func() {
+		BeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			request = request.WithContext(ctx)
+		})
+
+		It("does not count them against the worker", func() {
+			failRequests(5)
+			Expect(breakers.State("some-worker")).To(Equal(transport.CircuitClosed))
+		})
+
+		Context("while half-open", func() {
+			BeforeEach(func() {
+				breakers.Done("some-worker", errors.New("nope"))
+				breakers.Done("some-worker", errors.New("nope"))
+				breakers.Done("some-worker", errors.New("nope"))
+				fakeClock.Increment(time.Minute)
+			})
+
+			It("lets another request through", func() {
+				failRequests(1)
+				Expect(breakers.State("some-worker")).To(Equal(transport.CircuitHalfOpen))
+				Expect(breakers.Allow("some-worker")).To(Succeed())
+			})
+		})
+	})
+
+	Context("when the breakers are disabled", func() {
+		BeforeEach(func() {
+			breakers = transport.NewCircuitBreakers(lagertest.NewTestLogger("test"), fakeClock, 0, time.Minute)
+			roundTripper = transport.NewCircuitBreakerRoundTripper("some-worker", breakers, fakeRoundTripper)
+		})
+
+		It("never opens", func() {
+			failRequests(10)
+			Expect(breakers.State("some-worker")).To(Equal(transport.CircuitClosed))
+		})
+	})
+})
+
+var _ = Describe("IsCircuitOpen", func() {
+	It("sees through errors wrapped by an HTTP client", func() {
+		err := &url.Error{Op: "Get", URL: "http://1.2.3.4/ping", Err: transport.WorkerCircuitOpenError{WorkerName: "some-worker"}}
+		Expect(transport.IsCircuitOpen(err)).To(BeTrue())
+	})
+
+	It("is false for other errors", func() {
+		Expect(transport.IsCircuitOpen(errors.New("nope"))).To(BeFalse())
+	})
+})
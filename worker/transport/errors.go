@@ -1,6 +1,9 @@
 package transport
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
 
 type WorkerMissingError struct {
 	WorkerName string
@@ -18,3 +21,24 @@ type WorkerUnreachableError struct {
 func (e WorkerUnreachableError) Error() string {
 	return fmt.Sprintf("worker '%s' is unreachable (state is '%s')", e.WorkerName, e.WorkerState)
 }
+
+type WorkerCircuitOpenError struct {
+	WorkerName string
+}
+
+func (e WorkerCircuitOpenError) Error() string {
+	return fmt.Sprintf("worker '%s' is failing; its circuit breaker is open", e.WorkerName)
+}
+
+// IsCircuitOpen is true if the error, or the error an HTTP client wrapped, is
+// a WorkerCircuitOpenError.
+func IsCircuitOpen(err error) bool {
+	switch e := err.(type) {
+	case WorkerCircuitOpenError:
+		return true
+	case *url.Error:
+		return IsCircuitOpen(e.Err)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,126 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+)
+
+type healthProber struct {
+	logger            lager.Logger
+	workerFactory     dbng.WorkerFactory
+	innerRoundTripper http.RoundTripper
+	clock             clock.Clock
+	timeout           time.Duration
+}
+
+// NewHealthProber constructs a task which pings the Garden and baggageclaim
+// endpoints of every running worker and records the outcome, so that workers
+// which still heartbeat but no longer answer can be kept out of placement.
+func NewHealthProber(
+	logger lager.Logger,
+	workerFactory dbng.WorkerFactory,
+	innerRoundTripper http.RoundTripper,
+	clock clock.Clock,
+	timeout time.Duration,
+) *healthProber {
+	return &healthProber{
+		logger:            logger,
+		workerFactory:     workerFactory,
+		innerRoundTripper: innerRoundTripper,
+		clock:             clock,
+		timeout:           timeout,
+	}
+}
+
+func (p *healthProber) Run() error {
+	logger := p.logger.Session("run")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	workers, err := p.workerFactory.Workers()
+	if err != nil {
+		logger.Error("failed-to-get-workers", err)
+		return err
+	}
+
+	wg := new(sync.WaitGroup)
+	for _, worker := range workers {
+		if worker.State() != dbng.WorkerStateRunning {
+			continue
+		}
+
+		wg.Add(1)
+		go func(worker dbng.Worker) {
+			defer wg.Done()
+			p.probe(logger.Session("probe", lager.Data{"worker": worker.Name()}), worker)
+		}(worker)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+func (p *healthProber) probe(logger lager.Logger, worker dbng.Worker) {
+	check := atc.WorkerHealthCheck{
+		CheckedAt: p.clock.Now().Unix(),
+	}
+
+	gardenLatency, err := p.ping(NewGardenRoundTripper(worker.Name(), worker.GardenAddr(), p.workerFactory, p.innerRoundTripper))
+	check.GardenLatency = milliseconds(gardenLatency)
+	if err != nil {
+		check.Error = fmt.Sprintf("garden: %s", err)
+	}
+
+	baggageclaimLatency, err := p.ping(NewBaggageclaimRoundTripper(worker.Name(), worker.BaggageclaimURL(), p.workerFactory, p.innerRoundTripper))
+	check.BaggageclaimLatency = milliseconds(baggageclaimLatency)
+	if err != nil && check.Healthy() {
+		check.Error = fmt.Sprintf("baggageclaim: %s", err)
+	}
+
+	if !check.Healthy() {
+		logger.Info("unhealthy", lager.Data{"error": check.Error})
+	}
+
+	err = worker.RecordHealthCheck(check)
+	if err != nil {
+		logger.Error("failed-to-record-health-check", err)
+	}
+}
+
+// ping only cares that the server answers; baggageclaim has no ping endpoint
+// of its own, so any response short of a server error counts as healthy.
+func (p *healthProber) ping(roundTripper http.RoundTripper) (time.Duration, error) {
+	client := &http.Client{
+		Transport: roundTripper,
+		Timeout:   p.timeout,
+	}
+
+	start := p.clock.Now()
+
+	response, err := client.Get("http://127.0.0.1:8080/ping")
+	latency := p.clock.Now().Sub(start)
+	if err != nil {
+		return latency, err
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return latency, fmt.Errorf("unexpected status: %d", response.StatusCode)
+	}
+
+	return latency, nil
+}
+
+func milliseconds(duration time.Duration) int64 {
+	return int64(duration / time.Millisecond)
+}
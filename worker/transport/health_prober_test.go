@@ -0,0 +1,156 @@
+package transport_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/dbng"
+	"github.com/concourse/atc/dbng/dbngfakes"
+	"github.com/concourse/atc/worker/transport"
+	"github.com/concourse/retryhttp/retryhttpfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HealthProber", func() {
+	var (
+		fakeWorkerFactory *dbngfakes.FakeWorkerFactory
+		fakeRoundTripper  *retryhttpfakes.FakeRoundTripper
+		fakeClock         *fakeclock.FakeClock
+		fakeWorker        *dbngfakes.FakeWorker
+
+		statuses map[string]int
+		failures map[string]error
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbngfakes.FakeWorkerFactory)
+		fakeRoundTripper = new(retryhttpfakes.FakeRoundTripper)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+
+		gardenAddr := "some-garden-addr"
+		baggageclaimURL := "http://some-baggageclaim-addr"
+
+		fakeWorker = new(dbngfakes.FakeWorker)
+		fakeWorker.NameReturns("some-worker")
+		fakeWorker.StateReturns(dbng.WorkerStateRunning)
+		fakeWorker.GardenAddrReturns(&gardenAddr)
+		fakeWorker.BaggageclaimURLReturns(&baggageclaimURL)
+
+		stalledWorker := new(dbngfakes.FakeWorker)
+		stalledWorker.NameReturns("stalled-worker")
+		stalledWorker.StateReturns(dbng.WorkerStateStalled)
+
+		fakeWorkerFactory.WorkersReturns([]dbng.Worker{fakeWorker, stalledWorker}, nil)
+
+		statuses = map[string]int{
+			"some-garden-addr":       http.StatusOK,
+			"some-baggageclaim-addr": http.StatusNotFound,
+		}
+		failures = map[string]error{}
+
+		fakeRoundTripper.RoundTripStub = func(request *http.Request) (*http.Response, error) {
+			fakeClock.Increment(15 * time.Millisecond)
+
+			if err, found := failures[request.URL.Host]; found {
+				return nil, err
+			}
+
+			return &http.Response{
+				StatusCode: statuses[request.URL.Host],
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		runErr = transport.NewHealthProber(
+			lagertest.NewTestLogger("test"),
+			fakeWorkerFactory,
+			fakeRoundTripper,
+			fakeClock,
+			time.Second,
+		).Run()
+	})
+
+	It("only probes running workers", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+		Expect(fakeRoundTripper.RoundTripCallCount()).To(Equal(2))
+	})
+
+	It("pings garden and baggageclaim on the worker", func() {
+		hosts := []string{}
+		for i := 0; i < fakeRoundTripper.RoundTripCallCount(); i++ {
+			request := fakeRoundTripper.RoundTripArgsForCall(i)
+			Expect(request.URL.Path).To(Equal("/ping"))
+			hosts = append(hosts, request.URL.Host)
+		}
+
+		Expect(hosts).To(ConsistOf("some-garden-addr", "some-baggageclaim-addr"))
+	})
+
+	It("records a healthy check with the latencies", func() {
+		Expect(fakeWorker.RecordHealthCheckCallCount()).To(Equal(1))
+		Expect(fakeWorker.RecordHealthCheckArgsForCall(0)).To(Equal(atc.WorkerHealthCheck{
+			CheckedAt:           123,
+			GardenLatency:       15,
+			BaggageclaimLatency: 15,
+		}))
+	})
+
+	Context("when garden does not respond", func() {
+		BeforeEach(func() {
+			failures["some-garden-addr"] = errors.New("connection refused")
+		})
+
+		It("records the failure", func() {
+			Expect(fakeWorker.RecordHealthCheckCallCount()).To(Equal(1))
+			check := fakeWorker.RecordHealthCheckArgsForCall(0)
+			Expect(check.Healthy()).To(BeFalse())
+			Expect(check.Error).To(ContainSubstring("garden"))
+			Expect(check.Error).To(ContainSubstring("connection refused"))
+		})
+	})
+
+	Context("when baggageclaim returns a server error", func() {
+		BeforeEach(func() {
+			statuses["some-baggageclaim-addr"] = http.StatusInternalServerError
+		})
+
+		It("records the failure", func() {
+			Expect(fakeWorker.RecordHealthCheckCallCount()).To(Equal(1))
+			check := fakeWorker.RecordHealthCheckArgsForCall(0)
+			Expect(check.Healthy()).To(BeFalse())
+			Expect(check.Error).To(ContainSubstring("baggageclaim"))
+		})
+	})
+
+	Context("when recording the check fails", func() {
+		BeforeEach(func() {
+			fakeWorker.RecordHealthCheckReturns(errors.New("disaster"))
+		})
+
+		It("does not fail the run", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("when listing workers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerFactory.WorkersReturns(nil, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("disaster"))
+		})
+	})
+})
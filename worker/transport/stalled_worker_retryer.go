@@ -7,6 +7,10 @@ type UnreachableWorkerRetryer struct {
 }
 
 func (r *UnreachableWorkerRetryer) IsRetryable(err error) bool {
+	if IsCircuitOpen(err) {
+		return false
+	}
+
 	if _, ok := err.(WorkerUnreachableError); ok {
 		return true
 	}
@@ -35,6 +35,15 @@ var _ = Describe("UnreachableWorkerRetryer", func() {
 			Expect(retryer.IsRetryable(err)).To(BeTrue())
 		})
 
+		It("returns false when error is WorkerCircuitOpenError", func() {
+			err := transport.WorkerCircuitOpenError{
+				WorkerName: "foo",
+			}
+
+			delegateRetryer.IsRetryableReturns(true)
+			Expect(retryer.IsRetryable(err)).To(BeFalse())
+		})
+
 		It("delegates to DelegateRetryer if errors is not WorkerUnreachableError", func() {
 			err := errors.New("some-other-error")
 			delegateRetryer.IsRetryableReturns(true)
@@ -44,6 +44,22 @@ type Worker interface {
 
 	ActiveContainers() int
 
+	// Capacity is the total CPU and memory the worker reported, or nil if it
+	// did not report any.
+	Capacity() *atc.WorkerResources
+
+	// Allocated is the CPU and memory the worker reported as allocated to its
+	// containers as of its last heartbeat.
+	Allocated() atc.WorkerResources
+
+	// Disk is the disk usage the worker reported for its volumes, or nil if it
+	// did not report any.
+	Disk() *atc.WorkerDisk
+
+	// HealthCheckFailures is the number of consecutive health checks the
+	// worker has failed.
+	HealthCheckFailures() int
+
 	Description() string
 	Name() string
 	ResourceTypes() []atc.WorkerResourceType
@@ -63,14 +79,18 @@ type gardenWorker struct {
 
 	clock clock.Clock
 
-	activeContainers int
-	resourceTypes    []atc.WorkerResourceType
-	platform         string
-	tags             atc.Tags
-	teamID           int
-	name             string
-	startTime        int64
-	version          *string
+	activeContainers    int
+	capacity            *atc.WorkerResources
+	allocated           atc.WorkerResources
+	disk                *atc.WorkerDisk
+	healthCheckFailures int
+	resourceTypes       []atc.WorkerResourceType
+	platform            string
+	tags                atc.Tags
+	teamID              int
+	name                string
+	startTime           int64
+	version             *string
 }
 
 func NewGardenWorker(
@@ -80,6 +100,10 @@ func NewGardenWorker(
 	provider WorkerProvider,
 	clock clock.Clock,
 	activeContainers int,
+	capacity *atc.WorkerResources,
+	allocated atc.WorkerResources,
+	disk *atc.WorkerDisk,
+	healthCheckFailures int,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags atc.Tags,
@@ -93,17 +117,21 @@ func NewGardenWorker(
 
 		volumeClient: volumeClient,
 
-		lockDB:           lockDB,
-		provider:         provider,
-		clock:            clock,
-		activeContainers: activeContainers,
-		resourceTypes:    resourceTypes,
-		platform:         platform,
-		tags:             tags,
-		teamID:           teamID,
-		name:             name,
-		startTime:        startTime,
-		version:          version,
+		lockDB:              lockDB,
+		provider:            provider,
+		clock:               clock,
+		activeContainers:    activeContainers,
+		capacity:            capacity,
+		allocated:           allocated,
+		disk:                disk,
+		healthCheckFailures: healthCheckFailures,
+		resourceTypes:       resourceTypes,
+		platform:            platform,
+		tags:                tags,
+		teamID:              teamID,
+		name:                name,
+		startTime:           startTime,
+		version:             version,
 	}
 }
 
@@ -252,6 +280,22 @@ func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) Capacity() *atc.WorkerResources {
+	return worker.capacity
+}
+
+func (worker *gardenWorker) Allocated() atc.WorkerResources {
+	return worker.allocated
+}
+
+func (worker *gardenWorker) Disk() *atc.WorkerDisk {
+	return worker.disk
+}
+
+func (worker *gardenWorker) HealthCheckFailures() int {
+	return worker.healthCheckFailures
+}
+
 func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec, resourceTypes atc.VersionedResourceTypes) (Worker, error) {
 	if spec.TeamID != worker.teamID && worker.teamID != 0 {
 		return nil, ErrTeamMismatch
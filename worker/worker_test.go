@@ -30,6 +30,10 @@ var _ = Describe("Worker", func() {
 		fakeContainerProviderFactory *wfakes.FakeContainerProviderFactory
 		fakeContainerProvider        *wfakes.FakeContainerProvider
 		activeContainers             int
+		capacity                     *atc.WorkerResources
+		allocated                    atc.WorkerResources
+		disk                         *atc.WorkerDisk
+		healthCheckFailures          int
 		resourceTypes                []atc.WorkerResourceType
 		platform                     string
 		tags                         atc.Tags
@@ -48,6 +52,9 @@ var _ = Describe("Worker", func() {
 		fakeLockDB = new(wfakes.FakeLockDB)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		activeContainers = 42
+		capacity = &atc.WorkerResources{CPU: 4000, Memory: 1024}
+		allocated = atc.WorkerResources{CPU: 1000, Memory: 256}
+		disk = &atc.WorkerDisk{Used: 512, Total: 2048}
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -79,6 +86,10 @@ var _ = Describe("Worker", func() {
 			fakeWorkerProvider,
 			fakeClock,
 			activeContainers,
+			capacity,
+			allocated,
+			disk,
+			healthCheckFailures,
 			resourceTypes,
 			platform,
 			tags,
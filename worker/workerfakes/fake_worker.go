@@ -273,6 +273,42 @@ type FakeWorker struct {
 	isVersionCompatibleReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	CapacityStub        func() *atc.WorkerResources
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct{}
+	capacityReturns     struct {
+		result1 *atc.WorkerResources
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
+	AllocatedStub        func() atc.WorkerResources
+	allocatedMutex       sync.RWMutex
+	allocatedArgsForCall []struct{}
+	allocatedReturns     struct {
+		result1 atc.WorkerResources
+	}
+	allocatedReturnsOnCall map[int]struct {
+		result1 atc.WorkerResources
+	}
+	DiskStub        func() *atc.WorkerDisk
+	diskMutex       sync.RWMutex
+	diskArgsForCall []struct{}
+	diskReturns     struct {
+		result1 *atc.WorkerDisk
+	}
+	diskReturnsOnCall map[int]struct {
+		result1 *atc.WorkerDisk
+	}
+	HealthCheckFailuresStub        func() int
+	healthCheckFailuresMutex       sync.RWMutex
+	healthCheckFailuresArgsForCall []struct{}
+	healthCheckFailuresReturns     struct {
+		result1 int
+	}
+	healthCheckFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1211,6 +1247,166 @@ func (fake *FakeWorker) IsVersionCompatibleReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capacity() *atc.WorkerResources {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct{}{})
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if fake.CapacityStub != nil {
+		return fake.CapacityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.capacityReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 *atc.WorkerResources) {
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) Allocated() atc.WorkerResources {
+	fake.allocatedMutex.Lock()
+	ret, specificReturn := fake.allocatedReturnsOnCall[len(fake.allocatedArgsForCall)]
+	fake.allocatedArgsForCall = append(fake.allocatedArgsForCall, struct{}{})
+	fake.recordInvocation("Allocated", []interface{}{})
+	fake.allocatedMutex.Unlock()
+	if fake.AllocatedStub != nil {
+		return fake.AllocatedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.allocatedReturns.result1
+}
+
+func (fake *FakeWorker) AllocatedCallCount() int {
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	return len(fake.allocatedArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatedReturns(result1 atc.WorkerResources) {
+	fake.AllocatedStub = nil
+	fake.allocatedReturns = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatedReturnsOnCall(i int, result1 atc.WorkerResources) {
+	fake.AllocatedStub = nil
+	if fake.allocatedReturnsOnCall == nil {
+		fake.allocatedReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerResources
+		})
+	}
+	fake.allocatedReturnsOnCall[i] = struct {
+		result1 atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) Disk() *atc.WorkerDisk {
+	fake.diskMutex.Lock()
+	ret, specificReturn := fake.diskReturnsOnCall[len(fake.diskArgsForCall)]
+	fake.diskArgsForCall = append(fake.diskArgsForCall, struct{}{})
+	fake.recordInvocation("Disk", []interface{}{})
+	fake.diskMutex.Unlock()
+	if fake.DiskStub != nil {
+		return fake.DiskStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.diskReturns.result1
+}
+
+func (fake *FakeWorker) DiskCallCount() int {
+	fake.diskMutex.RLock()
+	defer fake.diskMutex.RUnlock()
+	return len(fake.diskArgsForCall)
+}
+
+func (fake *FakeWorker) DiskReturns(result1 *atc.WorkerDisk) {
+	fake.DiskStub = nil
+	fake.diskReturns = struct {
+		result1 *atc.WorkerDisk
+	}{result1}
+}
+
+func (fake *FakeWorker) DiskReturnsOnCall(i int, result1 *atc.WorkerDisk) {
+	fake.DiskStub = nil
+	if fake.diskReturnsOnCall == nil {
+		fake.diskReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerDisk
+		})
+	}
+	fake.diskReturnsOnCall[i] = struct {
+		result1 *atc.WorkerDisk
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthCheckFailures() int {
+	fake.healthCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.healthCheckFailuresReturnsOnCall[len(fake.healthCheckFailuresArgsForCall)]
+	fake.healthCheckFailuresArgsForCall = append(fake.healthCheckFailuresArgsForCall, struct{}{})
+	fake.recordInvocation("HealthCheckFailures", []interface{}{})
+	fake.healthCheckFailuresMutex.Unlock()
+	if fake.HealthCheckFailuresStub != nil {
+		return fake.HealthCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.healthCheckFailuresReturns.result1
+}
+
+func (fake *FakeWorker) HealthCheckFailuresCallCount() int {
+	fake.healthCheckFailuresMutex.RLock()
+	defer fake.healthCheckFailuresMutex.RUnlock()
+	return len(fake.healthCheckFailuresArgsForCall)
+}
+
+func (fake *FakeWorker) HealthCheckFailuresReturns(result1 int) {
+	fake.HealthCheckFailuresStub = nil
+	fake.healthCheckFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeWorker) HealthCheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.HealthCheckFailuresStub = nil
+	if fake.healthCheckFailuresReturnsOnCall == nil {
+		fake.healthCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.healthCheckFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1252,6 +1448,14 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.isOwnedByTeamMutex.RUnlock()
 	fake.isVersionCompatibleMutex.RLock()
 	defer fake.isVersionCompatibleMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	fake.allocatedMutex.RLock()
+	defer fake.allocatedMutex.RUnlock()
+	fake.diskMutex.RLock()
+	defer fake.diskMutex.RUnlock()
+	fake.healthCheckFailuresMutex.RLock()
+	defer fake.healthCheckFailuresMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -61,4 +61,16 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("WorkerDisk", func() {
+		Describe("UsedPercent", func() {
+			It("returns the percentage of the disk in use", func() {
+				Expect(atc.WorkerDisk{Used: 45, Total: 50}.UsedPercent()).To(Equal(90))
+			})
+
+			It("returns 0 when the total is unknown", func() {
+				Expect(atc.WorkerDisk{Used: 45}.UsedPercent()).To(Equal(0))
+			})
+		})
+	})
 })
@@ -0,0 +1,44 @@
+package wrappa
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+type APIAuditWrappa struct {
+	logger            lager.Logger
+	auditor           audit.Auditor
+	userContextReader auth.UserContextReader
+}
+
+func NewAPIAuditWrappa(logger lager.Logger, auditor audit.Auditor, userContextReader auth.UserContextReader) Wrappa {
+	return APIAuditWrappa{
+		logger:            logger,
+		auditor:           auditor,
+		userContextReader: userContextReader,
+	}
+}
+
+func (wrappa APIAuditWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		route, found := atc.Routes.FindRouteByName(name)
+		if !found || route.Method == "GET" {
+			wrapped[name] = handler
+			continue
+		}
+
+		switch name {
+		case atc.WritePipe, atc.HeartbeatWorker:
+			wrapped[name] = handler
+		default:
+			wrapped[name] = audit.WrapHandler(wrappa.logger, wrappa.auditor, wrappa.userContextReader, route, handler)
+		}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,50 @@
+package wrappa_test
+
+import (
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/audit"
+	"github.com/concourse/atc/audit/auditfakes"
+	"github.com/concourse/atc/auth/authfakes"
+	"github.com/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIAuditWrappa", func() {
+	var (
+		inputHandlers   rata.Handlers
+		wrappedHandlers rata.Handlers
+	)
+
+	BeforeEach(func() {
+		inputHandlers = rata.Handlers{}
+
+		for _, route := range atc.Routes {
+			inputHandlers[route.Name] = &stupidHandler{}
+		}
+
+		wrappedHandlers = wrappa.NewAPIAuditWrappa(
+			lagertest.NewTestLogger("test"),
+			new(auditfakes.FakeAuditor),
+			new(authfakes.FakeUserContextReader),
+		).Wrap(inputHandlers)
+	})
+
+	It("audits mutating routes", func() {
+		for _, name := range []string{atc.SaveConfig, atc.PausePipeline, atc.DestroyTeam, atc.CreateBuild, atc.CheckResourceWebHook} {
+			handler, ok := wrappedHandlers[name].(audit.AuditHandler)
+			Expect(ok).To(BeTrue(), name)
+			Expect(handler.Route.Name).To(Equal(name))
+			Expect(handler.Handler).To(Equal(inputHandlers[name]))
+		}
+	})
+
+	It("does not audit reads, heartbeats or pipe writes", func() {
+		for _, name := range []string{atc.GetConfig, atc.ListAuditEvents, atc.HeartbeatWorker, atc.WritePipe} {
+			Expect(wrappedHandlers[name]).To(Equal(inputHandlers[name]), name)
+		}
+	})
+})
@@ -226,14 +226,18 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized or public pipeline and public job
 				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
 				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.GetBuildArtifact:    checksIfPrivateJob(inputHandlers[atc.GetBuildArtifact]),
+				atc.GetBuildTests:       checksIfPrivateJob(inputHandlers[atc.GetBuildTests]),
 
 				// resource belongs to authorized team
 				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
 
 				// resource belongs to authorized team
-				atc.PruneWorker:  checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
-				atc.LandWorker:   checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
-				atc.RetireWorker: checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.PruneWorker:     checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
+				atc.LandWorker:      checkTeamAccessForWorker(inputHandlers[atc.LandWorker]),
+				atc.RetireWorker:    checkTeamAccessForWorker(inputHandlers[atc.RetireWorker]),
+				atc.GetWorkerHealth: checkTeamAccessForWorker(inputHandlers[atc.GetWorkerHealth]),
 
 				// belongs to public pipeline or authorized
 				atc.GetPipeline:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetPipeline]),
@@ -242,6 +246,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.GetJobTestHistory:             openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobTestHistory]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
 				atc.ListBuildsWithVersionAsOutput: openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsOutput]),
@@ -268,29 +273,37 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetUser:     authenticated(inputHandlers[atc.GetUser]),
 
 				// authenticated and is admin
-				atc.GetLogLevel: authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
-				atc.SetLogLevel: authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.GetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
+				atc.SetLogLevel:     authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
+				atc.ListAuditEvents: authenticatedAndAdmin(inputHandlers[atc.ListAuditEvents]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:          authorized(inputHandlers[atc.CheckResource]),
-				atc.CreateJobBuild:         authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.DeletePipeline:         authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion: authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:  authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.GetConfig:              authorized(inputHandlers[atc.GetConfig]),
-				atc.GetVersionsDB:          authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:          authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:         authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:               authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:          authorized(inputHandlers[atc.PausePipeline]),
-				atc.PauseResource:          authorized(inputHandlers[atc.PauseResource]),
-				atc.RenamePipeline:         authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:             authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:             authorized(inputHandlers[atc.UnpauseJob]),
-				atc.UnpausePipeline:        authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.UnpauseResource:        authorized(inputHandlers[atc.UnpauseResource]),
-				atc.ExposePipeline:         authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:           authorized(inputHandlers[atc.HidePipeline]),
+				atc.CheckResource:                  authorized(inputHandlers[atc.CheckResource]),
+				atc.CreateJobBuild:                 authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.DeletePipeline:                 authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:         authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:          authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.GetConfig:                      authorized(inputHandlers[atc.GetConfig]),
+				atc.GetVersionsDB:                  authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListJobInputs:                  authorized(inputHandlers[atc.ListJobInputs]),
+				atc.OrderPipelines:                 authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                       authorized(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:                  authorized(inputHandlers[atc.PausePipeline]),
+				atc.PauseResource:                  authorized(inputHandlers[atc.PauseResource]),
+				atc.RenamePipeline:                 authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveResourceVersions:           authorized(inputHandlers[atc.SaveResourceVersions]),
+				atc.SaveConfig:                     authorized(inputHandlers[atc.SaveConfig]),
+				atc.GetTeamQuotas:                  authorized(inputHandlers[atc.GetTeamQuotas]),
+				atc.ListNotificationSubscriptions:  authorized(inputHandlers[atc.ListNotificationSubscriptions]),
+				atc.CreateNotificationSubscription: authorized(inputHandlers[atc.CreateNotificationSubscription]),
+				atc.DeleteNotificationSubscription: authorized(inputHandlers[atc.DeleteNotificationSubscription]),
+				atc.ListNotificationDeliveries:     authorized(inputHandlers[atc.ListNotificationDeliveries]),
+				atc.ListTeamAuditEvents:            authorized(inputHandlers[atc.ListTeamAuditEvents]),
+				atc.UnpauseJob:                     authorized(inputHandlers[atc.UnpauseJob]),
+				atc.UnpausePipeline:                authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.UnpauseResource:                authorized(inputHandlers[atc.UnpauseResource]),
+				atc.ExposePipeline:                 authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:                   authorized(inputHandlers[atc.HidePipeline]),
 			}
 		})
 